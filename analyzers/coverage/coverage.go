@@ -0,0 +1,262 @@
+package coverage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// LineModel - hits of a source line
+type LineModel struct {
+	Number int
+	Hits   int
+}
+
+// ClassModel - the covered lines of a class
+type ClassModel struct {
+	Name     string
+	Filename string
+	Lines    []LineModel // sorted by the line number
+}
+
+// AssemblyModel ...
+type AssemblyModel struct {
+	Name    string
+	Classes []ClassModel // sorted by the class name
+}
+
+// ReportModel - line coverage of the assemblies
+type ReportModel struct {
+	Assemblies []AssemblyModel // sorted by the assembly name
+}
+
+// coveredLines returns the number of the hit and all lines
+func coveredLines(lines []LineModel) (int, int) {
+	covered := 0
+	for _, line := range lines {
+		if line.Hits > 0 {
+			covered++
+		}
+	}
+	return covered, len(lines)
+}
+
+// Coverage - the number of the covered and the valid lines of the class
+func (class ClassModel) Coverage() (int, int) {
+	return coveredLines(class.Lines)
+}
+
+// Coverage - the number of the covered and the valid lines of the assembly
+func (assembly AssemblyModel) Coverage() (int, int) {
+	covered, valid := 0, 0
+	for _, class := range assembly.Classes {
+		classCovered, classValid := class.Coverage()
+		covered += classCovered
+		valid += classValid
+	}
+	return covered, valid
+}
+
+// Coverage - the number of the covered and the valid lines of the report
+func (report ReportModel) Coverage() (int, int) {
+	covered, valid := 0, 0
+	for _, assembly := range report.Assemblies {
+		assemblyCovered, assemblyValid := assembly.Coverage()
+		covered += assemblyCovered
+		valid += assemblyValid
+	}
+	return covered, valid
+}
+
+// LineRate - the ratio of the covered lines, 0 if no line is reported
+func (report ReportModel) LineRate() float64 {
+	return rate(report.Coverage())
+}
+
+func rate(covered, valid int) float64 {
+	if valid == 0 {
+		return 0
+	}
+	return float64(covered) / float64(valid)
+}
+
+// mono log profiler coverage, the output of: mprof-report --coverage-out=<pth> <mlpd>
+type monoStatementElement struct {
+	Counter int `xml:"counter,attr"`
+	Line    int `xml:"line,attr"`
+}
+
+type monoMethodElement struct {
+	Assembly   string                 `xml:"assembly,attr"`
+	Class      string                 `xml:"class,attr"`
+	Filename   string                 `xml:"filename,attr"`
+	Statements []monoStatementElement `xml:"statement"`
+}
+
+type monoCoverageElement struct {
+	XMLName xml.Name            `xml:"coverage"`
+	Methods []monoMethodElement `xml:"method"`
+}
+
+// ParseMonoCoverage - parses the coverage XML of the mono log profiler,
+// the line hits are the max counter of the line's statements
+func ParseMonoCoverage(content []byte) (ReportModel, error) {
+	var coverage monoCoverageElement
+	if err := xml.Unmarshal(content, &coverage); err != nil {
+		return ReportModel{}, fmt.Errorf("failed to parse mono coverage, error: %s", err)
+	}
+
+	// assembly - class - line - hits
+	hits := map[string]map[string]map[int]int{}
+	filenames := map[string]string{}
+
+	for _, method := range coverage.Methods {
+		classes, ok := hits[method.Assembly]
+		if !ok {
+			classes = map[string]map[int]int{}
+			hits[method.Assembly] = classes
+		}
+		lines, ok := classes[method.Class]
+		if !ok {
+			lines = map[int]int{}
+			classes[method.Class] = lines
+		}
+		if method.Filename != "" {
+			filenames[method.Assembly+"/"+method.Class] = method.Filename
+		}
+
+		for _, statement := range method.Statements {
+			if statement.Line <= 0 {
+				continue
+			}
+			if current, ok := lines[statement.Line]; !ok || statement.Counter > current {
+				lines[statement.Line] = statement.Counter
+			}
+		}
+	}
+
+	report := ReportModel{Assemblies: []AssemblyModel{}}
+	assemblyNames := []string{}
+	for assemblyName := range hits {
+		assemblyNames = append(assemblyNames, assemblyName)
+	}
+	sort.Strings(assemblyNames)
+
+	for _, assemblyName := range assemblyNames {
+		assembly := AssemblyModel{Name: assemblyName, Classes: []ClassModel{}}
+
+		classNames := []string{}
+		for className := range hits[assemblyName] {
+			classNames = append(classNames, className)
+		}
+		sort.Strings(classNames)
+
+		for _, className := range classNames {
+			class := ClassModel{Name: className, Filename: filenames[assemblyName+"/"+className], Lines: []LineModel{}}
+
+			lineNumbers := []int{}
+			for number := range hits[assemblyName][className] {
+				lineNumbers = append(lineNumbers, number)
+			}
+			sort.Ints(lineNumbers)
+
+			for _, number := range lineNumbers {
+				class.Lines = append(class.Lines, LineModel{Number: number, Hits: hits[assemblyName][className][number]})
+			}
+			assembly.Classes = append(assembly.Classes, class)
+		}
+		report.Assemblies = append(report.Assemblies, assembly)
+	}
+
+	return report, nil
+}
+
+type coberturaLineElement struct {
+	Number int `xml:"number,attr"`
+	Hits   int `xml:"hits,attr"`
+}
+
+type coberturaClassElement struct {
+	Name     string                 `xml:"name,attr"`
+	Filename string                 `xml:"filename,attr"`
+	LineRate string                 `xml:"line-rate,attr"`
+	Lines    []coberturaLineElement `xml:"lines>line"`
+}
+
+type coberturaPackageElement struct {
+	Name     string                  `xml:"name,attr"`
+	LineRate string                  `xml:"line-rate,attr"`
+	Classes  []coberturaClassElement `xml:"classes>class"`
+}
+
+type coberturaCoverageElement struct {
+	XMLName      xml.Name                  `xml:"coverage"`
+	LineRate     string                    `xml:"line-rate,attr"`
+	LinesCovered int                       `xml:"lines-covered,attr"`
+	LinesValid   int                       `xml:"lines-valid,attr"`
+	Version      string                    `xml:"version,attr"`
+	Sources      []string                  `xml:"sources>source"`
+	Packages     []coberturaPackageElement `xml:"packages>package"`
+}
+
+// Cobertura - converts the report to Cobertura XML, the assemblies are reported as packages
+func (report ReportModel) Cobertura() ([]byte, error) {
+	covered, valid := report.Coverage()
+	coverage := coberturaCoverageElement{
+		LineRate:     formatRate(rate(covered, valid)),
+		LinesCovered: covered,
+		LinesValid:   valid,
+		Version:      "1.9",
+		Sources:      []string{},
+		Packages:     []coberturaPackageElement{},
+	}
+
+	sources := map[string]bool{}
+	for _, assembly := range report.Assemblies {
+		packageElement := coberturaPackageElement{Name: assembly.Name, LineRate: formatRate(rate(assembly.Coverage())), Classes: []coberturaClassElement{}}
+
+		for _, class := range assembly.Classes {
+			classElement := coberturaClassElement{Name: class.Name, Filename: class.Filename, LineRate: formatRate(rate(class.Coverage())), Lines: []coberturaLineElement{}}
+			for _, line := range class.Lines {
+				classElement.Lines = append(classElement.Lines, coberturaLineElement{Number: line.Number, Hits: line.Hits})
+			}
+			packageElement.Classes = append(packageElement.Classes, classElement)
+
+			if class.Filename != "" && filepath.IsAbs(class.Filename) {
+				sources[filepath.Dir(class.Filename)] = true
+			}
+		}
+		coverage.Packages = append(coverage.Packages, packageElement)
+	}
+
+	for source := range sources {
+		coverage.Sources = append(coverage.Sources, source)
+	}
+	sort.Strings(coverage.Sources)
+
+	content, err := xml.MarshalIndent(coverage, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+// WriteCobertura - writes the report to the path in Cobertura XML format
+func (report ReportModel) WriteCobertura(pth string) error {
+	content, err := report.Cobertura()
+	if err != nil {
+		return fmt.Errorf("failed to convert coverage to Cobertura, error: %s", err)
+	}
+
+	if err := fileutil.WriteBytesToFile(pth, content); err != nil {
+		return fmt.Errorf("failed to write Cobertura coverage (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+func formatRate(rate float64) string {
+	return fmt.Sprintf("%.4f", rate)
+}
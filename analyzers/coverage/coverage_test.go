@@ -0,0 +1,77 @@
+package coverage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const monoCoverageContent = `<?xml version="1.0"?>
+<coverage version="0.3">
+  <assembly name="Sample.Core" guid="ABC" filename="Sample.Core.dll" method-count="2" full="1" partial="1"/>
+  <class name="Sample.Core.Calculator" assembly="Sample.Core" full="1" partial="1"/>
+  <method assembly="Sample.Core" class="Sample.Core.Calculator" name="Add" signature="int(int,int)" filename="/src/Sample.Core/Calculator.cs" token="100663297">
+    <statement offset="0" counter="3" line="10" column="9"/>
+    <statement offset="2" counter="3" line="11" column="13"/>
+  </method>
+  <method assembly="Sample.Core" class="Sample.Core.Calculator" name="Divide" signature="int(int,int)" filename="/src/Sample.Core/Calculator.cs" token="100663298">
+    <statement offset="0" counter="0" line="15" column="9"/>
+    <statement offset="4" counter="1" line="15" column="20"/>
+    <statement offset="8" counter="0" line="16" column="13"/>
+    <statement offset="12" counter="0" line="0" column="0"/>
+  </method>
+</coverage>
+`
+
+func TestParseMonoCoverage(t *testing.T) {
+	report, err := ParseMonoCoverage([]byte(monoCoverageContent))
+	require.NoError(t, err)
+
+	t.Log("it merges the statements of the lines")
+	{
+		require.Equal(t, ReportModel{
+			Assemblies: []AssemblyModel{
+				{
+					Name: "Sample.Core",
+					Classes: []ClassModel{
+						{
+							Name:     "Sample.Core.Calculator",
+							Filename: "/src/Sample.Core/Calculator.cs",
+							Lines:    []LineModel{{Number: 10, Hits: 3}, {Number: 11, Hits: 3}, {Number: 15, Hits: 1}, {Number: 16, Hits: 0}},
+						},
+					},
+				},
+			},
+		}, report)
+	}
+
+	t.Log("it calculates the line rate")
+	{
+		covered, valid := report.Coverage()
+		require.Equal(t, 3, covered)
+		require.Equal(t, 4, valid)
+		require.Equal(t, 0.75, report.LineRate())
+	}
+
+	t.Log("it fails for invalid content")
+	{
+		_, err := ParseMonoCoverage([]byte("<coverage>"))
+		require.Error(t, err)
+	}
+}
+
+func TestCobertura(t *testing.T) {
+	report, err := ParseMonoCoverage([]byte(monoCoverageContent))
+	require.NoError(t, err)
+
+	content, err := report.Cobertura()
+	require.NoError(t, err)
+
+	cobertura := string(content)
+	require.True(t, strings.Contains(cobertura, `<coverage line-rate="0.7500" lines-covered="3" lines-valid="4" version="1.9">`), cobertura)
+	require.True(t, strings.Contains(cobertura, `<source>/src/Sample.Core</source>`), cobertura)
+	require.True(t, strings.Contains(cobertura, `<package name="Sample.Core" line-rate="0.7500">`), cobertura)
+	require.True(t, strings.Contains(cobertura, `<class name="Sample.Core.Calculator" filename="/src/Sample.Core/Calculator.cs" line-rate="0.7500">`), cobertura)
+	require.True(t, strings.Contains(cobertura, `<line number="16" hits="0"></line>`), cobertura)
+}
@@ -0,0 +1,123 @@
+package manifest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// Model - the attributes of an AndroidManifest.xml, the android: namespaced attributes are read by their local name
+type Model struct {
+	Package     string
+	VersionCode string
+	VersionName string
+
+	MinSDKVersion    string
+	TargetSDKVersion string
+
+	Permissions []string // names of the uses-permission elements
+
+	Application ApplicationModel
+}
+
+// ApplicationModel - the attributes of the manifest's application element
+type ApplicationModel struct {
+	Name       string
+	Label      string
+	Icon       string
+	Theme      string
+	Debuggable bool
+
+	Attributes map[string]string // every attribute of the application element, keyed by local name
+}
+
+type manifestElement struct {
+	XMLName     xml.Name `xml:"manifest"`
+	Package     string   `xml:"package,attr"`
+	VersionCode string   `xml:"versionCode,attr"`
+	VersionName string   `xml:"versionName,attr"`
+
+	UsesSDK struct {
+		MinSDKVersion    string `xml:"minSdkVersion,attr"`
+		TargetSDKVersion string `xml:"targetSdkVersion,attr"`
+	} `xml:"uses-sdk"`
+
+	UsesPermissions []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"uses-permission"`
+
+	Application struct {
+		Attributes []xml.Attr `xml:",any,attr"`
+	} `xml:"application"`
+}
+
+// New ...
+func New(pth string) (Model, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to read manifest (%s), error: %s", pth, err)
+	}
+
+	manifest, err := Parse(content)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to parse manifest (%s), error: %s", pth, err)
+	}
+
+	return manifest, nil
+}
+
+// Parse - parses the content of an AndroidManifest.xml source file (not the compiled binary xml of an apk)
+func Parse(content []byte) (Model, error) {
+	var element manifestElement
+	if err := xml.Unmarshal(content, &element); err != nil {
+		return Model{}, err
+	}
+
+	manifest := Model{
+		Package:          strings.TrimSpace(element.Package),
+		VersionCode:      strings.TrimSpace(element.VersionCode),
+		VersionName:      strings.TrimSpace(element.VersionName),
+		MinSDKVersion:    strings.TrimSpace(element.UsesSDK.MinSDKVersion),
+		TargetSDKVersion: strings.TrimSpace(element.UsesSDK.TargetSDKVersion),
+		Permissions:      []string{},
+		Application:      ApplicationModel{Attributes: map[string]string{}},
+	}
+
+	for _, permission := range element.UsesPermissions {
+		if name := strings.TrimSpace(permission.Name); name != "" {
+			manifest.Permissions = append(manifest.Permissions, name)
+		}
+	}
+
+	for _, attribute := range element.Application.Attributes {
+		value := strings.TrimSpace(attribute.Value)
+		manifest.Application.Attributes[attribute.Name.Local] = value
+
+		switch attribute.Name.Local {
+		case "name":
+			manifest.Application.Name = value
+		case "label":
+			manifest.Application.Label = value
+		case "icon":
+			manifest.Application.Icon = value
+		case "theme":
+			manifest.Application.Theme = value
+		case "debuggable":
+			manifest.Application.Debuggable = strings.EqualFold(value, "true")
+		}
+	}
+
+	return manifest, nil
+}
+
+// HasPermission ...
+func (manifest Model) HasPermission(permission string) bool {
+	for _, p := range manifest.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
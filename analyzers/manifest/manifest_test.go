@@ -0,0 +1,96 @@
+package manifest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestFileContent = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+    package="hu.bitrise.test" android:versionCode="12" android:versionName="1.2.0">
+
+    <uses-permission android:name="android.permission.USE_CREDENTIALS" />
+    <uses-permission android:name="android.permission.READ_PROFILE" />
+    <uses-permission android:name="android.permission.READ_CONTACTS" />
+    <uses-permission android:name="android.permission.INTERNET" />
+    <uses-permission android:name="android.permission.ACCESS_NETWORK_STATE" />
+    <uses-permission android:name="android.permission.ACCESS_WIFI_STATE" />
+    <uses-permission android:name="android.permission.WAKE_LOCK" />
+    <uses-permission android:name="com.google.android.c2dm.permission.RECEIVE" />
+    <uses-permission android:name="com.amazon.mysampleapp.permission.C2D_MESSAGE" />
+
+    <!--  Dangerous permissions -->
+    <uses-permission android:name="android.permission.GET_ACCOUNTS" />
+    <uses-permission android:name="android.permission.CAMERA" />
+
+    <uses-permission android:name="android.permission.ACCESS_COARSE_LOCATION" />
+    <uses-permission android:name="android.permission.ACCESS_FINE_LOCATION" />
+
+    <permission
+        android:name="com.amazon.mysampleapp.permission.C2D_MESSAGE"
+        android:protectionLevel="signature" />
+
+</manifest>
+`
+
+const applicationManifestContent = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android" xmlns:tools="http://schemas.android.com/tools" package="com.bitrise.sampleapp">
+	<uses-sdk android:minSdkVersion="21" android:targetSdkVersion="33" />
+	<application android:name="SampleApplication" android:label="Sample" android:icon="@mipmap/icon" android:debuggable="True" tools:replace="android:label" />
+</manifest>`
+
+func TestParse(t *testing.T) {
+	t.Log("it finds the package, versions and permissions")
+	{
+		manifest, err := Parse([]byte(manifestFileContent))
+		require.NoError(t, err)
+		require.Equal(t, "hu.bitrise.test", manifest.Package)
+		require.Equal(t, "12", manifest.VersionCode)
+		require.Equal(t, "1.2.0", manifest.VersionName)
+		require.Equal(t, 13, len(manifest.Permissions))
+		require.Equal(t, true, manifest.HasPermission("android.permission.CAMERA"))
+		require.Equal(t, false, manifest.HasPermission("com.amazon.mysampleapp.permission.C2D_MESSAGE.other"))
+		require.Equal(t, false, manifest.Application.Debuggable)
+	}
+
+	t.Log("it finds the sdk versions and application attributes")
+	{
+		manifest, err := Parse([]byte(applicationManifestContent))
+		require.NoError(t, err)
+		require.Equal(t, "21", manifest.MinSDKVersion)
+		require.Equal(t, "33", manifest.TargetSDKVersion)
+		require.Equal(t, "SampleApplication", manifest.Application.Name)
+		require.Equal(t, "Sample", manifest.Application.Label)
+		require.Equal(t, "@mipmap/icon", manifest.Application.Icon)
+		require.Equal(t, true, manifest.Application.Debuggable)
+		require.Equal(t, "android:label", manifest.Application.Attributes["replace"])
+	}
+
+	t.Log("it fails for invalid manifest")
+	{
+		_, err := Parse([]byte(`<application android:label="Sample" />`))
+		require.Error(t, err)
+
+		_, err = Parse([]byte(`<manifest package="com.bitrise`))
+		require.Error(t, err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("manifest_test")
+	require.NoError(t, err)
+
+	pth := filepath.Join(tmpDir, "AndroidManifest.xml")
+	require.NoError(t, fileutil.WriteStringToFile(pth, applicationManifestContent))
+
+	manifest, err := New(pth)
+	require.NoError(t, err)
+	require.Equal(t, "com.bitrise.sampleapp", manifest.Package)
+
+	_, err = New(filepath.Join(tmpDir, "Missing.xml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package plist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	binaryPlistHeader      = "bplist00"
+	binaryPlistTrailerSize = 32
+
+	// nesting limit, which also guards against reference cycles of malformed plists
+	binaryPlistMaxDepth = 128
+)
+
+// dates of binary plists are seconds since this reference date
+var binaryPlistReferenceDate = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// IsBinary - returns true if the content is a binary property list
+func IsBinary(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(binaryPlistHeader))
+}
+
+type binaryPlist struct {
+	content       []byte
+	offsets       []uint64
+	objectRefSize int
+}
+
+// parseBinary - parses bplist00 content, like the Info.plist of a built app bundle
+func parseBinary(content []byte) (Model, error) {
+	if len(content) < len(binaryPlistHeader)+binaryPlistTrailerSize {
+		return Model{}, fmt.Errorf("binary plist is too short")
+	}
+
+	trailer := content[len(content)-binaryPlistTrailerSize:]
+	offsetIntSize := int(trailer[6])
+	objectRefSize := int(trailer[7])
+	numObjects := binary.BigEndian.Uint64(trailer[8:16])
+	topObject := binary.BigEndian.Uint64(trailer[16:24])
+	offsetTableOffset := binary.BigEndian.Uint64(trailer[24:32])
+
+	if offsetIntSize < 1 || offsetIntSize > 8 || objectRefSize < 1 || objectRefSize > 8 {
+		return Model{}, fmt.Errorf("invalid binary plist trailer")
+	}
+	tableEnd := uint64(len(content) - binaryPlistTrailerSize)
+	if numObjects == 0 || numObjects > tableEnd || offsetTableOffset > tableEnd || numObjects*uint64(offsetIntSize) > tableEnd-offsetTableOffset {
+		return Model{}, fmt.Errorf("invalid binary plist offset table")
+	}
+
+	offsets := make([]uint64, numObjects)
+	for i := range offsets {
+		start := offsetTableOffset + uint64(i*offsetIntSize)
+		offsets[i] = readUint(content[start : start+uint64(offsetIntSize)])
+	}
+
+	plist := binaryPlist{content: content[:tableEnd], offsets: offsets, objectRefSize: objectRefSize}
+	value, err := plist.parseObject(topObject, 0)
+	if err != nil {
+		return Model{}, err
+	}
+
+	root, ok := value.(Model)
+	if !ok {
+		return Model{}, fmt.Errorf("root object is not a dict")
+	}
+	return root, nil
+}
+
+func (plist binaryPlist) parseObject(ref uint64, depth int) (interface{}, error) {
+	if depth > binaryPlistMaxDepth {
+		return nil, fmt.Errorf("binary plist is nested too deep")
+	}
+	if ref >= uint64(len(plist.offsets)) {
+		return nil, fmt.Errorf("invalid object reference: %d", ref)
+	}
+
+	offset := plist.offsets[ref]
+	if offset >= uint64(len(plist.content)) {
+		return nil, fmt.Errorf("invalid object offset: %d", offset)
+	}
+
+	marker := plist.content[offset]
+	objectType, info := marker>>4, marker&0x0f
+	offset++
+
+	switch objectType {
+	case 0x0:
+		switch info {
+		case 0x8:
+			return false, nil
+		case 0x9:
+			return true, nil
+		}
+		return nil, fmt.Errorf("unsupported object marker: 0x%02x", marker)
+	case 0x1:
+		data, err := plist.read(offset, uint64(1)<<info)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > 8 {
+			// 128 bit integers store the value in the lower 8 bytes
+			data = data[len(data)-8:]
+		}
+		if len(data) == 8 {
+			return int64(binary.BigEndian.Uint64(data)), nil
+		}
+		return int64(readUint(data)), nil
+	case 0x2:
+		data, err := plist.read(offset, uint64(1)<<info)
+		if err != nil {
+			return nil, err
+		}
+		switch len(data) {
+		case 4:
+			return float64(math.Float32frombits(binary.BigEndian.Uint32(data))), nil
+		case 8:
+			return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+		}
+		return nil, fmt.Errorf("unsupported real size: %d", len(data))
+	case 0x3:
+		data, err := plist.read(offset, 8)
+		if err != nil {
+			return nil, err
+		}
+		seconds := math.Float64frombits(binary.BigEndian.Uint64(data))
+		date := binaryPlistReferenceDate.Add(time.Duration(seconds * float64(time.Second)))
+		return date.Format(time.RFC3339), nil
+	}
+
+	count, offset, err := plist.readCount(info, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	switch objectType {
+	case 0x4:
+		data, err := plist.read(offset, count)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, data...), nil
+	case 0x5:
+		data, err := plist.read(offset, count)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	case 0x6:
+		data, err := plist.read(offset, count*2)
+		if err != nil {
+			return nil, err
+		}
+		chars := make([]uint16, count)
+		for i := range chars {
+			chars[i] = binary.BigEndian.Uint16(data[i*2:])
+		}
+		return string(utf16.Decode(chars)), nil
+	case 0xa:
+		refs, err := plist.readRefs(offset, count)
+		if err != nil {
+			return nil, err
+		}
+
+		array := []interface{}{}
+		for _, ref := range refs {
+			value, err := plist.parseObject(ref, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		return array, nil
+	case 0xd:
+		refs, err := plist.readRefs(offset, count*2)
+		if err != nil {
+			return nil, err
+		}
+
+		dict := Model{}
+		for i := uint64(0); i < count; i++ {
+			key, err := plist.parseObject(refs[i], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("dict key is not a string")
+			}
+
+			value, err := plist.parseObject(refs[count+i], depth+1)
+			if err != nil {
+				return nil, err
+			}
+			dict[keyStr] = value
+		}
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("unsupported object marker: 0x%02x", marker)
+}
+
+// readCount returns the element count of the object and the offset of its content,
+// counts above 14 are stored in a following integer object.
+// Every element takes at least a byte, so the count is checked against the remaining content,
+// before it is multiplied by the element size.
+func (plist binaryPlist) readCount(info byte, offset uint64) (uint64, uint64, error) {
+	if info != 0x0f {
+		return plist.checkCount(uint64(info), offset)
+	}
+
+	marker, err := plist.read(offset, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if marker[0]>>4 != 0x1 {
+		return 0, 0, fmt.Errorf("invalid count marker: 0x%02x", marker[0])
+	}
+
+	size := uint64(1) << (marker[0] & 0x0f)
+	data, err := plist.read(offset+1, size)
+	if err != nil {
+		return 0, 0, err
+	}
+	return plist.checkCount(readUint(data), offset+1+size)
+}
+
+func (plist binaryPlist) checkCount(count, offset uint64) (uint64, uint64, error) {
+	if offset > uint64(len(plist.content)) || count > uint64(len(plist.content))-offset {
+		return 0, 0, fmt.Errorf("binary plist object count out of bounds: %d", count)
+	}
+	return count, offset, nil
+}
+
+func (plist binaryPlist) readRefs(offset, count uint64) ([]uint64, error) {
+	data, err := plist.read(offset, count*uint64(plist.objectRefSize))
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]uint64, count)
+	for i := range refs {
+		refs[i] = readUint(data[i*plist.objectRefSize : (i+1)*plist.objectRefSize])
+	}
+	return refs, nil
+}
+
+func (plist binaryPlist) read(offset, length uint64) ([]byte, error) {
+	if offset > uint64(len(plist.content)) || length > uint64(len(plist.content))-offset {
+		return nil, fmt.Errorf("binary plist object out of bounds")
+	}
+	return plist.content[offset : offset+length], nil
+}
+
+func readUint(data []byte) uint64 {
+	value := uint64(0)
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
@@ -0,0 +1,152 @@
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// Info.plist keys of the app versions
+const (
+	BundleVersionKey      = "CFBundleVersion"
+	BundleShortVersionKey = "CFBundleShortVersionString"
+)
+
+const xmlPlistHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+`
+
+// Get ...
+func (plist Model) Get(key string) (interface{}, bool) {
+	value, ok := plist[key]
+	return value, ok
+}
+
+// Set - sets the value of the key, the value has to be one of the property list value types, int is stored as int64
+func (plist Model) Set(key string, value interface{}) error {
+	switch typed := value.(type) {
+	case int:
+		value = int64(typed)
+	case string, int64, float64, bool, []byte, []interface{}, Model:
+	default:
+		return fmt.Errorf("unsupported plist value type of key (%s): %T", key, value)
+	}
+
+	plist[key] = value
+	return nil
+}
+
+// Delete ...
+func (plist Model) Delete(key string) {
+	delete(plist, key)
+}
+
+// SetBundleVersion - sets the build number (CFBundleVersion)
+func (plist Model) SetBundleVersion(version string) {
+	plist[BundleVersionKey] = version
+}
+
+// SetShortVersion - sets the user-facing version (CFBundleShortVersionString)
+func (plist Model) SetShortVersion(version string) {
+	plist[BundleShortVersionKey] = version
+}
+
+// Encode - returns the XML property list, the dict keys are sorted.
+// Dates are stored as strings by the parser, so they are written as strings.
+func (plist Model) Encode() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(xmlPlistHeader)
+	if err := encodeValue(&buffer, plist, 0); err != nil {
+		return nil, err
+	}
+	buffer.WriteString("</plist>\n")
+	return buffer.Bytes(), nil
+}
+
+// Write - writes the plist to the path in XML format, binary plists are converted to XML
+func (plist Model) Write(pth string) error {
+	content, err := plist.Encode()
+	if err != nil {
+		return err
+	}
+
+	if err := fileutil.WriteBytesToFile(pth, content); err != nil {
+		return fmt.Errorf("failed to write plist (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+// SetVersion - sets the CFBundleVersion and CFBundleShortVersionString of the plist file, an empty value keeps the current one
+func SetVersion(pth, bundleVersion, shortVersion string) error {
+	plist, err := New(pth)
+	if err != nil {
+		return err
+	}
+
+	if bundleVersion != "" {
+		plist.SetBundleVersion(bundleVersion)
+	}
+	if shortVersion != "" {
+		plist.SetShortVersion(shortVersion)
+	}
+
+	return plist.Write(pth)
+}
+
+func encodeValue(buffer *bytes.Buffer, value interface{}, depth int) error {
+	indent := strings.Repeat("\t", depth)
+
+	switch typed := value.(type) {
+	case Model:
+		buffer.WriteString(indent + "<dict>\n")
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			buffer.WriteString(indent + "\t<key>" + escape(key) + "</key>\n")
+			if err := encodeValue(buffer, typed[key], depth+1); err != nil {
+				return fmt.Errorf("invalid value of key (%s): %s", key, err)
+			}
+		}
+		buffer.WriteString(indent + "</dict>\n")
+	case []interface{}:
+		buffer.WriteString(indent + "<array>\n")
+		for _, item := range typed {
+			if err := encodeValue(buffer, item, depth+1); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString(indent + "</array>\n")
+	case string:
+		buffer.WriteString(indent + "<string>" + escape(typed) + "</string>\n")
+	case int64:
+		buffer.WriteString(indent + "<integer>" + strconv.FormatInt(typed, 10) + "</integer>\n")
+	case float64:
+		buffer.WriteString(indent + "<real>" + strconv.FormatFloat(typed, 'g', -1, 64) + "</real>\n")
+	case bool:
+		buffer.WriteString(indent + "<" + strconv.FormatBool(typed) + "/>\n")
+	case []byte:
+		buffer.WriteString(indent + "<data>" + base64.StdEncoding.EncodeToString(typed) + "</data>\n")
+	default:
+		return fmt.Errorf("unsupported plist value type: %T", value)
+	}
+
+	return nil
+}
+
+func escape(text string) string {
+	var buffer bytes.Buffer
+	// xml.EscapeText only fails if the writer fails
+	_ = xml.EscapeText(&buffer, []byte(text))
+	return buffer.String()
+}
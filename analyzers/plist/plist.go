@@ -0,0 +1,172 @@
+package plist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// Model - property list with dict root,
+// values are: string, int64, float64, bool, []byte (data), string (date), []interface{} (array) and Model (dict)
+type Model map[string]interface{}
+
+// New ...
+func New(pth string) (Model, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to read plist (%s), error: %s", pth, err)
+	}
+
+	plist, err := Parse(content)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to parse plist (%s), error: %s", pth, err)
+	}
+
+	return plist, nil
+}
+
+// Parse - parses XML or binary property list content
+func Parse(content []byte) (Model, error) {
+	if IsBinary(content) {
+		return parseBinary(content)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return Model{}, fmt.Errorf("no root element found")
+		} else if err != nil {
+			return Model{}, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local == "plist" {
+			continue
+		}
+
+		value, err := parseValue(decoder, start)
+		if err != nil {
+			return Model{}, err
+		}
+
+		plist, ok := value.(Model)
+		if !ok {
+			return Model{}, fmt.Errorf("root element is not a dict: %s", start.Name.Local)
+		}
+		return plist, nil
+	}
+}
+
+// GetString ...
+func (plist Model) GetString(key string) (string, bool) {
+	value, ok := plist[key]
+	if !ok {
+		return "", false
+	}
+
+	str, ok := value.(string)
+	return str, ok
+}
+
+// GetDict ...
+func (plist Model) GetDict(key string) (Model, bool) {
+	value, ok := plist[key]
+	if !ok {
+		return Model{}, false
+	}
+
+	dict, ok := value.(Model)
+	return dict, ok
+}
+
+func parseValue(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		return parseDict(decoder)
+	case "array":
+		return parseArray(decoder)
+	case "true", "false":
+		if err := decoder.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+	}
+
+	var text string
+	if err := decoder.DecodeElement(&text, &start); err != nil {
+		return nil, err
+	}
+
+	switch start.Name.Local {
+	case "string", "date":
+		return text, nil
+	case "integer":
+		return strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	case "real":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "data":
+		return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+	default:
+		return nil, fmt.Errorf("unknown element: %s", start.Name.Local)
+	}
+}
+
+func parseDict(decoder *xml.Decoder) (Model, error) {
+	dict := Model{}
+	key := ""
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local == "key" {
+				if err := decoder.DecodeElement(&key, &element); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			value, err := parseValue(decoder, element)
+			if err != nil {
+				return nil, err
+			}
+			dict[key] = value
+		case xml.EndElement:
+			return dict, nil
+		}
+	}
+}
+
+func parseArray(decoder *xml.Decoder) ([]interface{}, error) {
+	array := []interface{}{}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			value, err := parseValue(decoder, element)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		case xml.EndElement:
+			return array, nil
+		}
+	}
+}
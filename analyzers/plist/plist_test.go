@@ -0,0 +1,248 @@
+package plist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+const infoPlistContent = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.bitrise.sampleapp</string>
+	<key>CFBundleVersion</key>
+	<string>12</string>
+	<key>LSRequiresIPhoneOS</key>
+	<true/>
+	<key>MinimumOSVersion</key>
+	<real>9.3</real>
+	<key>UIDeviceFamily</key>
+	<array>
+		<integer>1</integer>
+		<integer>2</integer>
+	</array>
+	<key>NSAppTransportSecurity</key>
+	<dict>
+		<key>NSAllowsArbitraryLoads</key>
+		<false/>
+	</dict>
+	<key>Data</key>
+	<data>
+	aGVsbG8=
+	</data>
+</dict>
+</plist>`
+
+// generated by python plistlib (FMT_BINARY)
+const binaryInfoPlistContent = "\x62\x70\x6c\x69\x73\x74\x30\x30\xd9\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0d\x0e\x0f\x10\x11" +
+	"\x12\x13\x14\x53\x41\x54\x53\x5f\x10\x12\x43\x46\x42\x75\x6e\x64\x6c\x65\x49\x64\x65\x6e\x74\x69" +
+	"\x66\x69\x65\x72\x54\x44\x61\x74\x61\x54\x44\x61\x74\x65\x5f\x10\x12\x4c\x53\x52\x65\x71\x75\x69" +
+	"\x72\x65\x73\x49\x50\x68\x6f\x6e\x65\x4f\x53\x5f\x10\x10\x4d\x69\x6e\x69\x6d\x75\x6d\x4f\x53\x56" +
+	"\x65\x72\x73\x69\x6f\x6e\x54\x4e\x61\x6d\x65\x55\x52\x61\x74\x69\x6f\x5e\x55\x49\x44\x65\x76\x69" +
+	"\x63\x65\x46\x61\x6d\x69\x6c\x79\xd1\x0b\x0c\x5f\x10\x16\x4e\x53\x41\x6c\x6c\x6f\x77\x73\x41\x72" +
+	"\x62\x69\x74\x72\x61\x72\x79\x4c\x6f\x61\x64\x73\x08\x5f\x10\x15\x63\x6f\x6d\x2e\x62\x69\x74\x72" +
+	"\x69\x73\x65\x2e\x73\x61\x6d\x70\x6c\x65\x61\x70\x70\x45\x68\x65\x6c\x6c\x6f\x33\x41\xbe\xc3\x38" +
+	"\x40\x00\x00\x00\x09\x54\x31\x30\x2e\x30\x6b\x00\x42\x00\x69\x00\x74\x00\x72\x00\x69\x00\x73\x00" +
+	"\x65\x00\x20\x00\xe1\x00\x70\x00\x70\x23\x3f\xf8\x00\x00\x00\x00\x00\x00\xa2\x15\x16\x10\x01\x10" +
+	"\x02\x08\x1b\x1f\x34\x39\x3e\x53\x66\x6b\x71\x80\x83\x9c\x9d\xb5\xbb\xc4\xc5\xca\xe1\xea\xed\xef" +
+	"\x00\x00\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x17\x00\x00\x00\x00\x00\x00\x00\x00" +
+	"\x00\x00\x00\x00\x00\x00\x00\xf1"
+
+func TestParse(t *testing.T) {
+	t.Log("it parses xml plist")
+	{
+		plist, err := Parse([]byte(infoPlistContent))
+		require.NoError(t, err)
+
+		bundleID, ok := plist.GetString("CFBundleIdentifier")
+		require.Equal(t, true, ok)
+		require.Equal(t, "com.bitrise.sampleapp", bundleID)
+
+		_, ok = plist.GetString("LSRequiresIPhoneOS")
+		require.Equal(t, false, ok)
+		require.Equal(t, true, plist["LSRequiresIPhoneOS"])
+
+		require.Equal(t, 9.3, plist["MinimumOSVersion"])
+		require.Equal(t, []interface{}{int64(1), int64(2)}, plist["UIDeviceFamily"])
+		require.Equal(t, []byte("hello"), plist["Data"])
+
+		ats, ok := plist.GetDict("NSAppTransportSecurity")
+		require.Equal(t, true, ok)
+		require.Equal(t, false, ats["NSAllowsArbitraryLoads"])
+	}
+
+	t.Log("it fails for invalid plist")
+	{
+		_, err := Parse([]byte(`<plist><array></array></plist>`))
+		require.Error(t, err)
+
+		_, err = Parse([]byte(`<plist><dict><key>a</key><string>b</dict></plist>`))
+		require.Error(t, err)
+
+		_, err = Parse([]byte(``))
+		require.Error(t, err)
+	}
+
+	t.Log("it parses binary plist")
+	{
+		require.Equal(t, true, IsBinary([]byte(binaryInfoPlistContent)))
+		require.Equal(t, false, IsBinary([]byte(infoPlistContent)))
+
+		plist, err := Parse([]byte(binaryInfoPlistContent))
+		require.NoError(t, err)
+
+		bundleID, ok := plist.GetString("CFBundleIdentifier")
+		require.Equal(t, true, ok)
+		require.Equal(t, "com.bitrise.sampleapp", bundleID)
+
+		require.Equal(t, "10.0", plist["MinimumOSVersion"])
+		require.Equal(t, "Bitrise \u00e1pp", plist["Name"])
+		require.Equal(t, true, plist["LSRequiresIPhoneOS"])
+		require.Equal(t, 1.5, plist["Ratio"])
+		require.Equal(t, "2017-05-10T12:00:00Z", plist["Date"])
+		require.Equal(t, []interface{}{int64(1), int64(2)}, plist["UIDeviceFamily"])
+		require.Equal(t, []byte("hello"), plist["Data"])
+
+		ats, ok := plist.GetDict("ATS")
+		require.Equal(t, true, ok)
+		require.Equal(t, false, ats["NSAllowsArbitraryLoads"])
+	}
+
+	t.Log("it fails for truncated binary plist")
+	{
+		_, err := Parse([]byte(binaryInfoPlistContent[:100]))
+		require.Error(t, err)
+
+		_, err = Parse([]byte(binaryPlistHeader))
+		require.Error(t, err)
+	}
+
+	t.Log("it fails for binary plist with out of bounds object count")
+	{
+		// the count overflows when multiplied by the 2 byte object references or utf-16 characters
+		count := "\x13\x80\x00\x00\x00\x00\x00\x00\x00"
+		for _, object := range []string{"\xdf" + count, "\xaf" + count, "\x6f" + count, "\x5f\x10\x20"} {
+			content := binaryPlistHeader + object + "\x08" +
+				"\x00\x00\x00\x00\x00\x00\x01\x02" +
+				"\x00\x00\x00\x00\x00\x00\x00\x01" +
+				"\x00\x00\x00\x00\x00\x00\x00\x00" +
+				"\x00\x00\x00\x00\x00\x00\x00" + string([]byte{byte(len(binaryPlistHeader) + len(object))})
+
+			_, err := Parse([]byte(content))
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	t.Log("it encodes the parsed plist")
+	{
+		for _, content := range []string{infoPlistContent, binaryInfoPlistContent} {
+			plist, err := Parse([]byte(content))
+			require.NoError(t, err)
+
+			encoded, err := plist.Encode()
+			require.NoError(t, err)
+
+			reparsed, err := Parse(encoded)
+			require.NoError(t, err)
+			require.Equal(t, plist, reparsed)
+		}
+	}
+
+	t.Log("it escapes the strings")
+	{
+		encoded, err := Model{"Name": "Bitrise & <app>"}.Encode()
+		require.NoError(t, err)
+		require.Contains(t, string(encoded), "<string>Bitrise &amp; &lt;app&gt;</string>")
+	}
+
+	t.Log("it fails for unsupported values")
+	{
+		_, err := Model{"Ratio": float32(1.5)}.Encode()
+		require.Error(t, err)
+	}
+}
+
+func TestSet(t *testing.T) {
+	plist := Model{}
+
+	t.Log("it sets the supported values")
+	{
+		require.NoError(t, plist.Set("CFBundleIdentifier", "com.bitrise.sampleapp"))
+		require.NoError(t, plist.Set("UIDeviceFamily", []interface{}{int64(1)}))
+		require.NoError(t, plist.Set("Count", 2))
+
+		value, ok := plist.Get("Count")
+		require.Equal(t, true, ok)
+		require.Equal(t, int64(2), value)
+	}
+
+	t.Log("it fails for unsupported values")
+	{
+		require.Error(t, plist.Set("Ratio", float32(1.5)))
+		_, ok := plist.Get("Ratio")
+		require.Equal(t, false, ok)
+	}
+
+	t.Log("it sets the versions")
+	{
+		plist.SetBundleVersion("42")
+		plist.SetShortVersion("1.2.0")
+
+		bundleVersion, _ := plist.GetString(BundleVersionKey)
+		require.Equal(t, "42", bundleVersion)
+		shortVersion, _ := plist.GetString(BundleShortVersionKey)
+		require.Equal(t, "1.2.0", shortVersion)
+
+		plist.Delete(BundleVersionKey)
+		_, ok := plist.Get(BundleVersionKey)
+		require.Equal(t, false, ok)
+	}
+}
+
+func TestSetVersion(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("plist_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	pth := filepath.Join(tmpDir, "Info.plist")
+	require.NoError(t, fileutil.WriteStringToFile(pth, infoPlistContent))
+
+	t.Log("it sets the versions of the plist file")
+	{
+		require.NoError(t, SetVersion(pth, "43", "2.0"))
+
+		plist, err := New(pth)
+		require.NoError(t, err)
+		bundleVersion, _ := plist.GetString(BundleVersionKey)
+		require.Equal(t, "43", bundleVersion)
+		shortVersion, _ := plist.GetString(BundleShortVersionKey)
+		require.Equal(t, "2.0", shortVersion)
+		bundleID, _ := plist.GetString("CFBundleIdentifier")
+		require.Equal(t, "com.bitrise.sampleapp", bundleID)
+	}
+
+	t.Log("it keeps the version of empty value")
+	{
+		require.NoError(t, SetVersion(pth, "", "2.1"))
+
+		plist, err := New(pth)
+		require.NoError(t, err)
+		bundleVersion, _ := plist.GetString(BundleVersionKey)
+		require.Equal(t, "43", bundleVersion)
+	}
+
+	t.Log("it fails for not existing plist")
+	{
+		require.Error(t, SetVersion(filepath.Join(tmpDir, "not-existing.plist"), "1", ""))
+	}
+}
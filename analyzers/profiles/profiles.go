@@ -0,0 +1,350 @@
+package profiles
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-tools/go-xamarin/analyzers/plist"
+)
+
+// DistributionType - the kind of distribution a provisioning profile is used for
+type DistributionType string
+
+const (
+	// DistributionTypeUnknown ...
+	DistributionTypeUnknown DistributionType = ""
+	// DistributionTypeDevelopment - installable on the registered devices, debuggable (get-task-allow)
+	DistributionTypeDevelopment DistributionType = "development"
+	// DistributionTypeAdHoc - installable on the registered devices
+	DistributionTypeAdHoc DistributionType = "ad-hoc"
+	// DistributionTypeEnterprise - installable on any device, in-house distribution
+	DistributionTypeEnterprise DistributionType = "enterprise"
+	// DistributionTypeAppStore - App Store and TestFlight distribution
+	DistributionTypeAppStore DistributionType = "app-store"
+)
+
+// ParseDistributionType ...
+func ParseDistributionType(distributionType string) (DistributionType, error) {
+	switch DistributionType(strings.ToLower(distributionType)) {
+	case DistributionTypeDevelopment:
+		return DistributionTypeDevelopment, nil
+	case DistributionTypeAdHoc:
+		return DistributionTypeAdHoc, nil
+	case DistributionTypeEnterprise:
+		return DistributionTypeEnterprise, nil
+	case DistributionTypeAppStore:
+		return DistributionTypeAppStore, nil
+	default:
+		return DistributionTypeUnknown, fmt.Errorf("unknown distribution type: %s", distributionType)
+	}
+}
+
+// CertificateModel - a developer certificate of the provisioning profile
+type CertificateModel struct {
+	CommonName string // signing identity, like: iPhone Distribution: Bitrise Ltd (72SA8V3WYL)
+	SHA1       string // upper case hex fingerprint, as listed by: security find-identity
+	NotAfter   time.Time
+}
+
+// Model - attributes of a provisioning profile (.mobileprovision or .provisionprofile)
+type Model struct {
+	Pth string
+
+	UUID           string
+	Name           string
+	BundleID       string // application identifier without the team prefix, may be a wildcard, like: com.bitrise.*
+	TeamID         string
+	TeamName       string
+	Platforms      []string // like: iOS, tvOS, OSX
+	Type           DistributionType
+	ExpirationDate time.Time
+	Certificates   []CertificateModel
+	Entitlements   plist.Model // capabilities enabled for the App ID
+}
+
+// DefaultDir - the directory Xcode installs the provisioning profiles into
+func DefaultDir() string {
+	return filepath.Join(os.Getenv("HOME"), "Library", "MobileDevice", "Provisioning Profiles")
+}
+
+// New ...
+func New(pth string) (Model, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to read provisioning profile (%s), error: %s", pth, err)
+	}
+
+	profile, err := Parse(content)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to parse provisioning profile (%s), error: %s", pth, err)
+	}
+	profile.Pth = pth
+
+	return profile, nil
+}
+
+// Parse - parses the property list embedded into the signed (CMS) provisioning profile content
+func Parse(content []byte) (Model, error) {
+	start := bytes.Index(content, []byte("<?xml"))
+	end := bytes.LastIndex(content, []byte("</plist>"))
+	if start < 0 || end < start {
+		return Model{}, fmt.Errorf("no property list found")
+	}
+
+	profilePlist, err := plist.Parse(content[start : end+len("</plist>")])
+	if err != nil {
+		return Model{}, err
+	}
+
+	profile := Model{}
+	profile.UUID, _ = profilePlist.GetString("UUID")
+	profile.Name, _ = profilePlist.GetString("Name")
+	profile.TeamName, _ = profilePlist.GetString("TeamName")
+	profile.Platforms = stringArray(profilePlist["Platform"])
+	if teamIDs := stringArray(profilePlist["TeamIdentifier"]); len(teamIDs) > 0 {
+		profile.TeamID = teamIDs[0]
+	}
+
+	if expirationDate, ok := profilePlist.GetString("ExpirationDate"); ok {
+		if profile.ExpirationDate, err = time.Parse(time.RFC3339, expirationDate); err != nil {
+			return Model{}, fmt.Errorf("invalid expiration date: %s", expirationDate)
+		}
+	}
+
+	entitlements, _ := profilePlist.GetDict("Entitlements")
+	profile.Entitlements = entitlements
+	applicationIdentifier, ok := entitlements.GetString("application-identifier")
+	if !ok {
+		// macOS profiles
+		applicationIdentifier, _ = entitlements.GetString("com.apple.application-identifier")
+	}
+	profile.BundleID = applicationIdentifier
+	if split := strings.SplitN(applicationIdentifier, ".", 2); len(split) == 2 {
+		profile.BundleID = split[1]
+	}
+
+	getTaskAllow, _ := entitlements["get-task-allow"].(bool)
+	provisionsAllDevices, _ := profilePlist["ProvisionsAllDevices"].(bool)
+	_, hasDevices := profilePlist["ProvisionedDevices"]
+	switch {
+	case provisionsAllDevices:
+		profile.Type = DistributionTypeEnterprise
+	case hasDevices && getTaskAllow:
+		profile.Type = DistributionTypeDevelopment
+	case hasDevices:
+		profile.Type = DistributionTypeAdHoc
+	case getTaskAllow:
+		// macOS development profiles may not list devices
+		profile.Type = DistributionTypeDevelopment
+	default:
+		profile.Type = DistributionTypeAppStore
+	}
+
+	if certificates, ok := profilePlist["DeveloperCertificates"].([]interface{}); ok {
+		for _, certificate := range certificates {
+			data, ok := certificate.([]byte)
+			if !ok {
+				continue
+			}
+
+			parsed, err := x509.ParseCertificate(data)
+			if err != nil {
+				return Model{}, fmt.Errorf("invalid developer certificate, error: %s", err)
+			}
+
+			profile.Certificates = append(profile.Certificates, CertificateModel{
+				CommonName: parsed.Subject.CommonName,
+				SHA1:       fmt.Sprintf("%X", sha1.Sum(data)),
+				NotAfter:   parsed.NotAfter,
+			})
+		}
+	}
+
+	return profile, nil
+}
+
+func stringArray(value interface{}) []string {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	strs := []string{}
+	for _, item := range array {
+		if str, ok := item.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+// List - parses the provisioning profiles of the directory, unreadable profiles are skipped
+func List(dir string) ([]Model, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provisioning profiles (%s), error: %s", dir, err)
+	}
+
+	profiles := []Model{}
+	for _, info := range infos {
+		ext := strings.ToLower(filepath.Ext(info.Name()))
+		if info.IsDir() || (ext != ".mobileprovision" && ext != ".provisionprofile") {
+			continue
+		}
+
+		profile, err := New(filepath.Join(dir, info.Name()))
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// IsExpired ...
+func (profile Model) IsExpired(now time.Time) bool {
+	return !profile.ExpirationDate.IsZero() && !now.Before(profile.ExpirationDate)
+}
+
+// IsWildcard - the profile matches every bundle id with its prefix
+func (profile Model) IsWildcard() bool {
+	return strings.HasSuffix(profile.BundleID, "*")
+}
+
+// MatchesBundleID - the profile's bundle id is the given one, or a wildcard matching it
+func (profile Model) MatchesBundleID(bundleID string) bool {
+	if profile.IsWildcard() {
+		return strings.HasPrefix(bundleID, strings.TrimSuffix(profile.BundleID, "*"))
+	}
+	return profile.BundleID == bundleID
+}
+
+// ValidCertificates - the certificates of the profile not expired at the given time
+func (profile Model) ValidCertificates(now time.Time) []CertificateModel {
+	certificates := []CertificateModel{}
+	for _, certificate := range profile.Certificates {
+		if now.Before(certificate.NotAfter) {
+			certificates = append(certificates, certificate)
+		}
+	}
+	return certificates
+}
+
+// Select - returns the best profile for the bundle id and distribution type, not expired and having a valid certificate:
+// explicit bundle ids are preferred over wildcards, the longest wildcard is preferred over shorter ones,
+// the latest expiring profile is preferred among the equal ones
+func Select(profiles []Model, bundleID string, distributionType DistributionType, now time.Time) (Model, bool) {
+	candidates := []Model{}
+	for _, profile := range profiles {
+		if profile.Type != distributionType || profile.IsExpired(now) || !profile.MatchesBundleID(bundleID) {
+			continue
+		}
+		if len(profile.ValidCertificates(now)) == 0 {
+			continue
+		}
+		candidates = append(candidates, profile)
+	}
+
+	if len(candidates) == 0 {
+		return Model{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].IsWildcard() != candidates[j].IsWildcard() {
+			return !candidates[i].IsWildcard()
+		}
+		if len(candidates[i].BundleID) != len(candidates[j].BundleID) {
+			return len(candidates[i].BundleID) > len(candidates[j].BundleID)
+		}
+		return candidates[i].ExpirationDate.After(candidates[j].ExpirationDate)
+	})
+
+	return candidates[0], true
+}
+
+// entitlementRequiresProfile - the entitlement has to be enabled for the App ID and included in the profile,
+// other entitlements (like the macOS sandbox) do not need provisioning
+func entitlementRequiresProfile(key string) bool {
+	switch key {
+	case "aps-environment", "com.apple.security.application-groups":
+		return true
+	case "com.apple.developer.team-identifier":
+		// resolved by the build
+		return false
+	}
+	return strings.HasPrefix(key, "com.apple.developer.")
+}
+
+func entitlementValueMatches(profileValue, value string) bool {
+	if strings.HasSuffix(profileValue, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(profileValue, "*"))
+	}
+	return profileValue == value
+}
+
+// ValidateEntitlements - returns the app's entitlements (Entitlements.plist) not granted by the profile:
+// capabilities (aps-environment, com.apple.developer.*) not enabled for the App ID,
+// app groups and associated domains not listed in the profile.
+// Values with build variables, like $(AppIdentifierPrefix), are not validated.
+func (profile Model) ValidateEntitlements(entitlements plist.Model) []string {
+	keys := []string{}
+	for key := range entitlements {
+		if entitlementRequiresProfile(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	mismatches := []string{}
+	for _, key := range keys {
+		if enabled, ok := entitlements[key].(bool); ok && !enabled {
+			continue
+		}
+
+		profileValue, ok := profile.Entitlements[key]
+		if !ok {
+			mismatches = append(mismatches, fmt.Sprintf("entitlement (%s) is not included in the provisioning profile (%s), enable the capability for the App ID (%s) and regenerate the profile", key, profile.Name, profile.BundleID))
+			continue
+		}
+
+		profileValues := stringArray(profileValue)
+		if str, ok := profileValue.(string); ok {
+			profileValues = []string{str}
+		}
+
+		values := stringArray(entitlements[key])
+		if str, ok := entitlements[key].(string); ok && key != "aps-environment" {
+			// the aps-environment of the profile is used for signing
+			values = []string{str}
+		}
+
+		for _, value := range values {
+			if strings.Contains(value, "$(") {
+				continue
+			}
+
+			matches := false
+			for _, allowed := range profileValues {
+				if entitlementValueMatches(allowed, value) {
+					matches = true
+					break
+				}
+			}
+			if !matches {
+				mismatches = append(mismatches, fmt.Sprintf("entitlement (%s) value (%s) is not included in the provisioning profile (%s), add it to the App ID (%s) and regenerate the profile", key, value, profile.Name, profile.BundleID))
+			}
+		}
+	}
+
+	return mismatches
+}
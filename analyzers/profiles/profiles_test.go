@@ -0,0 +1,234 @@
+package profiles
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/analyzers/plist"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testCertificate(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return certificate
+}
+
+// testProfileContent wraps the profile plist into binary garbage, like the CMS envelope of a real profile
+func testProfileContent(uuid, applicationIdentifier, expirationDate, entitlements, extra string, certificate []byte) string {
+	return "0\x82\x1d\x06\t*\x86H\x86\xf7\r\x01\x07\x02\xa0" + `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>UUID</key>
+	<string>` + uuid + `</string>
+	<key>Name</key>
+	<string>Sample ` + uuid + `</string>
+	<key>TeamName</key>
+	<string>Bitrise Ltd</string>
+	<key>TeamIdentifier</key>
+	<array>
+		<string>72SA8V3WYL</string>
+	</array>
+	<key>Platform</key>
+	<array>
+		<string>iOS</string>
+	</array>
+	<key>ExpirationDate</key>
+	<date>` + expirationDate + `</date>
+	<key>DeveloperCertificates</key>
+	<array>
+		<data>` + base64.StdEncoding.EncodeToString(certificate) + `</data>
+	</array>
+	<key>Entitlements</key>
+	<dict>
+		<key>application-identifier</key>
+		<string>72SA8V3WYL.` + applicationIdentifier + `</string>
+		` + entitlements + `
+	</dict>
+	` + extra + `
+</dict>
+</plist>` + "\xa0\x82\r\x00"
+}
+
+func TestParse(t *testing.T) {
+	certificate := testCertificate(t, "iPhone Distribution: Bitrise Ltd (72SA8V3WYL)", now.AddDate(1, 0, 0))
+
+	t.Log("it parses app store profile")
+	{
+		profile, err := Parse([]byte(testProfileContent("uuid-1", "com.bitrise.sampleapp", "2019-01-01T00:00:00Z", "", "", certificate)))
+		require.NoError(t, err)
+		require.Equal(t, "uuid-1", profile.UUID)
+		require.Equal(t, "Sample uuid-1", profile.Name)
+		require.Equal(t, "com.bitrise.sampleapp", profile.BundleID)
+		require.Equal(t, "72SA8V3WYL", profile.TeamID)
+		require.Equal(t, "Bitrise Ltd", profile.TeamName)
+		require.Equal(t, []string{"iOS"}, profile.Platforms)
+		require.Equal(t, DistributionTypeAppStore, profile.Type)
+		require.Equal(t, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), profile.ExpirationDate)
+		require.Equal(t, 1, len(profile.Certificates))
+		require.Equal(t, "iPhone Distribution: Bitrise Ltd (72SA8V3WYL)", profile.Certificates[0].CommonName)
+		require.Equal(t, 40, len(profile.Certificates[0].SHA1))
+		require.Equal(t, "72SA8V3WYL.com.bitrise.sampleapp", profile.Entitlements["application-identifier"])
+	}
+
+	t.Log("it detects the distribution type")
+	{
+		devices := `<key>ProvisionedDevices</key><array><string>device</string></array>`
+
+		profile, err := Parse([]byte(testProfileContent("uuid", "*", "2019-01-01T00:00:00Z", `<key>get-task-allow</key><true/>`, devices, certificate)))
+		require.NoError(t, err)
+		require.Equal(t, DistributionTypeDevelopment, profile.Type)
+		require.Equal(t, "*", profile.BundleID)
+
+		profile, err = Parse([]byte(testProfileContent("uuid", "*", "2019-01-01T00:00:00Z", `<key>get-task-allow</key><false/>`, devices, certificate)))
+		require.NoError(t, err)
+		require.Equal(t, DistributionTypeAdHoc, profile.Type)
+
+		profile, err = Parse([]byte(testProfileContent("uuid", "*", "2019-01-01T00:00:00Z", "", `<key>ProvisionsAllDevices</key><true/>`, certificate)))
+		require.NoError(t, err)
+		require.Equal(t, DistributionTypeEnterprise, profile.Type)
+	}
+
+	t.Log("it fails without property list")
+	{
+		_, err := Parse([]byte("0\x82\x1d\x06"))
+		require.Error(t, err)
+	}
+}
+
+func TestValidateEntitlements(t *testing.T) {
+	profile := Model{
+		Name:     "Sample",
+		BundleID: "com.bitrise.sampleapp",
+		Entitlements: plist.Model{
+			"application-identifier":                           "72SA8V3WYL.com.bitrise.sampleapp",
+			"aps-environment":                                  "production",
+			"com.apple.security.application-groups":            []interface{}{"group.com.bitrise.sampleapp"},
+			"com.apple.developer.associated-domains":           "*",
+			"com.apple.developer.icloud-container-identifiers": []interface{}{"iCloud.com.bitrise.*"},
+		},
+	}
+
+	t.Log("it accepts the entitlements granted by the profile")
+	{
+		entitlements := plist.Model{
+			"aps-environment":                                  "development",
+			"keychain-access-groups":                           []interface{}{"$(AppIdentifierPrefix)com.bitrise.sampleapp"},
+			"com.apple.security.app-sandbox":                   true,
+			"com.apple.security.application-groups":            []interface{}{"group.com.bitrise.sampleapp"},
+			"com.apple.developer.associated-domains":           []interface{}{"applinks:bitrise.io"},
+			"com.apple.developer.icloud-container-identifiers": []interface{}{"iCloud.com.bitrise.sampleapp", "iCloud.$(CFBundleIdentifier)"},
+			"com.apple.developer.siri":                         false,
+		}
+		require.Equal(t, []string{}, profile.ValidateEntitlements(entitlements))
+	}
+
+	t.Log("it reports the missing capabilities and values")
+	{
+		entitlements := plist.Model{
+			"com.apple.security.application-groups": []interface{}{"group.com.bitrise.sampleapp", "group.com.bitrise.other"},
+			"com.apple.developer.siri":              true,
+		}
+
+		profile.Entitlements = plist.Model{"com.apple.security.application-groups": []interface{}{"group.com.bitrise.sampleapp"}}
+		entitlements["aps-environment"] = "production"
+
+		mismatches := profile.ValidateEntitlements(entitlements)
+		require.Equal(t, 3, len(mismatches))
+		require.Contains(t, mismatches[0], "(aps-environment)")
+		require.Contains(t, mismatches[1], "(com.apple.developer.siri)")
+		require.Contains(t, mismatches[2], "(group.com.bitrise.other)")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	valid := []CertificateModel{{CommonName: "iPhone Distribution: Bitrise Ltd (72SA8V3WYL)", NotAfter: now.AddDate(1, 0, 0)}}
+	expired := []CertificateModel{{CommonName: "iPhone Distribution: Bitrise Ltd (72SA8V3WYL)", NotAfter: now.AddDate(-1, 0, 0)}}
+
+	profiles := []Model{
+		{UUID: "wildcard", BundleID: "*", Type: DistributionTypeAppStore, ExpirationDate: now.AddDate(0, 6, 0), Certificates: valid},
+		{UUID: "prefix-wildcard", BundleID: "com.bitrise.*", Type: DistributionTypeAppStore, ExpirationDate: now.AddDate(0, 1, 0), Certificates: valid},
+		{UUID: "explicit", BundleID: "com.bitrise.sampleapp", Type: DistributionTypeAppStore, ExpirationDate: now.AddDate(0, 1, 0), Certificates: valid},
+		{UUID: "explicit-later", BundleID: "com.bitrise.sampleapp", Type: DistributionTypeAppStore, ExpirationDate: now.AddDate(0, 2, 0), Certificates: valid},
+		{UUID: "explicit-expired", BundleID: "com.bitrise.sampleapp", Type: DistributionTypeAppStore, ExpirationDate: now.AddDate(0, -1, 0), Certificates: valid},
+		{UUID: "explicit-expired-certificate", BundleID: "com.bitrise.sampleapp", Type: DistributionTypeAppStore, ExpirationDate: now.AddDate(1, 0, 0), Certificates: expired},
+		{UUID: "explicit-adhoc", BundleID: "com.bitrise.sampleapp", Type: DistributionTypeAdHoc, ExpirationDate: now.AddDate(1, 0, 0), Certificates: valid},
+	}
+
+	t.Log("it prefers the latest expiring explicit profile")
+	{
+		profile, ok := Select(profiles, "com.bitrise.sampleapp", DistributionTypeAppStore, now)
+		require.Equal(t, true, ok)
+		require.Equal(t, "explicit-later", profile.UUID)
+	}
+
+	t.Log("it prefers the longest wildcard")
+	{
+		profile, ok := Select(profiles, "com.bitrise.otherapp", DistributionTypeAppStore, now)
+		require.Equal(t, true, ok)
+		require.Equal(t, "prefix-wildcard", profile.UUID)
+
+		profile, ok = Select(profiles, "io.bitrise.otherapp", DistributionTypeAppStore, now)
+		require.Equal(t, true, ok)
+		require.Equal(t, "wildcard", profile.UUID)
+	}
+
+	t.Log("it matches the distribution type")
+	{
+		profile, ok := Select(profiles, "com.bitrise.sampleapp", DistributionTypeAdHoc, now)
+		require.Equal(t, true, ok)
+		require.Equal(t, "explicit-adhoc", profile.UUID)
+
+		_, ok = Select(profiles, "com.bitrise.sampleapp", DistributionTypeEnterprise, now)
+		require.Equal(t, false, ok)
+	}
+}
+
+func TestList(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("profiles")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	certificate := testCertificate(t, "iPhone Developer: Bitrise Bot (ABCDE12345)", now.AddDate(1, 0, 0))
+	for i, ext := range []string{".mobileprovision", ".provisionprofile", ".txt"} {
+		uuid := fmt.Sprintf("uuid-%d", i)
+		content := testProfileContent(uuid, "com.bitrise.sampleapp", "2019-01-01T00:00:00Z", "", "", certificate)
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, uuid+ext), content))
+	}
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "invalid.mobileprovision"), "invalid"))
+
+	t.Log("it lists the parseable profiles")
+	{
+		profiles, err := List(tmpDir)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(profiles))
+		require.Equal(t, filepath.Join(tmpDir, "uuid-0.mobileprovision"), profiles[0].Pth)
+		require.Equal(t, "uuid-1", profiles[1].UUID)
+	}
+}
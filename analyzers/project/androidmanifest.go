@@ -0,0 +1,42 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/manifest"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+const androidManifestProperty = "AndroidManifest"
+
+// analyzeConfigurationManifests sets the AndroidManifest of every configuration:
+// the configuration's own AndroidManifest property overrides the project's manifest
+func analyzeConfigurationManifests(project Model) Model {
+	if project.SDK != constants.SDKAndroid {
+		return project
+	}
+
+	projectDir := filepath.Dir(project.Pth)
+
+	for configKey, configurationPlatform := range project.Configs {
+		configurationPlatform.ManifestPth = project.ManifestPth
+
+		if manifest := strings.TrimSpace(configurationPlatform.properties[strings.ToLower(androidManifestProperty)]); manifest != "" {
+			configurationPlatform.ManifestPth = resolvePath(projectDir, utility.FixWindowsPath(manifest))
+		}
+
+		if configurationPlatform.ManifestPth != "" {
+			// the manifest may be invalid or not yet generated
+			project.inputs.record(configurationPlatform.ManifestPth)
+			if androidManifest, err := manifest.New(configurationPlatform.ManifestPth); err == nil {
+				configurationPlatform.AndroidDebuggable = androidManifest.Application.Debuggable
+			}
+		}
+
+		project.Configs[configKey] = configurationPlatform
+	}
+
+	return project
+}
@@ -0,0 +1,83 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+// Android signing and packaging properties
+const (
+	androidKeyStoreProperty            = "AndroidKeyStore"
+	androidSigningKeyStoreProperty     = "AndroidSigningKeyStore"
+	androidSigningStorePassProperty    = "AndroidSigningStorePass"
+	androidSigningKeyAliasProperty     = "AndroidSigningKeyAlias"
+	androidSigningKeyPassProperty      = "AndroidSigningKeyPass"
+	androidPackageFormatProperty       = "AndroidPackageFormat"
+	androidCreatePackagePerAbiProperty = "AndroidCreatePackagePerAbi"
+	androidSupportedAbisProperty       = "AndroidSupportedAbis"
+	monoSymbolArchiveProperty          = "MonoSymbolArchive"
+	embedAssembliesIntoApkProperty     = "EmbedAssembliesIntoApk"
+	androidUseSharedRuntimeProperty    = "AndroidUseSharedRuntime"
+)
+
+// MissingAndroidSigningProperties - returns the names of the keystore properties, which are required for release signing but not defined
+func (configurationPlatform ConfigurationPlatformModel) MissingAndroidSigningProperties() []string {
+	missing := []string{}
+	if configurationPlatform.AndroidSigningKeyStore == "" {
+		missing = append(missing, androidSigningKeyStoreProperty)
+	}
+	if !configurationPlatform.HasAndroidSigningStorePass {
+		missing = append(missing, androidSigningStorePassProperty)
+	}
+	if configurationPlatform.AndroidSigningKeyAlias == "" {
+		missing = append(missing, androidSigningKeyAliasProperty)
+	}
+	if !configurationPlatform.HasAndroidSigningKeyPass {
+		missing = append(missing, androidSigningKeyPassProperty)
+	}
+	return missing
+}
+
+// analyzeAndroidSigning fills the configurations' signing fields from the evaluated properties.
+// Password values are not stored, only their existence.
+func analyzeAndroidSigning(project Model) Model {
+	if project.SDK != constants.SDKAndroid {
+		return project
+	}
+
+	projectDir := filepath.Dir(project.Pth)
+
+	for configKey, configurationPlatform := range project.Configs {
+		properties := evaluationProperties(project, project.Pth, configurationPlatform)
+		property := func(name string) string {
+			return strings.TrimSpace(properties[strings.ToLower(name)])
+		}
+
+		if strings.EqualFold(property(androidKeyStoreProperty), "true") {
+			configurationPlatform.SignAndroid = true
+		}
+
+		if keystore := property(androidSigningKeyStoreProperty); keystore != "" {
+			configurationPlatform.AndroidSigningKeyStore = resolvePath(projectDir, utility.FixWindowsPath(keystore))
+		}
+		configurationPlatform.AndroidSigningKeyAlias = property(androidSigningKeyAliasProperty)
+		configurationPlatform.HasAndroidSigningStorePass = property(androidSigningStorePassProperty) != ""
+		configurationPlatform.HasAndroidSigningKeyPass = property(androidSigningKeyPassProperty) != ""
+
+		configurationPlatform.AndroidPackageFormat = strings.ToLower(property(androidPackageFormatProperty))
+		configurationPlatform.AndroidCreatePackagePerAbi = strings.EqualFold(property(androidCreatePackagePerAbiProperty), "true")
+		if abis := property(androidSupportedAbisProperty); abis != "" {
+			configurationPlatform.AndroidSupportedAbis = utility.SplitAndStripList(abis, ";")
+		}
+		configurationPlatform.MonoSymbolArchive = strings.EqualFold(property(monoSymbolArchiveProperty), "true")
+		configurationPlatform.AndroidFastDeployment = strings.EqualFold(property(embedAssembliesIntoApkProperty), "false") ||
+			strings.EqualFold(property(androidUseSharedRuntimeProperty), "true")
+
+		project.Configs[configKey] = configurationPlatform
+	}
+
+	return project
+}
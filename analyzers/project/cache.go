@@ -0,0 +1,230 @@
+package project
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/version"
+)
+
+// CacheDirEnvKey - the environment variable of the project cache directory
+const CacheDirEnvKey = "GO_XAMARIN_CACHE_DIR"
+
+// Cache - an on-disk cache of the analyzed projects, keyed by the SHA-256 of the project file (and of its path,
+// as the analyzed model contains absolute paths). An entry is not used if any of the files read or looked up by the analysis
+// (the .props and .targets files, the Info.plist, the packages.config, the AndroidManifest, ...) changed, appeared or disappeared,
+// or if it was written by another go-xamarin build.
+type Cache struct {
+	dir string
+}
+
+// cacheEntryModel - the cached project, with its evaluated properties, which are not exported by the Model
+type cacheEntryModel struct {
+	Version string
+	Pth     string
+	Inputs  map[string]string // path read or looked up by the analysis - its state, see: inputState
+
+	Project          Model
+	Properties       map[string]string
+	OutputPath       string
+	GUIDProjectType  constants.ProjectType
+	ConfigProperties map[string]map[string]string // Project Configuration|Platform - evaluated properties
+}
+
+// NewCache - the cache directory is created on the first write
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir ...
+func (cache Cache) Dir() string {
+	return cache.dir
+}
+
+var (
+	cacheVersionOnce  sync.Once
+	cacheVersionValue string
+)
+
+// cacheVersion returns the version of the go-xamarin build writing the entries: the release version with the commit,
+// or with the SHA-256 of the executable for development builds, which have no commit set.
+// Empty version means the build can not be identified, and the cache is not used.
+func cacheVersion() string {
+	cacheVersionOnce.Do(func() {
+		build := version.Commit
+		if build == "" {
+			pth, err := os.Executable()
+			if err != nil {
+				return
+			}
+			if build, err = hashFile(pth); err != nil {
+				return
+			}
+		}
+		cacheVersionValue = version.VERSION + "+" + build
+	})
+	return cacheVersionValue
+}
+
+func hashContent(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func hashFile(pth string) (string, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return "", err
+	}
+	return hashContent(content), nil
+}
+
+// inputState returns the state of the analysis input: the SHA-256 of the file's content,
+// "dir" for a directory or "missing" for a not existing path
+func inputState(pth string) (string, error) {
+	info, err := os.Stat(pth)
+	if os.IsNotExist(err) {
+		return "missing", nil
+	} else if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "dir", nil
+	}
+	return hashFile(pth)
+}
+
+// entryPth returns the path of the project's cache entry, like: <dir>/<content sha256>-<path sha256 prefix>.gob
+func (cache Cache) entryPth(pth string, content []byte) string {
+	return filepath.Join(cache.dir, hashContent(content)+"-"+hashContent([]byte(pth))[:16]+".gob")
+}
+
+// load returns the cached project, if the entry exists and it is up to date
+func (cache Cache) load(pth string, content []byte) (Model, bool) {
+	file, err := os.Open(cache.entryPth(pth, content))
+	if err != nil {
+		return Model{}, false
+	}
+	defer func() {
+		// the entry is only read
+		_ = file.Close()
+	}()
+
+	var entry cacheEntryModel
+	if err := gob.NewDecoder(file).Decode(&entry); err != nil {
+		return Model{}, false
+	}
+	if entry.Version != cacheVersion() || entry.Pth != pth {
+		return Model{}, false
+	}
+	inputs := analysisInputs{}
+	for inputPth, inputHash := range entry.Inputs {
+		if state, err := inputState(inputPth); err != nil || state != inputHash {
+			return Model{}, false
+		}
+		inputs.record(inputPth)
+	}
+
+	project := entry.Project
+	project.properties = entry.Properties
+	project.outputPath = entry.OutputPath
+	project.guidProjectType = entry.GUIDProjectType
+	project.inputs = inputs
+	for config, configurationPlatform := range project.Configs {
+		configurationPlatform.properties = entry.ConfigProperties[config]
+		project.Configs[config] = configurationPlatform
+	}
+
+	return project, true
+}
+
+// store writes the project's entry, the entry is written into a temporary file and moved in place,
+// so concurrent loads never read a partial entry
+func (cache Cache) store(pth string, content []byte, project Model) error {
+	entry := cacheEntryModel{
+		Version:          cacheVersion(),
+		Pth:              pth,
+		Inputs:           map[string]string{},
+		Project:          project,
+		Properties:       project.properties,
+		OutputPath:       project.outputPath,
+		GUIDProjectType:  project.guidProjectType,
+		ConfigProperties: map[string]map[string]string{},
+	}
+	for inputPth := range project.inputs {
+		state, err := inputState(inputPth)
+		if err != nil {
+			return fmt.Errorf("failed to hash analysis input (%s), error: %s", inputPth, err)
+		}
+		entry.Inputs[inputPth] = state
+	}
+	for config, configurationPlatform := range project.Configs {
+		entry.ConfigProperties[config] = configurationPlatform.properties
+	}
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(entry); err != nil {
+		return fmt.Errorf("failed to encode project (%s), error: %s", pth, err)
+	}
+
+	if err := os.MkdirAll(cache.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache dir (%s), error: %s", cache.dir, err)
+	}
+
+	tmpFile, err := ioutil.TempFile(cache.dir, "entry")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry, error: %s", err)
+	}
+	if _, err := tmpFile.Write(buffer.Bytes()); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to write cache entry, error: %s", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to write cache entry, error: %s", err)
+	}
+
+	entryPth := cache.entryPth(pth, content)
+	if err := os.Rename(tmpFile.Name(), entryPth); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return fmt.Errorf("failed to write cache entry (%s), error: %s", entryPth, err)
+	}
+	return nil
+}
+
+// NewWithCache - returns the cached project if it is up to date, otherwise analyzes the project and caches it.
+// With nil cache the project is analyzed, like by New. Failing to write the cache does not fail the analysis.
+func NewWithCache(pth string, cache *Cache) (Model, error) {
+	if cache == nil || cacheVersion() == "" {
+		return New(pth)
+	}
+
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return New(pth)
+	}
+
+	if project, ok := cache.load(pth, content); ok {
+		return project, nil
+	}
+
+	project, err := New(pth)
+	if err != nil {
+		return Model{}, err
+	}
+
+	// the cache is an optimization only, the project is analyzed anyway
+	_ = cache.store(pth, content, project)
+
+	return project, nil
+}
@@ -0,0 +1,116 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	projectDir := filepath.Join(tmpDir, "App.iOS")
+	buildDir := filepath.Join(tmpDir, "build")
+	require.NoError(t, os.MkdirAll(projectDir, 0777))
+	require.NoError(t, os.MkdirAll(buildDir, 0777))
+
+	pth := tmpProjectWithContentInDir(t, importsTestProjectContent, projectDir)
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "Directory.Build.props"), importsTestDirectoryBuildPropsContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(buildDir, "common.targets"), importsTestCommonTargetsContent))
+
+	cache := NewCache(filepath.Join(tmpDir, "cache"))
+
+	analyzed, err := NewWithCache(pth, cache)
+	require.NoError(t, err)
+
+	t.Log("it caches the analyzed project")
+	{
+		content, err := fileutil.ReadBytesFromFile(pth)
+		require.NoError(t, err)
+
+		cached, ok := cache.load(pth, content)
+		require.Equal(t, true, ok)
+		require.Equal(t, analyzed.OutputModel(), cached.OutputModel())
+		require.Equal(t, analyzed.properties, cached.properties)
+		require.Equal(t, analyzed.Configs["Debug|iPhone"].OutputDir, cached.Configs["Debug|iPhone"].OutputDir)
+		require.Equal(t, analyzed.ResolvePath("Info.plist"), cached.ResolvePath("Info.plist"))
+
+		project, err := NewWithCache(pth, cache)
+		require.NoError(t, err)
+		require.Equal(t, analyzed.OutputModel(), project.OutputModel())
+	}
+
+	t.Log("it does not use the entry of a changed import")
+	{
+		content, err := fileutil.ReadBytesFromFile(pth)
+		require.NoError(t, err)
+
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(buildDir, "common.targets"), importsTestCommonTargetsContent+"\n"))
+		_, ok := cache.load(pth, content)
+		require.Equal(t, false, ok)
+	}
+
+	t.Log("it does not use the entry, if a looked up file appears")
+	{
+		content, err := fileutil.ReadBytesFromFile(pth)
+		require.NoError(t, err)
+
+		_, err = NewWithCache(pth, cache)
+		require.NoError(t, err)
+		_, ok := cache.load(pth, content)
+		require.Equal(t, true, ok)
+
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(projectDir, "Info.plist"), infoPlistTestContent))
+		_, ok = cache.load(pth, content)
+		require.Equal(t, false, ok)
+
+		project, err := NewWithCache(pth, cache)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(projectDir, "Info.plist"), project.InfoPlistPth)
+
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(projectDir, "Directory.Build.props"), "<Project />"))
+		_, ok = cache.load(pth, content)
+		require.Equal(t, false, ok)
+		require.NoError(t, os.Remove(filepath.Join(projectDir, "Directory.Build.props")))
+	}
+
+	t.Log("it does not use the entry of a changed Info.plist")
+	{
+		content, err := fileutil.ReadBytesFromFile(pth)
+		require.NoError(t, err)
+
+		_, err = NewWithCache(pth, cache)
+		require.NoError(t, err)
+		_, ok := cache.load(pth, content)
+		require.Equal(t, true, ok)
+
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(projectDir, "Info.plist"), infoPlistTestContent+"\n"))
+		_, ok = cache.load(pth, content)
+		require.Equal(t, false, ok)
+	}
+
+	t.Log("it does not use the entry of a changed project")
+	{
+		require.NoError(t, fileutil.WriteStringToFile(pth, importsTestProjectContent+"\n"))
+		content, err := fileutil.ReadBytesFromFile(pth)
+		require.NoError(t, err)
+
+		_, ok := cache.load(pth, content)
+		require.Equal(t, false, ok)
+	}
+
+	t.Log("it analyzes the project without cache")
+	{
+		project, err := NewWithCache(pth, nil)
+		require.NoError(t, err)
+		require.Equal(t, analyzed.AssemblyName, project.AssemblyName)
+	}
+}
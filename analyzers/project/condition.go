@@ -0,0 +1,328 @@
+package project
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+type conditionTokenType int
+
+const (
+	conditionTokenEOF conditionTokenType = iota
+	conditionTokenString
+	conditionTokenWord
+	conditionTokenOperator
+	conditionTokenLeftParen
+	conditionTokenRightParen
+	conditionTokenComma
+)
+
+type conditionToken struct {
+	tokenType conditionTokenType
+	value     string
+}
+
+// conditionParser evaluates MSBuild conditions, like:
+// '$(Configuration)|$(Platform)' == 'Release|iPhone' And !Exists('$(SolutionDir)custom.props')
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+
+	properties map[string]string
+	dir        string         // relative paths (of Exists) are resolved against dir
+	inputs     analysisInputs // the paths of Exists are recorded into inputs
+}
+
+// evaluateCondition returns the value of the MSBuild condition,
+// property references are expanded with the given properties, relative paths are resolved against dir
+func evaluateCondition(condition string, properties map[string]string, dir string, inputs analysisInputs) (bool, error) {
+	if strings.TrimSpace(condition) == "" {
+		return true, nil
+	}
+
+	tokens, err := tokenizeCondition(condition)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition (%s), error: %s", condition, err)
+	}
+
+	parser := conditionParser{
+		tokens:     tokens,
+		properties: properties,
+		dir:        dir,
+		inputs:     inputs,
+	}
+
+	value, err := parser.parseOr()
+	if err != nil {
+		return false, fmt.Errorf("invalid condition (%s), error: %s", condition, err)
+	}
+
+	if token := parser.peek(); token.tokenType != conditionTokenEOF {
+		return false, fmt.Errorf("invalid condition (%s), error: unexpected token: %s", condition, token.value)
+	}
+
+	return value, nil
+}
+
+func tokenizeCondition(condition string) ([]conditionToken, error) {
+	tokens := []conditionToken{}
+
+	for i := 0; i < len(condition); {
+		c := condition[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, conditionToken{conditionTokenLeftParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, conditionToken{conditionTokenRightParen, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, conditionToken{conditionTokenComma, ","})
+			i++
+		case c == '\'':
+			end := strings.IndexByte(condition[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at: %d", i)
+			}
+			tokens = append(tokens, conditionToken{conditionTokenString, condition[i+1 : i+1+end]})
+			i += end + 2
+		case c == '=' || c == '!' || c == '<' || c == '>':
+			operator := string(c)
+			if i+1 < len(condition) && condition[i+1] == '=' {
+				operator += "="
+			}
+			if operator == "=" {
+				return nil, fmt.Errorf("invalid operator at: %d", i)
+			}
+			tokens = append(tokens, conditionToken{conditionTokenOperator, operator})
+			i += len(operator)
+		default:
+			start := i
+			for i < len(condition) && !strings.ContainsRune(" \t\n\r(),'=!<>", rune(condition[i])) {
+				// unquoted property reference, like: $(Foo)
+				if condition[i] == '$' && i+1 < len(condition) && condition[i+1] == '(' {
+					end := strings.IndexByte(condition[i:], ')')
+					if end < 0 {
+						return nil, fmt.Errorf("unterminated property reference at: %d", i)
+					}
+					i += end + 1
+					continue
+				}
+				i++
+			}
+			tokens = append(tokens, conditionToken{conditionTokenWord, condition[start:i]})
+		}
+	}
+
+	return append(tokens, conditionToken{conditionTokenEOF, ""}), nil
+}
+
+func (parser *conditionParser) peek() conditionToken {
+	return parser.tokens[parser.pos]
+}
+
+func (parser *conditionParser) next() conditionToken {
+	token := parser.tokens[parser.pos]
+	if token.tokenType != conditionTokenEOF {
+		parser.pos++
+	}
+	return token
+}
+
+func (parser *conditionParser) isKeyword(keyword string) bool {
+	token := parser.peek()
+	return token.tokenType == conditionTokenWord && strings.EqualFold(token.value, keyword)
+}
+
+// or := and ('Or' and)*
+func (parser *conditionParser) parseOr() (bool, error) {
+	value, err := parser.parseAnd()
+	if err != nil {
+		return false, err
+	}
+
+	for parser.isKeyword("or") {
+		parser.next()
+
+		right, err := parser.parseAnd()
+		if err != nil {
+			return false, err
+		}
+		value = value || right
+	}
+
+	return value, nil
+}
+
+// and := unary ('And' unary)*
+func (parser *conditionParser) parseAnd() (bool, error) {
+	value, err := parser.parseUnary()
+	if err != nil {
+		return false, err
+	}
+
+	for parser.isKeyword("and") {
+		parser.next()
+
+		right, err := parser.parseUnary()
+		if err != nil {
+			return false, err
+		}
+		value = value && right
+	}
+
+	return value, nil
+}
+
+// unary := '!' unary | '(' or ')' | comparison
+func (parser *conditionParser) parseUnary() (bool, error) {
+	token := parser.peek()
+
+	if token.tokenType == conditionTokenOperator && token.value == "!" {
+		parser.next()
+
+		value, err := parser.parseUnary()
+		return !value, err
+	}
+
+	if token.tokenType == conditionTokenLeftParen {
+		parser.next()
+
+		value, err := parser.parseOr()
+		if err != nil {
+			return false, err
+		}
+
+		if token := parser.next(); token.tokenType != conditionTokenRightParen {
+			return false, fmt.Errorf("expected ), found: %s", token.value)
+		}
+		return value, nil
+	}
+
+	return parser.parseComparison()
+}
+
+// comparison := operand (operator operand)?
+func (parser *conditionParser) parseComparison() (bool, error) {
+	left, isFunctionResult, err := parser.parseOperand()
+	if err != nil {
+		return false, err
+	}
+
+	token := parser.peek()
+	if token.tokenType != conditionTokenOperator || token.value == "!" {
+		if isFunctionResult {
+			return left == "true", nil
+		}
+		return parseConditionBool(left)
+	}
+	parser.next()
+
+	right, _, err := parser.parseOperand()
+	if err != nil {
+		return false, err
+	}
+
+	switch token.value {
+	case "==":
+		return strings.EqualFold(left, right), nil
+	case "!=":
+		return !strings.EqualFold(left, right), nil
+	default:
+		leftNumber, err := strconv.ParseFloat(strings.TrimSpace(left), 64)
+		if err != nil {
+			return false, fmt.Errorf("not a number: %s", left)
+		}
+		rightNumber, err := strconv.ParseFloat(strings.TrimSpace(right), 64)
+		if err != nil {
+			return false, fmt.Errorf("not a number: %s", right)
+		}
+
+		switch token.value {
+		case "<":
+			return leftNumber < rightNumber, nil
+		case "<=":
+			return leftNumber <= rightNumber, nil
+		case ">":
+			return leftNumber > rightNumber, nil
+		default:
+			return leftNumber >= rightNumber, nil
+		}
+	}
+}
+
+// operand := string | word | function '(' (string (',' string)*)? ')'
+func (parser *conditionParser) parseOperand() (string, bool, error) {
+	token := parser.next()
+
+	switch token.tokenType {
+	case conditionTokenString:
+		return expandProperties(token.value, parser.properties), false, nil
+	case conditionTokenWord:
+		if parser.peek().tokenType != conditionTokenLeftParen {
+			return expandProperties(token.value, parser.properties), false, nil
+		}
+
+		value, err := parser.parseFunction(token.value)
+		if err != nil {
+			return "", false, err
+		}
+		return strconv.FormatBool(value), true, nil
+	default:
+		return "", false, fmt.Errorf("unexpected token: %s", token.value)
+	}
+}
+
+func (parser *conditionParser) parseFunction(name string) (bool, error) {
+	parser.next() // (
+
+	args := []string{}
+	for parser.peek().tokenType != conditionTokenRightParen {
+		if len(args) > 0 {
+			if token := parser.next(); token.tokenType != conditionTokenComma {
+				return false, fmt.Errorf("expected , found: %s", token.value)
+			}
+		}
+
+		token := parser.next()
+		if token.tokenType != conditionTokenString && token.tokenType != conditionTokenWord {
+			return false, fmt.Errorf("invalid argument of %s: %s", name, token.value)
+		}
+		args = append(args, expandProperties(token.value, parser.properties))
+	}
+	parser.next() // )
+
+	if len(args) != 1 {
+		return false, fmt.Errorf("%s expects 1 argument, got: %d", name, len(args))
+	}
+
+	switch strings.ToLower(name) {
+	case "exists":
+		pth := strings.TrimSpace(args[0])
+		if pth == "" {
+			return false, nil
+		}
+		return parser.inputs.pathExists(resolvePath(parser.dir, utility.FixWindowsPath(pth)))
+	case "hastrailingslash":
+		return strings.HasSuffix(args[0], "/") || strings.HasSuffix(args[0], `\`), nil
+	default:
+		return false, fmt.Errorf("unsupported function: %s", name)
+	}
+}
+
+func parseConditionBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "on", "yes":
+		return true, nil
+	case "false", "off", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("not a boolean: %s", value)
+	}
+}
@@ -0,0 +1,101 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	properties := map[string]string{
+		"configuration": "Release",
+		"platform":      "iPhone",
+		"version":       "10",
+		"enabled":       "true",
+	}
+
+	t.Log("it evaluates comparisons")
+	{
+		conditionValueMap := map[string]bool{
+			"": true,
+			"'$(Configuration)|$(Platform)' == 'Release|iPhone'":         true,
+			" '$(Configuration)|$(Platform)' == 'release|IPHONE' ":       true,
+			"'$(Configuration)|$(Platform)' == 'Debug|iPhone'":           false,
+			"'$(Configuration)' != 'Debug'":                              true,
+			"'$(NotDefined)' == ''":                                      true,
+			"$(Configuration) == Release":                                true,
+			"'$(Version)' >= '9' and '$(Version)' < 11":                  true,
+			"'$(Version)' > '10'":                                        false,
+			"$(Enabled)":                                                 true,
+			"'$(Enabled)' == 'true'":                                     true,
+			"!$(Enabled)":                                                false,
+			"'true'":                                                     true,
+			"'$(Configuration)' == 'Debug' Or '$(Platform)' == 'iPhone'": true,
+		}
+		for condition, expected := range conditionValueMap {
+			value, err := evaluateCondition(condition, properties, "", nil)
+			require.NoError(t, err, condition)
+			require.Equal(t, expected, value, condition)
+		}
+	}
+
+	t.Log("it respects precedence and parentheses")
+	{
+		conditionValueMap := map[string]bool{
+			"'a' == 'b' And 'a' == 'b' Or 'a' == 'a'":   true,
+			"'a' == 'b' And ('a' == 'b' Or 'a' == 'a')": false,
+			"!('a' == 'b') And !('c' == 'd')":           true,
+			"((('$(Platform)' == 'iPhone')))":           true,
+		}
+		for condition, expected := range conditionValueMap {
+			value, err := evaluateCondition(condition, properties, "", nil)
+			require.NoError(t, err, condition)
+			require.Equal(t, expected, value, condition)
+		}
+	}
+
+	t.Log("it evaluates Exists")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(tmpDir))
+		}()
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "custom.props"), ""))
+
+		conditionValueMap := map[string]bool{
+			"Exists('custom.props')":                                  true,
+			"Exists('$(Configuration).props')":                        false,
+			"!Exists('missing.props')":                                true,
+			"Exists('" + filepath.Join(tmpDir, "custom.props") + "')": true,
+			"Exists('')":                false,
+			"HasTrailingSlash('bin\\')": true,
+		}
+		for condition, expected := range conditionValueMap {
+			value, err := evaluateCondition(condition, properties, tmpDir, nil)
+			require.NoError(t, err, condition)
+			require.Equal(t, expected, value, condition)
+		}
+	}
+
+	t.Log("it fails for invalid conditions")
+	{
+		conditions := []string{
+			"'a' = 'b'",
+			"'a' == 'b",
+			"('a' == 'b'",
+			"'a' == 'b' And",
+			"'a' < 'b'",
+			"'maybe'",
+			"Unknown('a')",
+		}
+		for _, condition := range conditions {
+			_, err := evaluateCondition(condition, properties, "", nil)
+			require.Error(t, err, condition)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package project
+
+import (
+	"strings"
+)
+
+const defineConstantsProperty = "DefineConstants"
+
+// parseDefineConstants splits the DefineConstants property value, like: DEBUG;TRACE;__MOBILE__
+func parseDefineConstants(value string) []string {
+	symbols := []string{}
+	seen := map[string]bool{}
+
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' ' || r == '\t'
+	})
+	for _, symbol := range fields {
+		if seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols
+}
+
+// HasDefineConstant - returns true if the configuration defines the given conditional compilation symbol
+func (configurationPlatform ConfigurationPlatformModel) HasDefineConstant(constant string) bool {
+	for _, defineConstant := range configurationPlatform.DefineConstants {
+		if defineConstant == constant {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeDefineConstants fills the configurations' conditional compilation symbols from the evaluated properties
+func analyzeDefineConstants(project Model) Model {
+	for configKey, configurationPlatform := range project.Configs {
+		properties := evaluationProperties(project, project.Pth, configurationPlatform)
+
+		configurationPlatform.DefineConstants = parseDefineConstants(properties[strings.ToLower(defineConstantsProperty)])
+
+		project.Configs[configKey] = configurationPlatform
+	}
+
+	return project
+}
@@ -0,0 +1,103 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+// Nested property references are expanded up to this depth, to avoid infinite loops on self referencing values
+const maxPropertyExpansionDepth = 8
+
+var (
+	propertyReferencePattern = regexp.MustCompile(`\$\((?P<name>[A-Za-z_][A-Za-z0-9_.-]*)\)`)
+	propertyPattern          = regexp.MustCompile(`^<(?P<name>[A-Za-z_][A-Za-z0-9_.-]*)>(?P<value>.*)</(?P<end_name>[A-Za-z_][A-Za-z0-9_.-]*)>$`)
+)
+
+// expandProperties expands the $(Name) property references in value.
+// Property names are case insensitive, the properties map has to be keyed by lower case names.
+// Properties not found in the map are looked up in the environment, undefined properties expand to empty string.
+func expandProperties(value string, properties map[string]string) string {
+	for depth := 0; depth < maxPropertyExpansionDepth && strings.Contains(value, "$("); depth++ {
+		expanded := propertyReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+			name := propertyReferencePattern.FindStringSubmatch(reference)[1]
+
+			if propertyValue, ok := properties[strings.ToLower(name)]; ok {
+				return propertyValue
+			}
+			return os.Getenv(name)
+		})
+
+		if expanded == value {
+			break
+		}
+		value = expanded
+	}
+	return value
+}
+
+// builtinProperties returns the MSBuild reserved properties of the project (projectPth),
+// while evaluating the project or one of its imports (thisFilePth)
+func builtinProperties(projectPth, thisFilePth string) map[string]string {
+	projectDir := filepath.Dir(projectPth)
+	projectFile := filepath.Base(projectPth)
+	projectExt := filepath.Ext(projectPth)
+
+	thisFileDir := filepath.Dir(thisFilePth)
+	thisFile := filepath.Base(thisFilePth)
+	thisFileExt := filepath.Ext(thisFilePth)
+
+	return map[string]string{
+		"msbuildprojectdirectory": projectDir,
+		"msbuildprojectfile":      projectFile,
+		"msbuildprojectextension": projectExt,
+		"msbuildprojectfullpath":  projectPth,
+		"msbuildprojectname":      strings.TrimSuffix(projectFile, projectExt),
+
+		"msbuildthisfiledirectory": thisFileDir + string(os.PathSeparator),
+		"msbuildthisfile":          thisFile,
+		"msbuildthisfileextension": thisFileExt,
+		"msbuildthisfilefullpath":  thisFilePth,
+		"msbuildthisfilename":      strings.TrimSuffix(thisFile, thisFileExt),
+	}
+}
+
+// evaluationProperties returns the properties available while evaluating the given file of the project,
+// in the context of the given configuration and platform
+func evaluationProperties(project Model, thisFilePth string, configurationPlatform ConfigurationPlatformModel) map[string]string {
+	properties := map[string]string{}
+
+	for name, value := range project.properties {
+		properties[name] = value
+	}
+	for name, value := range configurationPlatform.properties {
+		properties[name] = value
+	}
+	for name, value := range builtinProperties(project.Pth, thisFilePth) {
+		properties[name] = value
+	}
+
+	if configurationPlatform.Configuration != "" {
+		properties["configuration"] = configurationPlatform.Configuration
+	}
+	if configurationPlatform.Platform != "" {
+		properties["platform"] = configurationPlatform.Platform
+	}
+
+	return properties
+}
+
+// resolvePath returns the absolute path of the (possibly relative, Windows style) pth
+func resolvePath(dir, pth string) string {
+	return utility.ResolvePath(dir, pth)
+}
+
+// ResolvePath - returns the absolute path of a project relative path, like: Properties\AndroidManifest.xml.
+// Property references are expanded by the project's global properties.
+func (project Model) ResolvePath(pth string) string {
+	pth = expandProperties(pth, evaluationProperties(project, project.Pth, ConfigurationPlatformModel{}))
+	return resolvePath(filepath.Dir(project.Pth), pth)
+}
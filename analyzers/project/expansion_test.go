@@ -0,0 +1,58 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandProperties(t *testing.T) {
+	properties := map[string]string{
+		"configuration":  "Release",
+		"platform":       "iPhone",
+		"baseoutputpath": `bin\$(Platform)`,
+		"self":           "$(Self);a",
+	}
+
+	t.Log("it expands property references")
+	{
+		require.Equal(t, `bin\iPhone\Release`, expandProperties(`$(BaseOutputPath)\$(Configuration)`, properties))
+		require.Equal(t, "Release|iPhone", expandProperties("$(configuration)|$(PLATFORM)", properties))
+	}
+
+	t.Log("it expands undefined properties to empty string")
+	{
+		require.Equal(t, "bin//", expandProperties("bin/$(NotDefined)/", properties))
+	}
+
+	t.Log("it falls back to environment variables")
+	{
+		require.NoError(t, os.Setenv("XAMARIN_BUILDER_TEST_PROPERTY", "env"))
+		defer func() {
+			require.NoError(t, os.Unsetenv("XAMARIN_BUILDER_TEST_PROPERTY"))
+		}()
+
+		require.Equal(t, "env/Release", expandProperties("$(XAMARIN_BUILDER_TEST_PROPERTY)/$(Configuration)", properties))
+	}
+
+	t.Log("it stops on self referencing properties")
+	{
+		require.NotPanics(t, func() {
+			expandProperties("$(Self)", properties)
+		})
+	}
+}
+
+func TestBuiltinProperties(t *testing.T) {
+	projectPth := filepath.Join("/", "src", "App", "App.iOS.csproj")
+	importPth := filepath.Join("/", "src", "build", "common.targets")
+
+	properties := builtinProperties(projectPth, importPth)
+	require.Equal(t, filepath.Join("/", "src", "App"), properties["msbuildprojectdirectory"])
+	require.Equal(t, "App.iOS", properties["msbuildprojectname"])
+	require.Equal(t, "App.iOS.csproj", properties["msbuildprojectfile"])
+	require.Equal(t, filepath.Join("/", "src", "build")+string(os.PathSeparator), properties["msbuildthisfiledirectory"])
+	require.Equal(t, "common", properties["msbuildthisfilename"])
+}
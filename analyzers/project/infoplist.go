@@ -0,0 +1,100 @@
+package project
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/plist"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+const (
+	infoPlistFileName = "Info.plist"
+
+	infoPlistPattern = `(?i)<(None|BundleResource|Content)\s+Include="(?P<info_plist>([^"]*[\\/])?Info\.plist)"`
+
+	bundleIdentifierKey   = "CFBundleIdentifier"
+	bundleVersionKey      = "CFBundleVersion"
+	bundleShortVersionKey = "CFBundleShortVersionString"
+	minimumOSVersionKey   = "MinimumOSVersion"
+	minimumMacOSKey       = "LSMinimumSystemVersion"
+)
+
+// Info.plist locations of the Xamarin and the MAUI single project layout
+var defaultInfoPlistPaths = []string{
+	infoPlistFileName,
+	filepath.Join("Platforms", "iOS", infoPlistFileName),
+	filepath.Join("Platforms", "MacCatalyst", infoPlistFileName),
+	filepath.Join("Platforms", "tvOS", infoPlistFileName),
+}
+
+func isAppleSDK(sdk constants.SDK) bool {
+	return sdk == constants.SDKIOS || sdk == constants.SDKTvOS || sdk == constants.SDKMacOS
+}
+
+// analyzeInfoPlistItem returns the Info.plist path, if the line is an Info.plist item
+func analyzeInfoPlistItem(projectDir, line string) (string, bool) {
+	matches := regexp.MustCompile(infoPlistPattern).FindStringSubmatch(line)
+	if len(matches) != 4 {
+		return "", false
+	}
+	return resolvePath(projectDir, utility.FixWindowsPath(matches[2])), true
+}
+
+// analyzeInfoPlist reads the bundle identifier, versions and minimum OS version from the Apple project's Info.plist.
+// SDK-style projects may define them by the ApplicationId, ApplicationVersion, ApplicationDisplayVersion
+// and SupportedOSPlatformVersion properties.
+func analyzeInfoPlist(project Model) (Model, error) {
+	if !isAppleSDK(project.SDK) {
+		return project, nil
+	}
+
+	if project.InfoPlistPth == "" {
+		projectDir := filepath.Dir(project.Pth)
+		for _, infoPlistRelativePth := range defaultInfoPlistPaths {
+			infoPlistPth := resolvePath(projectDir, infoPlistRelativePth)
+			if exist, err := project.inputs.pathExists(infoPlistPth); err != nil {
+				return Model{}, err
+			} else if exist {
+				project.InfoPlistPth = infoPlistPth
+				break
+			}
+		}
+	}
+
+	if project.InfoPlistPth != "" {
+		if exist, err := project.inputs.pathExists(project.InfoPlistPth); err != nil {
+			return Model{}, err
+		} else if exist {
+			infoPlist, err := plist.New(project.InfoPlistPth)
+			if err != nil {
+				return Model{}, err
+			}
+
+			project.BundleIdentifier, _ = infoPlist.GetString(bundleIdentifierKey)
+			project.BundleVersion, _ = infoPlist.GetString(bundleVersionKey)
+			project.BundleShortVersion, _ = infoPlist.GetString(bundleShortVersionKey)
+			if project.SDK == constants.SDKMacOS {
+				project.MinimumOSVersion, _ = infoPlist.GetString(minimumMacOSKey)
+			} else {
+				project.MinimumOSVersion, _ = infoPlist.GetString(minimumOSVersionKey)
+			}
+		}
+	}
+
+	if value := project.properties["applicationid"]; value != "" {
+		project.BundleIdentifier = value
+	}
+	if value := project.properties["applicationversion"]; value != "" {
+		project.BundleVersion = value
+	}
+	if value := project.properties["applicationdisplayversion"]; value != "" {
+		project.BundleShortVersion = value
+	}
+	if value := project.properties["supportedosplatformversion"]; value != "" {
+		project.MinimumOSVersion = value
+	}
+
+	return project, nil
+}
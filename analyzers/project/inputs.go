@@ -0,0 +1,22 @@
+package project
+
+import (
+	"github.com/bitrise-io/go-utils/pathutil"
+)
+
+// analysisInputs - the files read or looked up by the project analysis, like the imports, the Info.plist,
+// the packages.config, the AndroidManifest or the paths of Exists conditions, including the not existing ones.
+// The Cache does not use an entry, if any of them changed.
+type analysisInputs map[string]bool
+
+func (inputs analysisInputs) record(pth string) {
+	if inputs != nil && pth != "" {
+		inputs[pth] = true
+	}
+}
+
+// pathExists records the path, and reports whether it exists
+func (inputs analysisInputs) pathExists(pth string) (bool, error) {
+	inputs.record(pth)
+	return pathutil.IsPathExists(pth)
+}
@@ -0,0 +1,58 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+// iOS build properties
+const (
+	mtouchExtraArgsProperty      = "MtouchExtraArgs"
+	mtouchLinkProperty           = "MtouchLink"
+	mtouchUseLlvmProperty        = "MtouchUseLlvm"
+	mtouchEnableBitcodeProperty  = "MtouchEnableBitcode"
+	mtouchNoSymbolStripProperty  = "MtouchNoSymbolStrip"
+	codesignKeyProperty          = "CodesignKey"
+	codesignProvisionProperty    = "CodesignProvision"
+	codesignEntitlementsProperty = "CodesignEntitlements"
+	ipaPackageDirProperty        = "IpaPackageDir"
+	ipaPackageNameProperty       = "IpaPackageName"
+)
+
+// analyzeIOSBuildSettings fills the configurations' iOS build settings from the evaluated properties
+func analyzeIOSBuildSettings(project Model) Model {
+	if !isAppleSDK(project.SDK) {
+		return project
+	}
+
+	projectDir := filepath.Dir(project.Pth)
+
+	for configKey, configurationPlatform := range project.Configs {
+		properties := evaluationProperties(project, project.Pth, configurationPlatform)
+		property := func(name string) string {
+			return strings.TrimSpace(properties[strings.ToLower(name)])
+		}
+
+		configurationPlatform.MtouchExtraArgs = property(mtouchExtraArgsProperty)
+		configurationPlatform.MtouchLink = property(mtouchLinkProperty)
+		configurationPlatform.MtouchUseLlvm = strings.EqualFold(property(mtouchUseLlvmProperty), "true")
+		configurationPlatform.MtouchEnableBitcode = strings.EqualFold(property(mtouchEnableBitcodeProperty), "true")
+		configurationPlatform.MtouchNoSymbolStrip = strings.EqualFold(property(mtouchNoSymbolStripProperty), "true")
+		configurationPlatform.CodesignKey = property(codesignKeyProperty)
+		configurationPlatform.CodesignProvision = property(codesignProvisionProperty)
+		if entitlements := property(codesignEntitlementsProperty); entitlements != "" {
+			configurationPlatform.CodesignEntitlements = resolvePath(projectDir, utility.FixWindowsPath(entitlements))
+		}
+
+		if ipaPackageDir := property(ipaPackageDirProperty); ipaPackageDir != "" {
+			configurationPlatform.IpaPackageDir = resolvePath(projectDir, utility.FixWindowsPath(ipaPackageDir))
+		}
+		configurationPlatform.IpaPackageName = property(ipaPackageNameProperty)
+
+		project.Configs[configKey] = configurationPlatform
+	}
+
+	return project
+}
@@ -0,0 +1,117 @@
+package project
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// ConfigurationPlatformOutputModel - the serialized form of a project configuration
+type ConfigurationPlatformOutputModel struct {
+	Configuration string   `json:"configuration" yaml:"configuration"`
+	Platform      string   `json:"platform" yaml:"platform"`
+	OutputDir     string   `json:"output_dir,omitempty" yaml:"output_dir,omitempty"`
+	ManifestPth   string   `json:"manifest_path,omitempty" yaml:"manifest_path,omitempty"`
+	MtouchArchs   []string `json:"mtouch_archs,omitempty" yaml:"mtouch_archs,omitempty"`
+	BuildIpa      bool     `json:"build_ipa" yaml:"build_ipa"`
+	SignAndroid   bool     `json:"sign_android" yaml:"sign_android"`
+}
+
+// PackageOutputModel - the serialized form of a NuGet dependency
+type PackageOutputModel struct {
+	ID      string       `json:"id" yaml:"id"`
+	Version string       `json:"version,omitempty" yaml:"version,omitempty"`
+	Style   PackageStyle `json:"style" yaml:"style"`
+}
+
+// OutputModel - the stable, serialized form of the project model
+type OutputModel struct {
+	Name          string   `json:"name" yaml:"name"`
+	Pth           string   `json:"path" yaml:"path"`
+	ID            string   `json:"id,omitempty" yaml:"id,omitempty"`
+	TypeGUIDs     []string `json:"type_guids,omitempty" yaml:"type_guids,omitempty"`
+	ProjectType   string   `json:"project_type" yaml:"project_type"`
+	SDK           string   `json:"sdk" yaml:"sdk"`
+	TestFramework string   `json:"test_framework" yaml:"test_framework"`
+	OutputType    string   `json:"output_type,omitempty" yaml:"output_type,omitempty"`
+	AssemblyName  string   `json:"assembly_name,omitempty" yaml:"assembly_name,omitempty"`
+	RootNamespace string   `json:"root_namespace,omitempty" yaml:"root_namespace,omitempty"`
+
+	MSBuildSDK       string   `json:"msbuild_sdk,omitempty" yaml:"msbuild_sdk,omitempty"`
+	TargetFrameworks []string `json:"target_frameworks,omitempty" yaml:"target_frameworks,omitempty"`
+
+	ReferredProjectIDs  []string `json:"referred_project_ids,omitempty" yaml:"referred_project_ids,omitempty"`
+	ReferredProjectPths []string `json:"referred_project_paths,omitempty" yaml:"referred_project_paths,omitempty"`
+
+	ManifestPth        string `json:"manifest_path,omitempty" yaml:"manifest_path,omitempty"`
+	AndroidApplication bool   `json:"android_application" yaml:"android_application"`
+
+	InfoPlistPth     string `json:"info_plist_path,omitempty" yaml:"info_plist_path,omitempty"`
+	BundleIdentifier string `json:"bundle_identifier,omitempty" yaml:"bundle_identifier,omitempty"`
+
+	Packages []PackageOutputModel               `json:"packages,omitempty" yaml:"packages,omitempty"`
+	Configs  []ConfigurationPlatformOutputModel `json:"configs" yaml:"configs"`
+}
+
+// OutputModel - returns the serialized form of the project, configurations are sorted by Configuration|Platform
+func (project Model) OutputModel() OutputModel {
+	output := OutputModel{
+		Name:                project.Name,
+		Pth:                 project.Pth,
+		ID:                  project.ID,
+		TypeGUIDs:           project.TypeGUIDs,
+		ProjectType:         string(project.ProjectType),
+		SDK:                 string(project.SDK),
+		TestFramework:       string(project.TestFramework),
+		OutputType:          project.OutputType,
+		AssemblyName:        project.AssemblyName,
+		RootNamespace:       project.RootNamespace,
+		MSBuildSDK:          project.MSBuildSDK,
+		TargetFrameworks:    project.TargetFrameworks,
+		ReferredProjectIDs:  project.ReferredProjectIDs,
+		ReferredProjectPths: project.ReferredProjectPths,
+		ManifestPth:         project.ManifestPth,
+		AndroidApplication:  project.AndroidApplication,
+		InfoPlistPth:        project.InfoPlistPth,
+		BundleIdentifier:    project.BundleIdentifier,
+		Configs:             []ConfigurationPlatformOutputModel{},
+	}
+
+	for _, pkg := range project.Packages {
+		output.Packages = append(output.Packages, PackageOutputModel{
+			ID:      pkg.ID,
+			Version: pkg.Version,
+			Style:   pkg.Style,
+		})
+	}
+
+	configKeys := []string{}
+	for configKey := range project.Configs {
+		configKeys = append(configKeys, configKey)
+	}
+	sort.Strings(configKeys)
+
+	for _, configKey := range configKeys {
+		config := project.Configs[configKey]
+		output.Configs = append(output.Configs, ConfigurationPlatformOutputModel{
+			Configuration: config.Configuration,
+			Platform:      config.Platform,
+			OutputDir:     config.OutputDir,
+			ManifestPth:   config.ManifestPth,
+			MtouchArchs:   config.MtouchArchs,
+			BuildIpa:      config.BuildIpa,
+			SignAndroid:   config.SignAndroid,
+		})
+	}
+
+	return output
+}
+
+// MarshalJSON ...
+func (project Model) MarshalJSON() ([]byte, error) {
+	return json.Marshal(project.OutputModel())
+}
+
+// MarshalYAML ...
+func (project Model) MarshalYAML() (interface{}, error) {
+	return project.OutputModel(), nil
+}
@@ -0,0 +1,64 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+// NuGet packaging properties
+const (
+	generatePackageOnBuildProperty = "GeneratePackageOnBuild"
+	packageIDProperty              = "PackageId"
+	packageVersionProperty         = "PackageVersion"
+	packageOutputPathProperty      = "PackageOutputPath"
+	nuspecFileProperty             = "NuspecFile"
+
+	nuspecExt = ".nuspec"
+)
+
+// IsPackable - returns true if the project generates a NuGet package on build, or has a nuspec file for nuget pack
+func (project Model) IsPackable() bool {
+	return project.GeneratePackageOnBuild || project.NuspecPth != ""
+}
+
+// analyzeNuGetPackaging fills the NuGet packaging fields from the evaluated properties,
+// the PackageId defaults to the AssemblyName, the nuspec file is looked up next to the project, if not set
+func analyzeNuGetPackaging(project Model) Model {
+	projectDir := filepath.Dir(project.Pth)
+
+	property := func(properties map[string]string, name string) string {
+		return strings.TrimSpace(properties[strings.ToLower(name)])
+	}
+
+	project.GeneratePackageOnBuild = strings.EqualFold(property(project.properties, generatePackageOnBuildProperty), "true")
+
+	project.PackageID = property(project.properties, packageIDProperty)
+	if project.PackageID == "" {
+		project.PackageID = project.AssemblyName
+	}
+	project.PackageVersion = property(project.properties, packageVersionProperty)
+
+	if nuspecFile := property(project.properties, nuspecFileProperty); nuspecFile != "" {
+		project.NuspecPth = resolvePath(projectDir, utility.FixWindowsPath(nuspecFile))
+	} else {
+		projectName := strings.TrimSuffix(filepath.Base(project.Pth), filepath.Ext(project.Pth))
+		nuspecPth := filepath.Join(projectDir, projectName+nuspecExt)
+		if exist, err := project.inputs.pathExists(nuspecPth); err == nil && exist {
+			project.NuspecPth = nuspecPth
+		}
+	}
+
+	for configKey, configurationPlatform := range project.Configs {
+		properties := evaluationProperties(project, project.Pth, configurationPlatform)
+
+		if packageOutputPath := property(properties, packageOutputPathProperty); packageOutputPath != "" {
+			configurationPlatform.PackageOutputDir = resolvePath(projectDir, utility.FixWindowsPath(packageOutputPath))
+		}
+
+		project.Configs[configKey] = configurationPlatform
+	}
+
+	return project
+}
@@ -0,0 +1,167 @@
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+const (
+	packagesConfigFileName = "packages.config"
+	xamarinFormsPackageID  = "Xamarin.Forms"
+
+	packageIDPattern      = `(?i)<package\s+id="(?P<id>[^"]*)"`
+	packageVersionPattern = `(?i)\sversion="(?P<version>[^"]*)"`
+
+	packageReferencePattern                 = `(?i)<PackageReference\s+Include="(?P<id>[^"]*)"`
+	packageReferenceVersionAttributePattern = `(?i)\sVersion="(?P<version>[^"]*)"`
+	packageReferenceVersionElementPattern   = `(?i)<Version>(?P<version>.*)<\/Version>`
+	packageReferenceEndPattern              = `(?i)<\/PackageReference>`
+
+	xamarinUITestPackageID = "Xamarin.UITest"
+)
+
+// PackageStyle - the way the project references its NuGet packages
+type PackageStyle string
+
+const (
+	// PackageStylePackagesConfig ...
+	PackageStylePackagesConfig PackageStyle = "packages.config"
+	// PackageStylePackageReference ...
+	PackageStylePackageReference PackageStyle = "PackageReference"
+)
+
+// PackageModel - a NuGet dependency of the project
+type PackageModel struct {
+	ID      string
+	Version string
+	Style   PackageStyle
+}
+
+// IsFloatingVersion - returns true if the version is not pinned,
+// like: 1.0.*, * or a version range without upper bound: [1.0,)
+func (pkg PackageModel) IsFloatingVersion() bool {
+	version := strings.TrimSpace(pkg.Version)
+	if strings.Contains(version, "*") {
+		return true
+	}
+
+	if strings.HasPrefix(version, "[") || strings.HasPrefix(version, "(") {
+		bounds := strings.Split(strings.Trim(version, "[]()"), ",")
+		return len(bounds) == 2 && strings.TrimSpace(bounds[1]) == ""
+	}
+
+	return false
+}
+
+// HasPackage - returns true if the project depends on the given NuGet package, the package id is case insensitive
+func (project Model) HasPackage(id string) bool {
+	for _, pkg := range project.Packages {
+		if strings.EqualFold(pkg.ID, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesXamarinForms ...
+func (project Model) UsesXamarinForms() bool {
+	return project.HasPackage(xamarinFormsPackageID)
+}
+
+// FloatingPackages - returns the packages referenced with floating version
+func (project Model) FloatingPackages() []PackageModel {
+	packages := []PackageModel{}
+	for _, pkg := range project.Packages {
+		if pkg.IsFloatingVersion() {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// analyzePackageReference returns the package of the PackageReference item in the line
+func analyzePackageReference(line string, properties map[string]string) (PackageModel, bool) {
+	matches := regexp.MustCompile(packageReferencePattern).FindStringSubmatch(line)
+	if len(matches) != 2 {
+		return PackageModel{}, false
+	}
+
+	pkg := PackageModel{
+		ID:    matches[1],
+		Style: PackageStylePackageReference,
+	}
+	if versionMatches := regexp.MustCompile(packageReferenceVersionAttributePattern).FindStringSubmatch(line); len(versionMatches) == 2 {
+		pkg.Version = expandProperties(versionMatches[1], properties)
+	}
+
+	return pkg, true
+}
+
+// Unit test framework package IDs, the first match wins if a project references more of them
+var testFrameworkPackageIDs = []struct {
+	ID            string
+	TestFramework constants.TestFramework
+}{
+	{"NUnit", constants.TestFrameworkNunitTest},
+	{"xunit", constants.TestFrameworkXunitTest},
+	{"xunit.core", constants.TestFrameworkXunitTest},
+	{"MSTest.TestFramework", constants.TestFrameworkMSTest},
+}
+
+// analyzePackagesConfig analyzes the NuGet packages.config next to the project file, if exists
+func analyzePackagesConfig(project Model) (Model, error) {
+	pth := filepath.Join(filepath.Dir(project.Pth), packagesConfigFileName)
+	if exist, err := project.inputs.pathExists(pth); err != nil {
+		return Model{}, err
+	} else if !exist {
+		return project, nil
+	}
+
+	content, err := utility.ReadTextFile(pth)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to read packages config (%s), error: %s", pth, err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if matches := regexp.MustCompile(packageIDPattern).FindStringSubmatch(line); len(matches) == 2 {
+			packageID := matches[1]
+
+			pkg := PackageModel{ID: packageID, Style: PackageStylePackagesConfig}
+			if versionMatches := regexp.MustCompile(packageVersionPattern).FindStringSubmatch(line); len(versionMatches) == 2 {
+				pkg.Version = versionMatches[1]
+			}
+			project.Packages = append(project.Packages, pkg)
+
+			if strings.EqualFold(packageID, xamarinUITestPackageID) {
+				project.TestFramework = constants.TestFrameworkXamarinUITest
+				continue
+			}
+
+			if project.TestFramework != constants.TestFrameworkUnknown {
+				continue
+			}
+
+			for _, testFrameworkPackage := range testFrameworkPackageIDs {
+				if strings.EqualFold(packageID, testFrameworkPackage.ID) {
+					project.TestFramework = testFrameworkPackage.TestFramework
+					break
+				}
+			}
+			continue
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Model{}, err
+	}
+
+	return project, nil
+}
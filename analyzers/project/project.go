@@ -7,14 +7,25 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
 	"github.com/bitrise-tools/go-xamarin/utility"
 )
 
 const (
-	targetDefinitionPattern = `(?i)Import Project="(?P<target_definition>.*\.targets)"`
+	directoryBuildPropsFileName   = "Directory.Build.props"
+	directoryBuildTargetsFileName = "Directory.Build.targets"
+)
+
+const (
+	targetDefinitionPattern   = `(?i)<Import\s+Project="(?P<target_definition>[^"]*\.(props|targets))"`
+	conditionAttributePattern = `(?i)\sCondition="(?P<condition>[^"]*)"`
+	sharedItemsPattern        = `(?i)Import Project="(?P<shared_items>.*\.projitems)"`
+
+	// SDK-style project
+	sdkProjectPattern       = `(?i)<Project\s+Sdk="(?P<sdk>[^"]*)"`
+	targetFrameworkPattern  = `(?i)<TargetFramework>(?P<target_framework>.*)<\/TargetFramework>`
+	targetFrameworksPattern = `(?i)<TargetFrameworks>(?P<target_frameworks>.*)<\/TargetFrameworks>`
 
 	typeGUIDsPattern    = `(?i)<ProjectTypeGuids>(?P<project_type_guids>.*)<\/ProjectTypeGuids>`
 	guidPattern         = `(?i)<ProjectGuid>{(?P<project_id>.*)}<\/ProjectGuid>`
@@ -22,16 +33,23 @@ const (
 	assemblyNamePattern = `(?i)<AssemblyName>(?P<assembly_name>.*)<\/AssemblyName>`
 
 	// PropertyGroup with Condition
-	propertyGroupStartPattern                                 = `(?i)<PropertyGroup>`
-	propertyGroupWithConditionConfigurationAndPlatformPattern = `(?i)<PropertyGroup Condition="\s*'\$\(Configuration\)\|\$\(Platform\)'\s*==\s*'(?P<config>.*)\|(?P<platform>.*)'\s*">`
-	propertyGroupWithConditionConfigurationPattern            = `(?i)<PropertyGroup Condition="\s*'\$\(Configuration\)'\s*==\s*'(?P<config>.*)'\s*">`
-	propertyGroupWithConditionPlatformPattern                 = `(?i)<PropertyGroup Condition="\s*'\$\(Platform\)'\s*==\s*'(?P<platform>.*)'\s*">`
+	propertyGroupStartPattern                                 = `(?i)<PropertyGroup(\s+Label="[^"]*")?\s*>`
+	propertyGroupWithConditionConfigurationAndPlatformPattern = `(?i)<PropertyGroup Condition="\s*'\$\(Configuration\)\|\$\(Platform\)'\s*==\s*'(?P<config>[^'|]+)\|(?P<platform>[^']+)'\s*">`
+	propertyGroupWithConditionConfigurationPattern            = `(?i)<PropertyGroup Condition="\s*'\$\(Configuration\)'\s*==\s*'(?P<config>[^']+)'\s*">`
+	propertyGroupWithConditionPlatformPattern                 = `(?i)<PropertyGroup Condition="\s*'\$\(Platform\)'\s*==\s*'(?P<platform>[^']+)'\s*">`
+	propertyGroupWithConditionPattern                         = `(?i)<PropertyGroup\s+Condition="(?P<condition>[^"]*)"[^>]*>`
 	propertyGroupEndPattern                                   = `(?i)</PropertyGroup>`
 
+	// Conditions
+	itemGroupWithConditionPattern = `(?i)<ItemGroup\s+Condition="(?P<condition>[^"]*)"[^>]*>`
+	itemGroupEndPattern           = `(?i)</ItemGroup>`
+	elementWithConditionPattern   = `(?i)^<(?P<name>[A-Za-z_][A-Za-z0-9_.-]*)\s+Condition="(?P<condition>[^"]*)"\s*(?P<rest>/?>.*)$`
+
 	outputPathPattern = `(?i)<OutputPath>(?P<output_path>.*)<\/OutputPath>`
 
 	// ItemGroup
 	projectRefernceStartPattern = `(?i)<ProjectReference Include="(?P<project_path>.*)">`
+	projectReferencePattern     = `(?i)<ProjectReference Include="(?P<project_path>[^"]*)"\s*/>`
 	projectRefernceEndPattern   = `(?i)</ProjectReference>`
 	referredProjectIDPattern    = `(?i)<Project>{(?P<id>.*)}<\/Project>`
 
@@ -48,7 +66,10 @@ const (
 	// Testing frameworks
 	referenceXamarinUITestPattern = `(?i)Include="Xamarin.UITest`
 	referenceNunitFramework       = `(?i)Include="nunit.framework`
-	referenceNunitLiteFramework   = `(?i)Include="MonoTouch.NUnitLite`
+	referenceNunitLiteFramework   = `(?i)Include="(MonoTouch|Xamarin\.Android)\.NUnitLite`
+	referenceNunitPackage         = `(?i)Include="NUnit"`
+	referenceXunitFramework       = `(?i)Include="xunit(\.core)?[",]`
+	referenceMSTestFramework      = `(?i)Include="(MSTest\.TestFramework|Microsoft\.VisualStudio\.QualityTools\.UnitTestFramework)[",]`
 )
 
 // ConfigurationPlatformModel ...
@@ -56,11 +77,40 @@ type ConfigurationPlatformModel struct {
 	Configuration string
 	Platform      string
 	OutputDir     string
-
-	MtouchArchs []string
-	BuildIpa    bool
-
-	SignAndroid bool
+	ManifestPth   string // AndroidManifest of the configuration, defaults to the project's manifest
+
+	DefineConstants []string // Conditional compilation symbols, like: DEBUG, TRACE
+
+	MtouchArchs          []string
+	MtouchExtraArgs      string
+	MtouchLink           string // None, SdkOnly or Full
+	MtouchUseLlvm        bool
+	MtouchEnableBitcode  bool
+	MtouchNoSymbolStrip  bool // native symbols are kept in the app binary
+	CodesignKey          string
+	CodesignProvision    string
+	CodesignEntitlements string // Entitlements.plist of the configuration
+	BuildIpa             bool
+	IpaPackageDir        string // Custom directory of the generated ipa, instead of the OutputDir
+	IpaPackageName       string // Custom file name of the generated ipa, instead of the AssemblyName
+
+	SignAndroid                bool
+	AndroidSigningKeyStore     string
+	AndroidSigningKeyAlias     string
+	HasAndroidSigningStorePass bool // The password itself is not stored
+	HasAndroidSigningKeyPass   bool // The password itself is not stored
+	AndroidPackageFormat       string
+	AndroidCreatePackagePerAbi bool
+	AndroidSupportedAbis       []string
+	MonoSymbolArchive          bool // The build generates .mSYM symbol archives for crash reporting
+	AndroidFastDeployment      bool // EmbedAssembliesIntoApk=false or AndroidUseSharedRuntime=true, the apk does not contain the assemblies
+	AndroidDebuggable          bool // The application of the configuration's AndroidManifest is debuggable
+
+	PackageOutputDir string // Custom directory of the generated NuGet packages (PackageOutputPath)
+
+	RawProperties map[string]string // Properties of the configuration as written in the project files
+
+	properties map[string]string // Evaluated properties of the configuration, keyed by lower case name
 }
 
 // Model ...
@@ -72,18 +122,65 @@ type Model struct {
 	// !!! only set by solution analyze
 	ConfigMap map[string]string
 
-	ID            string
+	ID            string   // ProjectGuid, or the project's id in the solution, if the project has no ProjectGuid
+	ProjectGUID   string   // ProjectGuid of the project file
+	TypeGUIDs     []string // ProjectTypeGuids of the project file
+	ProjectType   constants.ProjectType
 	SDK           constants.SDK
 	TestFramework constants.TestFramework
 	OutputType    string
-	AssemblyName  string
+	AssemblyName  string // Falls back to the project file name, if not set
+	RootNamespace string // Falls back to the project file name for SDK-style projects
+
+	MSBuildSDK       string // Set for SDK-style projects, like: Microsoft.NET.Sdk
+	TargetFrameworks []string
 
-	ReferredProjectIDs []string
+	TargetFrameworkVersion      string // Set for legacy projects, like: v9.0
+	TargetPlatformVersion       string // Set for SDK-style projects, like: 33.0 for net7.0-android33.0
+	AndroidUseLatestPlatformSdk bool
+	AndroidTargetSdkVersion     string
+	AndroidAPILevel             int // API level of the Android SDK the project compiles against, 0 if unknown
+
+	ReferredProjectIDs  []string
+	ReferredProjectPths []string
+	SharedItemsPths     []string // Imported shared project items (.projitems)
+
+	Packages []PackageModel // NuGet dependencies from packages.config and PackageReference items
+
+	GeneratePackageOnBuild bool
+	PackageID              string // Id of the generated NuGet package, defaults to the AssemblyName
+	PackageVersion         string
+	NuspecPth              string // Nuspec file of the project, used by nuget pack
 
 	ManifestPth        string
 	AndroidApplication bool
 
+	InfoPlistPth       string
+	BundleIdentifier   string
+	BundleVersion      string
+	BundleShortVersion string
+	MinimumOSVersion   string
+
 	Configs map[string]ConfigurationPlatformModel // Project Configuration|Platform - ConfigurationPlatformModel map
+
+	Imports []string // Analyzed .props and .targets files, including the implicit Directory.Build.props and Directory.Build.targets
+
+	RawProperties map[string]string // Global properties as written in the project files, without expanding property references
+
+	properties map[string]string // Evaluated global properties, keyed by lower case name
+	outputPath string            // Global OutputPath, with unexpanded $(Configuration) and $(Platform) references
+
+	guidProjectType constants.ProjectType // App extension, watch or binding project type, identified by the project type guids
+
+	inputs analysisInputs // Files read or looked up by the analysis, used by the Cache
+}
+
+// conditionalPropertyGroup is a PropertyGroup with a configuration dependent condition,
+// which does not simply select a Configuration|Platform, like:
+// '$(Configuration)|$(Platform)' == 'Release|iPhone' Or '$(Configuration)|$(Platform)' == 'AppStore|iPhone'
+type conditionalPropertyGroup struct {
+	condition string
+	lines     []string
 }
 
 // New ...
@@ -91,15 +188,140 @@ func New(pth string) (Model, error) {
 	return analyzeProject(pth)
 }
 
+func isConfigurationDependentCondition(condition string) bool {
+	condition = strings.ToLower(condition)
+	return strings.Contains(condition, "$(configuration)") || strings.Contains(condition, "$(platform)")
+}
+
+// evaluateElementCondition evaluates the Condition attribute of the element in the line.
+// Returns the line without the Condition attribute and whether the element should be analyzed.
+// Groups and imports are not handled here, conditions failing to evaluate are ignored.
+func evaluateElementCondition(line string, properties map[string]string, dir string, inputs analysisInputs) (string, bool) {
+	matches := regexp.MustCompile(elementWithConditionPattern).FindStringSubmatch(line)
+	if len(matches) != 4 {
+		return line, true
+	}
+
+	switch strings.ToLower(matches[1]) {
+	case "project", "propertygroup", "itemgroup", "import", "choose", "when", "otherwise", "target":
+		return line, true
+	}
+
+	if ok, err := evaluateCondition(matches[2], properties, dir, inputs); err == nil && !ok {
+		return line, false
+	}
+
+	return "<" + matches[1] + matches[3], true
+}
+
+// analyzeProperty captures the property of the line into the properties map
+func analyzeProperty(properties map[string]string, line string, evaluationProperties map[string]string) map[string]string {
+	matches := propertyPattern.FindStringSubmatch(line)
+	if len(matches) != 4 || matches[1] != matches[3] {
+		return properties
+	}
+
+	if properties == nil {
+		properties = map[string]string{}
+	}
+	properties[strings.ToLower(matches[1])] = expandProperties(matches[2], evaluationProperties)
+
+	return properties
+}
+
+// analyzeRawProperty stores the property of the line as it is written in the project, without expanding the property references
+func analyzeRawProperty(rawProperties map[string]string, line string) map[string]string {
+	matches := propertyPattern.FindStringSubmatch(line)
+	if len(matches) != 4 || matches[1] != matches[3] {
+		return rawProperties
+	}
+
+	if rawProperties == nil {
+		rawProperties = map[string]string{}
+	}
+	rawProperties[matches[1]] = matches[2]
+
+	return rawProperties
+}
+
+// analyzeConfigurationProperty analyzes the configuration specific properties,
+// returns false if the line does not contain such property
+func analyzeConfigurationProperty(project Model, pth string, configurationPlatform ConfigurationPlatformModel, line string) (ConfigurationPlatformModel, bool) {
+	// OutputPath
+	if matches := regexp.MustCompile(outputPathPattern).FindStringSubmatch(line); len(matches) == 2 {
+		outputRelativePth := expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
+		outputRelativePth = utility.FixWindowsPath(outputRelativePth)
+
+		configurationPlatform.OutputDir = resolvePath(filepath.Dir(pth), outputRelativePth)
+		return configurationPlatform, true
+	}
+
+	// MtouchArch
+	if matches := regexp.MustCompile(mtouchArchPattern).FindStringSubmatch(line); len(matches) == 2 {
+		configurationPlatform.MtouchArchs = utility.SplitAndStripList(matches[1], ",")
+		return configurationPlatform, true
+	}
+
+	// AndroidKeyStore
+	if match := regexp.MustCompile(androidKeystorePattern).FindString(line); match != "" {
+		configurationPlatform.SignAndroid = true
+		return configurationPlatform, true
+	}
+
+	// BuildIpa ...
+	if match := regexp.MustCompile(buildIpaPattern).FindString(line); match != "" {
+		configurationPlatform.BuildIpa = true
+		return configurationPlatform, true
+	}
+
+	return configurationPlatform, false
+}
+
+// applyConditionalPropertyGroups applies the property groups to every configuration, which satisfies the group's condition
+func applyConditionalPropertyGroups(project Model, pth string, groups []conditionalPropertyGroup) Model {
+	for _, group := range groups {
+		for configKey, configurationPlatform := range project.Configs {
+			ok, err := evaluateCondition(group.condition, evaluationProperties(project, pth, configurationPlatform), filepath.Dir(pth), project.inputs)
+			if err != nil || !ok {
+				continue
+			}
+
+			for _, line := range group.lines {
+				line, ok := evaluateElementCondition(line, evaluationProperties(project, pth, configurationPlatform), filepath.Dir(pth), project.inputs)
+				if !ok {
+					continue
+				}
+
+				configurationPlatform.properties = analyzeProperty(configurationPlatform.properties, line, evaluationProperties(project, pth, configurationPlatform))
+				configurationPlatform.RawProperties = analyzeRawProperty(configurationPlatform.RawProperties, line)
+				configurationPlatform, _ = analyzeConfigurationProperty(project, pth, configurationPlatform, line)
+			}
+
+			project.Configs[configKey] = configurationPlatform
+		}
+	}
+	return project
+}
+
 func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 	configurationPlatform := ConfigurationPlatformModel{}
 
 	isPropertyGroupSection := false
+	isGlobalPropertyGroupSection := false
 	isProjectReferenceSection := false
+	isPackageReferenceSection := false
+
+	// Sections with false condition
+	isSkippedSection := false
+	skippedSectionEndPattern := ""
+
+	var conditionalGroup *conditionalPropertyGroup
+	conditionalGroups := []conditionalPropertyGroup{}
 
 	projectDir := filepath.Dir(pth)
 
-	projectDefinitionFileContent, err := fileutil.ReadStringFromFile(pth)
+	project.inputs.record(pth)
+	projectDefinitionFileContent, err := utility.ReadTextFile(pth)
 	if err != nil {
 		return Model{}, fmt.Errorf("failed to read project (%s), error: %s", pth, err)
 	}
@@ -108,38 +330,121 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 
-		// Target definition
+		//
+		// Conditions
+
+		if isSkippedSection {
+			if match := regexp.MustCompile(skippedSectionEndPattern).FindString(line); match != "" {
+				isSkippedSection = false
+			}
+			continue
+		}
+
+		// Configuration dependent PropertyGroup, applied once all the configurations are known
+		if conditionalGroup != nil {
+			if match := regexp.MustCompile(propertyGroupEndPattern).FindString(line); match != "" {
+				conditionalGroups = append(conditionalGroups, *conditionalGroup)
+				conditionalGroup = nil
+				continue
+			}
+
+			conditionalGroup.lines = append(conditionalGroup.lines, line)
+			continue
+		}
+
+		line, ok := evaluateElementCondition(line, evaluationProperties(project, pth, configurationPlatform), projectDir, project.inputs)
+		if !ok {
+			continue
+		}
+
+		// ItemGroup with Condition
+		if matches := regexp.MustCompile(itemGroupWithConditionPattern).FindStringSubmatch(line); len(matches) == 2 {
+			condition := matches[1]
+			if !isConfigurationDependentCondition(condition) {
+				if ok, err := evaluateCondition(condition, evaluationProperties(project, pth, ConfigurationPlatformModel{}), projectDir, project.inputs); err == nil && !ok {
+					isSkippedSection = true
+					skippedSectionEndPattern = itemGroupEndPattern
+				}
+			}
+			continue
+		}
+
+		// Properties
+		if isPropertyGroupSection {
+			configurationPlatform.properties = analyzeProperty(configurationPlatform.properties, line, evaluationProperties(project, pth, configurationPlatform))
+			configurationPlatform.RawProperties = analyzeRawProperty(configurationPlatform.RawProperties, line)
+		} else if isGlobalPropertyGroupSection {
+			project.properties = analyzeProperty(project.properties, line, evaluationProperties(project, pth, ConfigurationPlatformModel{}))
+			project.RawProperties = analyzeRawProperty(project.RawProperties, line)
+
+			// OutputPath
+			if matches := regexp.MustCompile(outputPathPattern).FindStringSubmatch(line); len(matches) == 2 {
+				properties := evaluationProperties(project, pth, ConfigurationPlatformModel{})
+				properties["configuration"] = "$(Configuration)"
+				properties["platform"] = "$(Platform)"
+
+				// relative OutputPath is relative to the project, even if it is defined in an imported file
+				project.outputPath = resolvePath(filepath.Dir(project.Pth), utility.FixWindowsPath(expandProperties(matches[1], properties)))
+			}
+		}
+
+		// Target definition (.props, .targets)
 		// Analyze target definition and point the current project to the target analyze result
-		if matches := regexp.MustCompile(targetDefinitionPattern).FindStringSubmatch(line); len(matches) == 2 {
-			targetDefinitionRelativePth := utility.FixWindowsPath(matches[1])
-
-			if !strings.Contains(targetDefinitionRelativePth, "$(MSBuild") {
-				targetDefinitionPth := filepath.Join(projectDir, targetDefinitionRelativePth)
-
-				if exist, err := pathutil.IsPathExists(targetDefinitionPth); err != nil {
-					return Model{}, err
-				} else if exist {
-					projectFromTargetDefinition, err := analyzeTargetDefinition(project, targetDefinitionPth)
-					if err != nil {
-						return Model{}, err
-					}
-
-					// Set properties became from solution analyze
-					projectFromTargetDefinition.Name = project.Name
-					projectFromTargetDefinition.Pth = project.Pth
-					projectFromTargetDefinition.ConfigMap = project.ConfigMap
-					// ---
-
-					project = projectFromTargetDefinition
+		if matches := regexp.MustCompile(targetDefinitionPattern).FindStringSubmatch(line); len(matches) == 3 {
+			properties := evaluationProperties(project, pth, configurationPlatform)
+
+			if conditionMatches := regexp.MustCompile(conditionAttributePattern).FindStringSubmatch(line); len(conditionMatches) == 2 {
+				if ok, err := evaluateCondition(conditionMatches[1], properties, projectDir, project.inputs); err == nil && !ok {
+					continue
 				}
 			}
 
+			if isSystemImport(matches[1]) {
+				continue
+			}
+
+			targetDefinitionRelativePth := expandProperties(matches[1], properties)
+			targetDefinitionRelativePth = utility.FixWindowsPath(targetDefinitionRelativePth)
+			targetDefinitionPth := resolvePath(projectDir, targetDefinitionRelativePth)
+
+			projectFromTargetDefinition, err := analyzeImport(project, targetDefinitionPth)
+			if err != nil {
+				return Model{}, err
+			}
+			project = projectFromTargetDefinition
+
+			continue
+		}
+
+		// Shared project items
+		if matches := regexp.MustCompile(sharedItemsPattern).FindStringSubmatch(line); len(matches) == 2 {
+			sharedItemsRelativePth := expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
+			project.SharedItemsPths = append(project.SharedItemsPths, resolvePath(projectDir, sharedItemsRelativePth))
+			continue
+		}
+
+		// SDK-style project
+		if matches := regexp.MustCompile(sdkProjectPattern).FindStringSubmatch(line); len(matches) == 2 {
+			project.MSBuildSDK = matches[1]
+			continue
+		}
+
+		// TargetFramework
+		if matches := regexp.MustCompile(targetFrameworkPattern).FindStringSubmatch(line); len(matches) == 2 {
+			project.TargetFrameworks = parseTargetFrameworks(matches[1])
+			continue
+		}
+
+		// TargetFrameworks
+		if matches := regexp.MustCompile(targetFrameworksPattern).FindStringSubmatch(line); len(matches) == 2 {
+			project.TargetFrameworks = parseTargetFrameworks(matches[1])
 			continue
 		}
 
 		// ProjectGuid
 		if matches := regexp.MustCompile(guidPattern).FindStringSubmatch(line); len(matches) == 2 {
 			project.ID = strings.ToUpper(matches[1])
+			project.ProjectGUID = project.ID
 			continue
 		}
 
@@ -151,15 +456,22 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 
 		// AssemblyName
 		if matches := regexp.MustCompile(assemblyNamePattern).FindStringSubmatch(line); len(matches) == 2 {
-			project.AssemblyName = matches[1]
+			project.AssemblyName = expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
 			continue
 		}
 
-		// AndroidManifest
-		if matches := regexp.MustCompile(manifestPattern).FindStringSubmatch(line); len(matches) == 2 {
-			manifestRelativePth := utility.FixWindowsPath(matches[1])
+		// AndroidManifest, the configuration specific one is set by analyzeConfigurationManifests
+		if matches := regexp.MustCompile(manifestPattern).FindStringSubmatch(line); len(matches) == 2 && !isPropertyGroupSection {
+			manifestRelativePth := expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
+			manifestRelativePth = utility.FixWindowsPath(manifestRelativePth)
+
+			project.ManifestPth = resolvePath(projectDir, manifestRelativePth)
+			continue
+		}
 
-			project.ManifestPth = filepath.Join(projectDir, manifestRelativePth)
+		// Info.plist
+		if infoPlistPth, ok := analyzeInfoPlistItem(projectDir, line); ok {
+			project.InfoPlistPth = infoPlistPth
 			continue
 		}
 
@@ -172,6 +484,19 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 		//
 		// PropertyGroups
 
+		// PropertyGroup without Condition
+		if isGlobalPropertyGroupSection {
+			if match := regexp.MustCompile(propertyGroupEndPattern).FindString(line); match != "" {
+				isGlobalPropertyGroupSection = false
+				continue
+			}
+		}
+
+		if match := regexp.MustCompile(propertyGroupStartPattern).FindString(line); match != "" {
+			isGlobalPropertyGroupSection = true
+			continue
+		}
+
 		if isPropertyGroupSection {
 			if match := regexp.MustCompile(propertyGroupEndPattern).FindString(line); match != "" {
 				project.Configs[utility.ToConfig(configurationPlatform.Configuration, configurationPlatform.Platform)] = configurationPlatform
@@ -214,32 +539,27 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 			continue
 		}
 
-		if isPropertyGroupSection {
-			// OutputPath
-			if matches := regexp.MustCompile(outputPathPattern).FindStringSubmatch(line); len(matches) == 2 {
-				outputRelativePth := utility.FixWindowsPath(matches[1])
-				strings.Replace(outputRelativePth, "$(Configuration)", configurationPlatform.Configuration, -1)
-				strings.Replace(outputRelativePth, "$(Platform)", configurationPlatform.Platform, -1)
-
-				configurationPlatform.OutputDir = filepath.Join(projectDir, outputRelativePth)
+		// PropertyGroup with any other Condition
+		if matches := regexp.MustCompile(propertyGroupWithConditionPattern).FindStringSubmatch(line); len(matches) == 2 {
+			condition := matches[1]
+			if isConfigurationDependentCondition(condition) {
+				conditionalGroup = &conditionalPropertyGroup{condition: condition}
 				continue
 			}
 
-			// MtouchArch
-			if matches := regexp.MustCompile(mtouchArchPattern).FindStringSubmatch(line); len(matches) == 2 {
-				configurationPlatform.MtouchArchs = utility.SplitAndStripList(matches[1], ",")
+			if ok, err := evaluateCondition(condition, evaluationProperties(project, pth, ConfigurationPlatformModel{}), projectDir, project.inputs); err == nil && !ok {
+				isSkippedSection = true
+				skippedSectionEndPattern = propertyGroupEndPattern
 				continue
 			}
 
-			// AndroidKeyStore
-			if match := regexp.MustCompile(androidKeystorePattern).FindString(line); match != "" {
-				configurationPlatform.SignAndroid = true
-				continue
-			}
+			isGlobalPropertyGroupSection = true
+			continue
+		}
 
-			// BuildIpa ...
-			if match := regexp.MustCompile(buildIpaPattern).FindString(line); match != "" {
-				configurationPlatform.BuildIpa = true
+		if isPropertyGroupSection {
+			if config, ok := analyzeConfigurationProperty(project, pth, configurationPlatform, line); ok {
+				configurationPlatform = config
 				continue
 			}
 		}
@@ -251,6 +571,13 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 		if matches := regexp.MustCompile(typeGUIDsPattern).FindStringSubmatch(line); len(matches) == 2 {
 			sdk := constants.SDKUnknown
 			projectTypeList := strings.Split(matches[1], ";")
+
+			project.TypeGUIDs = []string{}
+			for _, guid := range projectTypeList {
+				if guid = strings.ToUpper(strings.Trim(strings.TrimSpace(guid), "{}")); guid != "" {
+					project.TypeGUIDs = append(project.TypeGUIDs, guid)
+				}
+			}
 			for _, guid := range projectTypeList {
 				guid = strings.TrimPrefix(guid, "{")
 				guid = strings.TrimSuffix(guid, "}")
@@ -261,10 +588,40 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 				}
 			}
 
+			for _, guid := range projectTypeList {
+				guid = strings.ToUpper(strings.Trim(guid, "{}"))
+				if projectType, err := constants.ParseEmbeddedProjectTypeGUID(guid); err == nil {
+					project.guidProjectType = projectType
+					break
+				}
+				if projectType, err := constants.ParseBindingProjectTypeGUID(guid); err == nil {
+					project.guidProjectType = projectType
+					break
+				}
+			}
+
 			project.SDK = sdk
 			continue
 		}
 
+		//
+		// PackageReference
+
+		if isPackageReferenceSection {
+			if matches := regexp.MustCompile(packageReferenceVersionElementPattern).FindStringSubmatch(line); len(matches) == 2 {
+				project.Packages[len(project.Packages)-1].Version = expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
+			} else if match := regexp.MustCompile(packageReferenceEndPattern).FindString(line); match != "" {
+				isPackageReferenceSection = false
+			}
+			continue
+		}
+
+		// The test framework detection below also inspects the PackageReference items
+		if pkg, ok := analyzePackageReference(line, evaluationProperties(project, pth, configurationPlatform)); ok {
+			project.Packages = append(project.Packages, pkg)
+			isPackageReferenceSection = !strings.HasSuffix(line, "/>") && regexp.MustCompile(packageReferenceEndPattern).FindString(line) == ""
+		}
+
 		if match := regexp.MustCompile(referenceXamarinUITestPattern).FindString(line); match != "" {
 			project.TestFramework = constants.TestFrameworkXamarinUITest
 			continue
@@ -282,6 +639,27 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 			continue
 		}
 
+		if match := regexp.MustCompile(referenceNunitPackage).FindString(line); match != "" {
+			if project.TestFramework == constants.TestFrameworkUnknown {
+				project.TestFramework = constants.TestFrameworkNunitTest
+			}
+			continue
+		}
+
+		if match := regexp.MustCompile(referenceXunitFramework).FindString(line); match != "" {
+			if project.TestFramework == constants.TestFrameworkUnknown {
+				project.TestFramework = constants.TestFrameworkXunitTest
+			}
+			continue
+		}
+
+		if match := regexp.MustCompile(referenceMSTestFramework).FindString(line); match != "" {
+			if project.TestFramework == constants.TestFrameworkUnknown {
+				project.TestFramework = constants.TestFrameworkMSTest
+			}
+			continue
+		}
+
 		//
 		// ProjectReference
 
@@ -292,7 +670,16 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 		}
 
 		// ProjectReference
+		if matches := regexp.MustCompile(projectReferencePattern).FindStringSubmatch(line); len(matches) == 2 {
+			referredProjectRelativePth := expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
+			project.ReferredProjectPths = append(project.ReferredProjectPths, resolvePath(projectDir, referredProjectRelativePth))
+			continue
+		}
+
 		if matches := regexp.MustCompile(projectRefernceStartPattern).FindStringSubmatch(line); len(matches) == 2 {
+			referredProjectRelativePth := expandProperties(matches[1], evaluationProperties(project, pth, configurationPlatform))
+			project.ReferredProjectPths = append(project.ReferredProjectPths, resolvePath(projectDir, referredProjectRelativePth))
+
 			isProjectReferenceSection = true
 			continue
 		}
@@ -310,7 +697,72 @@ func analyzeTargetDefinition(project Model, pth string) (Model, error) {
 		return Model{}, err
 	}
 
-	return project, nil
+	return applyConditionalPropertyGroups(project, pth, conditionalGroups), nil
+}
+
+// Imports of the MSBuild installation, like: $(MSBuildExtensionsPath)\Xamarin\iOS\Xamarin.iOS.CSharp.targets
+var systemImportPathPrefixes = []string{
+	"$(msbuildextensionspath",
+	"$(msbuildbinpath",
+	"$(msbuildtoolspath",
+	"$(msbuildsdkspath",
+	"$(vstoolspath",
+}
+
+func isSystemImport(pth string) bool {
+	pth = strings.ToLower(strings.TrimSpace(pth))
+	for _, prefix := range systemImportPathPrefixes {
+		if strings.HasPrefix(pth, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeImport analyzes the imported target definition, if exists and was not yet imported
+func analyzeImport(project Model, pth string) (Model, error) {
+	for _, importPth := range project.Imports {
+		if importPth == pth {
+			return project, nil
+		}
+	}
+
+	if exist, err := project.inputs.pathExists(pth); err != nil {
+		return Model{}, err
+	} else if !exist {
+		return project, nil
+	}
+
+	project.Imports = append(project.Imports, pth)
+
+	projectFromTargetDefinition, err := analyzeTargetDefinition(project, pth)
+	if err != nil {
+		return Model{}, err
+	}
+
+	// Set properties became from solution analyze
+	projectFromTargetDefinition.Name = project.Name
+	projectFromTargetDefinition.Pth = project.Pth
+	projectFromTargetDefinition.ConfigMap = project.ConfigMap
+	// ---
+
+	return projectFromTargetDefinition, nil
+}
+
+// findFileInParentDirs returns the path of the first file with the given name in dir or in its parent dirs
+func findFileInParentDirs(dir, name string, inputs analysisInputs) string {
+	for {
+		pth := filepath.Join(dir, name)
+		if exist, err := inputs.pathExists(pth); err == nil && exist {
+			return pth
+		}
+
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return ""
+		}
+		dir = parentDir
+	}
 }
 
 func analyzeProject(pth string) (Model, error) {
@@ -328,8 +780,139 @@ func analyzeProject(pth string) (Model, error) {
 		Name:          fileName,
 		ConfigMap:     map[string]string{},
 		Configs:       map[string]ConfigurationPlatformModel{},
+		properties:    map[string]string{},
+		ProjectType:   constants.ProjectTypeUnknown,
 		SDK:           constants.SDKUnknown,
 		TestFramework: constants.TestFrameworkUnknown,
+		inputs:        analysisInputs{},
+	}
+
+	// Directory.Build.props is imported before, Directory.Build.targets after the project content
+	if directoryBuildPropsPth := findFileInParentDirs(filepath.Dir(absPth), directoryBuildPropsFileName, project.inputs); directoryBuildPropsPth != "" {
+		project, err = analyzeImport(project, directoryBuildPropsPth)
+		if err != nil {
+			return Model{}, err
+		}
+	}
+
+	project, err = analyzeTargetDefinition(project, absPth)
+	if err != nil {
+		return Model{}, err
+	}
+
+	if directoryBuildTargetsPth := findFileInParentDirs(filepath.Dir(absPth), directoryBuildTargetsFileName, project.inputs); directoryBuildTargetsPth != "" {
+		project, err = analyzeImport(project, directoryBuildTargetsPth)
+		if err != nil {
+			return Model{}, err
+		}
+	}
+
+	// Configurations without OutputPath use the global one
+	if project.outputPath != "" {
+		for configKey, configurationPlatform := range project.Configs {
+			if configurationPlatform.OutputDir == "" {
+				configurationPlatform.OutputDir = expandProperties(project.outputPath, evaluationProperties(project, project.Pth, configurationPlatform))
+				project.Configs[configKey] = configurationPlatform
+			}
+		}
+	}
+
+	if project.MSBuildSDK != "" {
+		project = applySDKStyleDefaults(project)
+	}
+
+	project = analyzeNames(project, fileName)
+	project = analyzeNuGetPackaging(project)
+
+	project = analyzeConfigurationManifests(project)
+	project = analyzeAndroidSigning(project)
+	project = analyzeIOSBuildSettings(project)
+	project = analyzeDefineConstants(project)
+	project = analyzeTargetFramework(project)
+
+	project, err = analyzeInfoPlist(project)
+	if err != nil {
+		return Model{}, err
+	}
+
+	project, err = analyzePackagesConfig(project)
+	if err != nil {
+		return Model{}, err
+	}
+
+	project.ProjectType = classifyProject(project)
+
+	return project, nil
+}
+
+// analyzeNames applies the msbuild defaults of the AssemblyName and RootNamespace
+func analyzeNames(project Model, projectFileName string) Model {
+	if project.AssemblyName == "" {
+		project.AssemblyName = projectFileName
+	}
+
+	project.RootNamespace = strings.TrimSpace(project.properties["rootnamespace"])
+	if project.RootNamespace == "" && project.MSBuildSDK != "" {
+		project.RootNamespace = projectFileName
+	}
+
+	return project
+}
+
+// Properties marking the projects embedded into a container app
+var embeddedProjectProperties = []struct {
+	property    string
+	projectType constants.ProjectType
+}{
+	{"IsWatchApp", constants.ProjectTypeWatchApp},
+	{"IsWatchExtension", constants.ProjectTypeWatchExtension},
+	{"IsAppExtension", constants.ProjectTypeAppExtension},
+}
+
+// IsApplication - returns true for the iOS, tvOS and macOS executables and the Android application projects
+func (project Model) IsApplication() bool {
+	switch project.SDK {
+	case constants.SDKIOS, constants.SDKTvOS, constants.SDKMacOS:
+		return project.OutputType == "exe"
+	case constants.SDKAndroid:
+		return project.AndroidApplication
+	default:
+		return false
+	}
+}
+
+// classifyProject ...
+func classifyProject(project Model) constants.ProjectType {
+	if strings.EqualFold(filepath.Ext(project.Pth), constants.SHProjExt) {
+		return constants.ProjectTypeShared
+	}
+	if project.guidProjectType != "" {
+		return project.guidProjectType
+	}
+	for _, embedded := range embeddedProjectProperties {
+		if strings.EqualFold(strings.TrimSpace(project.properties[strings.ToLower(embedded.property)]), "true") {
+			return embedded.projectType
+		}
+	}
+	// SDK-style binding projects have no project type guids
+	if strings.EqualFold(strings.TrimSpace(project.properties["isbindingproject"]), "true") {
+		switch project.SDK {
+		case constants.SDKIOS, constants.SDKTvOS, constants.SDKMacOS:
+			return constants.ProjectTypeiOSBinding
+		case constants.SDKAndroid:
+			return constants.ProjectTypeAndroidBinding
+		}
+	}
+	if project.TestFramework == constants.TestFrameworkXamarinUITest {
+		return constants.ProjectTypeXamarinUITest
+	}
+	if project.TestFramework == constants.TestFrameworkNunitTest ||
+		project.TestFramework == constants.TestFrameworkXunitTest ||
+		project.TestFramework == constants.TestFrameworkMSTest {
+		return constants.ProjectTypeUnitTest
+	}
+	if project.OutputType == "library" && !project.IsApplication() {
+		return constants.ProjectTypeLibrary
 	}
-	return analyzeTargetDefinition(project, absPth)
+	return constants.ProjectTypeUnknown
 }
@@ -162,6 +162,8 @@ func TestAnalyzeProject(t *testing.T) {
 		require.Equal(t, true, stringSliceContainsOnly(config.MtouchArchs, "i386"))
 		require.Equal(t, false, config.BuildIpa)
 		require.Equal(t, false, config.SignAndroid)
+		require.Equal(t, []string{"DEBUG", "ENABLE_TEST_CLOUD"}, config.DefineConstants)
+		require.Equal(t, true, config.HasDefineConstant("DEBUG"))
 
 		config, ok = project.Configs["Release|iPhone"]
 		require.Equal(t, true, ok)
@@ -217,6 +219,9 @@ func TestAnalyzeProject(t *testing.T) {
 
 		require.Equal(t, filepath.Join(dir, "Properties/AndroidManifest.xml"), project.ManifestPth)
 		require.Equal(t, true, project.AndroidApplication)
+		require.Equal(t, "v4.4", project.TargetFrameworkVersion)
+		require.Equal(t, false, project.AndroidUseLatestPlatformSdk)
+		require.Equal(t, 19, project.AndroidAPILevel)
 
 		// Configs
 		config, ok := project.Configs["Debug|AnyCPU"]
@@ -330,6 +335,15 @@ func TestAnalyzeProject(t *testing.T) {
 		require.Equal(t, true, stringSliceContainsOnly(config.MtouchArchs, "ARM64"))
 		require.Equal(t, false, config.BuildIpa)
 		require.Equal(t, false, config.SignAndroid)
+		require.Equal(t, "SdkOnly", config.MtouchLink)
+		require.Equal(t, true, config.MtouchUseLlvm)
+		require.Equal(t, true, config.MtouchEnableBitcode)
+		require.Equal(t, false, config.MtouchNoSymbolStrip)
+		require.Equal(t, "iPhone Developer", config.CodesignKey)
+		require.Equal(t, "", config.CodesignProvision)
+		require.Equal(t, filepath.Join(dir, "Entitlements.plist"), config.CodesignEntitlements)
+		require.Equal(t, filepath.Join(filepath.Dir(dir), "ipas"), config.IpaPackageDir)
+		require.Equal(t, "tvos-release.ipa", config.IpaPackageName)
 
 		config, ok = project.Configs["Release|iPhoneSimulator"]
 		require.Equal(t, true, ok)
@@ -348,6 +362,67 @@ func TestAnalyzeProject(t *testing.T) {
 		require.Equal(t, true, stringSliceContainsOnly(config.MtouchArchs, "ARM64"))
 		require.Equal(t, false, config.BuildIpa)
 		require.Equal(t, false, config.SignAndroid)
+		require.Equal(t, true, config.MtouchNoSymbolStrip)
+	}
+
+	t.Log("sdk-style android test")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleAndroidTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+		dir := filepath.Dir(pth)
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+
+		require.Equal(t, "", project.ID)
+		require.Equal(t, "Microsoft.NET.Sdk", project.MSBuildSDK)
+		require.Equal(t, true, stringSliceContainsOnly(project.TargetFrameworks, "net7.0-android"))
+		require.Equal(t, constants.SDKAndroid, project.SDK)
+		require.Equal(t, "exe", project.OutputType)
+		require.Equal(t, "SdkStyle.Droid", project.AssemblyName)
+		require.Equal(t, true, stringSliceContainsOnly(project.ReferredProjectPths, filepath.Join(dir, "../SdkStyle.Core/SdkStyle.Core.csproj")))
+
+		require.Equal(t, filepath.Join(dir, "AndroidManifest.xml"), project.ManifestPth)
+		require.Equal(t, true, project.AndroidApplication)
+		require.Equal(t, "", project.TargetPlatformVersion)
+		require.Equal(t, 33, project.AndroidAPILevel)
+
+		// Configs
+		config, ok := project.Configs["Debug|AnyCPU"]
+		require.Equal(t, true, ok)
+		require.Equal(t, "Debug", config.Configuration)
+		require.Equal(t, "AnyCPU", config.Platform)
+		require.Equal(t, filepath.Join(dir, "bin/Debug/net7.0-android"), config.OutputDir)
+		require.Equal(t, false, config.SignAndroid)
+
+		config, ok = project.Configs["Release|AnyCPU"]
+		require.Equal(t, true, ok)
+		require.Equal(t, "Release", config.Configuration)
+		require.Equal(t, "AnyCPU", config.Platform)
+		require.Equal(t, filepath.Join(dir, "bin/Release/net7.0-android"), config.OutputDir)
+		require.Equal(t, true, config.SignAndroid)
+	}
+
+	t.Log("sdk-style multi target test")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleMultiTargetTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+		dir := filepath.Dir(pth)
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+
+		require.Equal(t, true, stringSliceContainsOnly(project.TargetFrameworks, "net7.0-android", "net7.0-ios", "net7.0-maccatalyst"))
+		require.Equal(t, constants.SDKAndroid, project.SDK)
+		require.Equal(t, "exe", project.OutputType)
+
+		config, ok := project.Configs["Release|AnyCPU"]
+		require.Equal(t, true, ok)
+		require.Equal(t, filepath.Join(dir, "bin/Release/net7.0-android"), config.OutputDir)
 	}
 
 	t.Log("xamarin uitest test")
@@ -500,3 +575,620 @@ func TestAnalyzeProject(t *testing.T) {
 		require.Equal(t, false, config.SignAndroid)
 	}
 }
+
+func TestXamarinUITestProjectType(t *testing.T) {
+	t.Log("it detects Xamarin.UITest from packages.config")
+	{
+		pth := tmpProjectWithContent(t, packagesConfigXamarinUITestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "packages.config"), xamarinUITestPackagesConfigContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.TestFrameworkXamarinUITest, project.TestFramework)
+		require.Equal(t, constants.ProjectTypeXamarinUITest, project.ProjectType)
+	}
+
+	t.Log("it detects Xamarin.UITest from PackageReference")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleXamarinUITestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.TestFrameworkXamarinUITest, project.TestFramework)
+		require.Equal(t, constants.ProjectTypeXamarinUITest, project.ProjectType)
+	}
+
+	t.Log("it does not classify projects without Xamarin.UITest")
+	{
+		pth := tmpProjectWithContent(t, nunitTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.NotEqual(t, constants.ProjectTypeXamarinUITest, project.ProjectType)
+	}
+}
+
+func TestUnitTestProjectType(t *testing.T) {
+	t.Log("it detects test frameworks from references")
+	{
+		contentTestFrameworkMap := map[string]constants.TestFramework{
+			nunitTestProjectContent:     constants.TestFrameworkNunitTest,
+			sdkStyleXunitProjectContent: constants.TestFrameworkXunitTest,
+			mstestProjectContent:        constants.TestFrameworkMSTest,
+		}
+		for content, testFramework := range contentTestFrameworkMap {
+			pth := tmpProjectWithContent(t, content)
+
+			project, err := analyzeProject(pth)
+			require.NoError(t, err)
+			require.Equal(t, testFramework, project.TestFramework)
+			require.Equal(t, constants.ProjectTypeUnitTest, project.ProjectType)
+
+			require.NoError(t, os.Remove(pth))
+		}
+	}
+
+	t.Log("it detects test frameworks from packages.config")
+	{
+		pth := tmpProjectWithContent(t, packagesConfigXamarinUITestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "packages.config"), xunitPackagesConfigContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.TestFrameworkXunitTest, project.TestFramework)
+		require.Equal(t, constants.ProjectTypeUnitTest, project.ProjectType)
+	}
+
+	t.Log("it does not classify NUnitLite apps as unit test assemblies")
+	{
+		pth := tmpProjectWithContent(t, nunitLiteTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.TestFrameworkNunitLiteTest, project.TestFramework)
+		require.NotEqual(t, constants.ProjectTypeUnitTest, project.ProjectType)
+	}
+
+	t.Log("it detects the Xamarin.Android NUnitLite reference")
+	{
+		pth := tmpProjectWithContent(t, strings.Replace(nunitLiteTestProjectContent, "MonoTouch.NUnitLite", "Xamarin.Android.NUnitLite", -1))
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.TestFrameworkNunitLiteTest, project.TestFramework)
+	}
+}
+
+func TestPropertyExpansion(t *testing.T) {
+	pth := tmpProjectWithContent(t, propertyExpansionTestProjectContent)
+	defer func() {
+		require.NoError(t, os.Remove(pth))
+	}()
+	dir := filepath.Dir(pth)
+
+	project, err := analyzeProject(pth)
+	require.NoError(t, err)
+
+	require.Equal(t, "project.App", project.AssemblyName)
+
+	config, ok := project.Configs["Debug|iPhoneSimulator"]
+	require.Equal(t, true, ok)
+	require.Equal(t, filepath.Join(dir, "bin/iPhoneSimulator/Debug"), config.OutputDir)
+
+	config, ok = project.Configs["Release|iPhone"]
+	require.Equal(t, true, ok)
+	require.Equal(t, filepath.Join(dir, "build/iPhone-device/Release"), config.OutputDir)
+}
+
+func TestConditions(t *testing.T) {
+	pth := tmpProjectWithContent(t, conditionsTestProjectContent)
+	defer func() {
+		require.NoError(t, os.Remove(pth))
+	}()
+
+	project, err := analyzeProject(pth)
+	require.NoError(t, err)
+
+	t.Log("it skips groups with false condition")
+	{
+		require.Equal(t, "Conditions", project.AssemblyName)
+		require.Equal(t, constants.TestFrameworkUnknown, project.TestFramework)
+	}
+
+	t.Log("it does not create configs for non Configuration|Platform conditions")
+	{
+		require.Equal(t, 3, len(project.Configs))
+	}
+
+	t.Log("it evaluates property conditions")
+	{
+		config, ok := project.Configs["Debug|iPhone"]
+		require.Equal(t, true, ok)
+		require.Equal(t, []string{"ARM64"}, config.MtouchArchs)
+		require.Equal(t, false, config.BuildIpa)
+	}
+
+	t.Log("it applies configuration dependent groups")
+	{
+		for _, configKey := range []string{"Release|iPhone", "AppStore|iPhone"} {
+			config, ok := project.Configs[configKey]
+			require.Equal(t, true, ok)
+			require.Equal(t, []string{"ARM64"}, config.MtouchArchs)
+			require.Equal(t, true, config.BuildIpa)
+		}
+	}
+}
+
+func TestImports(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	projectDir := filepath.Join(tmpDir, "App.iOS")
+	buildDir := filepath.Join(tmpDir, "build")
+	require.NoError(t, os.MkdirAll(projectDir, 0777))
+	require.NoError(t, os.MkdirAll(buildDir, 0777))
+
+	pth := tmpProjectWithContentInDir(t, importsTestProjectContent, projectDir)
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "Directory.Build.props"), importsTestDirectoryBuildPropsContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(buildDir, "common.targets"), importsTestCommonTargetsContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(buildDir, "skipped.targets"), importsTestSkippedTargetsContent))
+
+	project, err := analyzeProject(pth)
+	require.NoError(t, err)
+
+	t.Log("it follows the existing imports")
+	{
+		require.Equal(t, []string{
+			filepath.Join(tmpDir, "Directory.Build.props"),
+			filepath.Join(buildDir, "common.targets"),
+		}, project.Imports)
+		require.Equal(t, "Imported", project.AssemblyName)
+	}
+
+	t.Log("it applies the imported OutputPath to configs without OutputPath")
+	{
+		config, ok := project.Configs["Debug|iPhone"]
+		require.Equal(t, true, ok)
+		require.Equal(t, filepath.Join(tmpDir, "artifacts/iPhone/Debug"), config.OutputDir)
+
+		config, ok = project.Configs["Release|iPhone"]
+		require.Equal(t, true, ok)
+		require.Equal(t, filepath.Join(projectDir, "bin/iPhone/Release"), config.OutputDir)
+	}
+}
+
+func TestInfoPlist(t *testing.T) {
+	t.Log("it reads the referred Info.plist")
+	{
+		pth := tmpProjectWithContent(t, infoPlistTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "Resources"), 0777))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "Resources", "Info.plist"), infoPlistTestContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "Resources", "Info.plist"), project.InfoPlistPth)
+		require.Equal(t, "com.bitrise.sampleapp", project.BundleIdentifier)
+		require.Equal(t, "12", project.BundleVersion)
+		require.Equal(t, "1.2.0", project.BundleShortVersion)
+		require.Equal(t, "10.0", project.MinimumOSVersion)
+	}
+
+	t.Log("it uses the application properties of SDK-style projects")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleIOSTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "Platforms", "iOS"), 0777))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "Platforms", "iOS", "Info.plist"), infoPlistTestContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "Platforms", "iOS", "Info.plist"), project.InfoPlistPth)
+		require.Equal(t, "com.bitrise.maui", project.BundleIdentifier)
+		require.Equal(t, "7", project.BundleVersion)
+		require.Equal(t, "2.0", project.BundleShortVersion)
+		require.Equal(t, "14.2", project.MinimumOSVersion)
+	}
+}
+
+func TestAndroidSigning(t *testing.T) {
+	t.Log("it reads the signing properties, but not the passwords")
+	{
+		pth := tmpProjectWithContent(t, androidSigningTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKAndroid, project.SDK)
+
+		debugConfig, ok := project.Configs["Debug|AnyCPU"]
+		require.True(t, ok)
+		require.False(t, debugConfig.SignAndroid)
+		require.Equal(t, "", debugConfig.AndroidSigningKeyStore)
+
+		releaseConfig, ok := project.Configs["Release|AnyCPU"]
+		require.True(t, ok)
+		require.True(t, releaseConfig.SignAndroid)
+		require.Equal(t, filepath.Join(dir, "Keystores", "release.keystore"), releaseConfig.AndroidSigningKeyStore)
+		require.Equal(t, "release", releaseConfig.AndroidSigningKeyAlias)
+		require.True(t, releaseConfig.HasAndroidSigningStorePass)
+		require.False(t, releaseConfig.HasAndroidSigningKeyPass)
+		require.Equal(t, "aab", releaseConfig.AndroidPackageFormat)
+		require.Equal(t, []string{"armeabi-v7a", "arm64-v8a"}, releaseConfig.AndroidSupportedAbis)
+		require.Equal(t, []string{"AndroidSigningKeyPass"}, releaseConfig.MissingAndroidSigningProperties())
+		require.True(t, releaseConfig.MonoSymbolArchive)
+		require.False(t, debugConfig.MonoSymbolArchive)
+		require.False(t, releaseConfig.AndroidFastDeployment)
+		require.True(t, debugConfig.AndroidFastDeployment)
+	}
+}
+
+func TestAndroidAPILevel(t *testing.T) {
+	t.Log("it maps Xamarin.Android target framework versions")
+	{
+		require.Equal(t, 28, AndroidAPILevel("v9.0"))
+		require.Equal(t, 20, AndroidAPILevel("v4.4.87"))
+	}
+
+	t.Log("it maps .NET target frameworks")
+	{
+		require.Equal(t, 33, AndroidAPILevel("net7.0-android"))
+		require.Equal(t, 34, AndroidAPILevel("net8.0-android34.0"))
+	}
+
+	t.Log("it returns 0 for unknown target frameworks")
+	{
+		require.Equal(t, 0, AndroidAPILevel("v1.0"))
+		require.Equal(t, 0, AndroidAPILevel("netstandard2.0"))
+	}
+}
+
+func TestAndroidTargetSdkVersion(t *testing.T) {
+	t.Log("it reads the target sdk version from the manifest")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleAndroidTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "AndroidManifest.xml"), androidManifestTestContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, "33", project.AndroidTargetSdkVersion)
+	}
+}
+
+func TestConfigurationManifests(t *testing.T) {
+	t.Log("it uses the configuration specific AndroidManifest")
+	{
+		pth := tmpProjectWithContent(t, androidSigningTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "Properties", "AndroidManifest.xml"), project.ManifestPth)
+
+		debugConfig, ok := project.Configs["Debug|AnyCPU"]
+		require.True(t, ok)
+		require.Equal(t, filepath.Join(dir, "Properties", "Debug", "AndroidManifest.xml"), debugConfig.ManifestPth)
+
+		releaseConfig, ok := project.Configs["Release|AnyCPU"]
+		require.True(t, ok)
+		require.Equal(t, filepath.Join(dir, "Properties", "AndroidManifest.xml"), releaseConfig.ManifestPth)
+	}
+
+	t.Log("it reads the debuggable attribute of the configuration's manifest")
+	{
+		pth := tmpProjectWithContent(t, androidSigningTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "Properties"), 0755))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "Properties", "AndroidManifest.xml"), `<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.bitrise.app">
+  <application android:debuggable="true" />
+</manifest>`))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.True(t, project.Configs["Release|AnyCPU"].AndroidDebuggable)
+		require.False(t, project.Configs["Debug|AnyCPU"].AndroidDebuggable)
+	}
+}
+
+func TestPackages(t *testing.T) {
+	t.Log("it lists the PackageReference items")
+	{
+		pth := tmpProjectWithContent(t, packageReferencesTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, []PackageModel{
+			{ID: "Xamarin.Forms", Version: "5.0.0.2578", Style: PackageStylePackageReference},
+			{ID: "Xamarin.Essentials", Version: "1.7.*", Style: PackageStylePackageReference},
+			{ID: "Newtonsoft.Json", Version: "[13.0,)", Style: PackageStylePackageReference},
+		}, project.Packages)
+
+		require.Equal(t, []PackageModel{
+			{ID: "Xamarin.Essentials", Version: "1.7.*", Style: PackageStylePackageReference},
+			{ID: "Newtonsoft.Json", Version: "[13.0,)", Style: PackageStylePackageReference},
+		}, project.FloatingPackages())
+	}
+
+	t.Log("it lists the packages.config packages")
+	{
+		pth := tmpProjectWithContent(t, packagesConfigXamarinUITestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "packages.config"), xamarinUITestPackagesConfigContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, []PackageModel{
+			{ID: "NUnit", Version: "2.6.4", Style: PackageStylePackagesConfig},
+			{ID: "Xamarin.UITest", Version: "2.0.0", Style: PackageStylePackagesConfig},
+		}, project.Packages)
+		require.Equal(t, 0, len(project.FloatingPackages()))
+	}
+
+	t.Log("it detects floating versions")
+	{
+		require.True(t, PackageModel{Version: "*"}.IsFloatingVersion())
+		require.True(t, PackageModel{Version: "(1.0,)"}.IsFloatingVersion())
+		require.False(t, PackageModel{Version: "[1.0,2.0)"}.IsFloatingVersion())
+		require.False(t, PackageModel{Version: "[1.0]"}.IsFloatingVersion())
+		require.False(t, PackageModel{Version: "1.0.0"}.IsFloatingVersion())
+	}
+}
+
+func TestEmbeddedProjectType(t *testing.T) {
+	t.Log("it detects watch extensions by project type guid")
+	{
+		pth := tmpProjectWithContent(t, watchExtensionTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKIOS, project.SDK)
+		require.Equal(t, constants.ProjectTypeWatchExtension, project.ProjectType)
+	}
+
+	t.Log("it detects app extensions by the IsAppExtension property")
+	{
+		pth := tmpProjectWithContent(t, appExtensionTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKIOS, project.SDK)
+		require.Equal(t, constants.ProjectTypeAppExtension, project.ProjectType)
+	}
+}
+
+func TestBindingAndLibraryProjectType(t *testing.T) {
+	t.Log("it detects ios binding projects by project type guid")
+	{
+		pth := tmpProjectWithContent(t, strings.Replace(watchExtensionTestProjectContent, "1E2E965C-F6D2-49ED-B86E-418A60C69EEF", "8FFB629D-F513-41CE-95D2-7ECE97B6EEEC", -1))
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKIOS, project.SDK)
+		require.Equal(t, constants.ProjectTypeiOSBinding, project.ProjectType)
+	}
+
+	t.Log("it detects android binding projects by project type guid")
+	{
+		pth := tmpProjectWithContent(t, strings.Replace(watchExtensionTestProjectContent, "1E2E965C-F6D2-49ED-B86E-418A60C69EEF", "10368E6C-D01B-4462-8E8B-01FC667A7035", -1))
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKAndroid, project.SDK)
+		require.Equal(t, constants.ProjectTypeAndroidBinding, project.ProjectType)
+	}
+
+	t.Log("it detects SDK-style binding projects by the IsBindingProject property")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleBindingTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKAndroid, project.SDK)
+		require.Equal(t, constants.ProjectTypeAndroidBinding, project.ProjectType)
+	}
+
+	t.Log("it detects class libraries")
+	{
+		pth := tmpProjectWithContent(t, strings.Replace(watchExtensionTestProjectContent, "1E2E965C-F6D2-49ED-B86E-418A60C69EEF", "FEACFBD2-3405-455C-9665-78FE426C6842", -1))
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, constants.SDKIOS, project.SDK)
+		require.Equal(t, constants.ProjectTypeLibrary, project.ProjectType)
+		require.Equal(t, false, project.IsApplication())
+	}
+}
+
+func TestAssemblyNameAndRootNamespace(t *testing.T) {
+	t.Log("it reads the AssemblyName and RootNamespace")
+	{
+		content := strings.Replace(watchExtensionTestProjectContent, "<OutputType>", "<RootNamespace>App.WatchOS</RootNamespace>\n    <OutputType>", -1)
+		pth := tmpProjectWithContent(t, content)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, "App.WatchOSExtension", project.AssemblyName)
+		require.Equal(t, "App.WatchOS", project.RootNamespace)
+	}
+
+	t.Log("legacy project without AssemblyName and RootNamespace")
+	{
+		pth := tmpProjectWithContent(t, strings.Replace(watchExtensionTestProjectContent, "<AssemblyName>App.WatchOSExtension</AssemblyName>", "", -1))
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, "project", project.AssemblyName)
+		require.Equal(t, "", project.RootNamespace)
+	}
+
+	t.Log("SDK-style project without AssemblyName and RootNamespace")
+	{
+		pth := tmpProjectWithContent(t, sdkStyleBindingTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, "project", project.AssemblyName)
+		require.Equal(t, "project", project.RootNamespace)
+	}
+}
+
+func TestNuGetPackaging(t *testing.T) {
+	t.Log("it reads the package properties")
+	{
+		pth := tmpProjectWithContent(t, nugetPackagingTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, true, project.IsPackable())
+		require.Equal(t, true, project.GeneratePackageOnBuild)
+		require.Equal(t, "App.Bindings", project.PackageID)
+		require.Equal(t, "1.2.0", project.PackageVersion)
+		require.Equal(t, "", project.NuspecPth)
+
+		releaseConfig, ok := project.Configs["Release|AnyCPU"]
+		require.True(t, ok)
+		require.Equal(t, filepath.Join(filepath.Dir(dir), "nupkgs"), releaseConfig.PackageOutputDir)
+	}
+
+	t.Log("it finds the nuspec file next to the project")
+	{
+		pth := tmpProjectWithContent(t, strings.Replace(nugetPackagingTestProjectContent, "<GeneratePackageOnBuild>true</GeneratePackageOnBuild>", "", -1))
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, false, project.IsPackable())
+
+		nuspecPth := filepath.Join(dir, "project.nuspec")
+		require.NoError(t, fileutil.WriteStringToFile(nuspecPth, "<package />"))
+
+		project, err = analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, true, project.IsPackable())
+		require.Equal(t, nuspecPth, project.NuspecPth)
+	}
+}
+
+func TestRawProperties(t *testing.T) {
+	t.Log("it exposes the guids and the unexpanded properties")
+	{
+		pth := tmpProjectWithContent(t, androidSigningTestProjectContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, "A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11", project.ProjectGUID)
+		require.Equal(t, []string{"EFBA0AD7-5A72-4C68-AF49-83D382785DCF", "FAE04EC0-301F-11D3-BF4B-00C04F79EFBC"}, project.TypeGUIDs)
+		require.Equal(t, "Keystores", project.RawProperties["KeystoreDir"])
+		require.Equal(t, "SignedApp.Droid", project.RawProperties["AssemblyName"])
+
+		releaseConfig, ok := project.Configs["Release|AnyCPU"]
+		require.True(t, ok)
+		require.Equal(t, `$(KeystoreDir)\release.keystore`, releaseConfig.RawProperties["AndroidSigningKeyStore"])
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	t.Log("it resolves windows style paths case-insensitively")
+	{
+		pth := tmpProjectWithContent(t, androidSigningTestProjectContent)
+		dir := filepath.Dir(pth)
+		defer func() {
+			require.NoError(t, os.RemoveAll(dir))
+		}()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "properties"), 0777))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, "properties", "AndroidManifest.xml"), androidManifestTestContent))
+
+		project, err := analyzeProject(pth)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "properties", "AndroidManifest.xml"), project.ManifestPth)
+
+		require.Equal(t, filepath.Join(dir, "Keystores", "release.keystore"), project.ResolvePath(`$(KeystoreDir)\release.keystore`))
+		require.Equal(t, filepath.Join(filepath.Dir(dir), "Other", "Other.csproj"), project.ResolvePath(`..\Other\Other.csproj`))
+	}
+}
@@ -347,6 +347,8 @@ const tvTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
     <MtouchUseRefCounting>true</MtouchUseRefCounting>
     <MtouchFloat32>true</MtouchFloat32>
     <MtouchEnableBitcode>true</MtouchEnableBitcode>
+    <IpaPackageDir>..\ipas</IpaPackageDir>
+    <IpaPackageName>tvos-release.ipa</IpaPackageName>
     <CodesignEntitlements>Entitlements.plist</CodesignEntitlements>
     <MtouchLink>SdkOnly</MtouchLink>
     <MtouchArch>ARM64</MtouchArch>
@@ -386,6 +388,7 @@ const tvTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
     <MtouchUseSGen>true</MtouchUseSGen>
     <MtouchUseRefCounting>true</MtouchUseRefCounting>
     <MtouchFloat32>true</MtouchFloat32>
+    <MtouchNoSymbolStrip>true</MtouchNoSymbolStrip>
     <CodesignEntitlements>Entitlements.plist</CodesignEntitlements>
     <MtouchLink>SdkOnly</MtouchLink>
     <MtouchArch>ARM64</MtouchArch>
@@ -691,3 +694,314 @@ const nunitLiteTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
   </ItemGroup>
   <Import Project="$(MSBuildExtensionsPath)\Xamarin\iOS\Xamarin.iOS.CSharp.targets" />
 </Project>`
+
+const sdkStyleAndroidTestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net7.0-android</TargetFramework>
+    <SupportedOSPlatformVersion>21</SupportedOSPlatformVersion>
+    <OutputType>Exe</OutputType>
+    <AssemblyName>SdkStyle.Droid</AssemblyName>
+    <ApplicationId>com.companyname.sdkstyle</ApplicationId>
+  </PropertyGroup>
+  <PropertyGroup Condition="'$(Configuration)|$(Platform)'=='Release|AnyCPU'">
+    <AndroidKeyStore>True</AndroidKeyStore>
+  </PropertyGroup>
+  <ItemGroup>
+    <ProjectReference Include="..\SdkStyle.Core\SdkStyle.Core.csproj" />
+  </ItemGroup>
+</Project>`
+
+const sdkStyleMultiTargetTestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFrameworks>net7.0-android;net7.0-ios;net7.0-maccatalyst</TargetFrameworks>
+    <OutputType>Exe</OutputType>
+    <AssemblyName>SdkStyle.Maui</AssemblyName>
+  </PropertyGroup>
+</Project>`
+
+const packagesConfigXamarinUITestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <ProjectGuid>{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}</ProjectGuid>
+    <OutputType>Library</OutputType>
+    <AssemblyName>App.UITests</AssemblyName>
+  </PropertyGroup>
+  <ItemGroup>
+    <Reference Include="System" />
+  </ItemGroup>
+</Project>`
+
+const xamarinUITestPackagesConfigContent = `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="NUnit" version="2.6.4" targetFramework="net45" />
+  <package id="Xamarin.UITest" version="2.0.0" targetFramework="net45" />
+</packages>`
+
+const sdkStyleXamarinUITestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net6.0</TargetFramework>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="NUnit" Version="3.13.3" />
+    <PackageReference Include="Xamarin.UITest" Version="4.1.4" />
+  </ItemGroup>
+</Project>`
+
+const sdkStyleXunitProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net6.0</TargetFramework>
+    <IsPackable>false</IsPackable>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Microsoft.NET.Test.Sdk" Version="17.1.0" />
+    <PackageReference Include="xunit" Version="2.4.1" />
+    <PackageReference Include="xunit.runner.visualstudio" Version="2.4.3" />
+  </ItemGroup>
+</Project>`
+
+const mstestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project ToolsVersion="14.0" DefaultTargets="Build" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <ProjectGuid>{3E0F8A2C-5B7D-4C1E-9A6F-0D2B4C6E8A1F}</ProjectGuid>
+    <OutputType>Library</OutputType>
+    <AssemblyName>App.MSTests</AssemblyName>
+  </PropertyGroup>
+  <ItemGroup>
+    <Reference Include="Microsoft.VisualStudio.QualityTools.UnitTestFramework, Version=10.1.0.0, Culture=neutral, PublicKeyToken=b03f5f7f11d50a3a" />
+    <Reference Include="System" />
+  </ItemGroup>
+</Project>`
+
+const xunitPackagesConfigContent = `<?xml version="1.0" encoding="utf-8"?>
+<packages>
+  <package id="xunit.abstractions" version="2.0.0" targetFramework="net45" />
+  <package id="xunit.core" version="2.1.0" targetFramework="net45" />
+</packages>`
+
+const propertyExpansionTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup Label="Globals">
+    <ProjectGuid>{90F3C584-FD69-4926-9903-6B9771847782}</ProjectGuid>
+    <ProjectTypeGuids>{FEACFBD2-3405-455C-9665-78FE426C6842};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <OutputType>Exe</OutputType>
+    <AssemblyName>$(MSBuildProjectName).App</AssemblyName>
+    <BaseOutputDir>$(MSBuildProjectDirectory)\build</BaseOutputDir>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Debug|iPhoneSimulator' ">
+    <OutputPath>bin\$(Platform)\$(Configuration)</OutputPath>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Release|iPhone' ">
+    <PlatformDir>$(Platform)-device</PlatformDir>
+    <OutputPath>$(BaseOutputDir)\$(PlatformDir)\$(Configuration)\</OutputPath>
+  </PropertyGroup>
+</Project>`
+
+const conditionsTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <ProjectGuid>{90F3C584-FD69-4926-9903-6B9771847782}</ProjectGuid>
+    <ProjectTypeGuids>{FEACFBD2-3405-455C-9665-78FE426C6842};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <OutputType>Exe</OutputType>
+    <AssemblyName>Conditions</AssemblyName>
+    <UseDeviceArch>true</UseDeviceArch>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)' == '' ">
+    <Configuration>Debug</Configuration>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Debug|iPhone' ">
+    <OutputPath>bin\iPhone\Debug</OutputPath>
+    <MtouchArch>ARMv7</MtouchArch>
+    <MtouchArch Condition=" '$(UseDeviceArch)' == 'true' ">ARM64</MtouchArch>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Release|iPhone' ">
+    <OutputPath>bin\iPhone\Release</OutputPath>
+    <MtouchArch Condition=" '$(UseDeviceArch)' != 'true' ">ARMv7</MtouchArch>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'AppStore|iPhone' ">
+    <OutputPath>bin\iPhone\AppStore</OutputPath>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Release|iPhone' Or '$(Configuration)|$(Platform)' == 'AppStore|iPhone' ">
+    <MtouchArch>ARM64</MtouchArch>
+    <BuildIpa>True</BuildIpa>
+  </PropertyGroup>
+  <PropertyGroup Condition=" Exists('not-existing.props') ">
+    <AssemblyName>NotExisting</AssemblyName>
+  </PropertyGroup>
+  <ItemGroup Condition=" '$(UseDeviceArch)' == 'false' ">
+    <Reference Include="nunit.framework" />
+  </ItemGroup>
+</Project>`
+
+const importsTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <ProjectGuid>{90F3C584-FD69-4926-9903-6B9771847782}</ProjectGuid>
+    <ProjectTypeGuids>{FEACFBD2-3405-455C-9665-78FE426C6842};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <OutputType>Exe</OutputType>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Debug|iPhone' ">
+    <MtouchArch>ARM64</MtouchArch>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Release|iPhone' ">
+    <OutputPath>bin\iPhone\Release</OutputPath>
+  </PropertyGroup>
+  <Import Project="$(MSBuildExtensionsPath)\Xamarin\iOS\Xamarin.iOS.CSharp.targets" />
+  <Import Project="$(BuildDir)common.targets" Condition="Exists('$(BuildDir)common.targets')" />
+  <Import Project="..\build\skipped.targets" Condition="'$(UseSkipped)' == 'true'" />
+  <Import Project="missing.targets" />
+</Project>`
+
+const importsTestDirectoryBuildPropsContent = `<Project>
+  <PropertyGroup>
+    <BuildDir>$(MSBuildThisFileDirectory)build\</BuildDir>
+    <OutputPath>$(MSBuildThisFileDirectory)artifacts\$(Platform)\$(Configuration)</OutputPath>
+  </PropertyGroup>
+</Project>`
+
+const importsTestCommonTargetsContent = `<Project>
+  <PropertyGroup>
+    <AssemblyName>Imported</AssemblyName>
+  </PropertyGroup>
+  <Import Project="$(MSBuildThisFileDirectory)common.targets" />
+</Project>`
+
+const importsTestSkippedTargetsContent = `<Project>
+  <PropertyGroup>
+    <AssemblyName>Skipped</AssemblyName>
+  </PropertyGroup>
+</Project>`
+
+const infoPlistTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <ProjectGuid>{90F3C584-FD69-4926-9903-6B9771847782}</ProjectGuid>
+    <ProjectTypeGuids>{FEACFBD2-3405-455C-9665-78FE426C6842};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <OutputType>Exe</OutputType>
+  </PropertyGroup>
+  <ItemGroup>
+    <None Include="Resources\Info.plist">
+      <SubType>Designer</SubType>
+    </None>
+    <None Include="Entitlements.plist" />
+  </ItemGroup>
+</Project>`
+
+const infoPlistTestContent = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.bitrise.sampleapp</string>
+	<key>CFBundleVersion</key>
+	<string>12</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.2.0</string>
+	<key>MinimumOSVersion</key>
+	<string>10.0</string>
+</dict>
+</plist>`
+
+const sdkStyleIOSTestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net7.0-ios</TargetFramework>
+    <OutputType>Exe</OutputType>
+    <ApplicationId>com.bitrise.maui</ApplicationId>
+    <ApplicationDisplayVersion>2.0</ApplicationDisplayVersion>
+    <ApplicationVersion>7</ApplicationVersion>
+    <SupportedOSPlatformVersion>14.2</SupportedOSPlatformVersion>
+  </PropertyGroup>
+</Project>`
+
+const androidSigningTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <Configuration Condition=" '$(Configuration)' == '' ">Debug</Configuration>
+    <Platform Condition=" '$(Platform)' == '' ">AnyCPU</Platform>
+    <ProjectTypeGuids>{EFBA0AD7-5A72-4C68-AF49-83D382785DCF};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <ProjectGuid>{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}</ProjectGuid>
+    <OutputType>Library</OutputType>
+    <AssemblyName>SignedApp.Droid</AssemblyName>
+    <AndroidApplication>True</AndroidApplication>
+    <KeystoreDir>Keystores</KeystoreDir>
+    <AndroidManifest>Properties\AndroidManifest.xml</AndroidManifest>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Debug|AnyCPU' ">
+    <OutputPath>bin\Debug</OutputPath>
+    <AndroidManifest>Properties\Debug\AndroidManifest.xml</AndroidManifest>
+    <EmbedAssembliesIntoApk>False</EmbedAssembliesIntoApk>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Release|AnyCPU' ">
+    <OutputPath>bin\Release</OutputPath>
+    <MonoSymbolArchive>True</MonoSymbolArchive>
+    <AndroidKeyStore>True</AndroidKeyStore>
+    <AndroidSigningKeyStore>$(KeystoreDir)\release.keystore</AndroidSigningKeyStore>
+    <AndroidSigningStorePass>store-secret</AndroidSigningStorePass>
+    <AndroidSigningKeyAlias>release</AndroidSigningKeyAlias>
+    <AndroidPackageFormat>aab</AndroidPackageFormat>
+    <AndroidSupportedAbis>armeabi-v7a;arm64-v8a</AndroidSupportedAbis>
+  </PropertyGroup>
+</Project>`
+
+const androidManifestTestContent = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.companyname.sdkstyle">
+  <uses-sdk android:minSdkVersion="21" android:targetSdkVersion="33" />
+  <application android:label="SdkStyle" />
+</manifest>`
+
+const packageReferencesTestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net7.0-android</TargetFramework>
+    <OutputType>Exe</OutputType>
+    <EssentialsVersion>1.7.*</EssentialsVersion>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Xamarin.Forms" Version="5.0.0.2578" />
+    <PackageReference Include="Xamarin.Essentials" Version="$(EssentialsVersion)" />
+    <PackageReference Include="Newtonsoft.Json">
+      <Version>[13.0,)</Version>
+    </PackageReference>
+    <PackageReference Update="Xamarin.Forms" Version="5.0.0.2612" />
+  </ItemGroup>
+</Project>`
+
+const watchExtensionTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <Configuration Condition=" '$(Configuration)' == '' ">Debug</Configuration>
+    <Platform Condition=" '$(Platform)' == '' ">iPhoneSimulator</Platform>
+    <ProjectTypeGuids>{1E2E965C-F6D2-49ED-B86E-418A60C69EEF};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <ProjectGuid>{4C6B3D61-9B1E-4B0F-8E0A-5A3B6A1C2D11}</ProjectGuid>
+    <OutputType>Library</OutputType>
+    <AssemblyName>App.WatchOSExtension</AssemblyName>
+  </PropertyGroup>
+</Project>`
+
+const appExtensionTestProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <Configuration Condition=" '$(Configuration)' == '' ">Debug</Configuration>
+    <Platform Condition=" '$(Platform)' == '' ">iPhoneSimulator</Platform>
+    <ProjectTypeGuids>{FEACFBD2-3405-455C-9665-78FE426C6842};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <ProjectGuid>{9D4B5E21-6C3A-4F0D-A2B1-7E8F9A0B1C22}</ProjectGuid>
+    <OutputType>Library</OutputType>
+    <AssemblyName>App.ShareExtension</AssemblyName>
+    <IsAppExtension>True</IsAppExtension>
+  </PropertyGroup>
+</Project>`
+
+const sdkStyleBindingTestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net7.0-android</TargetFramework>
+    <IsBindingProject>true</IsBindingProject>
+  </PropertyGroup>
+</Project>`
+
+const nugetPackagingTestProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>netstandard2.0</TargetFramework>
+    <AssemblyName>App.Bindings</AssemblyName>
+    <GeneratePackageOnBuild>true</GeneratePackageOnBuild>
+    <PackageVersion>1.2.0</PackageVersion>
+    <PackageOutputPath>..\nupkgs</PackageOutputPath>
+  </PropertyGroup>
+</Project>`
@@ -0,0 +1,116 @@
+package project
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+const defaultSDKStylePlatform = "AnyCPU"
+
+var (
+	defaultSDKStyleConfigurations = []string{"Debug", "Release"}
+
+	// AndroidManifest.xml locations used by the .NET SDK and by the MAUI single project layout
+	defaultSDKStyleManifestPaths = []string{
+		"AndroidManifest.xml",
+		filepath.Join("Platforms", "Android", "AndroidManifest.xml"),
+		filepath.Join("Properties", "AndroidManifest.xml"),
+	}
+)
+
+func parseTargetFrameworks(targetFrameworks string) []string {
+	frameworks := []string{}
+	for _, framework := range utility.SplitAndStripList(targetFrameworks, ";") {
+		// Skip property references, like: $(TargetFrameworks)
+		if framework == "" || strings.Contains(framework, "$(") {
+			continue
+		}
+		frameworks = append(frameworks, framework)
+	}
+	return frameworks
+}
+
+// platformTargetFramework returns the first target framework which belongs to the project's SDK
+func platformTargetFramework(project Model) string {
+	for _, framework := range project.TargetFrameworks {
+		if sdk, err := constants.ParseTargetFramework(framework); err == nil && sdk == project.SDK {
+			return framework
+		}
+	}
+	if len(project.TargetFrameworks) == 1 {
+		return project.TargetFrameworks[0]
+	}
+	return ""
+}
+
+func defaultSDKStyleOutputDir(projectDir, configuration, targetFramework string) string {
+	return filepath.Join(projectDir, "bin", configuration, targetFramework)
+}
+
+// applySDKStyleDefaults fills the properties, which are implicit in SDK-style projects
+func applySDKStyleDefaults(project Model) Model {
+	projectDir := filepath.Dir(project.Pth)
+
+	if project.SDK == constants.SDKUnknown {
+		for _, framework := range project.TargetFrameworks {
+			if sdk, err := constants.ParseTargetFramework(framework); err == nil {
+				project.SDK = sdk
+				break
+			}
+		}
+	}
+
+	if project.OutputType == "" {
+		project.OutputType = "library"
+	}
+
+	if project.SDK == constants.SDKAndroid {
+		if project.OutputType == "exe" {
+			project.AndroidApplication = true
+		}
+
+		if project.ManifestPth == "" {
+			project.ManifestPth = filepath.Join(projectDir, defaultSDKStyleManifestPaths[0])
+			for _, manifestRelativePth := range defaultSDKStyleManifestPaths {
+				manifestPth := filepath.Join(projectDir, manifestRelativePth)
+				if exist, err := project.inputs.pathExists(manifestPth); err == nil && exist {
+					project.ManifestPth = manifestPth
+					break
+				}
+			}
+		}
+	}
+
+	targetFramework := platformTargetFramework(project)
+
+	configs := map[string]ConfigurationPlatformModel{}
+	for _, config := range project.Configs {
+		if config.Platform == "" {
+			config.Platform = defaultSDKStylePlatform
+		}
+		if config.OutputDir == "" && config.Configuration != "" {
+			config.OutputDir = defaultSDKStyleOutputDir(projectDir, config.Configuration, targetFramework)
+		}
+		configs[utility.ToConfig(config.Configuration, config.Platform)] = config
+	}
+
+	for _, configuration := range defaultSDKStyleConfigurations {
+		configKey := utility.ToConfig(configuration, defaultSDKStylePlatform)
+		if _, ok := configs[configKey]; ok {
+			continue
+		}
+
+		configs[configKey] = ConfigurationPlatformModel{
+			Configuration: configuration,
+			Platform:      defaultSDKStylePlatform,
+			OutputDir:     defaultSDKStyleOutputDir(projectDir, configuration, targetFramework),
+		}
+	}
+
+	project.Configs = configs
+
+	return project
+}
@@ -0,0 +1,123 @@
+package project
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/manifest"
+	"github.com/bitrise-tools/go-xamarin/constants"
+)
+
+// Target framework properties
+const (
+	targetFrameworkVersionProperty      = "TargetFrameworkVersion"
+	androidUseLatestPlatformSdkProperty = "AndroidUseLatestPlatformSdk"
+	androidTargetSdkVersionProperty     = "AndroidTargetSdkVersion"
+)
+
+// netPlatformTargetFrameworkPattern matches the .NET platform target frameworks, like: net7.0-android33.0
+var netPlatformTargetFrameworkPattern = regexp.MustCompile(`(?i)^net(?P<net_version>\d+)\.\d+-[a-z]+(?P<platform_version>[\d.]*)$`)
+
+// Android API levels of the Xamarin.Android TargetFrameworkVersions (MonoAndroid versions)
+var androidAPILevelByTargetFrameworkVersion = map[string]int{
+	"v2.3":    10,
+	"v4.0.3":  15,
+	"v4.1":    16,
+	"v4.2":    17,
+	"v4.3":    18,
+	"v4.4":    19,
+	"v4.4.87": 20,
+	"v5.0":    21,
+	"v5.1":    22,
+	"v6.0":    23,
+	"v7.0":    24,
+	"v7.1":    25,
+	"v8.0":    26,
+	"v8.1":    27,
+	"v9.0":    28,
+	"v10.0":   29,
+	"v11.0":   30,
+	"v12.0":   31,
+	"v12.1":   32,
+	"v13.0":   33,
+}
+
+// Android API levels targeted by default by the .NET major versions, if the target framework does not specify it
+var androidAPILevelByNetVersion = map[int]int{
+	6: 31,
+	7: 33,
+	8: 34,
+	9: 35,
+}
+
+// AndroidAPILevel - returns the Android API level of the given TargetFrameworkVersion (v9.0)
+// or .NET target framework (net7.0-android, net7.0-android33.0), 0 if it is unknown
+func AndroidAPILevel(targetFramework string) int {
+	targetFramework = strings.ToLower(strings.TrimSpace(targetFramework))
+
+	if apiLevel, ok := androidAPILevelByTargetFrameworkVersion[targetFramework]; ok {
+		return apiLevel
+	}
+
+	matches := netPlatformTargetFrameworkPattern.FindStringSubmatch(targetFramework)
+	if len(matches) != 3 {
+		return 0
+	}
+
+	if matches[2] != "" {
+		apiLevel, err := strconv.Atoi(strings.Split(matches[2], ".")[0])
+		if err != nil {
+			return 0
+		}
+		return apiLevel
+	}
+
+	netVersion, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0
+	}
+	return androidAPILevelByNetVersion[netVersion]
+}
+
+// targetPlatformVersion returns the platform version of a .NET target framework, like 17.0 for net8.0-ios17.0
+func targetPlatformVersion(targetFramework string) string {
+	matches := netPlatformTargetFrameworkPattern.FindStringSubmatch(strings.TrimSpace(targetFramework))
+	if len(matches) != 3 {
+		return ""
+	}
+	return matches[2]
+}
+
+// analyzeTargetFramework fills the target framework and the platform SDK hints of the project
+func analyzeTargetFramework(project Model) Model {
+	property := func(name string) string {
+		return strings.TrimSpace(project.properties[strings.ToLower(name)])
+	}
+
+	project.TargetFrameworkVersion = property(targetFrameworkVersionProperty)
+
+	targetFramework := project.TargetFrameworkVersion
+	if project.MSBuildSDK != "" {
+		targetFramework = platformTargetFramework(project)
+		project.TargetPlatformVersion = targetPlatformVersion(targetFramework)
+	}
+
+	if project.SDK != constants.SDKAndroid {
+		return project
+	}
+
+	project.AndroidUseLatestPlatformSdk = strings.EqualFold(property(androidUseLatestPlatformSdkProperty), "true")
+	project.AndroidAPILevel = AndroidAPILevel(targetFramework)
+
+	project.AndroidTargetSdkVersion = property(androidTargetSdkVersionProperty)
+	if project.AndroidTargetSdkVersion == "" && project.ManifestPth != "" {
+		// the manifest may be invalid or not yet generated, the target sdk version is only a hint
+		project.inputs.record(project.ManifestPth)
+		if androidManifest, err := manifest.New(project.ManifestPth); err == nil {
+			project.AndroidTargetSdkVersion = androidManifest.TargetSDKVersion
+		}
+	}
+
+	return project
+}
@@ -0,0 +1,130 @@
+package results
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+type junitMessageElement struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Content string `xml:",chardata"`
+}
+
+type junitTestCaseElement struct {
+	Name      string               `xml:"name,attr"`
+	ClassName string               `xml:"classname,attr"`
+	Time      string               `xml:"time,attr"`
+	Failure   *junitMessageElement `xml:"failure"`
+	Error     *junitMessageElement `xml:"error"`
+	Skipped   *junitMessageElement `xml:"skipped"`
+	Flaky     *junitMessageElement `xml:"flakyFailure"`
+	SystemOut string               `xml:"system-out,omitempty"`
+}
+
+type junitTestSuiteElement struct {
+	Name      string                 `xml:"name,attr"`
+	Tests     int                    `xml:"tests,attr"`
+	Failures  int                    `xml:"failures,attr"`
+	Errors    int                    `xml:"errors,attr"`
+	Skipped   int                    `xml:"skipped,attr"`
+	Time      string                 `xml:"time,attr"`
+	TestCases []junitTestCaseElement `xml:"testcase"`
+}
+
+type junitTestSuitesElement struct {
+	XMLName    xml.Name                `xml:"testsuites"`
+	Tests      int                     `xml:"tests,attr"`
+	Failures   int                     `xml:"failures,attr"`
+	Errors     int                     `xml:"errors,attr"`
+	Skipped    int                     `xml:"skipped,attr"`
+	Time       string                  `xml:"time,attr"`
+	TestSuites []junitTestSuiteElement `xml:"testsuite"`
+}
+
+// JUnit - converts the result to JUnit XML, the test cases are grouped into test suites by their class name.
+// Failed cases labeled as Error are reported as errors, inconclusive cases as skipped,
+// flaky cases as passed with their first failure in flakyFailure, like the Maven Surefire reruns.
+func (result ResultModel) JUnit() ([]byte, error) {
+	testSuites := junitTestSuitesElement{TestSuites: []junitTestSuiteElement{}}
+	suiteIndexes := map[string]int{}
+	suiteSeconds := []float64{}
+
+	for _, testCase := range result.Cases() {
+		idx, ok := suiteIndexes[testCase.ClassName]
+		if !ok {
+			idx = len(testSuites.TestSuites)
+			suiteIndexes[testCase.ClassName] = idx
+			testSuites.TestSuites = append(testSuites.TestSuites, junitTestSuiteElement{Name: testCase.ClassName, TestCases: []junitTestCaseElement{}})
+			suiteSeconds = append(suiteSeconds, 0)
+		}
+		testSuite := &testSuites.TestSuites[idx]
+
+		testCaseElement := junitTestCaseElement{
+			Name:      testCase.Name,
+			ClassName: testCase.ClassName,
+			Time:      formatSeconds(testCase.Duration.Seconds()),
+			SystemOut: testCase.Output,
+		}
+
+		switch testCase.Outcome {
+		case OutcomePassed:
+			if testCase.Flaky {
+				testCaseElement.Flaky = &junitMessageElement{Message: testCase.Message, Type: testCase.Label, Content: testCase.StackTrace}
+			}
+		case OutcomeFailed:
+			element := &junitMessageElement{Message: testCase.Message, Type: testCase.Label, Content: testCase.StackTrace}
+			if testCase.Label == "Error" {
+				testCaseElement.Error = element
+				testSuite.Errors++
+			} else {
+				testCaseElement.Failure = element
+				testSuite.Failures++
+			}
+		case OutcomeSkipped, OutcomeInconclusive:
+			testCaseElement.Skipped = &junitMessageElement{Message: testCase.Message}
+			testSuite.Skipped++
+		}
+
+		testSuite.Tests++
+		testSuite.TestCases = append(testSuite.TestCases, testCaseElement)
+		suiteSeconds[idx] += testCase.Duration.Seconds()
+	}
+
+	var totalSeconds float64
+	for i, testSuite := range testSuites.TestSuites {
+		testSuites.TestSuites[i].Time = formatSeconds(suiteSeconds[i])
+		totalSeconds += suiteSeconds[i]
+
+		testSuites.Tests += testSuite.Tests
+		testSuites.Failures += testSuite.Failures
+		testSuites.Errors += testSuite.Errors
+		testSuites.Skipped += testSuite.Skipped
+	}
+	testSuites.Time = formatSeconds(totalSeconds)
+
+	content, err := xml.MarshalIndent(testSuites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+// WriteJUnit - writes the result to the path in JUnit XML format
+func (result ResultModel) WriteJUnit(pth string) error {
+	content, err := result.JUnit()
+	if err != nil {
+		return fmt.Errorf("failed to convert test result to JUnit, error: %s", err)
+	}
+
+	if err := fileutil.WriteBytesToFile(pth, content); err != nil {
+		return fmt.Errorf("failed to write JUnit result (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+func formatSeconds(secs float64) string {
+	return fmt.Sprintf("%.3f", secs)
+}
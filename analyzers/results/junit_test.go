@@ -0,0 +1,47 @@
+package results
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnit(t *testing.T) {
+	t.Log("it converts the NUnit result")
+	{
+		result, err := ParseNunit3([]byte(nunit3ResultContent))
+		require.NoError(t, err)
+
+		content, err := result.JUnit()
+		require.NoError(t, err)
+		require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="1" errors="1" skipped="1" time="0.750">
+  <testsuite name="Core.CalculatorTests" tests="4" failures="1" errors="1" skipped="1" time="0.750">
+    <testcase name="Add" classname="Core.CalculatorTests" time="0.012">
+      <system-out>adding</system-out>
+    </testcase>
+    <testcase name="Divide" classname="Core.CalculatorTests" time="0.500">
+      <failure message="Expected: 2&#xA;  But was:  0">at Core.CalculatorTests.Divide () [0x00001] in CalculatorTests.cs:21</failure>
+    </testcase>
+    <testcase name="Multiply" classname="Core.CalculatorTests" time="0.238">
+      <error message="System.NullReferenceException : Object reference not set to an instance of an object" type="Error"></error>
+    </testcase>
+    <testcase name="Subtract" classname="Core.CalculatorTests" time="0.000">
+      <skipped message="not implemented"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, string(content))
+	}
+
+	t.Log("it groups the xUnit test cases by class")
+	{
+		result, err := ParseXunit([]byte(xunitResultContent))
+		require.NoError(t, err)
+
+		content, err := result.JUnit()
+		require.NoError(t, err)
+		require.Contains(t, string(content), `<testsuites tests="3" failures="1" errors="0" skipped="1" time="0.200">`)
+		require.Contains(t, string(content), `<testsuite name="Core.CalculatorTests" tests="3" failures="1" errors="0" skipped="1" time="0.200">`)
+	}
+}
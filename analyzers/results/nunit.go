@@ -0,0 +1,266 @@
+package results
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+)
+
+type failureElement struct {
+	Message    string `xml:"message"`
+	StackTrace string `xml:"stack-trace"`
+}
+
+type reasonElement struct {
+	Message string `xml:"message"`
+}
+
+// NUnit 3: https://docs.nunit.org/articles/nunit/technical-notes/usage/Test-Result-XML-Format.html
+type nunit3TestCaseElement struct {
+	Name      string          `xml:"name,attr"`
+	FullName  string          `xml:"fullname,attr"`
+	ClassName string          `xml:"classname,attr"`
+	Result    string          `xml:"result,attr"`
+	Label     string          `xml:"label,attr"`
+	Duration  string          `xml:"duration,attr"`
+	Failure   *failureElement `xml:"failure"`
+	Reason    *reasonElement  `xml:"reason"`
+	Output    string          `xml:"output"`
+}
+
+type nunit3TestSuiteElement struct {
+	Type       string                   `xml:"type,attr"`
+	Name       string                   `xml:"name,attr"`
+	FullName   string                   `xml:"fullname,attr"`
+	Result     string                   `xml:"result,attr"`
+	Label      string                   `xml:"label,attr"`
+	Duration   string                   `xml:"duration,attr"`
+	Failure    *failureElement          `xml:"failure"`
+	Reason     *reasonElement           `xml:"reason"`
+	TestSuites []nunit3TestSuiteElement `xml:"test-suite"`
+	TestCases  []nunit3TestCaseElement  `xml:"test-case"`
+}
+
+type nunit3TestRunElement struct {
+	XMLName    xml.Name                 `xml:"test-run"`
+	Duration   string                   `xml:"duration,attr"`
+	TestSuites []nunit3TestSuiteElement `xml:"test-suite"`
+}
+
+// NUnit 2: the children of the suites are wrapped by a results element
+type nunit2TestCaseElement struct {
+	Name     string          `xml:"name,attr"`
+	Executed string          `xml:"executed,attr"`
+	Result   string          `xml:"result,attr"`
+	Success  string          `xml:"success,attr"`
+	Time     string          `xml:"time,attr"`
+	Failure  *failureElement `xml:"failure"`
+	Reason   *reasonElement  `xml:"reason"`
+}
+
+type nunit2TestSuiteElement struct {
+	Type       string                   `xml:"type,attr"`
+	Name       string                   `xml:"name,attr"`
+	Executed   string                   `xml:"executed,attr"`
+	Result     string                   `xml:"result,attr"`
+	Success    string                   `xml:"success,attr"`
+	Time       string                   `xml:"time,attr"`
+	Failure    *failureElement          `xml:"failure"`
+	Reason     *reasonElement           `xml:"reason"`
+	TestSuites []nunit2TestSuiteElement `xml:"results>test-suite"`
+	TestCases  []nunit2TestCaseElement  `xml:"results>test-case"`
+}
+
+type nunit2TestResultsElement struct {
+	XMLName    xml.Name                 `xml:"test-results"`
+	TestSuites []nunit2TestSuiteElement `xml:"test-suite"`
+}
+
+// ParseNunit3 - parses the test-run of a NUnit 3 TestResult.xml
+func ParseNunit3(content []byte) (ResultModel, error) {
+	var testRun nunit3TestRunElement
+	if err := xml.Unmarshal(content, &testRun); err != nil {
+		return ResultModel{}, err
+	}
+
+	result := ResultModel{Format: FormatNunit3, Duration: seconds(testRun.Duration), Suites: []SuiteModel{}}
+	for _, suite := range testRun.TestSuites {
+		result.Suites = append(result.Suites, nunit3Suite(suite))
+	}
+
+	return result, nil
+}
+
+func nunit3Suite(element nunit3TestSuiteElement) SuiteModel {
+	suite := SuiteModel{
+		Type:     element.Type,
+		Name:     element.Name,
+		FullName: element.FullName,
+		Outcome:  nunit3Outcome(element.Result),
+		Duration: seconds(element.Duration),
+		Suites:   []SuiteModel{},
+		Cases:    []CaseModel{},
+	}
+	suite.Message, suite.StackTrace = message(element.Failure, element.Reason)
+
+	for _, child := range element.TestSuites {
+		suite.Suites = append(suite.Suites, nunit3Suite(child))
+	}
+
+	for _, testCase := range element.TestCases {
+		class := testCase.ClassName
+		if class == "" {
+			class = className(testCase.FullName)
+		}
+
+		caseModel := CaseModel{
+			Name:      testCase.Name,
+			FullName:  testCase.FullName,
+			ClassName: class,
+			Outcome:   nunit3Outcome(testCase.Result),
+			Label:     testCase.Label,
+			Duration:  seconds(testCase.Duration),
+			Output:    strings.TrimSpace(testCase.Output),
+		}
+		caseModel.Message, caseModel.StackTrace = message(testCase.Failure, testCase.Reason)
+
+		suite.Cases = append(suite.Cases, caseModel)
+	}
+
+	return suite
+}
+
+// nunit3Outcome - the result attribute is one of: Passed, Failed, Skipped, Inconclusive, Warning
+func nunit3Outcome(result string) Outcome {
+	switch result {
+	case "Passed", "Warning":
+		return OutcomePassed
+	case "Failed":
+		return OutcomeFailed
+	case "Inconclusive":
+		return OutcomeInconclusive
+	default:
+		return OutcomeSkipped
+	}
+}
+
+// ParseNunit2 - parses the test-results of a NUnit 2 TestResult.xml
+func ParseNunit2(content []byte) (ResultModel, error) {
+	var testResults nunit2TestResultsElement
+	if err := xml.Unmarshal(content, &testResults); err != nil {
+		return ResultModel{}, err
+	}
+
+	result := ResultModel{Format: FormatNunit2, Suites: []SuiteModel{}}
+	for _, suite := range testResults.TestSuites {
+		suiteModel := nunit2Suite(suite, "")
+		result.Duration += suiteModel.Duration
+		result.Suites = append(result.Suites, suiteModel)
+	}
+
+	return result, nil
+}
+
+func nunit2Suite(element nunit2TestSuiteElement, parentFullName string) SuiteModel {
+	fullName := element.Name
+	if parentFullName != "" {
+		fullName = parentFullName + "." + element.Name
+	}
+
+	// the assembly and project suites are named by their path, they are not part of the full names
+	childParentFullName := fullName
+	if element.Type == "Assembly" || element.Type == "Project" {
+		childParentFullName = ""
+	}
+
+	suite := SuiteModel{
+		Type:     element.Type,
+		Name:     element.Name,
+		FullName: fullName,
+		Outcome:  nunit2Outcome(element.Result, element.Executed, element.Success),
+		Duration: seconds(element.Time),
+		Suites:   []SuiteModel{},
+		Cases:    []CaseModel{},
+	}
+	suite.Message, suite.StackTrace = message(element.Failure, element.Reason)
+
+	for _, child := range element.TestSuites {
+		suite.Suites = append(suite.Suites, nunit2Suite(child, childParentFullName))
+	}
+
+	for _, testCase := range element.TestCases {
+		// the name of the test case is its full name
+		class := className(testCase.Name)
+
+		caseModel := CaseModel{
+			Name:      strings.TrimPrefix(testCase.Name, class+"."),
+			FullName:  testCase.Name,
+			ClassName: class,
+			Outcome:   nunit2Outcome(testCase.Result, testCase.Executed, testCase.Success),
+			Label:     nunit2Label(testCase.Result),
+			Duration:  seconds(testCase.Time),
+		}
+		caseModel.Message, caseModel.StackTrace = message(testCase.Failure, testCase.Reason)
+
+		suite.Cases = append(suite.Cases, caseModel)
+	}
+
+	return suite
+}
+
+// nunit2Outcome - the result attribute is one of: Success, Failure, Error, Cancelled, NotRunnable, Ignored, Skipped, Inconclusive.
+// Older versions have the executed and success attributes only.
+func nunit2Outcome(result, executed, success string) Outcome {
+	switch result {
+	case "Success":
+		return OutcomePassed
+	case "Failure", "Error", "Cancelled", "NotRunnable":
+		return OutcomeFailed
+	case "Inconclusive":
+		return OutcomeInconclusive
+	case "Ignored", "Skipped":
+		return OutcomeSkipped
+	}
+
+	if strings.EqualFold(executed, "False") {
+		return OutcomeSkipped
+	}
+	if strings.EqualFold(success, "True") {
+		return OutcomePassed
+	}
+	return OutcomeFailed
+}
+
+func nunit2Label(result string) string {
+	switch result {
+	case "Error", "Cancelled", "Ignored":
+		return result
+	case "NotRunnable":
+		return "Invalid"
+	}
+	return ""
+}
+
+func message(failure *failureElement, reason *reasonElement) (string, string) {
+	if failure != nil {
+		return strings.TrimSpace(failure.Message), strings.TrimSpace(failure.StackTrace)
+	}
+	if reason != nil {
+		return strings.TrimSpace(reason.Message), ""
+	}
+	return "", ""
+}
+
+// rootElementName returns the local name of the first element of the XML
+func rootElementName(content []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return ""
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package results
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// Format - the format of the result XML
+type Format string
+
+const (
+	// FormatNunit2 - TestResult.xml of NUnit 2 and of the nunit2 result format of NUnit 3
+	FormatNunit2 Format = "nunit2"
+	// FormatNunit3 - TestResult.xml of NUnit 3
+	FormatNunit3 Format = "nunit3"
+	// FormatXunit - xUnit.net v2 XML, written by: xunit.console -xml
+	FormatXunit Format = "xunit"
+	// FormatTouchUnit - the text output of a Touch.Unit test app
+	FormatTouchUnit Format = "touchunit"
+)
+
+// Outcome - the outcome of a test case or suite
+type Outcome string
+
+const (
+	// OutcomePassed ...
+	OutcomePassed Outcome = "passed"
+	// OutcomeFailed - failed assertions and errors
+	OutcomeFailed Outcome = "failed"
+	// OutcomeSkipped - ignored, explicit and not runnable tests
+	OutcomeSkipped Outcome = "skipped"
+	// OutcomeInconclusive ...
+	OutcomeInconclusive Outcome = "inconclusive"
+)
+
+// CaseModel - a test case
+type CaseModel struct {
+	Name      string
+	FullName  string // like: Namespace.Fixture.Test
+	ClassName string // the full name of the fixture, like: Namespace.Fixture
+
+	Outcome  Outcome
+	Label    string // detail of the outcome, like: Error, Ignored
+	Flaky    bool   // failed, then passed on retry, the Message and StackTrace are of the failure
+	Duration time.Duration
+
+	Message    string // failure message or skip reason
+	StackTrace string
+	Output     string // the captured console output of the test
+}
+
+// SuiteModel - a test suite, like an assembly, namespace or fixture
+type SuiteModel struct {
+	Type     string // like: Assembly, TestSuite, TestFixture, ParameterizedTest
+	Name     string
+	FullName string
+
+	Outcome  Outcome
+	Duration time.Duration
+
+	Message    string // failure message of the suite, like a failing setup
+	StackTrace string
+
+	Suites []SuiteModel
+	Cases  []CaseModel
+}
+
+// ResultModel - a parsed result XML
+type ResultModel struct {
+	Format   Format
+	Duration time.Duration
+	Suites   []SuiteModel
+}
+
+// New - parses the result XML at the path
+func New(pth string) (ResultModel, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return ResultModel{}, fmt.Errorf("failed to read test result (%s), error: %s", pth, err)
+	}
+
+	result, err := Parse(content)
+	if err != nil {
+		return ResultModel{}, fmt.Errorf("failed to parse test result (%s), error: %s", pth, err)
+	}
+
+	return result, nil
+}
+
+// Parse - parses the result XML, the format is detected by the root element
+func Parse(content []byte) (ResultModel, error) {
+	switch rootElementName(content) {
+	case "test-run":
+		return ParseNunit3(content)
+	case "test-results":
+		return ParseNunit2(content)
+	case "assemblies":
+		return ParseXunit(content)
+	case "":
+		return ResultModel{}, fmt.Errorf("no root element found")
+	default:
+		return ResultModel{}, fmt.Errorf("unknown test result format, root element: %s", rootElementName(content))
+	}
+}
+
+// AllCases - the test cases of the suite and its child suites
+func (suite SuiteModel) AllCases() []CaseModel {
+	cases := append([]CaseModel{}, suite.Cases...)
+	for _, child := range suite.Suites {
+		cases = append(cases, child.AllCases()...)
+	}
+	return cases
+}
+
+// Cases - every test case of the result
+func (result ResultModel) Cases() []CaseModel {
+	cases := []CaseModel{}
+	for _, suite := range result.Suites {
+		cases = append(cases, suite.AllCases()...)
+	}
+	return cases
+}
+
+// Count - the number of test cases with the outcome
+func (result ResultModel) Count(outcome Outcome) int {
+	count := 0
+	for _, testCase := range result.Cases() {
+		if testCase.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+// Total ...
+func (result ResultModel) Total() int {
+	return len(result.Cases())
+}
+
+// Passed - true if no test case failed
+func (result ResultModel) Passed() bool {
+	return result.Count(OutcomeFailed) == 0
+}
+
+// FailedCases ...
+func (result ResultModel) FailedCases() []CaseModel {
+	failed := []CaseModel{}
+	for _, testCase := range result.Cases() {
+		if testCase.Outcome == OutcomeFailed {
+			failed = append(failed, testCase)
+		}
+	}
+	return failed
+}
+
+// Summary - like: 10 tests, 8 passed, 1 failed, 1 skipped
+func (result ResultModel) Summary() string {
+	summary := fmt.Sprintf("%d tests, %d passed, %d failed, %d skipped", result.Total(), result.Count(OutcomePassed), result.Count(OutcomeFailed), result.Count(OutcomeSkipped))
+	if inconclusive := result.Count(OutcomeInconclusive); inconclusive > 0 {
+		summary += fmt.Sprintf(", %d inconclusive", inconclusive)
+	}
+	return summary
+}
+
+// seconds parses the durations of the result XMLs, like: 0.123
+func seconds(value string) time.Duration {
+	secs, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// className returns the full name without the last component, the parameters of the test are ignored:
+// Namespace.Fixture.Test("a.b") -> Namespace.Fixture
+func className(fullName string) string {
+	name := fullName
+	if idx := strings.Index(name, "("); idx >= 0 {
+		name = name[:idx]
+	}
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		return name[:idx]
+	}
+	return ""
+}
@@ -0,0 +1,300 @@
+package results
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+const nunit3ResultContent = `<?xml version="1.0" encoding="utf-8" standalone="no"?>
+<test-run id="2" testcasecount="4" result="Failed" total="4" passed="1" failed="2" skipped="1" duration="0.752">
+  <test-suite type="Assembly" name="Core.Tests.dll" fullname="/bin/Debug/Core.Tests.dll" result="Failed" duration="0.75">
+    <test-suite type="TestSuite" name="Core" fullname="Core" result="Failed" duration="0.75">
+      <test-suite type="TestFixture" name="CalculatorTests" fullname="Core.CalculatorTests" classname="Core.CalculatorTests" result="Failed" duration="0.75">
+        <test-case id="1-1" name="Add" fullname="Core.CalculatorTests.Add" classname="Core.CalculatorTests" result="Passed" duration="0.012">
+          <output><![CDATA[adding
+]]></output>
+        </test-case>
+        <test-case id="1-2" name="Divide" fullname="Core.CalculatorTests.Divide" classname="Core.CalculatorTests" result="Failed" duration="0.5">
+          <failure>
+            <message><![CDATA[  Expected: 2
+  But was:  0
+]]></message>
+            <stack-trace><![CDATA[at Core.CalculatorTests.Divide () [0x00001] in CalculatorTests.cs:21
+]]></stack-trace>
+          </failure>
+        </test-case>
+        <test-case id="1-3" name="Multiply" fullname="Core.CalculatorTests.Multiply" classname="Core.CalculatorTests" result="Failed" label="Error" duration="0.238">
+          <failure>
+            <message><![CDATA[System.NullReferenceException : Object reference not set to an instance of an object]]></message>
+          </failure>
+        </test-case>
+        <test-case id="1-4" name="Subtract" fullname="Core.CalculatorTests.Subtract" classname="Core.CalculatorTests" result="Skipped" label="Ignored" duration="0">
+          <reason>
+            <message><![CDATA[not implemented]]></message>
+          </reason>
+        </test-case>
+      </test-suite>
+    </test-suite>
+  </test-suite>
+</test-run>`
+
+const nunit2ResultContent = `<?xml version="1.0" encoding="utf-8" standalone="no"?>
+<test-results name="/bin/Debug/Core.Tests.dll" total="3" errors="0" failures="1" not-run="1" inconclusive="0" ignored="1" skipped="0" invalid="0" date="2017-04-10" time="10:21:01">
+  <test-suite type="Assembly" name="/bin/Debug/Core.Tests.dll" executed="True" result="Failure" success="False" time="0.612">
+    <results>
+      <test-suite type="Namespace" name="Core" executed="True" result="Failure" success="False" time="0.6">
+        <results>
+          <test-suite type="TestFixture" name="CalculatorTests" executed="True" result="Failure" success="False" time="0.6">
+            <results>
+              <test-case name="Core.CalculatorTests.Add" executed="True" result="Success" success="True" time="0.100" asserts="1" />
+              <test-case name="Core.CalculatorTests.Divide" executed="True" result="Failure" success="False" time="0.500" asserts="1">
+                <failure>
+                  <message><![CDATA[  Expected: 2
+  But was:  0
+]]></message>
+                  <stack-trace><![CDATA[at Core.CalculatorTests.Divide () in CalculatorTests.cs:21
+]]></stack-trace>
+                </failure>
+              </test-case>
+              <test-case name="Core.CalculatorTests.Subtract" executed="False" result="Ignored">
+                <reason>
+                  <message><![CDATA[not implemented]]></message>
+                </reason>
+              </test-case>
+            </results>
+          </test-suite>
+        </results>
+      </test-suite>
+    </results>
+  </test-suite>
+</test-results>`
+
+func TestParseNunit3(t *testing.T) {
+	t.Log("it parses the suite tree")
+	{
+		result, err := Parse([]byte(nunit3ResultContent))
+		require.NoError(t, err)
+
+		require.Equal(t, FormatNunit3, result.Format)
+		require.Equal(t, 752*time.Millisecond, result.Duration)
+
+		require.Equal(t, 1, len(result.Suites))
+		assembly := result.Suites[0]
+		require.Equal(t, "Assembly", assembly.Type)
+		require.Equal(t, OutcomeFailed, assembly.Outcome)
+		require.Equal(t, 1, len(assembly.Suites))
+		require.Equal(t, "Core", assembly.Suites[0].FullName)
+
+		fixture := assembly.Suites[0].Suites[0]
+		require.Equal(t, "TestFixture", fixture.Type)
+		require.Equal(t, "Core.CalculatorTests", fixture.FullName)
+		require.Equal(t, 4, len(fixture.Cases))
+	}
+
+	t.Log("it parses the test cases")
+	{
+		result, err := ParseNunit3([]byte(nunit3ResultContent))
+		require.NoError(t, err)
+
+		cases := result.Cases()
+		require.Equal(t, 4, len(cases))
+		require.Equal(t, CaseModel{Name: "Add", FullName: "Core.CalculatorTests.Add", ClassName: "Core.CalculatorTests", Outcome: OutcomePassed, Duration: 12 * time.Millisecond, Output: "adding"}, cases[0])
+		require.Equal(t, CaseModel{
+			Name:       "Divide",
+			FullName:   "Core.CalculatorTests.Divide",
+			ClassName:  "Core.CalculatorTests",
+			Outcome:    OutcomeFailed,
+			Duration:   500 * time.Millisecond,
+			Message:    "Expected: 2\n  But was:  0",
+			StackTrace: "at Core.CalculatorTests.Divide () [0x00001] in CalculatorTests.cs:21",
+		}, cases[1])
+		require.Equal(t, "Error", cases[2].Label)
+		require.Equal(t, OutcomeFailed, cases[2].Outcome)
+		require.Equal(t, CaseModel{Name: "Subtract", FullName: "Core.CalculatorTests.Subtract", ClassName: "Core.CalculatorTests", Outcome: OutcomeSkipped, Label: "Ignored", Message: "not implemented"}, cases[3])
+
+		require.Equal(t, 4, result.Total())
+		require.Equal(t, 1, result.Count(OutcomePassed))
+		require.Equal(t, 2, result.Count(OutcomeFailed))
+		require.Equal(t, 1, result.Count(OutcomeSkipped))
+		require.Equal(t, false, result.Passed())
+		require.Equal(t, []CaseModel{cases[1], cases[2]}, result.FailedCases())
+		require.Equal(t, "4 tests, 1 passed, 2 failed, 1 skipped", result.Summary())
+	}
+}
+
+func TestParseNunit2(t *testing.T) {
+	t.Log("it parses the suite tree")
+	{
+		result, err := Parse([]byte(nunit2ResultContent))
+		require.NoError(t, err)
+
+		require.Equal(t, FormatNunit2, result.Format)
+		require.Equal(t, 612*time.Millisecond, result.Duration)
+
+		require.Equal(t, 1, len(result.Suites))
+		assembly := result.Suites[0]
+		require.Equal(t, "/bin/Debug/Core.Tests.dll", assembly.FullName)
+		require.Equal(t, OutcomeFailed, assembly.Outcome)
+
+		fixture := assembly.Suites[0].Suites[0]
+		require.Equal(t, "TestFixture", fixture.Type)
+		require.Equal(t, "Core.CalculatorTests", fixture.FullName)
+		require.Equal(t, 600*time.Millisecond, fixture.Duration)
+	}
+
+	t.Log("it parses the test cases")
+	{
+		result, err := ParseNunit2([]byte(nunit2ResultContent))
+		require.NoError(t, err)
+
+		cases := result.Cases()
+		require.Equal(t, 3, len(cases))
+		require.Equal(t, CaseModel{Name: "Add", FullName: "Core.CalculatorTests.Add", ClassName: "Core.CalculatorTests", Outcome: OutcomePassed, Duration: 100 * time.Millisecond}, cases[0])
+		require.Equal(t, CaseModel{
+			Name:       "Divide",
+			FullName:   "Core.CalculatorTests.Divide",
+			ClassName:  "Core.CalculatorTests",
+			Outcome:    OutcomeFailed,
+			Duration:   500 * time.Millisecond,
+			Message:    "Expected: 2\n  But was:  0",
+			StackTrace: "at Core.CalculatorTests.Divide () in CalculatorTests.cs:21",
+		}, cases[1])
+		require.Equal(t, CaseModel{Name: "Subtract", FullName: "Core.CalculatorTests.Subtract", ClassName: "Core.CalculatorTests", Outcome: OutcomeSkipped, Label: "Ignored", Message: "not implemented"}, cases[2])
+
+		require.Equal(t, "3 tests, 1 passed, 1 failed, 1 skipped", result.Summary())
+	}
+
+	t.Log("it falls back to the executed and success attributes")
+	{
+		require.Equal(t, OutcomePassed, nunit2Outcome("", "True", "True"))
+		require.Equal(t, OutcomeFailed, nunit2Outcome("", "True", "False"))
+		require.Equal(t, OutcomeSkipped, nunit2Outcome("", "False", ""))
+	}
+}
+
+func TestNew(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("results")
+	require.NoError(t, err)
+
+	t.Log("it detects the format")
+	{
+		pth := filepath.Join(tmpDir, "TestResult.xml")
+		require.NoError(t, fileutil.WriteStringToFile(pth, nunit2ResultContent))
+
+		result, err := New(pth)
+		require.NoError(t, err)
+		require.Equal(t, FormatNunit2, result.Format)
+		require.Equal(t, 3, result.Total())
+	}
+
+	t.Log("it fails for unknown format")
+	{
+		pth := filepath.Join(tmpDir, "unknown.xml")
+		require.NoError(t, fileutil.WriteStringToFile(pth, `<?xml version="1.0"?><testsuites></testsuites>`))
+
+		_, err := New(pth)
+		require.Error(t, err)
+	}
+}
+
+func TestClassName(t *testing.T) {
+	require.Equal(t, "Core.CalculatorTests", className("Core.CalculatorTests.Add"))
+	require.Equal(t, "Core.CalculatorTests", className(`Core.CalculatorTests.Divide(1.5,"a.b")`))
+	require.Equal(t, "", className("Add"))
+}
+
+const xunitResultContent = `<?xml version="1.0" encoding="utf-8"?>
+<assemblies>
+  <assembly name="/bin/Debug/Core.Tests.dll" total="3" passed="1" failed="1" skipped="1" time="0.250">
+    <collection total="3" passed="1" failed="1" skipped="1" name="Test collection for Core.CalculatorTests" time="0.200">
+      <test name="Core.CalculatorTests.Add" type="Core.CalculatorTests" method="Add" time="0.0500000" result="Pass">
+        <output><![CDATA[adding]]></output>
+      </test>
+      <test name="Core.CalculatorTests.Divide" type="Core.CalculatorTests" method="Divide" time="0.1500000" result="Fail">
+        <failure exception-type="Xunit.Sdk.EqualException">
+          <message><![CDATA[Assert.Equal() Failure
+Expected: 2
+Actual:   0]]></message>
+          <stack-trace><![CDATA[   at Core.CalculatorTests.Divide() in CalculatorTests.cs:line 21]]></stack-trace>
+        </failure>
+      </test>
+      <test name="Core.CalculatorTests.Subtract" type="Core.CalculatorTests" method="Subtract" time="0" result="Skip">
+        <reason><![CDATA[not implemented]]></reason>
+      </test>
+    </collection>
+  </assembly>
+</assemblies>`
+
+func TestParseXunit(t *testing.T) {
+	t.Log("it parses the assemblies and collections")
+	{
+		result, err := Parse([]byte(xunitResultContent))
+		require.NoError(t, err)
+
+		require.Equal(t, FormatXunit, result.Format)
+		require.Equal(t, 250*time.Millisecond, result.Duration)
+
+		require.Equal(t, 1, len(result.Suites))
+		assembly := result.Suites[0]
+		require.Equal(t, "Assembly", assembly.Type)
+		require.Equal(t, OutcomeFailed, assembly.Outcome)
+		require.Equal(t, 1, len(assembly.Suites))
+		require.Equal(t, "Collection", assembly.Suites[0].Type)
+		require.Equal(t, "Test collection for Core.CalculatorTests", assembly.Suites[0].Name)
+	}
+
+	t.Log("it parses the test cases")
+	{
+		result, err := ParseXunit([]byte(xunitResultContent))
+		require.NoError(t, err)
+
+		cases := result.Cases()
+		require.Equal(t, 3, len(cases))
+		require.Equal(t, CaseModel{Name: "Add", FullName: "Core.CalculatorTests.Add", ClassName: "Core.CalculatorTests", Outcome: OutcomePassed, Duration: 50 * time.Millisecond, Output: "adding"}, cases[0])
+		require.Equal(t, CaseModel{
+			Name:       "Divide",
+			FullName:   "Core.CalculatorTests.Divide",
+			ClassName:  "Core.CalculatorTests",
+			Outcome:    OutcomeFailed,
+			Duration:   150 * time.Millisecond,
+			Message:    "Assert.Equal() Failure\nExpected: 2\nActual:   0",
+			StackTrace: "at Core.CalculatorTests.Divide() in CalculatorTests.cs:line 21",
+		}, cases[1])
+		require.Equal(t, CaseModel{Name: "Subtract", FullName: "Core.CalculatorTests.Subtract", ClassName: "Core.CalculatorTests", Outcome: OutcomeSkipped, Message: "not implemented"}, cases[2])
+	}
+}
+
+func TestParseTouchUnit(t *testing.T) {
+	t.Log("it parses the XML output")
+	{
+		result, err := ParseTouchUnit([]byte("Tests are running\n" + nunit3ResultContent + "\nTests finished"))
+		require.NoError(t, err)
+		require.Equal(t, FormatNunit3, result.Format)
+		require.Equal(t, 4, result.Total())
+	}
+
+	t.Log("it parses the text output")
+	{
+		result, err := ParseTouchUnit([]byte(`[Runner executing:	Run Everything]
+[MonoTouch Version:	10.4.0.123]
+	[PASS] Add
+	[FAIL] Divide :   Expected: 2
+  But was:  0
+	[IGNORED] Subtract : not implemented
+Tests run: 3 Passed: 1 Inconclusive: 0 Failed: 1 Ignored: 1`))
+		require.NoError(t, err)
+		require.Equal(t, FormatTouchUnit, result.Format)
+		require.Equal(t, OutcomeFailed, result.Suites[0].Outcome)
+
+		cases := result.Cases()
+		require.Equal(t, 3, len(cases))
+		require.Equal(t, CaseModel{Name: "Add", FullName: "Add", Outcome: OutcomePassed}, cases[0])
+		require.Equal(t, CaseModel{Name: "Divide", FullName: "Divide", Outcome: OutcomeFailed, Message: "Expected: 2"}, cases[1])
+		require.Equal(t, CaseModel{Name: "Subtract", FullName: "Subtract", Outcome: OutcomeSkipped, Label: "Ignored", Message: "not implemented"}, cases[2])
+	}
+}
@@ -0,0 +1,45 @@
+package results
+
+// MarkFlaky - the failed cases of the result passing in the retry result are marked as flaky:
+// their outcome becomes passed, the failure of the first run is kept.
+// Returns the number of the cases marked as flaky.
+func (result *ResultModel) MarkFlaky(retry ResultModel) int {
+	passed := map[string]bool{}
+	for _, testCase := range retry.Cases() {
+		if testCase.Outcome == OutcomePassed {
+			passed[testCase.FullName] = true
+		}
+	}
+
+	marked := 0
+	for i := range result.Suites {
+		marked += result.Suites[i].markFlaky(passed)
+	}
+	return marked
+}
+
+func (suite *SuiteModel) markFlaky(passed map[string]bool) int {
+	marked := 0
+	for i, testCase := range suite.Cases {
+		if testCase.Outcome == OutcomeFailed && passed[testCase.FullName] {
+			suite.Cases[i].Outcome = OutcomePassed
+			suite.Cases[i].Flaky = true
+			marked++
+		}
+	}
+	for i := range suite.Suites {
+		marked += suite.Suites[i].markFlaky(passed)
+	}
+	return marked
+}
+
+// FlakyCases - the cases failed at first, then passed on retry
+func (result ResultModel) FlakyCases() []CaseModel {
+	flaky := []CaseModel{}
+	for _, testCase := range result.Cases() {
+		if testCase.Flaky {
+			flaky = append(flaky, testCase)
+		}
+	}
+	return flaky
+}
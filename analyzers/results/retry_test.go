@@ -0,0 +1,48 @@
+package results
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkFlaky(t *testing.T) {
+	retryContent := `<?xml version="1.0" encoding="utf-8" standalone="no"?>
+<test-run id="2" testcasecount="2" result="Failed" total="2" passed="1" failed="1" skipped="0" duration="0.2">
+  <test-suite type="Assembly" name="Core.Tests.dll" fullname="/bin/Debug/Core.Tests.dll">
+    <test-suite type="TestSuite" name="Core" fullname="Core">
+      <test-suite type="TestFixture" name="CalculatorTests" fullname="Core.CalculatorTests">
+        <test-case id="1-2" name="Divide" fullname="Core.CalculatorTests.Divide" result="Passed" duration="0.1" />
+        <test-case id="1-4" name="Multiply" fullname="Core.CalculatorTests.Multiply" result="Failed" label="Error" duration="0.1" />
+      </test-suite>
+    </test-suite>
+  </test-suite>
+</test-run>`
+
+	result, err := ParseNunit3([]byte(nunit3ResultContent))
+	require.NoError(t, err)
+	retry, err := ParseNunit3([]byte(retryContent))
+	require.NoError(t, err)
+
+	t.Log("it marks the cases passed on retry as flaky")
+	{
+		require.Equal(t, 1, result.MarkFlaky(retry))
+		require.Equal(t, 1, len(result.FailedCases()))
+		require.Equal(t, "Core.CalculatorTests.Multiply", result.FailedCases()[0].FullName)
+
+		flaky := result.FlakyCases()
+		require.Equal(t, 1, len(flaky))
+		require.Equal(t, "Core.CalculatorTests.Divide", flaky[0].FullName)
+		require.Equal(t, OutcomePassed, flaky[0].Outcome)
+		require.Equal(t, "Expected: 2\n  But was:  0", flaky[0].Message)
+	}
+
+	t.Log("it reports the first failure of the flaky cases in JUnit")
+	{
+		content, err := result.JUnit()
+		require.NoError(t, err)
+		require.True(t, strings.Contains(string(content), `<testsuites tests="4" failures="0" errors="1" skipped="1"`), string(content))
+		require.True(t, strings.Contains(string(content), `<flakyFailure message="Expected: 2&#xA;  But was:  0">at Core.CalculatorTests.Divide () [0x00001] in CalculatorTests.cs:21</flakyFailure>`), string(content))
+	}
+}
@@ -0,0 +1,55 @@
+package results
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// touchUnitLinePattern - the result lines of the Touch.Unit text output, like:
+// [FAIL] Divide : Expected: 2 But was: 0
+var touchUnitLinePattern = regexp.MustCompile(`^\[(PASS|FAIL|IGNORED|SKIPPED|INCONCLUSIVE)\] (.+?)(?: : (.*))?$`)
+
+// ParseTouchUnit - parses the output of a Touch.Unit (NUnitLite) test app: the NUnit XML if the app
+// was started with XML output, the [PASS] and [FAIL] result lines otherwise
+func ParseTouchUnit(content []byte) (ResultModel, error) {
+	for _, root := range []string{"test-run", "test-results"} {
+		start := bytes.Index(content, []byte("<"+root))
+		end := bytes.LastIndex(content, []byte("</"+root+">"))
+		if start >= 0 && end > start {
+			return Parse(content[start : end+len("</"+root+">")])
+		}
+	}
+
+	suite := SuiteModel{Type: "TestSuite", Name: "Touch.Unit", FullName: "Touch.Unit", Outcome: OutcomePassed, Suites: []SuiteModel{}, Cases: []CaseModel{}}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		match := touchUnitLinePattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if len(match) != 4 {
+			continue
+		}
+
+		testCase := CaseModel{Name: match[2], FullName: match[2], Message: strings.TrimSpace(match[3])}
+		switch match[1] {
+		case "PASS":
+			testCase.Outcome = OutcomePassed
+		case "FAIL":
+			testCase.Outcome = OutcomeFailed
+			suite.Outcome = OutcomeFailed
+		case "INCONCLUSIVE":
+			testCase.Outcome = OutcomeInconclusive
+		default:
+			testCase.Outcome = OutcomeSkipped
+			testCase.Label = "Ignored"
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	if err := scanner.Err(); err != nil {
+		return ResultModel{}, err
+	}
+
+	return ResultModel{Format: FormatTouchUnit, Suites: []SuiteModel{suite}}, nil
+}
@@ -0,0 +1,113 @@
+package results
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// xUnit.net v2: https://xunit.net/docs/format-xml-v2
+type xunitTestElement struct {
+	Name    string          `xml:"name,attr"`
+	Type    string          `xml:"type,attr"`
+	Method  string          `xml:"method,attr"`
+	Result  string          `xml:"result,attr"`
+	Time    string          `xml:"time,attr"`
+	Failure *failureElement `xml:"failure"`
+	Reason  string          `xml:"reason"`
+	Output  string          `xml:"output"`
+}
+
+type xunitCollectionElement struct {
+	Name  string             `xml:"name,attr"`
+	Time  string             `xml:"time,attr"`
+	Tests []xunitTestElement `xml:"test"`
+}
+
+type xunitAssemblyElement struct {
+	Name        string                   `xml:"name,attr"`
+	Time        string                   `xml:"time,attr"`
+	Collections []xunitCollectionElement `xml:"collection"`
+}
+
+type xunitAssembliesElement struct {
+	XMLName    xml.Name               `xml:"assemblies"`
+	Assemblies []xunitAssemblyElement `xml:"assembly"`
+}
+
+// ParseXunit - parses the assemblies of a xUnit.net v2 XML, the collections are the child suites of the assemblies
+func ParseXunit(content []byte) (ResultModel, error) {
+	var assemblies xunitAssembliesElement
+	if err := xml.Unmarshal(content, &assemblies); err != nil {
+		return ResultModel{}, err
+	}
+
+	result := ResultModel{Format: FormatXunit, Suites: []SuiteModel{}}
+	for _, assembly := range assemblies.Assemblies {
+		assemblySuite := SuiteModel{
+			Type:     "Assembly",
+			Name:     assembly.Name,
+			FullName: assembly.Name,
+			Outcome:  OutcomePassed,
+			Duration: seconds(assembly.Time),
+			Suites:   []SuiteModel{},
+			Cases:    []CaseModel{},
+		}
+
+		for _, collection := range assembly.Collections {
+			collectionSuite := SuiteModel{
+				Type:     "Collection",
+				Name:     collection.Name,
+				FullName: collection.Name,
+				Outcome:  OutcomePassed,
+				Duration: seconds(collection.Time),
+				Suites:   []SuiteModel{},
+				Cases:    []CaseModel{},
+			}
+
+			for _, test := range collection.Tests {
+				testCase := CaseModel{
+					Name:      test.Method,
+					FullName:  test.Name,
+					ClassName: test.Type,
+					Outcome:   xunitOutcome(test.Result),
+					Duration:  seconds(test.Time),
+					Output:    strings.TrimSpace(test.Output),
+				}
+				if testCase.Name == "" {
+					testCase.Name = strings.TrimPrefix(test.Name, test.Type+".")
+				}
+				testCase.Message, testCase.StackTrace = message(test.Failure, nil)
+				if test.Failure == nil {
+					testCase.Message = strings.TrimSpace(test.Reason)
+				}
+
+				if testCase.Outcome == OutcomeFailed {
+					collectionSuite.Outcome = OutcomeFailed
+				}
+				collectionSuite.Cases = append(collectionSuite.Cases, testCase)
+			}
+
+			if collectionSuite.Outcome == OutcomeFailed {
+				assemblySuite.Outcome = OutcomeFailed
+			}
+			assemblySuite.Suites = append(assemblySuite.Suites, collectionSuite)
+		}
+
+		result.Duration += assemblySuite.Duration
+		result.Suites = append(result.Suites, assemblySuite)
+	}
+
+	return result, nil
+}
+
+// xunitOutcome - the result attribute is one of: Pass, Fail, Skip, NotRun
+func xunitOutcome(result string) Outcome {
+	switch result {
+	case "Pass":
+		return OutcomePassed
+	case "Fail":
+		return OutcomeFailed
+	default:
+		return OutcomeSkipped
+	}
+}
@@ -0,0 +1,100 @@
+package solution
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+// ConfigurationPlatformModel - a solution Configuration|Platform and its project mappings
+type ConfigurationPlatformModel struct {
+	Configuration string
+	Platform      string
+
+	Projects []ProjectConfigurationPlatformModel // Project mappings, sorted by project name
+}
+
+// ProjectConfigurationPlatformModel - the project Configuration|Platform, which a solution Configuration|Platform is mapped to
+type ProjectConfigurationPlatformModel struct {
+	ProjectID   string
+	ProjectName string
+
+	Configuration string
+	Platform      string
+
+	BuildEnabled  bool // The project is built in the solution configuration (Build.0)
+	DeployEnabled bool // The project is deployed in the solution configuration (Deploy.0)
+}
+
+// String - returns the Configuration|Platform form, like: Release|iPhone
+func (configurationPlatform ConfigurationPlatformModel) String() string {
+	return utility.ToConfig(configurationPlatform.Configuration, configurationPlatform.Platform)
+}
+
+// String - returns the Configuration|Platform form, like: Release|iPhone
+func (configurationPlatform ProjectConfigurationPlatformModel) String() string {
+	return utility.ToConfig(configurationPlatform.Configuration, configurationPlatform.Platform)
+}
+
+// ConfigurationPlatforms - returns the solution's Configuration|Platforms sorted by Configuration|Platform,
+// with the mappings of the projects, which have an ActiveCfg or Build entry for the configuration
+func (solution Model) ConfigurationPlatforms() []ConfigurationPlatformModel {
+	solutionConfigs := solution.ConfigList()
+	sort.Strings(solutionConfigs)
+
+	projectIDs := []string{}
+	for projectID := range solution.ProjectMap {
+		projectIDs = append(projectIDs, projectID)
+	}
+	sort.Slice(projectIDs, func(i, j int) bool {
+		nameI, nameJ := solution.ProjectMap[projectIDs[i]].Name, solution.ProjectMap[projectIDs[j]].Name
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return projectIDs[i] < projectIDs[j]
+	})
+
+	configurationPlatforms := []ConfigurationPlatformModel{}
+	for _, solutionConfig := range solutionConfigs {
+		configuration, platform := splitConfig(solutionConfig)
+		configurationPlatform := ConfigurationPlatformModel{
+			Configuration: configuration,
+			Platform:      platform,
+			Projects:      []ProjectConfigurationPlatformModel{},
+		}
+
+		for _, projectID := range projectIDs {
+			proj := solution.ProjectMap[projectID]
+
+			projectConfig, buildEnabled := proj.ConfigMap[solutionConfig]
+			if activeConfig, ok := solution.activeConfigMap[projectID][solutionConfig]; ok {
+				projectConfig = activeConfig
+			} else if !buildEnabled {
+				continue
+			}
+
+			projectConfiguration, projectPlatform := splitConfig(projectConfig)
+			configurationPlatform.Projects = append(configurationPlatform.Projects, ProjectConfigurationPlatformModel{
+				ProjectID:     projectID,
+				ProjectName:   proj.Name,
+				Configuration: projectConfiguration,
+				Platform:      projectPlatform,
+				BuildEnabled:  buildEnabled,
+				DeployEnabled: solution.deployConfigMap[projectID][solutionConfig],
+			})
+		}
+
+		configurationPlatforms = append(configurationPlatforms, configurationPlatform)
+	}
+
+	return configurationPlatforms
+}
+
+func splitConfig(config string) (string, string) {
+	split := strings.SplitN(config, "|", 2)
+	if len(split) < 2 {
+		return split[0], ""
+	}
+	return split[0], split[1]
+}
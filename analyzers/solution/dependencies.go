@@ -0,0 +1,108 @@
+package solution
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Dependencies - returns the IDs of the solution's projects, which the given project directly depends on.
+// Dependencies are collected from the solution's ProjectDependencies sections
+// and from the project's ProjectReference items (the latter requires the solution to be analyzed with loadProjects).
+func (solution Model) Dependencies(projectID string) []string {
+	projectID = strings.ToUpper(projectID)
+
+	proj, ok := solution.ProjectMap[projectID]
+	if !ok {
+		return []string{}
+	}
+
+	dependencyIDs := []string{}
+	dependencyIDs = append(dependencyIDs, solution.DependencyMap[projectID]...)
+	dependencyIDs = append(dependencyIDs, proj.ReferredProjectIDs...)
+	for _, pth := range proj.ReferredProjectPths {
+		if dependencyID := solution.projectIDByPath(pth); dependencyID != "" {
+			dependencyIDs = append(dependencyIDs, dependencyID)
+		}
+	}
+
+	dependencyIDMap := map[string]bool{}
+	dependencies := []string{}
+	for _, dependencyID := range dependencyIDs {
+		if dependencyID == projectID || dependencyIDMap[dependencyID] {
+			continue
+		}
+		if _, ok := solution.ProjectMap[dependencyID]; !ok {
+			continue
+		}
+
+		dependencyIDMap[dependencyID] = true
+		dependencies = append(dependencies, dependencyID)
+	}
+
+	sort.Strings(dependencies)
+	return dependencies
+}
+
+// DependencyOrder - returns the solution's project IDs ordered so, that every project comes after its dependencies.
+// Fails if the dependency graph contains a cycle.
+func (solution Model) DependencyOrder() ([]string, error) {
+	dependentMap := map[string][]string{}
+	inDegreeMap := map[string]int{}
+
+	for projectID := range solution.ProjectMap {
+		dependencies := solution.Dependencies(projectID)
+
+		inDegreeMap[projectID] = len(dependencies)
+		for _, dependencyID := range dependencies {
+			dependentMap[dependencyID] = append(dependentMap[dependencyID], projectID)
+		}
+	}
+
+	ready := []string{}
+	for projectID, inDegree := range inDegreeMap {
+		if inDegree == 0 {
+			ready = append(ready, projectID)
+		}
+	}
+
+	order := []string{}
+	for len(ready) > 0 {
+		sort.Strings(ready)
+
+		projectID := ready[0]
+		ready = ready[1:]
+
+		order = append(order, projectID)
+
+		for _, dependentID := range dependentMap[projectID] {
+			inDegreeMap[dependentID]--
+			if inDegreeMap[dependentID] == 0 {
+				ready = append(ready, dependentID)
+			}
+		}
+	}
+
+	if len(order) != len(solution.ProjectMap) {
+		cycle := []string{}
+		for projectID, inDegree := range inDegreeMap {
+			if inDegree > 0 {
+				cycle = append(cycle, solution.ProjectMap[projectID].Name)
+			}
+		}
+		sort.Strings(cycle)
+
+		return []string{}, fmt.Errorf("dependency cycle found between projects: %s", strings.Join(cycle, ", "))
+	}
+
+	return order, nil
+}
+
+func (solution Model) projectIDByPath(pth string) string {
+	for projectID, proj := range solution.ProjectMap {
+		if strings.EqualFold(proj.Pth, pth) {
+			return projectID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,36 @@
+package solution
+
+import (
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+	"github.com/bitrise-tools/go-xamarin/constants"
+)
+
+// UsesXamarinForms - returns true if any of the solution's projects depends on the Xamarin.Forms package.
+// Requires the solution to be analyzed with loadProjects.
+func (solution Model) UsesXamarinForms() bool {
+	for _, proj := range solution.ProjectMap {
+		if proj.UsesXamarinForms() {
+			return true
+		}
+	}
+	return false
+}
+
+// HeadProjects - returns the application projects per platform, sorted by name.
+// Test, app extension and watch projects are not head projects.
+// Requires the solution to be analyzed with loadProjects.
+func (solution Model) HeadProjects() map[constants.SDK][]project.Model {
+	headProjects := map[constants.SDK][]project.Model{}
+	for _, proj := range solution.ProjectMap {
+		if proj.ProjectType != constants.ProjectTypeUnknown || !proj.IsApplication() {
+			continue
+		}
+		headProjects[proj.SDK] = append(headProjects[proj.SDK], proj)
+	}
+
+	for _, projects := range headProjects {
+		sortProjectsByName(projects)
+	}
+
+	return headProjects
+}
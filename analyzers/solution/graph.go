@@ -0,0 +1,128 @@
+package solution
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+	"github.com/bitrise-tools/go-xamarin/constants"
+)
+
+// GraphNodeModel - a project of the dependency graph
+type GraphNodeModel struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	ProjectType constants.ProjectType `json:"project_type,omitempty"`
+	SDK         constants.SDK         `json:"sdk,omitempty"`
+	BuildOrder  int                   `json:"build_order,omitempty"` // 1 based position in the build order, 0 if the project is not built
+}
+
+// GraphEdgeModel - the project (From) depends on the dependency (To)
+type GraphEdgeModel struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// GraphModel - the dependency graph of the solution's projects
+type GraphModel struct {
+	Solution string           `json:"solution"`
+	Nodes    []GraphNodeModel `json:"nodes"`
+	Edges    []GraphEdgeModel `json:"edges"`
+}
+
+// Graph - returns the dependency graph of the solution's projects, nodes are sorted by name, edges are the Dependencies of the projects
+func (solution Model) Graph() GraphModel {
+	graph := GraphModel{
+		Solution: solution.Name,
+		Nodes:    []GraphNodeModel{},
+		Edges:    []GraphEdgeModel{},
+	}
+
+	projects := []project.Model{}
+	for _, proj := range solution.ProjectMap {
+		projects = append(projects, proj)
+	}
+	sortProjectsByName(projects)
+
+	for _, proj := range projects {
+		graph.Nodes = append(graph.Nodes, GraphNodeModel{
+			ID:          proj.ID,
+			Name:        proj.Name,
+			ProjectType: proj.ProjectType,
+			SDK:         proj.SDK,
+		})
+
+		for _, dependencyID := range solution.Dependencies(proj.ID) {
+			graph.Edges = append(graph.Edges, GraphEdgeModel{From: proj.ID, To: dependencyID})
+		}
+	}
+
+	return graph
+}
+
+// SetBuildOrder - numbers the nodes by the given project IDs, like by the builder's planned build order,
+// the other nodes are not built
+func (graph GraphModel) SetBuildOrder(projectIDs []string) GraphModel {
+	orderMap := map[string]int{}
+	for i, projectID := range projectIDs {
+		orderMap[strings.ToUpper(projectID)] = i + 1
+	}
+
+	nodes := []GraphNodeModel{}
+	for _, node := range graph.Nodes {
+		node.BuildOrder = orderMap[strings.ToUpper(node.ID)]
+		nodes = append(nodes, node)
+	}
+	graph.Nodes = nodes
+
+	return graph
+}
+
+func dotQuote(text string) string {
+	return `"` + strings.Replace(strings.Replace(text, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
+
+// DOT - returns the graph in Graphviz DOT format, render it like: dot -Tsvg graph.dot -o graph.svg.
+// Built projects are labeled by their build order, the not built ones are dashed.
+func (graph GraphModel) DOT() string {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "digraph %s {\n", dotQuote(graph.Solution))
+	buffer.WriteString("\trankdir=LR;\n")
+	buffer.WriteString("\tnode [shape=box];\n")
+
+	for _, node := range graph.Nodes {
+		label := node.Name
+		if node.SDK != "" && node.SDK != constants.SDKUnknown {
+			label += fmt.Sprintf(" (%s)", node.SDK)
+		}
+
+		attributes := []string{}
+		if node.BuildOrder > 0 {
+			label = fmt.Sprintf("%d. %s", node.BuildOrder, label)
+		} else {
+			attributes = append(attributes, "style=dashed")
+		}
+		attributes = append([]string{"label=" + dotQuote(label)}, attributes...)
+
+		fmt.Fprintf(&buffer, "\t%s [%s];\n", dotQuote(node.ID), strings.Join(attributes, ", "))
+	}
+
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&buffer, "\t%s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+
+	buffer.WriteString("}\n")
+	return buffer.String()
+}
+
+// JSON - returns the graph in indented JSON format
+func (graph GraphModel) JSON() ([]byte, error) {
+	content, err := json.MarshalIndent(graph, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize dependency graph, error: %s", err)
+	}
+	return content, nil
+}
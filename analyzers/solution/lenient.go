@@ -0,0 +1,124 @@
+package solution
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+	"github.com/bitrise-tools/go-xamarin/utility"
+)
+
+// ProjectWarningReason ...
+type ProjectWarningReason string
+
+const (
+	// ProjectWarningReasonMissing - the project file does not exist
+	ProjectWarningReasonMissing ProjectWarningReason = "missing"
+	// ProjectWarningReasonUnreadable - the project file can not be read or its encoding is not supported
+	ProjectWarningReasonUnreadable ProjectWarningReason = "unreadable"
+	// ProjectWarningReasonMalformed - the project file is not a well-formed xml, it is analyzed anyway
+	ProjectWarningReasonMalformed ProjectWarningReason = "malformed"
+	// ProjectWarningReasonAnalyzeFailed - the project analysis failed
+	ProjectWarningReasonAnalyzeFailed ProjectWarningReason = "analyze-failed"
+)
+
+// ProjectWarning - a problem with a solution's project, collected by the lenient analysis
+type ProjectWarning struct {
+	ProjectID   string
+	ProjectName string
+	ProjectPth  string
+	Reason      ProjectWarningReason
+	Message     string
+}
+
+// NewLenient - analyzes the solution, but collects the problems of the projects into the Warnings, instead of failing.
+// The problematic projects are kept with the information found in the solution file.
+func NewLenient(pth string, loadProjects bool) (Model, error) {
+	return NewAnalyzer().NewLenient(pth, loadProjects)
+}
+
+// NewLenient - analyzes the solution leniently (see: NewLenient) with the analyzer's options
+func (analyzer Analyzer) NewLenient(pth string, loadProjects bool) (Model, error) {
+	solution, err := parseSolution(pth)
+	if err != nil {
+		return Model{}, err
+	}
+	solution.lenient = true
+	solution.analyzer = analyzer
+
+	if loadProjects {
+		if err := solution.loadProjects(true); err != nil {
+			return Model{}, err
+		}
+	}
+
+	return solution, nil
+}
+
+// checkProjectFile returns a warning, if the project file is missing, unreadable or malformed
+func checkProjectFile(proj project.Model) (ProjectWarning, bool) {
+	warning := ProjectWarning{
+		ProjectID:   proj.ID,
+		ProjectName: proj.Name,
+		ProjectPth:  proj.Pth,
+	}
+
+	if exist, err := pathutil.IsPathExists(proj.Pth); err != nil || !exist {
+		warning.Reason = ProjectWarningReasonMissing
+		warning.Message = fmt.Sprintf("project (%s) does not exist at: %s", proj.Name, proj.Pth)
+		return warning, true
+	}
+
+	rawContent, err := fileutil.ReadBytesFromFile(proj.Pth)
+	if err != nil {
+		warning.Reason = ProjectWarningReasonUnreadable
+		warning.Message = fmt.Sprintf("failed to read project (%s), error: %s", proj.Pth, err)
+		return warning, true
+	}
+
+	content := []byte(utility.DecodeText(rawContent))
+	if !utf8.Valid(content) {
+		warning.Reason = ProjectWarningReasonUnreadable
+		warning.Message = fmt.Sprintf("project (%s) is neither utf-8 nor utf-16 encoded", proj.Pth)
+		return warning, true
+	}
+
+	if err := checkWellFormedXML(content); err != nil {
+		warning.Reason = ProjectWarningReasonMalformed
+		warning.Message = fmt.Sprintf("project (%s) is not a well-formed xml, error: %s", proj.Pth, err)
+		return warning, true
+	}
+
+	return ProjectWarning{}, false
+}
+
+func checkWellFormedXML(content []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	// the content is already decoded, the declared encoding is not relevant for the well-formedness
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func sortProjectWarnings(warnings []ProjectWarning) {
+	sort.Slice(warnings, func(i, j int) bool {
+		if warnings[i].ProjectName != warnings[j].ProjectName {
+			return warnings[i].ProjectName < warnings[j].ProjectName
+		}
+		return warnings[i].Reason < warnings[j].Reason
+	})
+}
@@ -0,0 +1,99 @@
+package solution
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+)
+
+// OutputSchemaVersion - the version of the serialized solution format, increased on breaking changes
+const OutputSchemaVersion = 1
+
+// FolderOutputModel - the serialized form of a solution folder
+type FolderOutputModel struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+	Pth  string `json:"path" yaml:"path"` // Slash separated path of the folder in the solution, like: Apps/iOS
+}
+
+// OutputModel - the stable, serialized form of the solution model
+type OutputModel struct {
+	SchemaVersion int    `json:"schema_version" yaml:"schema_version"`
+	Name          string `json:"name" yaml:"name"`
+	Pth           string `json:"path" yaml:"path"`
+	ID            string `json:"id" yaml:"id"`
+
+	ConfigMap map[string]string `json:"config_map" yaml:"config_map"`
+
+	Projects      []project.OutputModel `json:"projects" yaml:"projects"`
+	Folders       []FolderOutputModel   `json:"folders,omitempty" yaml:"folders,omitempty"`
+	DependencyMap map[string][]string   `json:"dependency_map,omitempty" yaml:"dependency_map,omitempty"`
+}
+
+// OutputModel - returns the serialized form of the solution, projects are sorted by name, folders by path
+func (solution Model) OutputModel() OutputModel {
+	output := OutputModel{
+		SchemaVersion: OutputSchemaVersion,
+		Name:          solution.Name,
+		Pth:           solution.Pth,
+		ID:            solution.ID,
+		ConfigMap:     solution.ConfigMap,
+		Projects:      []project.OutputModel{},
+		DependencyMap: solution.DependencyMap,
+	}
+
+	projects := []project.Model{}
+	for _, proj := range solution.ProjectMap {
+		projects = append(projects, proj)
+	}
+	sortProjectsByName(projects)
+
+	for _, proj := range projects {
+		output.Projects = append(output.Projects, proj.OutputModel())
+	}
+
+	for _, folder := range solution.FolderMap {
+		folderPth := solution.FolderPath(folder.ID)
+		if folderPth != "" {
+			folderPth += "/"
+		}
+
+		output.Folders = append(output.Folders, FolderOutputModel{
+			ID:   folder.ID,
+			Name: folder.Name,
+			Pth:  folderPth + folder.Name,
+		})
+	}
+	sort.Slice(output.Folders, func(i, j int) bool {
+		return output.Folders[i].Pth < output.Folders[j].Pth
+	})
+
+	return output
+}
+
+// MarshalJSON ...
+func (solution Model) MarshalJSON() ([]byte, error) {
+	return json.Marshal(solution.OutputModel())
+}
+
+// MarshalYAML ...
+func (solution Model) MarshalYAML() (interface{}, error) {
+	return solution.OutputModel(), nil
+}
+
+// Analyze - analyzes the solution and its projects, returns the solution in its serialized (JSON) form
+func Analyze(solutionPth string) ([]byte, error) {
+	solution, err := New(solutionPth, true)
+	if err != nil {
+		return nil, err
+	}
+
+	serBytes, err := json.MarshalIndent(solution, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize solution (%s), error: %s", solutionPth, err)
+	}
+
+	return serBytes, nil
+}
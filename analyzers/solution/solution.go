@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
-	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-tools/go-xamarin/analyzers/project"
 	"github.com/bitrise-tools/go-xamarin/constants"
@@ -24,8 +26,26 @@ const (
 	projectConfigurationPlatformsSectionStartPattern = `GlobalSection\(ProjectConfigurationPlatforms\) = postSolution`
 	projectConfigurationPlatformsSectionEndPattern   = `EndGlobalSection`
 	projectConfigurationPlatformPattern              = `{(?P<project_id>.*)}.(?P<config>.*)\|(?P<platform>.*)\.Build.* = (?P<mapped_config>.*)\|(?P<mapped_platform>.*)`
+	projectActiveConfigurationPlatformPattern        = `{(?P<project_id>.*)}.(?P<config>.*)\|(?P<platform>.*)\.ActiveCfg = (?P<mapped_config>.*)\|(?P<mapped_platform>.*)`
+	projectDeployConfigurationPlatformPattern        = `{(?P<project_id>.*)}.(?P<config>.*)\|(?P<platform>.*)\.Deploy.* = `
+
+	projectDependenciesSectionStartPattern = `ProjectSection\(ProjectDependencies\) = postProject`
+	projectDependenciesSectionEndPattern   = `EndProjectSection`
+	projectDependencyPattern               = `{(?P<id>[^}]*)} = {(?P<dependency_id>[^}]*)}`
+
+	nestedProjectsSectionStartPattern = `GlobalSection\(NestedProjects\) = preSolution`
+	nestedProjectsSectionEndPattern   = `EndGlobalSection`
+	nestedProjectPattern              = `{(?P<id>[^}]*)} = {(?P<parent_id>[^}]*)}`
+
+	solutionFolderTypeGUID = "2150E333-8FDC-42A3-9474-1A3956D46DE8"
 )
 
+// FolderModel ...
+type FolderModel struct {
+	ID   string
+	Name string
+}
+
 // Model ...
 type Model struct {
 	Pth  string
@@ -35,11 +55,68 @@ type Model struct {
 	ConfigMap map[string]string // Internal Configuartion|Platform - External Configuartion|Platform map
 
 	ProjectMap map[string]project.Model // Project ID - Project Model map
+
+	FolderMap map[string]FolderModel // Solution Folder ID - Solution Folder Model map
+	NestedMap map[string]string      // Project or Solution Folder ID - Parent Solution Folder ID map
+
+	DependencyMap map[string][]string // Project ID - Project IDs from the solution's ProjectDependencies section
+
+	Warnings []ProjectWarning // Project problems, only collected by NewLenient
+
+	activeConfigMap     map[string]map[string]string // Project ID - Solution Configuration|Platform - ActiveCfg Project Configuration|Platform map
+	deployConfigMap     map[string]map[string]bool   // Project ID - Solution Configuration|Platforms with Deploy mapping
+	duplicateProjectIDs []string                     // Project IDs listed more than once in the solution
+
+	projectsLoaded bool     // The projects were analyzed, used by Reload
+	lenient        bool     // The solution was analyzed by NewLenient, used by Reload
+	analyzer       Analyzer // The options of the analysis, used by Reload
+}
+
+// Analyzer - the options of the solution analysis
+type Analyzer struct {
+	projectLoadParallelism int
+	projectCache           *project.Cache
+}
+
+// NewAnalyzer - the project files are analyzed by runtime.NumCPU() workers, without cache
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{
+		projectLoadParallelism: runtime.NumCPU(),
+	}
+}
+
+// SetProjectLoadParallelism - the maximum number of project files analyzed concurrently
+func (analyzer *Analyzer) SetProjectLoadParallelism(parallelism int) *Analyzer {
+	analyzer.projectLoadParallelism = parallelism
+	return analyzer
+}
+
+// SetProjectCache - the analyzed projects are cached in the given cache, so repeated analyses skip the unchanged project files
+func (analyzer *Analyzer) SetProjectCache(cache *project.Cache) *Analyzer {
+	analyzer.projectCache = cache
+	return analyzer
+}
+
+// New - analyzes the solution with the analyzer's options
+func (analyzer Analyzer) New(pth string, loadProjects bool) (Model, error) {
+	solution, err := parseSolution(pth)
+	if err != nil {
+		return Model{}, err
+	}
+	solution.analyzer = analyzer
+
+	if loadProjects {
+		if err := solution.loadProjects(false); err != nil {
+			return Model{}, err
+		}
+	}
+
+	return solution, nil
 }
 
 // New ...
 func New(pth string, loadProjects bool) (Model, error) {
-	return analyzeSolution(pth, loadProjects)
+	return NewAnalyzer().New(pth, loadProjects)
 }
 
 // ConfigList ...
@@ -51,7 +128,113 @@ func (solution Model) ConfigList() []string {
 	return configList
 }
 
+// ProjectsOfType - returns the solution's projects classified as the given project type, sorted by name
+func (solution Model) ProjectsOfType(projectType constants.ProjectType) []project.Model {
+	projects := []project.Model{}
+	for _, proj := range solution.ProjectMap {
+		if proj.ProjectType == projectType {
+			projects = append(projects, proj)
+		}
+	}
+	sortProjectsByName(projects)
+	return projects
+}
+
+// SharedProjects ...
+func (solution Model) SharedProjects() []project.Model {
+	return solution.ProjectsOfType(constants.ProjectTypeShared)
+}
+
+// UnitTestProjects - returns the NUnit, xUnit and MSTest test assembly projects.
+// Requires the solution to be analyzed with loadProjects.
+func (solution Model) UnitTestProjects() []project.Model {
+	return solution.ProjectsOfType(constants.ProjectTypeUnitTest)
+}
+
+// ProjectsImportingSharedProject - returns the projects, which compile the given shared project's sources.
+// Requires the solution to be analyzed with loadProjects.
+func (solution Model) ProjectsImportingSharedProject(sharedProjectID string) []project.Model {
+	projects := []project.Model{}
+
+	sharedProject, ok := solution.ProjectMap[strings.ToUpper(sharedProjectID)]
+	if !ok || sharedProject.ProjectType != constants.ProjectTypeShared {
+		return projects
+	}
+
+	sharedItemsPths := map[string]bool{}
+	for _, pth := range sharedProject.SharedItemsPths {
+		sharedItemsPths[pth] = true
+	}
+
+	for _, proj := range solution.ProjectMap {
+		if proj.ProjectType == constants.ProjectTypeShared {
+			continue
+		}
+
+		for _, pth := range proj.SharedItemsPths {
+			if sharedItemsPths[pth] {
+				projects = append(projects, proj)
+				break
+			}
+		}
+	}
+
+	sortProjectsByName(projects)
+	return projects
+}
+
+// FolderPath - returns the slash separated solution folder path of the given project or solution folder,
+// like: Apps/iOS
+func (solution Model) FolderPath(id string) string {
+	names := []string{}
+
+	visited := map[string]bool{}
+	parentID, ok := solution.NestedMap[strings.ToUpper(id)]
+	for ok && !visited[parentID] {
+		visited[parentID] = true
+
+		folder, found := solution.FolderMap[parentID]
+		if !found {
+			break
+		}
+		names = append([]string{folder.Name}, names...)
+
+		parentID, ok = solution.NestedMap[parentID]
+	}
+
+	return strings.Join(names, "/")
+}
+
+// ProjectsInFolder - returns the projects placed into the given solution folder or into any of its subfolders
+func (solution Model) ProjectsInFolder(folderPth string) []project.Model {
+	projects := []project.Model{}
+	for projectID, proj := range solution.ProjectMap {
+		if IsInFolder(solution.FolderPath(projectID), folderPth) {
+			projects = append(projects, proj)
+		}
+	}
+	sortProjectsByName(projects)
+	return projects
+}
+
+// IsInFolder - returns true if the given solution folder path equals to, or is placed into the folder
+func IsInFolder(pth, folderPth string) bool {
+	folderPth = strings.Trim(utility.FixWindowsPath(folderPth), "/")
+	return pth == folderPth || strings.HasPrefix(pth, folderPth+"/")
+}
+
+func sortProjectsByName(projects []project.Model) {
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+}
+
 func analyzeSolution(pth string, analyzeProjects bool) (Model, error) {
+	return NewAnalyzer().New(pth, analyzeProjects)
+}
+
+// parseSolution analyzes the solution file, without its projects
+func parseSolution(pth string) (Model, error) {
 	absPth, err := pathutil.AbsPath(pth)
 	if err != nil {
 		return Model{}, fmt.Errorf("Failed to expand path (%s), error: %s", pth, err)
@@ -66,14 +249,24 @@ func analyzeSolution(pth string, analyzeProjects bool) (Model, error) {
 		Name:       fileName,
 		ConfigMap:  map[string]string{},
 		ProjectMap: map[string]project.Model{},
+		FolderMap:  map[string]FolderModel{},
+		NestedMap:  map[string]string{},
+
+		DependencyMap: map[string][]string{},
+
+		activeConfigMap: map[string]map[string]string{},
+		deployConfigMap: map[string]map[string]bool{},
 	}
 
 	isSolutionConfigurationPlatformsSection := false
 	isProjectConfigurationPlatformsSection := false
+	isNestedProjectsSection := false
+	isProjectDependenciesSection := false
+	currentProjectID := ""
 
 	solutionDir := filepath.Dir(absPth)
 
-	content, err := fileutil.ReadStringFromFile(absPth)
+	content, err := utility.ReadTextFile(absPth)
 	if err != nil {
 		return Model{}, fmt.Errorf("failed to read solution (%s), error: %s", absPth, err)
 	}
@@ -87,17 +280,37 @@ func analyzeSolution(pth string, analyzeProjects bool) (Model, error) {
 			ID := strings.ToUpper(matches[1])
 			projectName := matches[2]
 			projectID := strings.ToUpper(matches[4])
-			projectRelativePth := utility.FixWindowsPath(matches[3])
-			projectPth := filepath.Join(solutionDir, projectRelativePth)
+			projectPth := utility.ResolvePath(solutionDir, matches[3])
+
+			currentProjectID = projectID
+
+			// Solution folder
+			if ID == solutionFolderTypeGUID {
+				solution.FolderMap[projectID] = FolderModel{
+					ID:   projectID,
+					Name: projectName,
+				}
+				continue
+			}
 
 			if strings.HasSuffix(projectPth, constants.CSProjExt) ||
 				strings.HasSuffix(projectPth, constants.SHProjExt) ||
 				strings.HasSuffix(projectPth, constants.FSProjExt) {
 
+				projectType := constants.ProjectTypeUnknown
+				if strings.HasSuffix(projectPth, constants.SHProjExt) {
+					projectType = constants.ProjectTypeShared
+				}
+
+				if _, ok := solution.ProjectMap[projectID]; ok {
+					solution.duplicateProjectIDs = append(solution.duplicateProjectIDs, projectID)
+				}
+
 				project := project.Model{
-					ID:   projectID,
-					Name: projectName,
-					Pth:  projectPth,
+					ID:          projectID,
+					Name:        projectName,
+					Pth:         projectPth,
+					ProjectType: projectType,
 
 					ConfigMap: map[string]string{},
 					Configs:   map[string]project.ConfigurationPlatformModel{},
@@ -110,6 +323,29 @@ func analyzeSolution(pth string, analyzeProjects bool) (Model, error) {
 			continue
 		}
 
+		// ProjectSection(ProjectDependencies) = postProject
+		if isProjectDependenciesSection {
+			if match := regexp.MustCompile(projectDependenciesSectionEndPattern).FindString(line); match != "" {
+				isProjectDependenciesSection = false
+				continue
+			}
+		}
+
+		if match := regexp.MustCompile(projectDependenciesSectionStartPattern).FindString(line); match != "" {
+			isProjectDependenciesSection = true
+			continue
+		}
+
+		if isProjectDependenciesSection {
+			if matches := regexp.MustCompile(projectDependencyPattern).FindStringSubmatch(line); len(matches) == 3 {
+				dependencyID := strings.ToUpper(matches[2])
+
+				solution.DependencyMap[currentProjectID] = append(solution.DependencyMap[currentProjectID], dependencyID)
+
+				continue
+			}
+		}
+
 		// GlobalSection(SolutionConfigurationPlatforms) = preSolution
 		if isSolutionConfigurationPlatformsSection {
 			if match := regexp.MustCompile(solutionConfigurationPlatformsSectionEndPattern).FindString(line); match != "" {
@@ -173,30 +409,170 @@ func analyzeSolution(pth string, analyzeProjects bool) (Model, error) {
 
 				continue
 			}
+
+			if matches := regexp.MustCompile(projectActiveConfigurationPlatformPattern).FindStringSubmatch(line); len(matches) == 6 {
+				projectID := strings.ToUpper(matches[1])
+				solutionConfig := utility.ToConfig(matches[2], matches[3])
+
+				projectPlatform := matches[5]
+				if projectPlatform == "Any CPU" {
+					projectPlatform = "AnyCPU"
+				}
+
+				if _, ok := solution.activeConfigMap[projectID]; !ok {
+					solution.activeConfigMap[projectID] = map[string]string{}
+				}
+				solution.activeConfigMap[projectID][solutionConfig] = utility.ToConfig(matches[4], projectPlatform)
+
+				continue
+			}
+
+			if matches := regexp.MustCompile(projectDeployConfigurationPlatformPattern).FindStringSubmatch(line); len(matches) == 4 {
+				projectID := strings.ToUpper(matches[1])
+				solutionConfig := utility.ToConfig(matches[2], matches[3])
+
+				if _, ok := solution.deployConfigMap[projectID]; !ok {
+					solution.deployConfigMap[projectID] = map[string]bool{}
+				}
+				solution.deployConfigMap[projectID][solutionConfig] = true
+
+				continue
+			}
+		}
+
+		// GlobalSection(NestedProjects) = preSolution
+		if isNestedProjectsSection {
+			if match := regexp.MustCompile(nestedProjectsSectionEndPattern).FindString(line); match != "" {
+				isNestedProjectsSection = false
+				continue
+			}
+		}
+
+		if match := regexp.MustCompile(nestedProjectsSectionStartPattern).FindString(line); match != "" {
+			isNestedProjectsSection = true
+			continue
+		}
+
+		if isNestedProjectsSection {
+			if matches := regexp.MustCompile(nestedProjectPattern).FindStringSubmatch(line); len(matches) == 3 {
+				ID := strings.ToUpper(matches[1])
+				parentID := strings.ToUpper(matches[2])
+
+				solution.NestedMap[ID] = parentID
+
+				continue
+			}
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		return Model{}, err
 	}
 
-	if analyzeProjects {
-		projectMap := map[string]project.Model{}
+	return solution, nil
+}
+
+// projectLoadResult - the analyzed project, or the project of the solution file with the problems found
+type projectLoadResult struct {
+	proj     project.Model
+	warnings []ProjectWarning
+	err      error
+}
+
+// loadProjects analyzes the solution's project files concurrently, the ProjectMap and the Warnings are assembled
+// in project ID order, so the result does not depend on the scheduling.
+// In lenient mode the problematic projects are reported in the Warnings, instead of failing.
+func (solution *Model) loadProjects(lenient bool) error {
+	solution.projectsLoaded = true
+	solution.lenient = lenient
 
-		for projectID, proj := range solution.ProjectMap {
-			projectDefinition, err := project.New(proj.Pth)
-			if err != nil {
-				return Model{}, fmt.Errorf("failed to analyze project (%s), error: %s", proj.Pth, err)
+	projectIDs := []string{}
+	for projectID := range solution.ProjectMap {
+		projectIDs = append(projectIDs, projectID)
+	}
+	sort.Strings(projectIDs)
+
+	parallelism := solution.analyzer.projectLoadParallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(projectIDs) {
+		parallelism = len(projectIDs)
+	}
+
+	results := make([]projectLoadResult, len(projectIDs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				projectID := projectIDs[idx]
+				results[idx] = loadProject(projectID, solution.ProjectMap[projectID], lenient, solution.analyzer.projectCache)
 			}
+		}()
+	}
 
-			projectDefinition.Name = proj.Name
-			projectDefinition.Pth = proj.Pth
-			projectDefinition.ConfigMap = proj.ConfigMap
+	for idx := range projectIDs {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
 
-			projectMap[projectID] = projectDefinition
+	projectMap := map[string]project.Model{}
+	for idx, result := range results {
+		if result.err != nil {
+			return result.err
 		}
+		solution.Warnings = append(solution.Warnings, result.warnings...)
+		projectMap[projectIDs[idx]] = result.proj
+	}
 
-		solution.ProjectMap = projectMap
+	solution.ProjectMap = projectMap
+	sortProjectWarnings(solution.Warnings)
+
+	return nil
+}
+
+// loadProject analyzes the project file of the solution's project
+func loadProject(projectID string, proj project.Model, lenient bool, cache *project.Cache) projectLoadResult {
+	result := projectLoadResult{proj: proj}
+
+	if lenient {
+		if warning, ok := checkProjectFile(proj); ok {
+			result.warnings = append(result.warnings, warning)
+			if warning.Reason != ProjectWarningReasonMalformed {
+				return result
+			}
+		}
 	}
 
-	return solution, nil
+	projectDefinition, err := project.NewWithCache(proj.Pth, cache)
+	if err != nil {
+		if !lenient {
+			result.err = fmt.Errorf("failed to analyze project (%s), error: %s", proj.Pth, err)
+			return result
+		}
+
+		result.warnings = append(result.warnings, ProjectWarning{
+			ProjectID:   projectID,
+			ProjectName: proj.Name,
+			ProjectPth:  proj.Pth,
+			Reason:      ProjectWarningReasonAnalyzeFailed,
+			Message:     fmt.Sprintf("failed to analyze project (%s), error: %s", proj.Pth, err),
+		})
+		return result
+	}
+
+	projectDefinition.Name = proj.Name
+	projectDefinition.Pth = proj.Pth
+	projectDefinition.ConfigMap = proj.ConfigMap
+	if projectDefinition.ID == "" {
+		// SDK-style projects usually have no ProjectGuid, the solution's project id is used instead
+		projectDefinition.ID = projectID
+	}
+
+	result.proj = projectDefinition
+	return result
 }
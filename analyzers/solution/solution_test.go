@@ -1,13 +1,19 @@
 package solution
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf16"
 
 	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
 	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
 )
 
 func tmpSolutionWithContentInDir(t *testing.T, content, dir string) string {
@@ -370,6 +376,174 @@ func TestAnalyzeSolution(t *testing.T) {
 	}
 }
 
+func TestSolutionFolders(t *testing.T) {
+	pth := tmpSolutionWithContent(t, solutionFoldersSolutionContent)
+	defer func() {
+		require.NoError(t, os.Remove(pth))
+	}()
+
+	solution, err := analyzeSolution(pth, false)
+	require.NoError(t, err)
+
+	t.Log("it does not treat solution folders as projects")
+	{
+		require.Equal(t, "FAE04EC0-301F-11D3-BF4B-00C04F79EFBC", solution.ID)
+		require.Equal(t, 4, len(solution.ProjectMap))
+		require.Equal(t, 3, len(solution.FolderMap))
+		require.Equal(t, "Mobile", solution.FolderMap["5D0C7E9A-8F1B-4B2E-A1C3-7E6F5D4C3B21"].Name)
+	}
+
+	t.Log("it resolves folder paths")
+	{
+		require.Equal(t, "Apps/Mobile", solution.FolderPath("90F3C584-FD69-4926-9903-6B9771847782"))
+		require.Equal(t, "Apps", solution.FolderPath("9D1D32A3-D13F-4F23-B7D4-EF9D52B06E60"))
+		require.Equal(t, "Tests", solution.FolderPath("ba48743d-06f3-4d2d-acfd-ee2642ce155a"))
+		require.Equal(t, "", solution.FolderPath("99A825A6-6F99-4B94-9F65-E908A6347F1E"))
+		require.Equal(t, "Apps", solution.FolderPath("5D0C7E9A-8F1B-4B2E-A1C3-7E6F5D4C3B21"))
+	}
+
+	t.Log("it lists projects in folder")
+	{
+		projects := solution.ProjectsInFolder("Apps")
+		require.Equal(t, 2, len(projects))
+		require.Equal(t, "App.Droid", projects[0].Name)
+		require.Equal(t, "App.iOS", projects[1].Name)
+
+		projects = solution.ProjectsInFolder("Apps/Mobile/")
+		require.Equal(t, 1, len(projects))
+		require.Equal(t, "App.iOS", projects[0].Name)
+
+		projects = solution.ProjectsInFolder("App")
+		require.Equal(t, 0, len(projects))
+	}
+}
+
+func TestProjectDependencies(t *testing.T) {
+	pth := tmpSolutionWithContent(t, projectDependenciesSolutionContent)
+	defer func() {
+		require.NoError(t, os.Remove(pth))
+	}()
+
+	solution, err := analyzeSolution(pth, false)
+	require.NoError(t, err)
+
+	t.Log("it parses project dependencies")
+	{
+		require.Equal(t, []string{"6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21", "99A825A6-6F99-4B94-9F65-E908A6347F1E"}, solution.Dependencies("90F3C584-FD69-4926-9903-6B9771847782"))
+		require.Equal(t, []string{"99A825A6-6F99-4B94-9F65-E908A6347F1E"}, solution.Dependencies("6f1b6db4-7c0a-4f45-a4b5-9a4b4e1f3d21"))
+		require.Equal(t, []string{}, solution.Dependencies("99A825A6-6F99-4B94-9F65-E908A6347F1E"))
+	}
+
+	t.Log("it orders projects by dependencies")
+	{
+		order, err := solution.DependencyOrder()
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"99A825A6-6F99-4B94-9F65-E908A6347F1E",
+			"6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21",
+			"90F3C584-FD69-4926-9903-6B9771847782",
+			"BA48743D-06F3-4D2D-ACFD-EE2642CE155A",
+		}, order)
+	}
+
+	t.Log("it creates the dependency graph")
+	{
+		graph := solution.Graph()
+		require.Equal(t, []string{"App.Core", "App.Services", "App.UITests", "App.iOS"}, []string{graph.Nodes[0].Name, graph.Nodes[1].Name, graph.Nodes[2].Name, graph.Nodes[3].Name})
+		require.Equal(t, []GraphEdgeModel{
+			{From: "6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21", To: "99A825A6-6F99-4B94-9F65-E908A6347F1E"},
+			{From: "BA48743D-06F3-4D2D-ACFD-EE2642CE155A", To: "90F3C584-FD69-4926-9903-6B9771847782"},
+			{From: "90F3C584-FD69-4926-9903-6B9771847782", To: "6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21"},
+			{From: "90F3C584-FD69-4926-9903-6B9771847782", To: "99A825A6-6F99-4B94-9F65-E908A6347F1E"},
+		}, graph.Edges)
+
+		graph = graph.SetBuildOrder([]string{"99a825a6-6f99-4b94-9f65-e908a6347f1e", "90F3C584-FD69-4926-9903-6B9771847782"})
+		require.Equal(t, 1, graph.Nodes[0].BuildOrder)
+		require.Equal(t, 0, graph.Nodes[1].BuildOrder)
+		require.Equal(t, 2, graph.Nodes[3].BuildOrder)
+
+		require.Equal(t, `digraph "`+solution.Name+`" {
+	rankdir=LR;
+	node [shape=box];
+	"99A825A6-6F99-4B94-9F65-E908A6347F1E" [label="1. App.Core"];
+	"6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21" [label="App.Services", style=dashed];
+	"BA48743D-06F3-4D2D-ACFD-EE2642CE155A" [label="App.UITests", style=dashed];
+	"90F3C584-FD69-4926-9903-6B9771847782" [label="2. App.iOS"];
+	"6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21" -> "99A825A6-6F99-4B94-9F65-E908A6347F1E";
+	"BA48743D-06F3-4D2D-ACFD-EE2642CE155A" -> "90F3C584-FD69-4926-9903-6B9771847782";
+	"90F3C584-FD69-4926-9903-6B9771847782" -> "6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21";
+	"90F3C584-FD69-4926-9903-6B9771847782" -> "99A825A6-6F99-4B94-9F65-E908A6347F1E";
+}
+`, graph.DOT())
+
+		content, err := graph.JSON()
+		require.NoError(t, err)
+		require.Contains(t, string(content), `"build_order": 2`)
+	}
+
+	t.Log("it fails for dependency cycle")
+	{
+		solution.DependencyMap["99A825A6-6F99-4B94-9F65-E908A6347F1E"] = []string{"BA48743D-06F3-4D2D-ACFD-EE2642CE155A"}
+
+		order, err := solution.DependencyOrder()
+		require.Error(t, err)
+		require.Equal(t, 0, len(order))
+	}
+}
+
+func TestSharedProjects(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	pth := tmpSolutionWithContentInDir(t, sharedProjectSolutionContent, tmpDir)
+	for _, dir := range []string{"App.Shared", "App.iOS", "App.Droid"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0777))
+	}
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.Shared", "App.Shared.shproj"), sharedProjectContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.iOS", "App.iOS.csproj"), sharedProjectImportingProjectContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.Droid", "App.Droid.csproj"), strings.Replace(sharedProjectImportingProjectContent, "App.Shared.projitems", "Other.projitems", -1)))
+
+	solution, err := analyzeSolution(pth, true)
+	require.NoError(t, err)
+
+	t.Log("it classifies shared projects")
+	{
+		sharedProjects := solution.SharedProjects()
+		require.Equal(t, 1, len(sharedProjects))
+		require.Equal(t, "App.Shared", sharedProjects[0].Name)
+		require.Equal(t, constants.ProjectTypeShared, sharedProjects[0].ProjectType)
+		require.Equal(t, []string{filepath.Join(tmpDir, "App.Shared", "App.Shared.projitems")}, sharedProjects[0].SharedItemsPths)
+	}
+
+	t.Log("it lists the projects importing the shared project")
+	{
+		projects := solution.ProjectsImportingSharedProject("6f1b6db4-7c0a-4f45-a4b5-9a4b4e1f3d21")
+		require.Equal(t, 1, len(projects))
+		require.Equal(t, "App.iOS", projects[0].Name)
+	}
+
+	t.Log("it returns empty list for not shared project")
+	{
+		projects := solution.ProjectsImportingSharedProject("90F3C584-FD69-4926-9903-6B9771847782")
+		require.Equal(t, 0, len(projects))
+	}
+
+	t.Log("it loads the projects concurrently, with the same result as the serial load")
+	{
+		serial, err := NewAnalyzer().SetProjectLoadParallelism(1).New(pth, true)
+		require.NoError(t, err)
+
+		concurrent, err := NewAnalyzer().SetProjectLoadParallelism(3).New(pth, true)
+		require.NoError(t, err)
+
+		require.Equal(t, serial.ProjectMap, concurrent.ProjectMap)
+		require.Equal(t, solution.ProjectMap, concurrent.ProjectMap)
+	}
+}
+
 func TestAnalyzePCLSolution(t *testing.T) {
 	t.Log("android test")
 	{
@@ -482,3 +656,314 @@ func TestAnalyzePCLSolution(t *testing.T) {
 		}
 	}
 }
+
+func TestOutputModel(t *testing.T) {
+	pth := tmpSolutionWithContent(t, solutionFoldersSolutionContent)
+	defer func() {
+		require.NoError(t, os.Remove(pth))
+	}()
+
+	solution, err := analyzeSolution(pth, false)
+	require.NoError(t, err)
+
+	t.Log("it sorts projects by name and folders by path")
+	{
+		output := solution.OutputModel()
+		require.Equal(t, OutputSchemaVersion, output.SchemaVersion)
+		require.Equal(t, 4, len(output.Projects))
+		require.Equal(t, "App.Core", output.Projects[0].Name)
+		require.Equal(t, "App.Droid", output.Projects[1].Name)
+
+		require.Equal(t, 3, len(output.Folders))
+		require.Equal(t, "Apps", output.Folders[0].Pth)
+		require.Equal(t, "Apps/Mobile", output.Folders[1].Pth)
+		require.Equal(t, "Tests", output.Folders[2].Pth)
+	}
+
+	t.Log("it marshals to stable json")
+	{
+		first, err := json.Marshal(solution)
+		require.NoError(t, err)
+		second, err := json.Marshal(solution)
+		require.NoError(t, err)
+		require.Equal(t, string(first), string(second))
+		require.True(t, strings.Contains(string(first), `"schema_version":1`))
+		require.True(t, strings.Contains(string(first), `"name":"App.Droid"`))
+	}
+
+	t.Log("it marshals to yaml")
+	{
+		serBytes, err := yaml.Marshal(solution)
+		require.NoError(t, err)
+		require.True(t, strings.Contains(string(serBytes), "schema_version: 1"))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Log("it validates config mappings and project ids")
+	{
+		pth := tmpSolutionWithContent(t, validateSolutionContent)
+		defer func() {
+			require.NoError(t, os.Remove(pth))
+		}()
+
+		solution, err := analyzeSolution(pth, false)
+		require.NoError(t, err)
+
+		findings := solution.Validate()
+		require.Equal(t, 2, len(findings))
+
+		require.Equal(t, FindingBuildDisabled, findings[0].Code)
+		require.Equal(t, FindingSeverityWarning, findings[0].Severity)
+		require.Equal(t, "BA48743D-06F3-4D2D-ACFD-EE2642CE155A", findings[0].ProjectID)
+		require.Equal(t, "Release|Any CPU", findings[0].SolutionConfig)
+
+		require.Equal(t, FindingDuplicateProjectID, findings[1].Code)
+		require.Equal(t, FindingSeverityError, findings[1].Severity)
+	}
+
+	t.Log("it validates android signing of the loaded projects")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(tmpDir))
+		}()
+
+		pth := tmpSolutionWithContentInDir(t, validateSolutionContent, tmpDir)
+		for _, dir := range []string{"App.Droid", "App.UITests", "App.UITests.Copy"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0777))
+		}
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.Droid", "App.Droid.csproj"), validateAndroidProjectContent))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.UITests", "App.UITests.csproj"), sharedProjectImportingProjectContent))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.UITests.Copy", "App.UITests.csproj"), sharedProjectImportingProjectContent))
+
+		solution, err := analyzeSolution(pth, true)
+		require.NoError(t, err)
+
+		findings := solution.Validate()
+		require.Equal(t, 4, len(findings))
+
+		require.Equal(t, FindingDebugSymbolInRelease, findings[0].Code)
+		require.Equal(t, "Release|AnyCPU", findings[0].ProjectConfig)
+
+		require.Equal(t, FindingMissingAndroidSigning, findings[1].Code)
+		require.Equal(t, "App.Droid", findings[1].ProjectName)
+		require.Equal(t, "Release|AnyCPU", findings[1].ProjectConfig)
+
+		require.Equal(t, FindingBuildDisabled, findings[2].Code)
+		require.Equal(t, FindingDuplicateProjectID, findings[3].Code)
+	}
+}
+
+func TestNewLenient(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	pth := tmpSolutionWithContentInDir(t, sharedProjectSolutionContent, tmpDir)
+	for _, dir := range []string{"App.iOS", "App.Droid"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0777))
+	}
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.iOS", "App.iOS.csproj"), sharedProjectImportingProjectContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.Droid", "App.Droid.csproj"), strings.Replace(sharedProjectImportingProjectContent, "</Project>", "", -1)))
+
+	t.Log("it fails on missing projects by default")
+	{
+		_, err := New(pth, true)
+		require.Error(t, err)
+	}
+
+	t.Log("it collects project problems in lenient mode")
+	{
+		solution, err := NewLenient(pth, true)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(solution.ProjectMap))
+
+		require.Equal(t, 2, len(solution.Warnings))
+		require.Equal(t, "App.Droid", solution.Warnings[0].ProjectName)
+		require.Equal(t, ProjectWarningReasonMalformed, solution.Warnings[0].Reason)
+		require.Equal(t, "App.Shared", solution.Warnings[1].ProjectName)
+		require.Equal(t, ProjectWarningReasonMissing, solution.Warnings[1].Reason)
+	}
+}
+
+func utf16LEContent(content string) []byte {
+	bytes := []byte{0xFF, 0xFE}
+	for _, codeUnit := range utf16.Encode([]rune(content)) {
+		bytes = append(bytes, byte(codeUnit), byte(codeUnit>>8))
+	}
+	return bytes
+}
+
+func TestSolutionEncodings(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	pth := filepath.Join(tmpDir, "solution.sln")
+
+	t.Log("utf-8 bom and crlf line endings")
+	{
+		content := "\xEF\xBB\xBF" + strings.Replace(macIDTestSolutionContent, "\n", "\r\n", -1)
+		require.NoError(t, fileutil.WriteStringToFile(pth, content))
+
+		solution, err := analyzeSolution(pth, false)
+		require.NoError(t, err)
+		require.Equal(t, "FAE04EC0-301F-11D3-BF4B-00C04F79EFBC", solution.ID)
+		_, ok := solution.ProjectMap["4DA5EAC6-6F80-4FEC-AF81-194210F10B51"]
+		require.Equal(t, true, ok)
+	}
+
+	t.Log("utf-16 and cr line endings")
+	{
+		content := utf16LEContent(strings.Replace(macIDTestSolutionContent, "\n", "\r", -1))
+		require.NoError(t, fileutil.WriteBytesToFile(pth, content))
+
+		solution, err := analyzeSolution(pth, false)
+		require.NoError(t, err)
+		require.Equal(t, "FAE04EC0-301F-11D3-BF4B-00C04F79EFBC", solution.ID)
+		_, ok := solution.ProjectMap["4DA5EAC6-6F80-4FEC-AF81-194210F10B51"]
+		require.Equal(t, true, ok)
+	}
+}
+
+func TestReloadAndWatch(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	pth := tmpSolutionWithContentInDir(t, macIDTestSolutionContent, tmpDir)
+
+	solution, err := New(pth, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(tmpDir, "Hello_Mac", "Hello_Mac.csproj"),
+		filepath.Join(tmpDir, "Hello_Mac", "packages.config"),
+		pth,
+	}, solution.WatchedFiles())
+
+	t.Log("it keeps the model on failed reload")
+	{
+		require.NoError(t, os.Remove(pth))
+		require.Error(t, solution.Reload())
+		_, ok := solution.ProjectMap["4DA5EAC6-6F80-4FEC-AF81-194210F10B51"]
+		require.Equal(t, true, ok)
+	}
+
+	t.Log("it reloads the solution")
+	{
+		content := strings.Replace(macIDTestSolutionContent, "4da5eac6-6f80-4fec-af81-194210f10b51", "0c4d8a3e-53b3-4c2c-9c4f-7a1d2f1c3b5e", -1)
+		tmpSolutionWithContentInDir(t, content, tmpDir)
+
+		require.NoError(t, solution.Reload())
+		_, ok := solution.ProjectMap["0C4D8A3E-53B3-4C2C-9C4F-7A1D2F1C3B5E"]
+		require.Equal(t, true, ok)
+	}
+
+	t.Log("the watcher reloads on change")
+	{
+		watcher := NewWatcher(solution, time.Second)
+
+		reloaded, err := watcher.Poll()
+		require.NoError(t, err)
+		require.Equal(t, false, reloaded)
+
+		tmpSolutionWithContentInDir(t, macIDTestSolutionContent+"\n", tmpDir)
+
+		reloaded, err = watcher.Poll()
+		require.NoError(t, err)
+		require.Equal(t, true, reloaded)
+		_, ok := watcher.Model().ProjectMap["4DA5EAC6-6F80-4FEC-AF81-194210F10B51"]
+		require.Equal(t, true, ok)
+	}
+}
+
+func TestXamarinForms(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("__xamarin-builder-test__")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	pth := tmpSolutionWithContentInDir(t, formsSolutionContent, tmpDir)
+	for _, dir := range []string{"App", "App.Droid"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, dir), 0777))
+	}
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App", "App.csproj"), formsProjectContent))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App.Droid", "App.Droid.csproj"), validateAndroidProjectContent))
+
+	t.Log("it detects xamarin forms and the head projects")
+	{
+		solution, err := New(pth, true)
+		require.NoError(t, err)
+		require.Equal(t, true, solution.UsesXamarinForms())
+
+		headProjects := solution.HeadProjects()
+		require.Equal(t, 1, len(headProjects))
+		require.Equal(t, 1, len(headProjects[constants.SDKAndroid]))
+		require.Equal(t, "App.Droid", headProjects[constants.SDKAndroid][0].Name)
+	}
+
+	t.Log("traditional app")
+	{
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "App", "App.csproj"), strings.Replace(formsProjectContent, "Xamarin.Forms", "Newtonsoft.Json", -1)))
+
+		solution, err := New(pth, true)
+		require.NoError(t, err)
+		require.Equal(t, false, solution.UsesXamarinForms())
+	}
+}
+
+func TestConfigurationPlatforms(t *testing.T) {
+	content := strings.Replace(validateSolutionContent,
+		"{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Release|Any CPU.Build.0 = Release|Any CPU",
+		"{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Release|Any CPU.Build.0 = Release|Any CPU\n\t\t{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Release|Any CPU.Deploy.0 = Release|Any CPU", -1)
+	pth := tmpSolutionWithContent(t, content)
+	defer func() {
+		require.NoError(t, os.Remove(pth))
+	}()
+
+	solution, err := analyzeSolution(pth, false)
+	require.NoError(t, err)
+
+	configurationPlatforms := solution.ConfigurationPlatforms()
+	require.Equal(t, 2, len(configurationPlatforms))
+
+	t.Log("debug config")
+	{
+		debug := configurationPlatforms[0]
+		require.Equal(t, "Debug", debug.Configuration)
+		require.Equal(t, "Any CPU", debug.Platform)
+		require.Equal(t, "Debug|Any CPU", debug.String())
+
+		require.Equal(t, 2, len(debug.Projects))
+		require.Equal(t, "App.Droid", debug.Projects[0].ProjectName)
+		require.Equal(t, "Debug|AnyCPU", debug.Projects[0].String())
+		require.Equal(t, true, debug.Projects[0].BuildEnabled)
+		require.Equal(t, false, debug.Projects[0].DeployEnabled)
+		require.Equal(t, true, debug.Projects[1].BuildEnabled)
+	}
+
+	t.Log("release config")
+	{
+		release := configurationPlatforms[1]
+		require.Equal(t, "Release|Any CPU", release.String())
+
+		require.Equal(t, 2, len(release.Projects))
+		require.Equal(t, "A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11", release.Projects[0].ProjectID)
+		require.Equal(t, true, release.Projects[0].BuildEnabled)
+		require.Equal(t, true, release.Projects[0].DeployEnabled)
+
+		require.Equal(t, "BA48743D-06F3-4D2D-ACFD-EE2642CE155A", release.Projects[1].ProjectID)
+		require.Equal(t, "Release", release.Projects[1].Configuration)
+		require.Equal(t, "AnyCPU", release.Projects[1].Platform)
+		require.Equal(t, false, release.Projects[1].BuildEnabled)
+	}
+}
@@ -366,3 +366,165 @@ Global
 	EndGlobalSection
 EndGlobal
 `
+
+const sharedProjectSolutionContent = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+# Visual Studio 14
+Project("{D954291E-2A0B-460D-934E-DC6B0785DB48}") = "App.Shared", "App.Shared\App.Shared.shproj", "{6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.iOS", "App.iOS\App.iOS.csproj", "{90F3C584-FD69-4926-9903-6B9771847782}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Droid", "App.Droid\App.Droid.csproj", "{9D1D32A3-D13F-4F23-B7D4-EF9D52B06E60}"
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+	EndGlobalSection
+EndGlobal
+`
+
+const sharedProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project ToolsVersion="14.0" DefaultTargets="Build" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup Label="Globals">
+    <ProjectGuid>{6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21}</ProjectGuid>
+  </PropertyGroup>
+  <Import Project="$(MSBuildExtensionsPath32)\Microsoft\VisualStudio\v$(VisualStudioVersion)\CodeSharing\Microsoft.CodeSharing.Common.Default.props" />
+  <Import Project="App.Shared.projitems" Label="Shared" />
+  <Import Project="$(MSBuildExtensionsPath32)\Microsoft\VisualStudio\v$(VisualStudioVersion)\CodeSharing\Microsoft.CodeSharing.CSharp.targets" />
+</Project>`
+
+const sharedProjectImportingProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+  </PropertyGroup>
+  <Import Project="..\App.Shared\App.Shared.projitems" Label="Shared" Condition="Exists('..\App.Shared\App.Shared.projitems')" />
+</Project>`
+
+const solutionFoldersSolutionContent = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+# Visual Studio 14
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.iOS", "App.iOS\App.iOS.csproj", "{90F3C584-FD69-4926-9903-6B9771847782}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Droid", "App.Droid\App.Droid.csproj", "{9D1D32A3-D13F-4F23-B7D4-EF9D52B06E60}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.UITests", "App.UITests\App.UITests.csproj", "{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Core", "App.Core\App.Core.csproj", "{99A825A6-6F99-4B94-9F65-E908A6347F1E}"
+EndProject
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "Apps", "Apps", "{3B2A5E0C-2C7F-4C5A-9E0B-6C9E1B5D7A10}"
+EndProject
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "Mobile", "Mobile", "{5D0C7E9A-8F1B-4B2E-A1C3-7E6F5D4C3B21}"
+EndProject
+Project("{2150E333-8FDC-42A3-9474-1A3956D46DE8}") = "Tests", "Tests", "{7F3E2D1C-0B9A-4876-9543-21FEDCBA9876}"
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+	EndGlobalSection
+	GlobalSection(NestedProjects) = preSolution
+		{5D0C7E9A-8F1B-4B2E-A1C3-7E6F5D4C3B21} = {3B2A5E0C-2C7F-4C5A-9E0B-6C9E1B5D7A10}
+		{90F3C584-FD69-4926-9903-6B9771847782} = {5d0c7e9a-8f1b-4b2e-a1c3-7e6f5d4c3b21}
+		{9D1D32A3-D13F-4F23-B7D4-EF9D52B06E60} = {3B2A5E0C-2C7F-4C5A-9E0B-6C9E1B5D7A10}
+		{BA48743D-06F3-4D2D-ACFD-EE2642CE155A} = {7F3E2D1C-0B9A-4876-9543-21FEDCBA9876}
+	EndGlobalSection
+EndGlobal
+`
+
+const projectDependenciesSolutionContent = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+# Visual Studio 14
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.iOS", "App.iOS\App.iOS.csproj", "{90F3C584-FD69-4926-9903-6B9771847782}"
+	ProjectSection(ProjectDependencies) = postProject
+		{99A825A6-6F99-4B94-9F65-E908A6347F1E} = {99A825A6-6F99-4B94-9F65-E908A6347F1E}
+		{6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21} = {6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21}
+	EndProjectSection
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.UITests", "App.UITests\App.UITests.csproj", "{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}"
+	ProjectSection(ProjectDependencies) = postProject
+		{90F3C584-FD69-4926-9903-6B9771847782} = {90F3C584-FD69-4926-9903-6B9771847782}
+	EndProjectSection
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Core", "App.Core\App.Core.csproj", "{99A825A6-6F99-4B94-9F65-E908A6347F1E}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Services", "App.Services\App.Services.csproj", "{6F1B6DB4-7C0A-4F45-A4B5-9A4B4E1F3D21}"
+	ProjectSection(ProjectDependencies) = postProject
+		{99a825a6-6f99-4b94-9f65-e908a6347f1e} = {99a825a6-6f99-4b94-9f65-e908a6347f1e}
+	EndProjectSection
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+	EndGlobalSection
+EndGlobal
+`
+
+const validateSolutionContent = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Droid", "App.Droid\App.Droid.csproj", "{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.UITests", "App.UITests\App.UITests.csproj", "{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.UITests.Copy", "App.UITests.Copy\App.UITests.csproj", "{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}"
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+		Release|Any CPU = Release|Any CPU
+	EndGlobalSection
+	GlobalSection(ProjectConfigurationPlatforms) = postSolution
+		{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Debug|Any CPU.ActiveCfg = Debug|Any CPU
+		{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Debug|Any CPU.Build.0 = Debug|Any CPU
+		{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Release|Any CPU.ActiveCfg = Release|Any CPU
+		{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Release|Any CPU.Build.0 = Release|Any CPU
+		{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}.Debug|Any CPU.ActiveCfg = Debug|Any CPU
+		{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}.Debug|Any CPU.Build.0 = Debug|Any CPU
+		{BA48743D-06F3-4D2D-ACFD-EE2642CE155A}.Release|Any CPU.ActiveCfg = Release|Any CPU
+	EndGlobalSection
+EndGlobal
+`
+
+const validateAndroidProjectContent = `<?xml version="1.0" encoding="utf-8"?>
+<Project DefaultTargets="Build" ToolsVersion="4.0" xmlns="http://schemas.microsoft.com/developer/msbuild/2003">
+  <PropertyGroup>
+    <ProjectTypeGuids>{EFBA0AD7-5A72-4C68-AF49-83D382785DCF};{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}</ProjectTypeGuids>
+    <ProjectGuid>{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}</ProjectGuid>
+    <OutputType>Library</OutputType>
+    <AndroidApplication>True</AndroidApplication>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Debug|AnyCPU' ">
+    <OutputPath>bin\Debug</OutputPath>
+  </PropertyGroup>
+  <PropertyGroup Condition=" '$(Configuration)|$(Platform)' == 'Release|AnyCPU' ">
+    <OutputPath>bin\Release</OutputPath>
+    <DefineConstants>DEBUG;TRACE</DefineConstants>
+  </PropertyGroup>
+</Project>`
+
+const formsSolutionContent = `
+Microsoft Visual Studio Solution File, Format Version 12.00
+Project("{9A19103F-16F7-4668-BE54-9A1E7A4F7556}") = "App", "App\App.csproj", "{5E1B7A1C-1D2E-4F3A-8B4C-6D7E8F9A0B1C}"
+EndProject
+Project("{FAE04EC0-301F-11D3-BF4B-00C04F79EFBC}") = "App.Droid", "App.Droid\App.Droid.csproj", "{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}"
+EndProject
+Global
+	GlobalSection(SolutionConfigurationPlatforms) = preSolution
+		Debug|Any CPU = Debug|Any CPU
+	EndGlobalSection
+	GlobalSection(ProjectConfigurationPlatforms) = postSolution
+		{5E1B7A1C-1D2E-4F3A-8B4C-6D7E8F9A0B1C}.Debug|Any CPU.ActiveCfg = Debug|Any CPU
+		{5E1B7A1C-1D2E-4F3A-8B4C-6D7E8F9A0B1C}.Debug|Any CPU.Build.0 = Debug|Any CPU
+		{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Debug|Any CPU.ActiveCfg = Debug|Any CPU
+		{A2B2D3D6-4C9E-4B07-9D7F-8D2C6E3A6B11}.Debug|Any CPU.Build.0 = Debug|Any CPU
+	EndGlobalSection
+EndGlobal
+`
+
+const formsProjectContent = `<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>netstandard2.0</TargetFramework>
+  </PropertyGroup>
+  <ItemGroup>
+    <PackageReference Include="Xamarin.Forms" Version="5.0.0.2012" />
+  </ItemGroup>
+</Project>`
@@ -0,0 +1,245 @@
+package solution
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+	"github.com/bitrise-tools/go-xamarin/constants"
+)
+
+// FindingSeverity ...
+type FindingSeverity string
+
+const (
+	// FindingSeverityError - the solution can not be built as expected
+	FindingSeverityError FindingSeverity = "error"
+	// FindingSeverityWarning - the solution can be built, but probably not as expected
+	FindingSeverityWarning FindingSeverity = "warning"
+)
+
+// FindingCode ...
+type FindingCode string
+
+const (
+	// FindingMissingProjectMapping - the project has no configuration mapping for a solution configuration
+	FindingMissingProjectMapping FindingCode = "missing-project-mapping"
+	// FindingBuildDisabled - the project is not built (Build.0) in a solution configuration
+	FindingBuildDisabled FindingCode = "build-disabled"
+	// FindingMissingDeviceArchitecture - iOS Release configuration without device (arm) architecture
+	FindingMissingDeviceArchitecture FindingCode = "missing-device-architecture"
+	// FindingMissingAndroidSigning - Android Release configuration without signing
+	FindingMissingAndroidSigning FindingCode = "missing-android-signing"
+	// FindingDebugSymbolInRelease - Release configuration defining the DEBUG symbol
+	FindingDebugSymbolInRelease FindingCode = "debug-symbol-in-release"
+	// FindingDuplicateProjectID - more projects with the same project guid
+	FindingDuplicateProjectID FindingCode = "duplicate-project-id"
+)
+
+// Finding - a misconfiguration found in the solution
+type Finding struct {
+	Severity       FindingSeverity
+	Code           FindingCode
+	ProjectID      string
+	ProjectName    string
+	SolutionConfig string // Solution Configuration|Platform, if the finding is configuration specific
+	ProjectConfig  string // Project Configuration|Platform, if the finding is configuration specific
+	Message        string
+}
+
+// Validate - checks the solution for common misconfigurations, findings are sorted by project name.
+// Project level checks require the solution to be analyzed with loadProjects.
+func (solution Model) Validate() []Finding {
+	findings := []Finding{}
+
+	projects := []project.Model{}
+	for _, proj := range solution.ProjectMap {
+		projects = append(projects, proj)
+	}
+	sortProjectsByName(projects)
+
+	for _, proj := range projects {
+		if proj.ProjectType == constants.ProjectTypeShared {
+			continue
+		}
+
+		findings = append(findings, solution.validateConfigMappings(proj)...)
+		findings = append(findings, validateProjectConfigs(proj)...)
+	}
+
+	findings = append(findings, solution.validateProjectIDs()...)
+
+	return findings
+}
+
+func (solution Model) validateConfigMappings(proj project.Model) []Finding {
+	findings := []Finding{}
+
+	activeConfigs := solution.activeConfigMap[proj.ID]
+
+	solutionConfigs := solution.ConfigList()
+	sort.Strings(solutionConfigs)
+
+	for _, solutionConfig := range solutionConfigs {
+		if _, ok := proj.ConfigMap[solutionConfig]; ok {
+			continue
+		}
+
+		if _, ok := activeConfigs[solutionConfig]; ok {
+			findings = append(findings, Finding{
+				Severity:       FindingSeverityWarning,
+				Code:           FindingBuildDisabled,
+				ProjectID:      proj.ID,
+				ProjectName:    proj.Name,
+				SolutionConfig: solutionConfig,
+				Message:        fmt.Sprintf("project (%s) is not built in solution config (%s)", proj.Name, solutionConfig),
+			})
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Severity:       FindingSeverityError,
+			Code:           FindingMissingProjectMapping,
+			ProjectID:      proj.ID,
+			ProjectName:    proj.Name,
+			SolutionConfig: solutionConfig,
+			Message:        fmt.Sprintf("project (%s) does not have config mapping for solution config (%s)", proj.Name, solutionConfig),
+		})
+	}
+
+	return findings
+}
+
+func isReleaseConfiguration(configuration string) bool {
+	return strings.Contains(strings.ToLower(configuration), "release")
+}
+
+func hasDeviceArchitecture(architectures []string) bool {
+	// default is armv7
+	if len(architectures) == 0 {
+		return true
+	}
+
+	for _, arch := range architectures {
+		if strings.HasPrefix(strings.ToLower(arch), "arm") {
+			return true
+		}
+	}
+	return false
+}
+
+func validateProjectConfigs(proj project.Model) []Finding {
+	findings := []Finding{}
+
+	for _, projectConfig := range sortedConfigKeys(proj.Configs) {
+		config := proj.Configs[projectConfig]
+		if !isReleaseConfiguration(config.Configuration) {
+			continue
+		}
+
+		if config.HasDefineConstant("DEBUG") {
+			findings = append(findings, Finding{
+				Severity:      FindingSeverityWarning,
+				Code:          FindingDebugSymbolInRelease,
+				ProjectID:     proj.ID,
+				ProjectName:   proj.Name,
+				ProjectConfig: projectConfig,
+				Message:       fmt.Sprintf("project (%s) config (%s) defines the DEBUG symbol", proj.Name, projectConfig),
+			})
+		}
+
+		switch proj.SDK {
+		case constants.SDKIOS:
+			if config.Platform != "iPhone" || proj.OutputType != "exe" || hasDeviceArchitecture(config.MtouchArchs) {
+				continue
+			}
+
+			findings = append(findings, Finding{
+				Severity:      FindingSeverityWarning,
+				Code:          FindingMissingDeviceArchitecture,
+				ProjectID:     proj.ID,
+				ProjectName:   proj.Name,
+				ProjectConfig: projectConfig,
+				Message:       fmt.Sprintf("project (%s) config (%s) does not build for device architecture, MtouchArch: %s", proj.Name, projectConfig, strings.Join(config.MtouchArchs, ",")),
+			})
+		case constants.SDKAndroid:
+			if !proj.AndroidApplication {
+				continue
+			}
+
+			if !config.SignAndroid {
+				findings = append(findings, Finding{
+					Severity:      FindingSeverityWarning,
+					Code:          FindingMissingAndroidSigning,
+					ProjectID:     proj.ID,
+					ProjectName:   proj.Name,
+					ProjectConfig: projectConfig,
+					Message:       fmt.Sprintf("project (%s) config (%s) does not sign the android package", proj.Name, projectConfig),
+				})
+			} else if missing := config.MissingAndroidSigningProperties(); len(missing) > 0 {
+				findings = append(findings, Finding{
+					Severity:      FindingSeverityWarning,
+					Code:          FindingMissingAndroidSigning,
+					ProjectID:     proj.ID,
+					ProjectName:   proj.Name,
+					ProjectConfig: projectConfig,
+					Message:       fmt.Sprintf("project (%s) config (%s) signs the android package, but keystore properties are not set: %s", proj.Name, projectConfig, strings.Join(missing, ", ")),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+func (solution Model) validateProjectIDs() []Finding {
+	findings := []Finding{}
+
+	for _, projectID := range solution.duplicateProjectIDs {
+		findings = append(findings, Finding{
+			Severity:  FindingSeverityError,
+			Code:      FindingDuplicateProjectID,
+			ProjectID: projectID,
+			Message:   fmt.Sprintf("project id (%s) is listed more than once in the solution", projectID),
+		})
+	}
+
+	// Projects copied without regenerating their ProjectGuid
+	projectNamesByID := map[string][]string{}
+	for _, proj := range solution.ProjectMap {
+		projectNamesByID[proj.ID] = append(projectNamesByID[proj.ID], proj.Name)
+	}
+
+	projectIDs := []string{}
+	for projectID := range projectNamesByID {
+		projectIDs = append(projectIDs, projectID)
+	}
+	sort.Strings(projectIDs)
+
+	for _, projectID := range projectIDs {
+		names := projectNamesByID[projectID]
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+
+		findings = append(findings, Finding{
+			Severity:  FindingSeverityError,
+			Code:      FindingDuplicateProjectID,
+			ProjectID: projectID,
+			Message:   fmt.Sprintf("projects (%s) have the same project id (%s)", strings.Join(names, ", "), projectID),
+		})
+	}
+
+	return findings
+}
+
+func sortedConfigKeys(configs map[string]project.ConfigurationPlatformModel) []string {
+	keys := []string{}
+	for key := range configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,185 @@
+package solution
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reload - re-analyzes the solution the same way as it was created by New or NewLenient.
+// The model is left untouched if the analysis fails.
+func (solution *Model) Reload() error {
+	var reloaded Model
+	var err error
+
+	if solution.lenient {
+		reloaded, err = solution.analyzer.NewLenient(solution.Pth, solution.projectsLoaded)
+	} else {
+		reloaded, err = solution.analyzer.New(solution.Pth, solution.projectsLoaded)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reload solution (%s), error: %s", solution.Pth, err)
+	}
+
+	*solution = reloaded
+	return nil
+}
+
+// WatchedFiles - returns the files the solution model depends on:
+// the solution, the projects and their imports, shared items, packages.config and AndroidManifest files
+func (solution Model) WatchedFiles() []string {
+	pthMap := map[string]bool{solution.Pth: true}
+
+	for _, proj := range solution.ProjectMap {
+		pthMap[proj.Pth] = true
+		pthMap[filepath.Join(filepath.Dir(proj.Pth), "packages.config")] = true
+
+		for _, pth := range proj.Imports {
+			pthMap[pth] = true
+		}
+		for _, pth := range proj.SharedItemsPths {
+			pthMap[pth] = true
+		}
+		if proj.ManifestPth != "" {
+			pthMap[proj.ManifestPth] = true
+		}
+	}
+
+	pths := []string{}
+	for pth := range pthMap {
+		pths = append(pths, pth)
+	}
+	sort.Strings(pths)
+	return pths
+}
+
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func (state fileState) equal(other fileState) bool {
+	return state.exists == other.exists && state.size == other.size && state.modTime.Equal(other.modTime)
+}
+
+func snapshotFiles(pths []string) map[string]fileState {
+	snapshot := map[string]fileState{}
+	for _, pth := range pths {
+		info, err := os.Stat(pth)
+		if err != nil {
+			snapshot[pth] = fileState{}
+			continue
+		}
+		snapshot[pth] = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+	}
+	return snapshot
+}
+
+// Watcher - polls the files of a solution and reloads the solution model if any of them changes
+type Watcher struct {
+	interval time.Duration
+
+	mutex    sync.Mutex
+	solution Model
+	snapshot map[string]fileState
+
+	stop chan bool
+	done chan bool
+}
+
+// NewWatcher ...
+func NewWatcher(solution Model, interval time.Duration) *Watcher {
+	return &Watcher{
+		interval: interval,
+		solution: solution,
+		snapshot: snapshotFiles(solution.WatchedFiles()),
+	}
+}
+
+// Model - returns the up to date solution model
+func (watcher *Watcher) Model() Model {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	return watcher.solution
+}
+
+// Poll - reloads the solution, if any of its files changed since the last poll, returns true if the solution was reloaded.
+// If the reload fails, the previous model is kept and the next change triggers a new reload.
+func (watcher *Watcher) Poll() (bool, error) {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	snapshot := snapshotFiles(watcher.solution.WatchedFiles())
+	if !snapshotChanged(watcher.snapshot, snapshot) {
+		return false, nil
+	}
+	watcher.snapshot = snapshot
+
+	solution := watcher.solution
+	if err := solution.Reload(); err != nil {
+		return false, err
+	}
+
+	watcher.solution = solution
+	// the reloaded solution may depend on new files
+	watcher.snapshot = snapshotFiles(solution.WatchedFiles())
+
+	return true, nil
+}
+
+// Start - polls the solution files periodically in the background,
+// onReload is called after every reload attempt, with the reloaded model or the reload error
+func (watcher *Watcher) Start(onReload func(solution Model, err error)) {
+	watcher.stop = make(chan bool)
+	watcher.done = make(chan bool)
+
+	go func() {
+		defer close(watcher.done)
+
+		ticker := time.NewTicker(watcher.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-watcher.stop:
+				return
+			case <-ticker.C:
+				reloaded, err := watcher.Poll()
+				if (reloaded || err != nil) && onReload != nil {
+					onReload(watcher.Model(), err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop - stops the background polling started by Start
+func (watcher *Watcher) Stop() {
+	if watcher.stop == nil {
+		return
+	}
+
+	close(watcher.stop)
+	<-watcher.done
+	watcher.stop = nil
+}
+
+func snapshotChanged(previous, current map[string]fileState) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+
+	for pth, state := range current {
+		previousState, ok := previous[pth]
+		if !ok || !previousState.equal(state) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// SetAndroidSupportedABIs - Android projects are built for the given ABIs (AndroidSupportedAbis),
+// overriding the ABIs of the project files
+func (builder *Model) SetAndroidSupportedABIs(abis ...string) *Model {
+	builder.androidSupportedABIs = abis
+	return builder
+}
+
+// SetAndroidCreatePackagePerABI - Android projects create a separate apk per ABI (AndroidCreatePackagePerAbi) or a single apk,
+// overriding the project files
+func (builder *Model) SetAndroidCreatePackagePerABI(createPackagePerABI bool) *Model {
+	builder.androidCreatePackagePerABI = &createPackagePerABI
+	return builder
+}
+
+// setAndroidABIProperties sets the ABI overrides and returns warnings for the unknown ABIs
+func (builder Model) setAndroidABIProperties(command *xbuild.Model) []string {
+	warnings := []string{}
+
+	if len(builder.androidSupportedABIs) > 0 {
+		for _, abi := range builder.androidSupportedABIs {
+			if !isKnownAndroidABI(abi) {
+				warnings = append(warnings, fmt.Sprintf("unknown android ABI: %s, supported ABIs: %s", abi, strings.Join(androidABIs, ", ")))
+			}
+		}
+		command.SetProperty("AndroidSupportedAbis", strings.Join(builder.androidSupportedABIs, ";"))
+	}
+
+	if builder.androidCreatePackagePerABI != nil {
+		command.SetProperty("AndroidCreatePackagePerAbi", strconv.FormatBool(*builder.androidCreatePackagePerABI))
+	}
+
+	return warnings
+}
+
+// createsPackagePerABI - whether the project config creates a separate apk per ABI, considering the builder's override
+func (builder Model) createsPackagePerABI(projectConfig project.ConfigurationPlatformModel) bool {
+	if builder.androidCreatePackagePerABI != nil {
+		return *builder.androidCreatePackagePerABI
+	}
+	return projectConfig.AndroidCreatePackagePerAbi
+}
+
+func isKnownAndroidABI(abi string) bool {
+	for _, knownABI := range androidABIs {
+		if strings.EqualFold(abi, knownABI) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAndroidABIOverride(t *testing.T) {
+	t.Log("it keeps the project values by default")
+	{
+		builder := Model{}
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{}, builder.setAndroidABIProperties(command))
+		require.NotContains(t, command.PrintableCommand(), "AndroidSupportedAbis")
+		require.NotContains(t, command.PrintableCommand(), "AndroidCreatePackagePerAbi")
+		require.Equal(t, true, builder.createsPackagePerABI(project.ConfigurationPlatformModel{AndroidCreatePackagePerAbi: true}))
+	}
+
+	t.Log("it overrides the ABIs and the package per ABI")
+	{
+		builder := Model{}
+		builder.SetAndroidSupportedABIs("arm64-v8a", "x86_64").SetAndroidCreatePackagePerABI(false)
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{}, builder.setAndroidABIProperties(command))
+		require.Contains(t, command.PrintableCommand(), `"/p:AndroidSupportedAbis=arm64-v8a;x86_64"`)
+		require.Contains(t, command.PrintableCommand(), `"/p:AndroidCreatePackagePerAbi=false"`)
+		require.Equal(t, false, builder.createsPackagePerABI(project.ConfigurationPlatformModel{AndroidCreatePackagePerAbi: true}))
+	}
+
+	t.Log("it warns for unknown ABIs")
+	{
+		builder := Model{}
+		builder.SetAndroidSupportedABIs("arm64")
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		require.Equal(t, 1, len(builder.setAndroidABIProperties(command)))
+	}
+}
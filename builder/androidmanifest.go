@@ -0,0 +1,118 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+const androidNamespaceURI = "http://schemas.android.com/apk/res/android"
+
+var (
+	androidNamespacePattern = regexp.MustCompile(`xmlns:(\w+)\s*=\s*["']` + regexp.QuoteMeta(androidNamespaceURI) + `["']`)
+	xmlAttributeEscaper     = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;")
+)
+
+// androidManifestPatch - modifies the content of an AndroidManifest.xml
+type androidManifestPatch func(content string) (string, error)
+
+// setAndroidManifestProperty - the project is built with a patched copy of its AndroidManifest.xml (AndroidManifest),
+// if any patch is given
+func setAndroidManifestProperty(command *xbuild.Model, proj project.Model, projectConfig project.ConfigurationPlatformModel, patches []androidManifestPatch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+
+	manifestPth := projectConfig.ManifestPth
+	if manifestPth == "" {
+		manifestPth = proj.ManifestPth
+	}
+	if manifestPth == "" {
+		return fmt.Errorf("project (%s) has no AndroidManifest.xml to patch", proj.Name)
+	}
+
+	patchedManifestPth, err := patchAndroidManifestCopy(manifestPth, patches)
+	if err != nil {
+		return err
+	}
+	command.SetProperty("AndroidManifest", patchedManifestPth)
+
+	return nil
+}
+
+// patchAndroidManifestCopy writes the patched manifest into a temporary directory
+func patchAndroidManifestCopy(manifestPth string, patches []androidManifestPatch) (string, error) {
+	content, err := fileutil.ReadStringFromFile(manifestPth)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest (%s), error: %s", manifestPth, err)
+	}
+
+	for _, patch := range patches {
+		if content, err = patch(content); err != nil {
+			return "", fmt.Errorf("failed to patch manifest (%s), error: %s", manifestPth, err)
+		}
+	}
+
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("android_manifest")
+	if err != nil {
+		return "", err
+	}
+
+	patchedManifestPth := filepath.Join(tmpDir, filepath.Base(manifestPth))
+	if err := fileutil.WriteStringToFile(patchedManifestPth, content); err != nil {
+		return "", fmt.Errorf("failed to write manifest (%s), error: %s", patchedManifestPth, err)
+	}
+	return patchedManifestPth, nil
+}
+
+// setAndroidAttribute sets the android: namespaced attribute of the first element with the given name,
+// keeping the rest of the content untouched
+func setAndroidAttribute(content, element, name, value string) (string, error) {
+	return setElementAttribute(content, element, androidAttributeName(content, name), value)
+}
+
+// androidAttributeName returns the attribute name prefixed by the manifest's android namespace prefix
+func androidAttributeName(content, name string) string {
+	prefix := "android"
+	if match := androidNamespacePattern.FindStringSubmatch(content); len(match) == 2 {
+		prefix = match[1]
+	}
+	return prefix + ":" + name
+}
+
+// setElementAttribute sets the attribute of the first element with the given name
+func setElementAttribute(content, element, name, value string) (string, error) {
+	loc := elementStartTagPattern(element).FindStringIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("no %s element found", element)
+	}
+
+	startTag := setXMLAttribute(content[loc[0]:loc[1]], name, value)
+	return content[:loc[0]] + startTag + content[loc[1]:], nil
+}
+
+func elementStartTagPattern(element string) *regexp.Regexp {
+	return regexp.MustCompile(`<` + regexp.QuoteMeta(element) + `(\s[^>]*)?>`)
+}
+
+// setXMLAttribute replaces the attribute's value in the start tag or appends the attribute
+func setXMLAttribute(startTag, name, value string) string {
+	quoted := `"` + xmlAttributeEscaper.Replace(value) + `"`
+
+	attributePattern := regexp.MustCompile(`\s` + regexp.QuoteMeta(name) + `\s*=\s*("[^"]*"|'[^']*')`)
+	if loc := attributePattern.FindStringSubmatchIndex(startTag); loc != nil {
+		return startTag[:loc[2]] + quoted + startTag[loc[3]:]
+	}
+
+	end := len(startTag) - 1
+	if startTag[end-1] == '/' {
+		end--
+	}
+	return startTag[:end] + " " + name + "=" + quoted + startTag[end:]
+}
@@ -0,0 +1,180 @@
+package builder
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/manifest"
+)
+
+var (
+	metaDataPattern = regexp.MustCompile(`<meta-data\s[^>]*>`)
+	indentPattern   = regexp.MustCompile(`[ \t]*$`)
+)
+
+// AndroidManifestVariantModel - per build changes of the Android projects' manifest, like for white-label apps
+type AndroidManifestVariantModel struct {
+	PackageSuffix string            // appended to the package name, like: .staging
+	Placeholders  map[string]string // ${name} placeholders of the manifest are replaced by the values
+	Permissions   []string          // uses-permission elements are added, if not yet declared, like: android.permission.CAMERA
+	MetaData      map[string]string // meta-data values of the application are set or added
+}
+
+// SetAndroidManifestVariant - Android projects are built with a copy of their AndroidManifest.xml,
+// which has the placeholders substituted, the package suffix, permissions and meta-data values applied
+func (builder *Model) SetAndroidManifestVariant(variant AndroidManifestVariantModel) *Model {
+	builder.androidManifestVariant = variant
+	return builder
+}
+
+func (variant AndroidManifestVariantModel) isEmpty() bool {
+	return variant.PackageSuffix == "" && len(variant.Placeholders) == 0 && len(variant.Permissions) == 0 && len(variant.MetaData) == 0
+}
+
+// androidManifestVariantPatch returns the manifest patch of the variant, nil if no variant set
+func (builder Model) androidManifestVariantPatch() androidManifestPatch {
+	if builder.androidManifestVariant.isEmpty() {
+		return nil
+	}
+
+	return func(content string) (string, error) {
+		return applyAndroidManifestVariant(content, builder.androidManifestVariant)
+	}
+}
+
+// variantAndroidManifest returns the manifest attributes of the manifest with the variant applied
+func (builder Model) variantAndroidManifest(manifestPth string) (manifest.Model, error) {
+	if builder.androidManifestVariant.isEmpty() {
+		return manifest.New(manifestPth)
+	}
+
+	content, err := fileutil.ReadStringFromFile(manifestPth)
+	if err != nil {
+		return manifest.Model{}, err
+	}
+
+	if content, err = applyAndroidManifestVariant(content, builder.androidManifestVariant); err != nil {
+		return manifest.Model{}, err
+	}
+	return manifest.Parse([]byte(content))
+}
+
+// applyAndroidManifestVariant substitutes the placeholders first, so the package suffix is appended to the substituted package name
+func applyAndroidManifestVariant(content string, variant AndroidManifestVariantModel) (string, error) {
+	for _, name := range sortedKeys(variant.Placeholders) {
+		content = strings.Replace(content, "${"+name+"}", xmlAttributeEscaper.Replace(variant.Placeholders[name]), -1)
+	}
+
+	if variant.PackageSuffix != "" {
+		androidManifest, err := manifest.Parse([]byte(content))
+		if err != nil {
+			return "", err
+		}
+		if androidManifest.Package == "" {
+			return "", fmt.Errorf("no package name found to append the suffix (%s) to", variant.PackageSuffix)
+		}
+
+		if content, err = setElementAttribute(content, "manifest", "package", androidManifest.Package+variant.PackageSuffix); err != nil {
+			return "", err
+		}
+	}
+
+	for _, permission := range variant.Permissions {
+		var err error
+		if content, err = addAndroidPermission(content, permission); err != nil {
+			return "", err
+		}
+	}
+
+	for _, name := range sortedKeys(variant.MetaData) {
+		var err error
+		if content, err = setAndroidMetaData(content, name, variant.MetaData[name]); err != nil {
+			return "", err
+		}
+	}
+
+	return content, nil
+}
+
+// addAndroidPermission adds the uses-permission element before the application element, if the permission is not yet declared
+func addAndroidPermission(content, permission string) (string, error) {
+	declaredPattern := regexp.MustCompile(`<uses-permission\s[^>]*name\s*=\s*["']` + regexp.QuoteMeta(permission) + `["']`)
+	if declaredPattern.MatchString(content) {
+		return content, nil
+	}
+
+	element := fmt.Sprintf(`<uses-permission %s="%s" />`, androidAttributeName(content, "name"), xmlAttributeEscaper.Replace(permission))
+
+	if loc := elementStartTagPattern("application").FindStringIndex(content); loc != nil {
+		return insertElement(content, loc[0], element), nil
+	}
+	if index := strings.LastIndex(content, "</manifest>"); index >= 0 {
+		return insertElement(content, index, element), nil
+	}
+	return "", fmt.Errorf("no manifest element found to add the permission (%s) to", permission)
+}
+
+// setAndroidMetaData sets the value of the meta-data with the given name, or adds it as the last child of the application
+func setAndroidMetaData(content, name, value string) (string, error) {
+	nameAttribute, valueAttribute := androidAttributeName(content, "name"), androidAttributeName(content, "value")
+	namePattern := regexp.MustCompile(`\s` + regexp.QuoteMeta(nameAttribute) + `\s*=\s*["']` + regexp.QuoteMeta(name) + `["']`)
+
+	found := false
+	content = metaDataPattern.ReplaceAllStringFunc(content, func(element string) string {
+		if !namePattern.MatchString(element) {
+			return element
+		}
+		found = true
+		return setXMLAttribute(element, valueAttribute, value)
+	})
+	if found {
+		return content, nil
+	}
+
+	element := fmt.Sprintf(`<meta-data %s="%s" %s="%s" />`, nameAttribute, xmlAttributeEscaper.Replace(name), valueAttribute, xmlAttributeEscaper.Replace(value))
+
+	if index := strings.LastIndex(content, "</application>"); index >= 0 {
+		indent := indentPattern.FindString(content[:index])
+		return content[:index-len(indent)] + childIndent(indent) + element + "\n" + content[index-len(indent):], nil
+	}
+
+	// self-closing application element
+	loc := elementStartTagPattern("application").FindStringIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("no application element found to add the meta-data (%s) to", name)
+	}
+	startTag := content[loc[0]:loc[1]]
+	if !strings.HasSuffix(startTag, "/>") {
+		return "", fmt.Errorf("invalid application element")
+	}
+
+	indent := indentPattern.FindString(content[:loc[0]])
+	expanded := strings.TrimSuffix(strings.TrimSuffix(startTag, "/>"), " ") + ">\n" + childIndent(indent) + element + "\n" + indent + "</application>"
+	return content[:loc[0]] + expanded + content[loc[1]:], nil
+}
+
+// insertElement inserts the element before the index, keeping the indentation of the line
+func insertElement(content string, index int, element string) string {
+	indent := indentPattern.FindString(content[:index])
+	return content[:index] + element + "\n" + indent + content[index:]
+}
+
+// childIndent returns the indentation of the children of an element indented by the given indentation
+func childIndent(indent string) string {
+	if strings.Contains(indent, "\t") {
+		return indent + "\t"
+	}
+	return indent + "  "
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
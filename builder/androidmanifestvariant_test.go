@@ -0,0 +1,61 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+const variantTestManifestContent = `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android" android:versionCode="1" package="com.bitrise.${brand}">
+	<uses-permission android:name="android.permission.INTERNET" />
+	<application android:label="${brandName}">
+		<meta-data android:name="com.bitrise.api_key" android:value="debug-key" />
+	</application>
+</manifest>`
+
+func TestApplyAndroidManifestVariant(t *testing.T) {
+	t.Log("it applies the variant")
+	{
+		content, err := applyAndroidManifestVariant(variantTestManifestContent, AndroidManifestVariantModel{
+			PackageSuffix: ".staging",
+			Placeholders:  map[string]string{"brand": "acme", "brandName": "Acme & Co"},
+			Permissions:   []string{"android.permission.INTERNET", "android.permission.CAMERA"},
+			MetaData:      map[string]string{"com.bitrise.api_key": "release-key", "com.bitrise.channel": "staging"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android" android:versionCode="1" package="com.bitrise.acme.staging">
+	<uses-permission android:name="android.permission.INTERNET" />
+	<uses-permission android:name="android.permission.CAMERA" />
+	<application android:label="Acme &amp; Co">
+		<meta-data android:name="com.bitrise.api_key" android:value="release-key" />
+		<meta-data android:name="com.bitrise.channel" android:value="staging" />
+	</application>
+</manifest>`, content)
+
+		androidManifest, err := manifest.Parse([]byte(content))
+		require.NoError(t, err)
+		require.Equal(t, "com.bitrise.acme.staging", androidManifest.Package)
+	}
+
+	t.Log("it expands the self-closing application to add meta-data")
+	{
+		content, err := applyAndroidManifestVariant(`<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.bitrise.app">
+  <application android:label="App" />
+</manifest>`, AndroidManifestVariantModel{MetaData: map[string]string{"channel": "beta"}})
+		require.NoError(t, err)
+		require.Equal(t, `<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.bitrise.app">
+  <application android:label="App">
+    <meta-data android:name="channel" android:value="beta" />
+  </application>
+</manifest>`, content)
+	}
+
+	t.Log("it fails without package for the suffix")
+	{
+		_, err := applyAndroidManifestVariant(`<manifest><application /></manifest>`, AndroidManifestVariantModel{PackageSuffix: ".staging"})
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,69 @@
+package builder
+
+import (
+	"strconv"
+
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// AndroidDexTool - the tool compiling the java bytecode into dex (AndroidDexTool)
+type AndroidDexTool string
+
+const (
+	// AndroidDexToolDX - the legacy dx compiler
+	AndroidDexToolDX AndroidDexTool = "dx"
+	// AndroidDexToolD8 ...
+	AndroidDexToolD8 AndroidDexTool = "d8"
+)
+
+// AndroidLinkTool - the code shrinker of the java code (AndroidLinkTool)
+type AndroidLinkTool string
+
+const (
+	// AndroidLinkToolNone - the java code is not shrinked
+	AndroidLinkToolNone AndroidLinkTool = ""
+	// AndroidLinkToolProguard ...
+	AndroidLinkToolProguard AndroidLinkTool = "proguard"
+	// AndroidLinkToolR8 ...
+	AndroidLinkToolR8 AndroidLinkTool = "r8"
+)
+
+// SetAndroidAOT - Android projects are built with (or without) ahead of time compiled assemblies (AotAssemblies)
+func (builder *Model) SetAndroidAOT(aot bool) *Model {
+	return builder.setAndroidBuildProperty("AotAssemblies", strconv.FormatBool(aot))
+}
+
+// SetAndroidLLVM - the AOT compilation of the Android projects uses (or not) the LLVM optimizing compiler (EnableLLVM)
+func (builder *Model) SetAndroidLLVM(llvm bool) *Model {
+	return builder.setAndroidBuildProperty("EnableLLVM", strconv.FormatBool(llvm))
+}
+
+// SetAndroidDexTool - Android projects are built with the given dex compiler (AndroidDexTool)
+func (builder *Model) SetAndroidDexTool(dexTool AndroidDexTool) *Model {
+	return builder.setAndroidBuildProperty("AndroidDexTool", string(dexTool))
+}
+
+// SetAndroidLinkTool - Android projects are built with the given java code shrinker (AndroidLinkTool),
+// AndroidLinkToolNone disables the shrinking
+func (builder *Model) SetAndroidLinkTool(linkTool AndroidLinkTool) *Model {
+	return builder.setAndroidBuildProperty("AndroidLinkTool", string(linkTool))
+}
+
+// SetAndroidEnableProguard - Android projects are built with (or without) the code shrinking of the legacy projects (AndroidEnableProguard)
+func (builder *Model) SetAndroidEnableProguard(enableProguard bool) *Model {
+	return builder.setAndroidBuildProperty("AndroidEnableProguard", strconv.FormatBool(enableProguard))
+}
+
+func (builder *Model) setAndroidBuildProperty(name, value string) *Model {
+	if builder.androidBuildProperties == nil {
+		builder.androidBuildProperties = map[string]string{}
+	}
+	builder.androidBuildProperties[name] = value
+	return builder
+}
+
+func (builder Model) setAndroidBuildProperties(command *xbuild.Model) {
+	for name, value := range builder.androidBuildProperties {
+		command.SetProperty(name, value)
+	}
+}
@@ -0,0 +1,40 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAndroidBuildProperties(t *testing.T) {
+	t.Log("it sets the optimization properties")
+	{
+		builder := Model{}
+		builder.SetAndroidAOT(true).
+			SetAndroidLLVM(false).
+			SetAndroidDexTool(AndroidDexToolD8).
+			SetAndroidLinkTool(AndroidLinkToolR8).
+			SetAndroidEnableProguard(true)
+
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+		builder.setAndroidBuildProperties(command)
+
+		printableCommand := command.PrintableCommand()
+		require.Contains(t, printableCommand, `"/p:AndroidDexTool=d8" "/p:AndroidEnableProguard=true" "/p:AndroidLinkTool=r8" "/p:AotAssemblies=true" "/p:EnableLLVM=false"`)
+	}
+
+	t.Log("it disables the code shrinking")
+	{
+		builder := Model{}
+		builder.SetAndroidLinkTool(AndroidLinkToolNone)
+
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+		builder.setAndroidBuildProperties(command)
+
+		require.Contains(t, command.PrintableCommand(), `"/p:AndroidLinkTool="`)
+	}
+}
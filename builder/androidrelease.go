@@ -0,0 +1,63 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// AndroidReleaseGuard - how to handle the Release configurations of the Android projects,
+// which build fast-deployment (EmbedAssembliesIntoApk=false) or debuggable apks, unfit for the store
+type AndroidReleaseGuard string
+
+const (
+	// AndroidReleaseGuardOff - the Release configurations are built as configured
+	AndroidReleaseGuardOff AndroidReleaseGuard = ""
+	// AndroidReleaseGuardWarn - the problems are reported as build warnings
+	AndroidReleaseGuardWarn AndroidReleaseGuard = "warn"
+	// AndroidReleaseGuardFix - the problems are reported and corrected: the assemblies are embedded into the apk
+	// and the application is built from a non-debuggable copy of the manifest
+	AndroidReleaseGuardFix AndroidReleaseGuard = "fix"
+)
+
+// SetAndroidReleaseGuard - Release configurations of the Android projects are checked for fast-deployment and debuggable settings
+func (builder *Model) SetAndroidReleaseGuard(guard AndroidReleaseGuard) *Model {
+	builder.androidReleaseGuard = guard
+	return builder
+}
+
+// guardAndroidRelease returns the warnings of the Release configuration and,
+// in fix mode, sets the embedding properties and returns the manifest patch making the application non-debuggable
+func (builder Model) guardAndroidRelease(command *xbuild.Model, proj project.Model, projectConfig project.ConfigurationPlatformModel) ([]string, androidManifestPatch) {
+	warnings := []string{}
+	if builder.androidReleaseGuard == AndroidReleaseGuardOff || !isReleaseConfiguration(projectConfig.Configuration) {
+		return warnings, nil
+	}
+
+	fix := builder.androidReleaseGuard == AndroidReleaseGuardFix
+
+	if projectConfig.AndroidFastDeployment {
+		if fix {
+			command.SetProperty("EmbedAssembliesIntoApk", "true")
+			command.SetProperty("AndroidUseSharedRuntime", "false")
+			warnings = append(warnings, fmt.Sprintf("project (%s) config (%s) uses fast deployment, the assemblies are embedded into the apk", proj.Name, projectConfig.Configuration))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("project (%s) config (%s) uses fast deployment, the apk does not contain the assemblies", proj.Name, projectConfig.Configuration))
+		}
+	}
+
+	if !projectConfig.AndroidDebuggable {
+		return warnings, nil
+	}
+
+	if !fix {
+		warnings = append(warnings, fmt.Sprintf("project (%s) config (%s) builds a debuggable application", proj.Name, projectConfig.Configuration))
+		return warnings, nil
+	}
+
+	warnings = append(warnings, fmt.Sprintf("project (%s) config (%s) builds a debuggable application, the application is built as non-debuggable", proj.Name, projectConfig.Configuration))
+	return warnings, func(content string) (string, error) {
+		return setAndroidAttribute(content, "application", "debuggable", "false")
+	}
+}
@@ -0,0 +1,75 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuardAndroidRelease(t *testing.T) {
+	releaseConfig := project.ConfigurationPlatformModel{
+		Configuration:         "Release",
+		AndroidFastDeployment: true,
+		AndroidDebuggable:     true,
+	}
+
+	t.Log("it is off by default")
+	{
+		builder := Model{}
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		warnings, patch := builder.guardAndroidRelease(command, project.Model{Name: "Droid"}, releaseConfig)
+		require.Equal(t, []string{}, warnings)
+		require.Nil(t, patch)
+	}
+
+	t.Log("it warns for release configs only")
+	{
+		builder := Model{}
+		builder.SetAndroidReleaseGuard(AndroidReleaseGuardWarn)
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		warnings, patch := builder.guardAndroidRelease(command, project.Model{Name: "Droid"}, releaseConfig)
+		require.Equal(t, 2, len(warnings))
+		require.Nil(t, patch)
+		require.NotContains(t, command.PrintableCommand(), "EmbedAssembliesIntoApk")
+
+		debugConfig := releaseConfig
+		debugConfig.Configuration = "Debug"
+		warnings, _ = builder.guardAndroidRelease(command, project.Model{Name: "Droid"}, debugConfig)
+		require.Equal(t, []string{}, warnings)
+	}
+
+	t.Log("it fixes the release configs")
+	{
+		builder := Model{}
+		builder.SetAndroidReleaseGuard(AndroidReleaseGuardFix)
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		warnings, patch := builder.guardAndroidRelease(command, project.Model{Name: "Droid"}, releaseConfig)
+		require.Equal(t, 2, len(warnings))
+		require.Contains(t, command.PrintableCommand(), `"/p:AndroidUseSharedRuntime=false" "/p:EmbedAssembliesIntoApk=true"`)
+
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("androidrelease_test")
+		require.NoError(t, err)
+		manifestPth := filepath.Join(tmpDir, "AndroidManifest.xml")
+		require.NoError(t, fileutil.WriteStringToFile(manifestPth, `<manifest xmlns:android="http://schemas.android.com/apk/res/android" package="com.bitrise.app">
+	<application android:label="App" android:debuggable="true"></application>
+</manifest>`))
+
+		patchedManifestPth, err := patchAndroidManifestCopy(manifestPth, []androidManifestPatch{patch})
+		require.NoError(t, err)
+		content, err := fileutil.ReadStringFromFile(patchedManifestPth)
+		require.NoError(t, err)
+		require.Contains(t, content, `<application android:label="App" android:debuggable="false">`)
+	}
+}
@@ -0,0 +1,34 @@
+package builder
+
+import (
+	"strconv"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools/androidsdk"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// SetAndroidSDK - Android projects are built with the given SDK, NDK and JDK (AndroidSdkDirectory, AndroidNdkDirectory, JavaSdkDirectory),
+// the SDK is validated against the projects' API levels, see: androidsdk.Resolve
+func (builder *Model) SetAndroidSDK(sdk androidsdk.Model) *Model {
+	builder.androidSDK = &sdk
+	return builder
+}
+
+// setAndroidSDKProperties sets the SDK locations and returns warnings for the platforms and build-tools missing from the SDK
+func (builder Model) setAndroidSDKProperties(command *xbuild.Model, proj project.Model) []string {
+	if builder.androidSDK == nil {
+		return []string{}
+	}
+
+	for name, value := range builder.androidSDK.BuildProperties() {
+		command.SetProperty(name, value)
+	}
+
+	apiLevels := []int{proj.AndroidAPILevel}
+	if targetSdkVersion, err := strconv.Atoi(proj.AndroidTargetSdkVersion); err == nil && targetSdkVersion != proj.AndroidAPILevel {
+		apiLevels = append(apiLevels, targetSdkVersion)
+	}
+
+	return builder.androidSDK.Validate(apiLevels...)
+}
@@ -0,0 +1,45 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/androidsdk"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAndroidSDKProperties(t *testing.T) {
+	t.Log("it sets nothing by default")
+	{
+		builder := Model{}
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{}, builder.setAndroidSDKProperties(command, project.Model{AndroidAPILevel: 27}))
+		require.NotContains(t, command.PrintableCommand(), "AndroidSdkDirectory")
+	}
+
+	t.Log("it sets the sdk locations and validates the api levels")
+	{
+		sdkDir, err := pathutil.NormalizedOSTempDirPath("androidsdk_test")
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(sdkDir, "platforms", "android-27"), 0755))
+		createTestFile(t, sdkDir, filepath.Join("build-tools", "27.0.3", "zipalign"))
+
+		builder := Model{}
+		builder.SetAndroidSDK(androidsdk.Model{SDKDir: sdkDir, JDKDir: "/jdk"})
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		warnings := builder.setAndroidSDKProperties(command, project.Model{AndroidAPILevel: 27, AndroidTargetSdkVersion: "28"})
+		require.Equal(t, 1, len(warnings))
+		require.Contains(t, warnings[0], "android-28")
+		require.Contains(t, command.PrintableCommand(), `"/p:AndroidSdkDirectory=`+sdkDir+`"`)
+		require.Contains(t, command.PrintableCommand(), `"/p:JavaSdkDirectory=/jdk"`)
+	}
+}
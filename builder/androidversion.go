@@ -0,0 +1,67 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// SetAndroidVersion - Android projects are built with the given android:versionCode and android:versionName,
+// overriding the project's manifest, an empty value keeps the project's one.
+// SDK-style projects get the ApplicationVersion and ApplicationDisplayVersion properties,
+// legacy projects are built with a patched copy of their AndroidManifest.xml.
+func (builder *Model) SetAndroidVersion(versionCode, versionName string) *Model {
+	builder.androidVersionCode = versionCode
+	builder.androidVersionName = versionName
+	return builder
+}
+
+func (builder Model) overridesAndroidVersion() bool {
+	return builder.androidVersionCode != "" || builder.androidVersionName != ""
+}
+
+// setAndroidVersionProperties sets the version properties of SDK-style projects,
+// and returns the manifest patch of the legacy projects
+func (builder Model) setAndroidVersionProperties(command *xbuild.Model, proj project.Model) (androidManifestPatch, error) {
+	if !builder.overridesAndroidVersion() {
+		return nil, nil
+	}
+
+	if builder.androidVersionCode != "" {
+		if versionCode, err := strconv.Atoi(builder.androidVersionCode); err != nil || versionCode <= 0 {
+			return nil, fmt.Errorf("invalid android version code: %s, a positive integer expected", builder.androidVersionCode)
+		}
+	}
+
+	if proj.MSBuildSDK != "" {
+		if builder.androidVersionCode != "" {
+			command.SetProperty("ApplicationVersion", builder.androidVersionCode)
+		}
+		if builder.androidVersionName != "" {
+			command.SetProperty("ApplicationDisplayVersion", builder.androidVersionName)
+		}
+		return nil, nil
+	}
+
+	return func(content string) (string, error) {
+		return patchAndroidManifestVersion(content, builder.androidVersionCode, builder.androidVersionName)
+	}, nil
+}
+
+// patchAndroidManifestVersion sets the version attributes of the manifest element
+func patchAndroidManifestVersion(content, versionCode, versionName string) (string, error) {
+	var err error
+	if versionCode != "" {
+		if content, err = setAndroidAttribute(content, "manifest", "versionCode", versionCode); err != nil {
+			return "", err
+		}
+	}
+	if versionName != "" {
+		if content, err = setAndroidAttribute(content, "manifest", "versionName", versionName); err != nil {
+			return "", err
+		}
+	}
+	return content, nil
+}
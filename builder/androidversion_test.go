@@ -0,0 +1,106 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/manifest"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchAndroidManifestVersion(t *testing.T) {
+	t.Log("it replaces the version attributes")
+	{
+		content := `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+          android:versionCode="1"
+          android:versionName="1.0" package="com.bitrise.app">
+	<uses-sdk android:minSdkVersion="21" />
+</manifest>`
+
+		patched, err := patchAndroidManifestVersion(content, "42", "2.1.0")
+		require.NoError(t, err)
+		require.Equal(t, `<?xml version="1.0" encoding="utf-8"?>
+<manifest xmlns:android="http://schemas.android.com/apk/res/android"
+          android:versionCode="42"
+          android:versionName="2.1.0" package="com.bitrise.app">
+	<uses-sdk android:minSdkVersion="21" />
+</manifest>`, patched)
+
+		androidManifest, err := manifest.Parse([]byte(patched))
+		require.NoError(t, err)
+		require.Equal(t, "42", androidManifest.VersionCode)
+		require.Equal(t, "2.1.0", androidManifest.VersionName)
+	}
+
+	t.Log("it adds the missing attributes with the declared namespace prefix")
+	{
+		content := `<manifest xmlns:a='http://schemas.android.com/apk/res/android' a:versionCode='3' package="com.bitrise.app"></manifest>`
+
+		patched, err := patchAndroidManifestVersion(content, "4", "1.0 & beta")
+		require.NoError(t, err)
+		require.Equal(t, `<manifest xmlns:a='http://schemas.android.com/apk/res/android' a:versionCode="4" package="com.bitrise.app" a:versionName="1.0 &amp; beta"></manifest>`, patched)
+	}
+
+	t.Log("it fails without manifest element")
+	{
+		_, err := patchAndroidManifestVersion(`<application />`, "4", "")
+		require.Error(t, err)
+	}
+}
+
+func TestSetAndroidVersionProperties(t *testing.T) {
+	t.Log("it sets the application version properties of SDK-style projects")
+	{
+		builder := Model{}
+		builder.SetAndroidVersion("42", "2.1.0")
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		patch, err := builder.setAndroidVersionProperties(command, project.Model{MSBuildSDK: "Microsoft.NET.Sdk"})
+		require.NoError(t, err)
+		require.Nil(t, patch)
+		require.Contains(t, command.PrintableCommand(), `"/p:ApplicationVersion=42"`)
+		require.Contains(t, command.PrintableCommand(), `"/p:ApplicationDisplayVersion=2.1.0"`)
+	}
+
+	t.Log("it builds legacy projects with a patched manifest copy")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("androidversion_test")
+		require.NoError(t, err)
+		manifestPth := filepath.Join(tmpDir, "AndroidManifest.xml")
+		require.NoError(t, fileutil.WriteStringToFile(manifestPth, `<manifest xmlns:android="http://schemas.android.com/apk/res/android" android:versionCode="1" package="com.bitrise.app" />`))
+
+		builder := Model{}
+		builder.SetAndroidVersion("42", "")
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		proj := project.Model{ManifestPth: manifestPth}
+		patch, err := builder.setAndroidVersionProperties(command, proj)
+		require.NoError(t, err)
+		require.NoError(t, setAndroidManifestProperty(command, proj, project.ConfigurationPlatformModel{}, []androidManifestPatch{patch}))
+		require.Contains(t, command.PrintableCommand(), `"/p:AndroidManifest=`)
+		require.NotContains(t, command.PrintableCommand(), manifestPth)
+
+		androidManifest, err := manifest.New(manifestPth)
+		require.NoError(t, err)
+		require.Equal(t, "1", androidManifest.VersionCode)
+	}
+
+	t.Log("it fails for invalid version code")
+	{
+		builder := Model{}
+		builder.SetAndroidVersion("1.2", "")
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+
+		_, err = builder.setAndroidVersionProperties(command, project.Model{MSBuildSDK: "Microsoft.NET.Sdk"})
+		require.Error(t, err)
+	}
+}
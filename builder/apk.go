@@ -0,0 +1,354 @@
+package builder
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// Android binary XML chunk types
+const (
+	axmlStringPoolType   = 0x0001
+	axmlFileType         = 0x0003
+	axmlResourceMapType  = 0x0180
+	axmlStartElementType = 0x0102
+
+	axmlUTF8Flag   = 1 << 8
+	axmlNoIndex    = 0xffffffff
+	axmlTypeString = 0x03
+	axmlTypeIntDec = 0x10
+	axmlTypeIntHex = 0x11
+)
+
+// android: attribute resource ids, used if the attribute names are stripped from the string pool
+var axmlAttributeResourceIDs = map[uint32]string{
+	0x01010003: "name",
+	0x0101021b: "versionCode",
+	0x0101021c: "versionName",
+	0x0101020c: "minSdkVersion",
+	0x01010270: "targetSdkVersion",
+}
+
+// apkSigningBlockMagic - the APK Signing Block (v2+ signature schemes) ends with this magic, right before the central directory
+const apkSigningBlockMagic = "APK Sig Block 42"
+
+// APKMetadataModel - properties of the built apk, read from its compiled AndroidManifest.xml
+type APKMetadataModel struct {
+	PackageName      string
+	VersionCode      string
+	VersionName      string
+	MinSDKVersion    string
+	TargetSDKVersion string
+	Instrumentations []string // class names of the declared instrumentations, like the NUnitLite test runner
+
+	Signed bool // signed by v1 (jar) or v2+ (APK Signing Block) scheme
+}
+
+// ReadAPKMetadata - reads the package name, versions and sdk versions from the apk's manifest
+// and checks whether the apk is signed
+func ReadAPKMetadata(apkPth string) (APKMetadataModel, error) {
+	return readAPKMetadata(tools.NewDefaultLogger(), apkPth)
+}
+
+func readAPKMetadata(logger tools.Logger, apkPth string) (APKMetadataModel, error) {
+	reader, err := zip.OpenReader(apkPth)
+	if err != nil {
+		return APKMetadataModel{}, fmt.Errorf("failed to open apk (%s), error: %s", apkPth, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Warnf("Failed to close apk (%s), error: %s", apkPth, err)
+		}
+	}()
+
+	var manifestFile *zip.File
+	signed := false
+	for _, file := range reader.File {
+		if file.Name == "AndroidManifest.xml" {
+			manifestFile = file
+		}
+		if isJarSignatureFile(file.Name) {
+			signed = true
+		}
+	}
+	if manifestFile == nil {
+		return APKMetadataModel{}, fmt.Errorf("no AndroidManifest.xml found in apk (%s)", apkPth)
+	}
+
+	content, err := readZipFile(logger, manifestFile)
+	if err != nil {
+		return APKMetadataModel{}, fmt.Errorf("failed to read AndroidManifest.xml of (%s), error: %s", apkPth, err)
+	}
+
+	metadata, err := parseBinaryManifest(content)
+	if err != nil {
+		return APKMetadataModel{}, fmt.Errorf("failed to parse AndroidManifest.xml of (%s), error: %s", apkPth, err)
+	}
+
+	if !signed {
+		if signed, err = hasAPKSigningBlock(logger, apkPth); err != nil {
+			return APKMetadataModel{}, fmt.Errorf("failed to check signing block of (%s), error: %s", apkPth, err)
+		}
+	}
+	metadata.Signed = signed
+
+	return metadata, nil
+}
+
+func isJarSignatureFile(name string) bool {
+	if path.Dir(name) != "META-INF" {
+		return false
+	}
+	ext := strings.ToUpper(path.Ext(name))
+	return ext == ".RSA" || ext == ".DSA" || ext == ".EC"
+}
+
+// hasAPKSigningBlock checks for the signing block magic before the central directory
+func hasAPKSigningBlock(logger tools.Logger, apkPth string) (bool, error) {
+	file, err := os.Open(apkPth)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Warnf("Failed to close apk (%s), error: %s", apkPth, err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	// end of central directory record: 22 bytes + comment of max 64KB
+	tailSize := int64(22 + 0xffff)
+	if tailSize > info.Size() {
+		tailSize = info.Size()
+	}
+	tail := make([]byte, tailSize)
+	if _, err := file.ReadAt(tail, info.Size()-tailSize); err != nil {
+		return false, err
+	}
+
+	eocd := bytes.LastIndex(tail, []byte("PK\x05\x06"))
+	if eocd < 0 || eocd+20 > len(tail) {
+		return false, fmt.Errorf("end of central directory not found")
+	}
+	centralDirectoryOffset := int64(binary.LittleEndian.Uint32(tail[eocd+16:]))
+	if centralDirectoryOffset < int64(len(apkSigningBlockMagic)) {
+		return false, nil
+	}
+
+	magic := make([]byte, len(apkSigningBlockMagic))
+	if _, err := file.ReadAt(magic, centralDirectoryOffset-int64(len(magic))); err != nil && err != io.EOF {
+		return false, err
+	}
+	return string(magic) == apkSigningBlockMagic, nil
+}
+
+// parseBinaryManifest - reads the manifest and uses-sdk attributes of the compiled (binary XML) AndroidManifest.xml
+func parseBinaryManifest(content []byte) (APKMetadataModel, error) {
+	if len(content) < 8 || binary.LittleEndian.Uint16(content) != axmlFileType {
+		return APKMetadataModel{}, fmt.Errorf("not a binary xml")
+	}
+
+	metadata := APKMetadataModel{}
+	pool := []string{}
+	resourceIDs := []uint32{}
+
+	offset := int(binary.LittleEndian.Uint16(content[2:]))
+	for offset+8 <= len(content) {
+		chunkType := binary.LittleEndian.Uint16(content[offset:])
+		headerSize := int(binary.LittleEndian.Uint16(content[offset+2:]))
+		chunkSize := int(binary.LittleEndian.Uint32(content[offset+4:]))
+		if chunkSize < 8 || headerSize > chunkSize || offset+chunkSize > len(content) {
+			return APKMetadataModel{}, fmt.Errorf("invalid chunk at %d", offset)
+		}
+		chunk := content[offset : offset+chunkSize]
+
+		switch chunkType {
+		case axmlStringPoolType:
+			var err error
+			if pool, err = parseAXMLStringPool(chunk); err != nil {
+				return APKMetadataModel{}, err
+			}
+		case axmlResourceMapType:
+			for i := headerSize; i+4 <= len(chunk); i += 4 {
+				resourceIDs = append(resourceIDs, binary.LittleEndian.Uint32(chunk[i:]))
+			}
+		case axmlStartElementType:
+			name, attributes, err := parseAXMLStartElement(chunk, headerSize, pool, resourceIDs)
+			if err != nil {
+				return APKMetadataModel{}, err
+			}
+
+			switch name {
+			case "manifest":
+				metadata.PackageName = attributes["package"]
+				metadata.VersionCode = attributes["versionCode"]
+				metadata.VersionName = attributes["versionName"]
+			case "uses-sdk":
+				metadata.MinSDKVersion = attributes["minSdkVersion"]
+				metadata.TargetSDKVersion = attributes["targetSdkVersion"]
+			case "instrumentation":
+				if instrumentation := attributes["name"]; instrumentation != "" {
+					metadata.Instrumentations = append(metadata.Instrumentations, instrumentation)
+				}
+			}
+		}
+
+		offset += chunkSize
+	}
+
+	if metadata.PackageName == "" {
+		return APKMetadataModel{}, fmt.Errorf("no package name found")
+	}
+	return metadata, nil
+}
+
+func parseAXMLStringPool(chunk []byte) ([]string, error) {
+	if len(chunk) < 28 {
+		return nil, fmt.Errorf("invalid string pool")
+	}
+
+	count := int(binary.LittleEndian.Uint32(chunk[8:]))
+	flags := binary.LittleEndian.Uint32(chunk[16:])
+	stringsStart := int(binary.LittleEndian.Uint32(chunk[20:]))
+	headerSize := int(binary.LittleEndian.Uint16(chunk[2:]))
+	if headerSize+count*4 > len(chunk) || stringsStart > len(chunk) {
+		return nil, fmt.Errorf("invalid string pool")
+	}
+
+	pool := make([]string, count)
+	for i := range pool {
+		start := stringsStart + int(binary.LittleEndian.Uint32(chunk[headerSize+i*4:]))
+		if start >= len(chunk) {
+			return nil, fmt.Errorf("invalid string offset")
+		}
+
+		var err error
+		if flags&axmlUTF8Flag != 0 {
+			pool[i], err = decodeAXMLUTF8String(chunk[start:])
+		} else {
+			pool[i], err = decodeAXMLUTF16String(chunk[start:])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+// decodeAXMLUTF8String - utf-8 strings are prefixed with their utf-16 length and their byte length,
+// lengths above 0x7f take 2 bytes
+func decodeAXMLUTF8String(data []byte) (string, error) {
+	offset := 0
+	for i := 0; i < 2; i++ {
+		if offset >= len(data) {
+			return "", fmt.Errorf("invalid utf-8 string")
+		}
+		length := int(data[offset])
+		offset++
+		if length&0x80 != 0 {
+			if offset >= len(data) {
+				return "", fmt.Errorf("invalid utf-8 string")
+			}
+			length = (length&0x7f)<<8 | int(data[offset])
+			offset++
+		}
+
+		if i == 1 {
+			if offset+length > len(data) {
+				return "", fmt.Errorf("invalid utf-8 string")
+			}
+			return string(data[offset : offset+length]), nil
+		}
+	}
+	return "", nil
+}
+
+// decodeAXMLUTF16String - utf-16 strings are prefixed with their length, lengths above 0x7fff take 2 units
+func decodeAXMLUTF16String(data []byte) (string, error) {
+	if len(data) < 2 {
+		return "", fmt.Errorf("invalid utf-16 string")
+	}
+	offset := 2
+	length := int(binary.LittleEndian.Uint16(data))
+	if length&0x8000 != 0 {
+		if len(data) < 4 {
+			return "", fmt.Errorf("invalid utf-16 string")
+		}
+		length = (length&0x7fff)<<16 | int(binary.LittleEndian.Uint16(data[2:]))
+		offset = 4
+	}
+	if offset+length*2 > len(data) {
+		return "", fmt.Errorf("invalid utf-16 string")
+	}
+
+	chars := make([]uint16, length)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[offset+i*2:])
+	}
+	return string(utf16.Decode(chars)), nil
+}
+
+// parseAXMLStartElement returns the element name and its attribute values by attribute name
+func parseAXMLStartElement(chunk []byte, headerSize int, pool []string, resourceIDs []uint32) (string, map[string]string, error) {
+	if headerSize+20 > len(chunk) {
+		return "", nil, fmt.Errorf("invalid start element")
+	}
+	ext := chunk[headerSize:]
+
+	name := axmlString(pool, binary.LittleEndian.Uint32(ext[4:]))
+	attributeStart := int(binary.LittleEndian.Uint16(ext[8:]))
+	attributeSize := int(binary.LittleEndian.Uint16(ext[10:]))
+	attributeCount := int(binary.LittleEndian.Uint16(ext[12:]))
+	if attributeSize < 20 || attributeStart+attributeCount*attributeSize > len(ext) {
+		return "", nil, fmt.Errorf("invalid attributes of element: %s", name)
+	}
+
+	attributes := map[string]string{}
+	for i := 0; i < attributeCount; i++ {
+		attribute := ext[attributeStart+i*attributeSize:]
+		nameIndex := binary.LittleEndian.Uint32(attribute[4:])
+		rawValue := binary.LittleEndian.Uint32(attribute[8:])
+		dataType := attribute[15]
+		data := binary.LittleEndian.Uint32(attribute[16:])
+
+		attributeName := axmlString(pool, nameIndex)
+		if int(nameIndex) < len(resourceIDs) {
+			if knownName, ok := axmlAttributeResourceIDs[resourceIDs[nameIndex]]; ok {
+				attributeName = knownName
+			}
+		}
+
+		switch {
+		case rawValue != axmlNoIndex:
+			attributes[attributeName] = axmlString(pool, rawValue)
+		case dataType == axmlTypeString:
+			attributes[attributeName] = axmlString(pool, data)
+		case dataType == axmlTypeIntDec:
+			attributes[attributeName] = strconv.FormatInt(int64(int32(data)), 10)
+		case dataType == axmlTypeIntHex:
+			attributes[attributeName] = fmt.Sprintf("0x%x", data)
+		}
+	}
+
+	return name, attributes, nil
+}
+
+func axmlString(pool []string, index uint32) string {
+	if index == axmlNoIndex || int(index) >= len(pool) {
+		return ""
+	}
+	return pool[index]
+}
@@ -0,0 +1,192 @@
+package builder
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testAXMLAttribute struct {
+	name     uint32
+	rawValue uint32
+	dataType byte
+	data     uint32
+}
+
+// encodeTestAXML builds a compiled AndroidManifest.xml with an utf-16 string pool,
+// elements are string pool indexes of the element names, attributes are listed per element
+func encodeTestAXML(pool []string, resourceIDs []uint32, elements []uint32, attributes [][]testAXMLAttribute) []byte {
+	write := func(buf *bytes.Buffer, v interface{}) {
+		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
+			panic(err)
+		}
+	}
+	chunk := func(chunkType uint16, headerSize uint16, body []byte) []byte {
+		buf := &bytes.Buffer{}
+		write(buf, chunkType)
+		write(buf, headerSize)
+		write(buf, uint32(8+len(body)))
+		buf.Write(body)
+		return buf.Bytes()
+	}
+
+	stringData := &bytes.Buffer{}
+	offsets := []uint32{}
+	for _, str := range pool {
+		offsets = append(offsets, uint32(stringData.Len()))
+		chars := utf16.Encode([]rune(str))
+		write(stringData, uint16(len(chars)))
+		write(stringData, chars)
+		write(stringData, uint16(0))
+	}
+	poolBody := &bytes.Buffer{}
+	write(poolBody, uint32(len(pool)))
+	write(poolBody, uint32(0))
+	write(poolBody, uint32(0))
+	write(poolBody, uint32(28+4*len(pool)))
+	write(poolBody, uint32(0))
+	write(poolBody, offsets)
+	poolBody.Write(stringData.Bytes())
+
+	resourceBody := &bytes.Buffer{}
+	write(resourceBody, resourceIDs)
+
+	body := &bytes.Buffer{}
+	body.Write(chunk(axmlStringPoolType, 28, poolBody.Bytes()))
+	body.Write(chunk(axmlResourceMapType, 8, resourceBody.Bytes()))
+
+	for i, name := range elements {
+		elementBody := &bytes.Buffer{}
+		write(elementBody, uint32(1))           // line number
+		write(elementBody, uint32(axmlNoIndex)) // comment
+		write(elementBody, uint32(axmlNoIndex)) // namespace
+		write(elementBody, name)
+		write(elementBody, uint16(20)) // attribute start
+		write(elementBody, uint16(20)) // attribute size
+		write(elementBody, uint16(len(attributes[i])))
+		write(elementBody, [3]uint16{})
+		for _, attribute := range attributes[i] {
+			write(elementBody, uint32(axmlNoIndex))
+			write(elementBody, attribute.name)
+			write(elementBody, attribute.rawValue)
+			write(elementBody, uint16(8))
+			write(elementBody, byte(0))
+			write(elementBody, attribute.dataType)
+			write(elementBody, attribute.data)
+		}
+		body.Write(chunk(axmlStartElementType, 16, elementBody.Bytes()))
+	}
+
+	return chunk(axmlFileType, 8, body.Bytes())
+}
+
+func createTestAPK(t *testing.T, pth string, files map[string][]byte) {
+	file, err := os.Create(pth)
+	require.NoError(t, err)
+
+	writer := zip.NewWriter(file)
+	for name, content := range files {
+		entryWriter, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entryWriter.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+}
+
+func TestReadAPKMetadata(t *testing.T) {
+	// string pool: attribute names with resource ids come first, the versionCode name is stripped
+	pool := []string{"", "versionName", "minSdkVersion", "package", "manifest", "uses-sdk", "com.bitrise.sampleapp", "1.2.0"}
+	resourceIDs := []uint32{0x0101021b, 0x0101021c, 0x0101020c}
+	manifest := encodeTestAXML(pool, resourceIDs, []uint32{4, 5}, [][]testAXMLAttribute{
+		{
+			{name: 0, rawValue: axmlNoIndex, dataType: axmlTypeIntDec, data: 12},
+			{name: 1, rawValue: 7, dataType: axmlTypeString, data: 7},
+			{name: 3, rawValue: 6, dataType: axmlTypeString, data: 6},
+		},
+		{
+			{name: 2, rawValue: axmlNoIndex, dataType: axmlTypeIntDec, data: 21},
+		},
+	})
+
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("apk_test")
+	require.NoError(t, err)
+
+	t.Log("it reads the manifest of a signed apk")
+	{
+		apkPth := filepath.Join(tmpDir, "com.bitrise.sampleapp-Signed.apk")
+		createTestAPK(t, apkPth, map[string][]byte{
+			"AndroidManifest.xml":   manifest,
+			"META-INF/MANIFEST.MF":  []byte("Manifest-Version: 1.0"),
+			"META-INF/ANDROIDD.RSA": []byte("signature"),
+		})
+
+		metadata, err := ReadAPKMetadata(apkPth)
+		require.NoError(t, err)
+		require.Equal(t, APKMetadataModel{
+			PackageName:   "com.bitrise.sampleapp",
+			VersionCode:   "12",
+			VersionName:   "1.2.0",
+			MinSDKVersion: "21",
+			Signed:        true,
+		}, metadata)
+	}
+
+	t.Log("it reports unsigned apk")
+	{
+		apkPth := filepath.Join(tmpDir, "com.bitrise.sampleapp.apk")
+		createTestAPK(t, apkPth, map[string][]byte{
+			"AndroidManifest.xml": manifest,
+		})
+
+		metadata, err := ReadAPKMetadata(apkPth)
+		require.NoError(t, err)
+		require.Equal(t, "com.bitrise.sampleapp", metadata.PackageName)
+		require.Equal(t, false, metadata.Signed)
+	}
+
+	t.Log("it reads the instrumentations of a test apk")
+	{
+		testManifest := encodeTestAXML([]string{"name", "package", "manifest", "instrumentation", "com.bitrise.sampleapp.tests", "app.tests.TestInstrumentation"}, []uint32{0x01010003}, []uint32{2, 3}, [][]testAXMLAttribute{
+			{
+				{name: 1, rawValue: 4, dataType: axmlTypeString, data: 4},
+			},
+			{
+				{name: 0, rawValue: 5, dataType: axmlTypeString, data: 5},
+			},
+		})
+
+		apkPth := filepath.Join(tmpDir, "com.bitrise.sampleapp.tests-Signed.apk")
+		createTestAPK(t, apkPth, map[string][]byte{
+			"AndroidManifest.xml": testManifest,
+		})
+
+		metadata, err := ReadAPKMetadata(apkPth)
+		require.NoError(t, err)
+		require.Equal(t, "com.bitrise.sampleapp.tests", metadata.PackageName)
+		require.Equal(t, []string{"app.tests.TestInstrumentation"}, metadata.Instrumentations)
+	}
+
+	t.Log("it fails for invalid manifest")
+	{
+		apkPth := filepath.Join(tmpDir, "invalid.apk")
+		createTestAPK(t, apkPth, map[string][]byte{
+			"AndroidManifest.xml": []byte(`<manifest package="com.bitrise.sampleapp" />`),
+		})
+
+		_, err := ReadAPKMetadata(apkPth)
+		require.Error(t, err)
+
+		_, err = ReadAPKMetadata(filepath.Join(tmpDir, "missing.apk"))
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,109 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// supportedArchitectures returns the MtouchArch values supported by the platform:
+// Apple TV devices are arm64 only and the tvOS simulator is x86_64 only
+func supportedArchitectures(sdk constants.SDK, simulator bool) []string {
+	switch {
+	case sdk == constants.SDKTvOS && simulator:
+		return []string{"x86_64"}
+	case sdk == constants.SDKTvOS:
+		return []string{"arm64"}
+	case simulator:
+		return []string{"i386", "x86_64"}
+	default:
+		return []string{"armv7", "armv7s", "arm64"}
+	}
+}
+
+func unsupportedArchitectures(sdk constants.SDK, simulator bool, architectures ...string) []string {
+	unsupported := []string{}
+	for _, arch := range architectures {
+		supported := false
+		for _, supportedArch := range supportedArchitectures(sdk, simulator) {
+			if strings.EqualFold(arch, supportedArch) {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			unsupported = append(unsupported, arch)
+		}
+	}
+	return unsupported
+}
+
+// validateAppleArchitectures returns a warning if the project configuration targets architectures
+// not supported by the device or simulator platform of the build
+func (builder Model) validateAppleArchitectures(proj project.Model, projectConfig project.ConfigurationPlatformModel) []string {
+	simulator := builder.isIOSSimulatorBuild(projectConfig)
+	if unsupported := unsupportedArchitectures(proj.SDK, simulator, projectConfig.MtouchArchs...); len(unsupported) > 0 {
+		target := "device"
+		if simulator {
+			target = "simulator"
+		}
+		return []string{fmt.Sprintf("project (%s) config (%s|%s) targets architectures (%s) not supported by the %s %s, supported: %s",
+			proj.Name, projectConfig.Configuration, projectConfig.Platform, strings.Join(unsupported, ", "), proj.SDK, target, strings.Join(supportedArchitectures(proj.SDK, simulator), ", "))}
+	}
+	return []string{}
+}
+
+// simulatorArchitectures returns the simulator build mode's architectures supported by the SDK's simulator,
+// like x86_64 only for tvOS, if the architectures are set for iOS
+func (builder Model) simulatorArchitectures(sdk constants.SDK) []string {
+	architectures := []string{}
+	for _, arch := range builder.iosSimulatorArchitectures {
+		if len(unsupportedArchitectures(sdk, true, arch)) == 0 {
+			architectures = append(architectures, arch)
+		}
+	}
+	if len(architectures) == 0 {
+		return defaultSimulatorArchitectures
+	}
+	return architectures
+}
+
+// xcarchivePlatform returns the platform of the archived app, like: iphoneos, appletvos
+func xcarchivePlatform(xcarchivePth string) (string, error) {
+	appPth, err := xcarchiveAppPth(xcarchivePth)
+	if err != nil {
+		return "", err
+	}
+
+	infoPlist, err := plist.New(filepath.Join(appPth, "Info.plist"))
+	if err != nil {
+		return "", err
+	}
+
+	platform, _ := infoPlist.GetString("DTPlatformName")
+	return strings.ToLower(platform), nil
+}
+
+// xcarchiveMatchesSDK - the xcarchive selected by the assembly name contains an app of the SDK,
+// like the iOS and tvOS apps of a solution may share the assembly name prefix, archives of unknown platform match
+func xcarchiveMatchesSDK(logger tools.Logger, xcarchivePth string, sdk constants.SDK) bool {
+	platform, err := xcarchivePlatform(xcarchivePth)
+	if err != nil || platform == "" {
+		return true
+	}
+
+	expected := "iphoneos"
+	if sdk == constants.SDKTvOS {
+		expected = "appletvos"
+	}
+	if platform != expected {
+		logger.Warnf("xcarchive (%s) contains %s app, instead of %s, skipping...", xcarchivePth, platform, sdk)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAppleArchitectures(t *testing.T) {
+	t.Log("it accepts the supported architectures")
+	{
+		builder := Model{}
+		require.Equal(t, []string{}, builder.validateAppleArchitectures(project.Model{SDK: constants.SDKIOS}, project.ConfigurationPlatformModel{Platform: "iPhone", MtouchArchs: []string{"ARMv7", "ARM64"}}))
+		require.Equal(t, []string{}, builder.validateAppleArchitectures(project.Model{SDK: constants.SDKIOS}, project.ConfigurationPlatformModel{Platform: "iPhoneSimulator", MtouchArchs: []string{"i386", "x86_64"}}))
+		require.Equal(t, []string{}, builder.validateAppleArchitectures(project.Model{SDK: constants.SDKTvOS}, project.ConfigurationPlatformModel{Platform: "iPhone", MtouchArchs: []string{"ARM64"}}))
+		require.Equal(t, []string{}, builder.validateAppleArchitectures(project.Model{SDK: constants.SDKTvOS}, project.ConfigurationPlatformModel{Platform: "iPhoneSimulator", MtouchArchs: []string{"x86_64"}}))
+		require.Equal(t, []string{}, builder.validateAppleArchitectures(project.Model{SDK: constants.SDKTvOS}, project.ConfigurationPlatformModel{Platform: "iPhone"}))
+	}
+
+	t.Log("it warns about the architectures not supported by tvOS")
+	{
+		builder := Model{}
+		warnings := builder.validateAppleArchitectures(project.Model{Name: "TV", SDK: constants.SDKTvOS}, project.ConfigurationPlatformModel{Configuration: "Release", Platform: "iPhone", MtouchArchs: []string{"ARMv7", "ARM64"}})
+		require.Equal(t, 1, len(warnings))
+		require.Contains(t, warnings[0], "(ARMv7)")
+		require.Contains(t, warnings[0], "supported: arm64")
+
+		warnings = builder.validateAppleArchitectures(project.Model{Name: "TV", SDK: constants.SDKTvOS}, project.ConfigurationPlatformModel{Configuration: "Debug", Platform: "iPhoneSimulator", MtouchArchs: []string{"i386"}})
+		require.Equal(t, 1, len(warnings))
+		require.Contains(t, warnings[0], "simulator")
+	}
+
+	t.Log("it builds tvOS for the x86_64 simulator")
+	{
+		builder := Model{}
+		builder.SetIOSSimulatorBuild(true, "i386")
+		require.Equal(t, []string{"i386"}, builder.simulatorArchitectures(constants.SDKIOS))
+		require.Equal(t, []string{"x86_64"}, builder.simulatorArchitectures(constants.SDKTvOS))
+	}
+}
+
+func TestXCArchiveMatchesSDK(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("architectures_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	xcarchivePth := filepath.Join(tmpDir, "TV 2018-06-01 12-00-00.xcarchive")
+	writeTestInfoPlist(t, filepath.Join(xcarchivePth, "Info.plist"), `<key>ApplicationProperties</key>
+<dict>
+	<key>ApplicationPath</key>
+	<string>Applications/TV.app</string>
+</dict>`)
+	writeTestInfoPlist(t, filepath.Join(xcarchivePth, "Products", "Applications", "TV.app", "Info.plist"), `<key>DTPlatformName</key><string>appletvos</string>`)
+
+	t.Log("it matches the archived app's platform")
+	{
+		platform, err := xcarchivePlatform(xcarchivePth)
+		require.NoError(t, err)
+		require.Equal(t, "appletvos", platform)
+
+		require.Equal(t, true, xcarchiveMatchesSDK(tools.NewDefaultLogger(), xcarchivePth, constants.SDKTvOS))
+		require.Equal(t, false, xcarchiveMatchesSDK(tools.NewDefaultLogger(), xcarchivePth, constants.SDKIOS))
+	}
+
+	t.Log("it matches the archives of unknown platform")
+	{
+		require.Equal(t, true, xcarchiveMatchesSDK(tools.NewDefaultLogger(), filepath.Join(tmpDir, "not-exist.xcarchive"), constants.SDKIOS))
+	}
+}
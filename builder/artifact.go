@@ -0,0 +1,67 @@
+package builder
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+)
+
+// ArtifactModel - a collected output with the context it was built in
+type ArtifactModel struct {
+	Pth        string
+	OutputType constants.OutputType
+	ABI        string
+
+	Project       string
+	SDK           constants.SDK
+	Configuration string
+	Platform      string
+
+	Version      string // CFBundleShortVersionString or android:versionName
+	BuildNumber  string // CFBundleVersion or android:versionCode
+	CreationTime time.Time
+
+	Signature *SignatureModel
+}
+
+// Artifacts - returns the outputs of every project, sorted by project name and path
+func (projectOutputMap ProjectOutputMap) Artifacts() []ArtifactModel {
+	artifacts := []ArtifactModel{}
+	for projectName, projectOutput := range projectOutputMap {
+		for _, output := range projectOutput.Outputs {
+			artifacts = append(artifacts, ArtifactModel{
+				Pth:           output.Pth,
+				OutputType:    output.OutputType,
+				ABI:           output.ABI,
+				Project:       projectName,
+				SDK:           projectOutput.ProjectType,
+				Configuration: projectOutput.Configuration,
+				Platform:      projectOutput.Platform,
+				Version:       projectOutput.Version,
+				BuildNumber:   projectOutput.BuildNumber,
+				CreationTime:  output.CreationTime,
+				Signature:     output.Signature,
+			})
+		}
+	}
+
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Project != artifacts[j].Project {
+			return artifacts[i].Project < artifacts[j].Project
+		}
+		return artifacts[i].Pth < artifacts[j].Pth
+	})
+
+	return artifacts
+}
+
+// setCreationTimes sets the creation time of the outputs to their (newest) modification time
+func setCreationTimes(outputs []OutputModel) []OutputModel {
+	for i, output := range outputs {
+		if output.CreationTime.IsZero() {
+			outputs[i].CreationTime = artifactModTime(output.Pth)
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,70 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifacts(t *testing.T) {
+	t.Log("it flattens the outputs with their project context")
+	{
+		creationTime := time.Date(2017, 5, 10, 12, 0, 0, 0, time.UTC)
+		projectOutputMap := ProjectOutputMap{
+			"App.iOS": ProjectOutputModel{
+				ProjectType: constants.SDKIOS,
+				Outputs: []OutputModel{
+					{Pth: "/bin/iPhone/Release/App.ipa", OutputType: constants.OutputTypeIPA, CreationTime: creationTime},
+				},
+				Configuration: "Release",
+				Platform:      "iPhone",
+				Version:       "1.2.0",
+				BuildNumber:   "12",
+			},
+			"App.Droid": ProjectOutputModel{
+				ProjectType: constants.SDKAndroid,
+				Outputs: []OutputModel{
+					{Pth: "/bin/Release/com.bitrise.app-Signed.apk", OutputType: constants.OutputTypeAPK},
+				},
+			},
+		}
+
+		artifacts := projectOutputMap.Artifacts()
+		require.Equal(t, 2, len(artifacts))
+		require.Equal(t, "App.Droid", artifacts[0].Project)
+		require.Equal(t, ArtifactModel{
+			Pth:           "/bin/iPhone/Release/App.ipa",
+			OutputType:    constants.OutputTypeIPA,
+			Project:       "App.iOS",
+			SDK:           constants.SDKIOS,
+			Configuration: "Release",
+			Platform:      "iPhone",
+			Version:       "1.2.0",
+			BuildNumber:   "12",
+			CreationTime:  creationTime,
+		}, artifacts[1])
+	}
+
+	t.Log("it sets the creation time to the newest modification time")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("artifact_test")
+		require.NoError(t, err)
+
+		createTestFile(t, tmpDir, "App.app/Info.plist")
+		createTestFile(t, tmpDir, "App.app/App")
+
+		oldTime := time.Date(2017, 5, 10, 12, 0, 0, 0, time.UTC)
+		newTime := oldTime.Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "App.app", "Info.plist"), oldTime, oldTime))
+		require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "App.app", "App"), newTime, newTime))
+		require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "App.app"), oldTime, oldTime))
+
+		outputs := setCreationTimes([]OutputModel{{Pth: filepath.Join(tmpDir, "App.app"), OutputType: constants.OutputTypeAPP}})
+		require.Equal(t, newTime, outputs[0].CreationTime.UTC())
+	}
+}
@@ -0,0 +1,111 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/utility"
+)
+
+// Requirement - requirement of a build setting
+type Requirement string
+
+const (
+	// RequirementAny - the setting is not checked
+	RequirementAny Requirement = ""
+	// RequirementEnabled ...
+	RequirementEnabled Requirement = "enabled"
+	// RequirementDisabled ...
+	RequirementDisabled Requirement = "disabled"
+)
+
+// BitcodePolicyModel - the required bitcode and symbol stripping settings of the archived iOS and tvOS configurations
+type BitcodePolicyModel struct {
+	Bitcode      Requirement // MtouchEnableBitcode
+	StripSymbols Requirement // native symbols are stripped, unless MtouchNoSymbolStrip or --nosymbolstrip of MtouchExtraArgs is set
+}
+
+// SetBitcodePolicy - sets the policy of the distribution type, like: bitcode disabled for ad-hoc, enabled for app-store.
+// The policy of the distribution type of SetIOSProvisioningProfileSelection is enforced before building,
+// the policy of profiles.DistributionTypeUnknown applies to every other build.
+// Build properties set by SetBuildProperty override the project's settings.
+func (builder *Model) SetBitcodePolicy(distributionType profiles.DistributionType, policy BitcodePolicyModel) *Model {
+	if builder.bitcodePolicies == nil {
+		builder.bitcodePolicies = map[profiles.DistributionType]BitcodePolicyModel{}
+	}
+	builder.bitcodePolicies[distributionType] = policy
+	return builder
+}
+
+func (builder Model) bitcodePolicy() (BitcodePolicyModel, bool) {
+	if policy, ok := builder.bitcodePolicies[builder.iosDistributionType]; ok {
+		return policy, true
+	}
+	policy, ok := builder.bitcodePolicies[profiles.DistributionTypeUnknown]
+	return policy, ok
+}
+
+// checkBitcodePolicy fails with every violation of the archived iOS and tvOS project configurations
+func (builder Model) checkBitcodePolicy(projects []project.Model, configuration, platform string) error {
+	policy, ok := builder.bitcodePolicy()
+	if !ok {
+		return nil
+	}
+
+	solutionConfig := utility.ToConfig(configuration, platform)
+
+	violations := []string{}
+	for _, proj := range projects {
+		if proj.SDK != constants.SDKIOS && proj.SDK != constants.SDKTvOS {
+			continue
+		}
+
+		projectConfig, ok := proj.Configs[proj.ConfigMap[solutionConfig]]
+		if !ok || !builder.archivesIOSProject(projectConfig) {
+			continue
+		}
+
+		for _, violation := range builder.bitcodePolicyViolations(policy, projectConfig) {
+			violations = append(violations, fmt.Sprintf("project (%s) config (%s): %s", proj.Name, utility.ToConfig(projectConfig.Configuration, projectConfig.Platform), violation))
+		}
+	}
+
+	if len(violations) > 0 {
+		distributionType := builder.iosDistributionType
+		if distributionType == profiles.DistributionTypeUnknown {
+			distributionType = "default"
+		}
+		return fmt.Errorf("%s bitcode policy is violated:\n%s", distributionType, strings.Join(violations, "\n"))
+	}
+	return nil
+}
+
+func (builder Model) bitcodePolicyViolations(policy BitcodePolicyModel, projectConfig project.ConfigurationPlatformModel) []string {
+	bitcode := projectConfig.MtouchEnableBitcode
+	if value, ok := builder.buildProperties["MtouchEnableBitcode"]; ok {
+		bitcode = strings.EqualFold(value, "true")
+	}
+
+	noSymbolStrip := projectConfig.MtouchNoSymbolStrip || strings.Contains(projectConfig.MtouchExtraArgs, "--nosymbolstrip")
+	if value, ok := builder.buildProperties["MtouchNoSymbolStrip"]; ok {
+		noSymbolStrip = strings.EqualFold(value, "true")
+	}
+
+	violations := []string{}
+	switch {
+	case policy.Bitcode == RequirementEnabled && !bitcode:
+		violations = append(violations, "bitcode is required, set MtouchEnableBitcode to true")
+	case policy.Bitcode == RequirementDisabled && bitcode:
+		violations = append(violations, "bitcode is not allowed, set MtouchEnableBitcode to false")
+	}
+	switch {
+	case policy.StripSymbols == RequirementEnabled && noSymbolStrip:
+		violations = append(violations, "symbol stripping is required, remove MtouchNoSymbolStrip and --nosymbolstrip")
+	case policy.StripSymbols == RequirementDisabled && !noSymbolStrip:
+		violations = append(violations, "symbol stripping is not allowed, set MtouchNoSymbolStrip to true")
+	}
+	return violations
+}
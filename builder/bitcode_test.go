@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBitcodePolicy(t *testing.T) {
+	projects := []project.Model{
+		{
+			Name:      "iOS",
+			SDK:       constants.SDKIOS,
+			ConfigMap: map[string]string{"Release|iPhone": "Release|iPhone", "Debug|iPhoneSimulator": "Debug|iPhoneSimulator"},
+			Configs: map[string]project.ConfigurationPlatformModel{
+				"Release|iPhone":        {Configuration: "Release", Platform: "iPhone", MtouchArchs: []string{"ARM64"}, MtouchEnableBitcode: true},
+				"Debug|iPhoneSimulator": {Configuration: "Debug", Platform: "iPhoneSimulator", MtouchArchs: []string{"x86_64"}},
+			},
+		},
+		{Name: "Android", SDK: constants.SDKAndroid},
+	}
+
+	t.Log("it enforces the policy of the distribution type")
+	{
+		builder := Model{}
+		builder.SetIOSProvisioningProfileSelection(profiles.DistributionTypeAdHoc, "/profiles")
+		builder.SetBitcodePolicy(profiles.DistributionTypeAdHoc, BitcodePolicyModel{Bitcode: RequirementDisabled})
+		builder.SetBitcodePolicy(profiles.DistributionTypeAppStore, BitcodePolicyModel{Bitcode: RequirementEnabled})
+
+		err := builder.checkBitcodePolicy(projects, "Release", "iPhone")
+		require.EqualError(t, err, "ad-hoc bitcode policy is violated:\nproject (iOS) config (Release|iPhone): bitcode is not allowed, set MtouchEnableBitcode to false")
+
+		builder.SetBuildProperty("MtouchEnableBitcode", "false")
+		require.NoError(t, builder.checkBitcodePolicy(projects, "Release", "iPhone"))
+	}
+
+	t.Log("it applies the default policy")
+	{
+		builder := Model{}
+		builder.SetBitcodePolicy(profiles.DistributionTypeUnknown, BitcodePolicyModel{Bitcode: RequirementEnabled, StripSymbols: RequirementDisabled})
+
+		err := builder.checkBitcodePolicy(projects, "Release", "iPhone")
+		require.EqualError(t, err, "default bitcode policy is violated:\nproject (iOS) config (Release|iPhone): symbol stripping is not allowed, set MtouchNoSymbolStrip to true")
+	}
+
+	t.Log("it skips the simulator builds and the builds without policy")
+	{
+		builder := Model{}
+		builder.SetBitcodePolicy(profiles.DistributionTypeUnknown, BitcodePolicyModel{Bitcode: RequirementDisabled})
+		require.NoError(t, builder.checkBitcodePolicy(projects, "Debug", "iPhoneSimulator"))
+
+		require.NoError(t, Model{}.checkBitcodePolicy(projects, "Release", "iPhone"))
+	}
+}
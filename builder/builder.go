@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
 	"github.com/brandonrisell/go-xamarin/analyzers/project"
 	"github.com/brandonrisell/go-xamarin/analyzers/solution"
 	"github.com/brandonrisell/go-xamarin/constants"
 	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/tools/androidsdk"
+	"github.com/brandonrisell/go-xamarin/tools/notarytool"
 	"github.com/brandonrisell/go-xamarin/tools/nunit"
 	"github.com/brandonrisell/go-xamarin/utility"
 )
@@ -20,19 +24,100 @@ type Model struct {
 	solution solution.Model
 
 	projectTypeWhitelist []constants.SDK
+	folderWhitelist      []string
 	forceMDTool          bool
+	buildLibraries       bool
+
+	commandHooks  []tools.CommandHook
+	preBuildHooks []PreBuildHook
+
+	archiveBasePath   string
+	buildProperties   map[string]string
+	artifactSelection ArtifactSelectionStrategy
+	artifactSearch    ArtifactSearchModel
+	zipDSYMs          bool
+	collectSymbols    bool
+
+	symbolUploaders []SymbolUploader
+
+	androidKeystore         AndroidKeystoreModel
+	androidPostBuildSigning bool
+	secrets                 *tools.Secrets
+
+	androidSupportedABIs       []string
+	androidCreatePackagePerABI *bool
+	androidVersionCode         string
+	androidVersionName         string
+	androidSDK                 *androidsdk.Model
+	androidBuildProperties     map[string]string
+	androidReleaseGuard        AndroidReleaseGuard
+	androidManifestVariant     AndroidManifestVariantModel
+
+	iosDistributionType     profiles.DistributionType
+	provisioningProfilesDir string
+	codesignIdentity        string
+	keychainPth             string
+	keychainPassword        string
+	xcodeCompatibilityCheck XcodeCompatibilityCheck
+	bitcodePolicies         map[profiles.DistributionType]BitcodePolicyModel
+
+	iosSimulatorBuild         bool
+	iosSimulatorArchitectures []string
+
+	verifyMacOSSigning      bool
+	expectedSigningIdentity string
+	macOSNotarization       *notarytool.CredentialsModel
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+
+	quiet bool
+
+	session              *buildSession
+	disableSessionFilter bool
+
+	events           *eventWriter
+	logger           tools.Logger
+	progressCallback ProgressCallback
+	logArchive       *logArchive
 }
 
 // OutputModel ...
 type OutputModel struct {
 	Pth        string
 	OutputType constants.OutputType
+	ABI        string // Android ABI of the per-ABI split apk, like: arm64-v8a
+
+	DistributionType string // Distribution method of the ipa re-exported from the xcarchive, like: ad-hoc
+
+	Signature    *SignatureModel    // Signing status of the macOS .app and .pkg, if verification is enabled
+	Notarization *NotarizationModel // Notarization status of the macOS .app and .pkg, if notarization is enabled
+	Upload       *UploadModel       // App Store Connect upload status of the ipa and .pkg, if uploaded
+
+	CreationTime time.Time // Modification time of the output, the newest of its files for bundles
 }
 
 // ProjectOutputModel ...
 type ProjectOutputModel struct {
 	ProjectType constants.SDK
 	Outputs     []OutputModel
+
+	Configuration string // Project configuration of the outputs
+	Platform      string // Project platform of the outputs
+
+	Version     string // Version of the app outputs: CFBundleShortVersionString or android:versionName
+	BuildNumber string // Build number of the app outputs: CFBundleVersion or android:versionCode
+}
+
+// APKsByABI - returns the per-ABI split apks, keyed by the ABI
+func (projectOutput ProjectOutputModel) APKsByABI() map[string]string {
+	apks := map[string]string{}
+	for _, output := range projectOutput.Outputs {
+		if output.OutputType == constants.OutputTypeAPK && output.ABI != "" {
+			apks[output.ABI] = output.Pth
+		}
+	}
+	return apks
 }
 
 // ProjectOutputMap ...
@@ -43,6 +128,7 @@ type TestProjectOutputModel struct {
 	TestFramwork         constants.TestFramework
 	ReferredProjectNames []string
 	Output               OutputModel
+	DependencyDir        string // Directory of the test assembly and its dependencies, set by CollectTestProjectOutputs
 }
 
 // TestProjectOutputMap ...
@@ -59,11 +145,20 @@ type ClearCommandCallback func(project project.Model, dir string)
 
 // New ...
 func New(solutionPth string, projectTypeWhitelist []constants.SDK, forceMDTool bool) (Model, error) {
+	return NewWithAnalyzer(solutionPth, projectTypeWhitelist, forceMDTool, solution.NewAnalyzer())
+}
+
+// NewWithAnalyzer - analyzes the solution with the given analyzer's options, like its project cache
+func NewWithAnalyzer(solutionPth string, projectTypeWhitelist []constants.SDK, forceMDTool bool, analyzer *solution.Analyzer) (Model, error) {
 	if err := validateSolutionPth(solutionPth); err != nil {
 		return Model{}, err
 	}
 
-	solution, err := solution.New(solutionPth, true)
+	if analyzer == nil {
+		analyzer = solution.NewAnalyzer()
+	}
+
+	solution, err := analyzer.New(solutionPth, true)
 	if err != nil {
 		return Model{}, err
 	}
@@ -77,9 +172,107 @@ func New(solutionPth string, projectTypeWhitelist []constants.SDK, forceMDTool b
 
 		projectTypeWhitelist: projectTypeWhitelist,
 		forceMDTool:          forceMDTool,
+		artifactSelection:    ArtifactSelectionNewest,
+		artifactSearch:       DefaultArtifactSearch(),
+		session:              &buildSession{},
+		secrets:              tools.NewSecrets(),
 	}, nil
 }
 
+// SetFolderWhitelist - only the projects placed into the given solution folders (or into their subfolders) will be built,
+// folders are specified by their slash separated path, like: Apps/iOS
+func (builder *Model) SetFolderWhitelist(folders ...string) *Model {
+	builder.folderWhitelist = folders
+	return builder
+}
+
+// SetBuildLibraries - class library and binding projects are built as well, like for NuGet packaging,
+// their assemblies are collected as dll outputs
+func (builder *Model) SetBuildLibraries(buildLibraries bool) *Model {
+	builder.buildLibraries = buildLibraries
+	return builder
+}
+
+// AddCommandHook - registers a hook, which is notified about every command the builder runs
+func (builder *Model) AddCommandHook(hook tools.CommandHook) *Model {
+	builder.commandHooks = append(builder.commandHooks, hook)
+	return builder
+}
+
+// SetArchiveBasePath - archives created by xbuild will be placed into the given directory, instead of ~/Library/Developer/Xcode/Archives
+func (builder *Model) SetArchiveBasePath(archiveBasePath string) *Model {
+	builder.archiveBasePath = archiveBasePath
+	return builder
+}
+
+// SetArtifactSelectionStrategy - defines which ipa and xcarchive is collected, if more than one matches,
+// defaults to ArtifactSelectionNewest
+func (builder *Model) SetArtifactSelectionStrategy(strategy ArtifactSelectionStrategy) *Model {
+	builder.artifactSelection = strategy
+	return builder
+}
+
+// SetZipDSYMs - the collected .app.dSYM bundles are zipped next to the bundle,
+// the dsym output points to the zip
+func (builder *Model) SetZipDSYMs(zipDSYMs bool) *Model {
+	builder.zipDSYMs = zipDSYMs
+	return builder
+}
+
+// SetCollectSymbols - the .pdb and .mdb symbol files next to the built assemblies are zipped
+// and collected as symbols output, for debugging release crashes
+func (builder *Model) SetCollectSymbols(collectSymbols bool) *Model {
+	builder.collectSymbols = collectSymbols
+	return builder
+}
+
+// SetVerifyMacOSSigning - the collected macOS .app and .pkg outputs are verified by codesign and pkgutil,
+// if expectedIdentity is not empty, the outputs are expected to be signed with it (common name or team id)
+func (builder *Model) SetVerifyMacOSSigning(verify bool, expectedIdentity string) *Model {
+	builder.verifyMacOSSigning = verify
+	builder.expectedSigningIdentity = expectedIdentity
+	return builder
+}
+
+// SetBuildProperty - the given msbuild property is passed to every xbuild build command,
+// overriding the project's value, like: MtouchLink=SdkOnly
+func (builder *Model) SetBuildProperty(name, value string) *Model {
+	if builder.buildProperties == nil {
+		builder.buildProperties = map[string]string{}
+	}
+	builder.buildProperties[name] = value
+	return builder
+}
+
+// SetTimeout - every command run by the builder is terminated if it does not finish within the given timeout
+func (builder *Model) SetTimeout(timeout time.Duration) *Model {
+	builder.timeout = timeout
+	return builder
+}
+
+// SetKillGracePeriod - time to wait for a timed out command to terminate, before killing it
+func (builder *Model) SetKillGracePeriod(killGracePeriod time.Duration) *Model {
+	builder.killGracePeriod = killGracePeriod
+	return builder
+}
+
+// SetQuiet - filters the repetitive tool banners and NuGet progress from the build output, warnings and errors are kept
+func (builder *Model) SetQuiet(quiet bool) *Model {
+	builder.quiet = quiet
+	return builder
+}
+
+// SetLogger - the builder's and its build tools' messages are passed to the logger, instead of printing them
+func (builder *Model) SetLogger(logger tools.Logger) *Model {
+	builder.logger = logger
+	builder.events.setLogger(logger)
+	return builder
+}
+
+func (builder Model) log() tools.Logger {
+	return tools.LoggerOrDefault(builder.logger)
+}
+
 // CleanAll ...
 func (builder Model) CleanAll(callback ClearCommandCallback) error {
 	whitelistedProjects := builder.whitelistedProjects()
@@ -128,17 +321,40 @@ func (builder Model) BuildSolution(configuration, platform string, callback Buil
 		return err
 	}
 
+	compatibilityWarnings, err := builder.checkXcodeCompatibility(builder.whitelistedProjects())
+	if err != nil {
+		return err
+	}
+	for _, warning := range compatibilityWarnings {
+		builder.log().Warnf("%s", warning)
+	}
+
+	if err := builder.checkBitcodePolicy(builder.whitelistedProjects(), configuration, platform); err != nil {
+		return err
+	}
+
+	if err := builder.unlockKeychain(builder.whitelistedProjects(), callback); err != nil {
+		return err
+	}
+
+	if err := builder.runPreBuildHooks(builder.whitelistedProjects()); err != nil {
+		return err
+	}
+
 	buildCommand, err := builder.buildSolutionCommand(configuration, platform)
 	if err != nil {
-		return fmt.Errorf("Failed to create build command, error: %s", err)
+		return fmt.Errorf("Failed to create build command, error: %w", err)
 	}
 
 	// Callback to notify the caller about next running command
 	if callback != nil {
-		callback(builder.solution.Name, "", constants.SDKUnknown, constants.TestFrameworkUnknown, buildCommand.PrintableCommand(), false)
+		callback(builder.solution.Name, "", constants.SDKUnknown, constants.TestFrameworkUnknown, builder.printableCommand(buildCommand), false)
 	}
 
-	return buildCommand.Run()
+	if err := builder.runCommand(buildCommand); err != nil {
+		return newBuildFailedError("", err)
+	}
+	return nil
 }
 
 // BuildAllProjects ...
@@ -154,43 +370,32 @@ func (builder Model) BuildAllProjects(configuration, platform string, prepareCal
 		return warns, fmt.Errorf("No project to build found")
 	}
 
-	perfomedCommands := []tools.Printable{}
-
-	for _, proj := range buildableProjects {
-		buildCommands, warns, err := builder.buildProjectCommand(configuration, platform, proj)
-		warnings = append(warnings, warns...)
-		if err != nil {
-			return warnings, fmt.Errorf("Failed to create build command, error: %s", err)
-		}
+	compatibilityWarnings, err := builder.checkXcodeCompatibility(buildableProjects)
+	warnings = append(warnings, compatibilityWarnings...)
+	builder.events.warnings(compatibilityWarnings)
+	if err != nil {
+		return warnings, err
+	}
 
-		for _, buildCommand := range buildCommands {
-			// Callback to let the caller to modify the command
-			if prepareCallback != nil {
-				editabeCommand := tools.Editable(buildCommand)
-				prepareCallback(builder.solution.Name, proj.Name, proj.SDK, proj.TestFramework, &editabeCommand)
-			}
+	if err := builder.checkBitcodePolicy(buildableProjects, configuration, platform); err != nil {
+		return warnings, err
+	}
 
-			// Check if same command was already performed
-			alreadyPerformed := false
-			if tools.PrintableSliceContains(perfomedCommands, buildCommand) {
-				alreadyPerformed = true
-			}
+	if err := builder.unlockKeychain(buildableProjects, callback); err != nil {
+		return warnings, err
+	}
 
-			// Callback to notify the caller about next running command
-			if callback != nil {
-				callback(builder.solution.Name, proj.Name, proj.SDK, proj.TestFramework, buildCommand.PrintableCommand(), alreadyPerformed)
-			}
+	if err := builder.runPreBuildHooks(buildableProjects); err != nil {
+		return warnings, err
+	}
 
-			if !alreadyPerformed {
-				if err := buildCommand.Run(); err != nil {
-					return warnings, err
-				}
-				perfomedCommands = append(perfomedCommands, buildCommand)
-			}
-		}
+	steps, warns, err := builder.planBuildSteps(configuration, platform, buildableProjects, prepareCallback)
+	warnings = append(warnings, warns...)
+	if err != nil {
+		return warnings, err
 	}
 
-	return warnings, nil
+	return warnings, builder.runBuildSteps(steps, callback)
 }
 
 // BuildAllUITestableXamarinProjects ...
@@ -206,40 +411,48 @@ func (builder Model) BuildAllUITestableXamarinProjects(configuration, platform s
 		return warns, fmt.Errorf("No project to build found")
 	}
 
-	perfomedCommands := []tools.Printable{}
+	compatibilityWarnings, err := builder.checkXcodeCompatibility(buildableReferredProjects)
+	warnings = append(warnings, compatibilityWarnings...)
+	builder.events.warnings(compatibilityWarnings)
+	if err != nil {
+		return warnings, err
+	}
 
-	for _, proj := range buildableReferredProjects {
-		buildCommands, warns, err := builder.buildProjectCommand(configuration, platform, proj)
-		warnings = append(warnings, warns...)
-		if err != nil {
-			return warnings, fmt.Errorf("Failed to create build command, error: %s", err)
-		}
+	if err := builder.checkBitcodePolicy(buildableReferredProjects, configuration, platform); err != nil {
+		return warnings, err
+	}
 
-		for _, buildCommand := range buildCommands {
-			// Callback to let the caller to modify the command
-			if prepareCallback != nil {
-				editabeCommand := tools.Editable(buildCommand)
-				prepareCallback(builder.solution.Name, proj.Name, proj.SDK, proj.TestFramework, &editabeCommand)
-			}
+	if err := builder.unlockKeychain(buildableReferredProjects, callback); err != nil {
+		return warnings, err
+	}
 
-			// Check if same command was already performed
-			alreadyPerformed := false
-			if tools.PrintableSliceContains(perfomedCommands, buildCommand) {
-				alreadyPerformed = true
-			}
+	if err := builder.runPreBuildHooks(buildableReferredProjects); err != nil {
+		return warnings, err
+	}
 
-			// Callback to notify the caller about next running command
-			if callback != nil {
-				callback(builder.solution.Name, proj.Name, proj.SDK, proj.TestFramework, buildCommand.PrintableCommand(), alreadyPerformed)
-			}
+	steps, warns, err := builder.planBuildSteps(configuration, platform, buildableReferredProjects, prepareCallback)
+	warnings = append(warnings, warns...)
+	if err != nil {
+		return warnings, err
+	}
 
-			if !alreadyPerformed {
-				if err := buildCommand.Run(); err != nil {
-					return warnings, err
-				}
-				perfomedCommands = append(perfomedCommands, buildCommand)
-			}
-		}
+	return warnings, builder.runBuildSteps(steps, callback)
+}
+
+// BuildAllUITestableProjects - builds the Xamarin.UITest projects and the app projects they refer to
+func (builder Model) BuildAllUITestableProjects(configuration, platform string, prepareCallback PrepareCommandCallback, callback BuildCommandCallback) ([]string, error) {
+	warnings := []string{}
+
+	buildWarnings, err := builder.BuildAllUITestableXamarinProjects(configuration, platform, prepareCallback, callback)
+	warnings = append(warnings, buildWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	testBuildWarnings, err := builder.RunAllXamarinUITests(configuration, platform, prepareCallback, callback)
+	warnings = append(warnings, testBuildWarnings...)
+	if err != nil {
+		return warnings, err
 	}
 
 	return warnings, nil
@@ -263,8 +476,9 @@ func (builder Model) RunAllXamarinUITests(configuration, platform string, prepar
 	for _, testProj := range buildableTestProjects {
 		buildCommand, warns, err := builder.buildXamarinUITestProjectCommand(configuration, platform, testProj)
 		warnings = append(warnings, warns...)
+		builder.events.warnings(warns)
 		if err != nil {
-			return warnings, fmt.Errorf("Failed to create build command, error: %s", err)
+			return warnings, fmt.Errorf("Failed to create build command, error: %w", err)
 		}
 
 		// Callback to let the caller to modify the command
@@ -279,14 +493,16 @@ func (builder Model) RunAllXamarinUITests(configuration, platform string, prepar
 			alreadyPerformed = true
 		}
 
+		builder.events.projectStarted(testProj)
+
 		// Callback to notify the caller about next running command
 		if callback != nil {
-			callback(builder.solution.Name, testProj.Name, testProj.SDK, testProj.TestFramework, buildCommand.PrintableCommand(), alreadyPerformed)
+			callback(builder.solution.Name, testProj.Name, testProj.SDK, testProj.TestFramework, builder.printableCommand(buildCommand), alreadyPerformed)
 		}
 
 		if !alreadyPerformed {
-			if err := buildCommand.Run(); err != nil {
-				return warnings, err
+			if err := builder.runProjectCommand(testProj.Name, buildCommand); err != nil {
+				return warnings, newBuildFailedError(testProj.Name, err)
 			}
 			perfomedCommands = append(perfomedCommands, buildCommand)
 		}
@@ -297,21 +513,7 @@ func (builder Model) RunAllXamarinUITests(configuration, platform string, prepar
 
 // BuildAndRunAllXamarinUITestAndReferredProjects ...
 func (builder Model) BuildAndRunAllXamarinUITestAndReferredProjects(configuration, platform string, prepareCallback PrepareCommandCallback, callback BuildCommandCallback) ([]string, error) {
-	warnings := []string{}
-
-	buildWarnings, err := builder.BuildAllUITestableXamarinProjects(configuration, platform, prepareCallback, callback)
-	warnings = append(warnings, buildWarnings...)
-	if err != nil {
-		return warnings, err
-	}
-
-	runWarnings, err := builder.RunAllXamarinUITests(configuration, platform, prepareCallback, callback)
-	warnings = append(warnings, runWarnings...)
-	if err != nil {
-		return warnings, err
-	}
-
-	return warnings, nil
+	return builder.BuildAllUITestableProjects(configuration, platform, prepareCallback, callback)
 }
 
 // RunAllNunitTestProjects ...
@@ -336,8 +538,9 @@ func (builder Model) RunAllNunitTestProjects(configuration, platform string, cal
 	for _, testProj := range buildableProjects {
 		buildCommand, warns, err := builder.buildNunitTestProjectCommand(configuration, platform, testProj, nunitConsolePth)
 		warnings = append(warnings, warns...)
+		builder.events.warnings(warns)
 		if err != nil {
-			return warnings, fmt.Errorf("Failed to create build command, error: %s", err)
+			return warnings, fmt.Errorf("Failed to create build command, error: %w", err)
 		}
 
 		// Callback to let the caller to modify the command
@@ -352,14 +555,16 @@ func (builder Model) RunAllNunitTestProjects(configuration, platform string, cal
 			alreadyPerformed = true
 		}
 
+		builder.events.projectStarted(testProj)
+
 		// Callback to notify the caller about next running command
 		if callback != nil {
-			callback(builder.solution.Name, testProj.Name, constants.SDKUnknown, constants.TestFrameworkNunitTest, buildCommand.PrintableCommand(), alreadyPerformed)
+			callback(builder.solution.Name, testProj.Name, constants.SDKUnknown, constants.TestFrameworkNunitTest, builder.printableCommand(buildCommand), alreadyPerformed)
 		}
 
 		if !alreadyPerformed {
-			if err := buildCommand.Run(); err != nil {
-				return warnings, err
+			if err := builder.runProjectCommand(testProj.Name, buildCommand); err != nil {
+				return warnings, newBuildFailedError(testProj.Name, err)
 			}
 			perfomedCommands = append(perfomedCommands, buildCommand)
 		}
@@ -380,6 +585,7 @@ func (builder Model) BuildAndRunAllNunitTestProjects(configuration, platform str
 // CollectProjectOutputs ...
 func (builder Model) CollectProjectOutputs(configuration, platform string, startTime, endTime time.Time) (ProjectOutputMap, error) {
 	projectOutputMap := ProjectOutputMap{}
+	walker := builder.newArtifactWalker()
 
 	buildableProjects, _ := builder.buildableProjects(configuration, platform)
 
@@ -399,24 +605,73 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 		projectOutputs, ok := projectOutputMap[proj.Name]
 		if !ok {
 			projectOutputs = ProjectOutputModel{
-				ProjectType: proj.SDK,
-				Outputs:     []OutputModel{},
+				ProjectType:   proj.SDK,
+				Outputs:       []OutputModel{},
+				Configuration: projectConfig.Configuration,
+				Platform:      projectConfig.Platform,
+				Version:       proj.BundleShortVersion,
+				BuildNumber:   proj.BundleVersion,
+			}
+		}
+
+		if isLibraryProjectType(proj.ProjectType) {
+			if dllPth, err := exportDLL(builder.log(), walker, projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+				return ProjectOutputMap{}, err
+			} else if dllPth != "" {
+				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+					Pth:        dllPth,
+					OutputType: constants.OutputTypeDLL,
+				})
+			}
+
+			if proj.IsPackable() {
+				nupkgPths, err := exportNuPkgs(builder.log(), nuPkgDirs(proj, projectConfig), proj.PackageID, startTime, endTime)
+				if err != nil {
+					return ProjectOutputMap{}, err
+				}
+
+				for _, nupkgPth := range nupkgPths {
+					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+						Pth:        nupkgPth,
+						OutputType: constants.OutputTypeNuPkg,
+					})
+				}
+			}
+
+			if builder.collectSymbols {
+				if symbolsPth, err := exportSymbols(builder.log(), projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+					return ProjectOutputMap{}, err
+				} else if symbolsPth != "" {
+					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+						Pth:        symbolsPth,
+						OutputType: constants.OutputTypeSymbols,
+					})
+				}
+			}
+
+			projectOutputs.Outputs = builder.filterSessionOutputs(setCreationTimes(projectOutputs.Outputs))
+			if len(projectOutputs.Outputs) > 0 {
+				projectOutputMap[proj.Name] = projectOutputs
 			}
+			continue
 		}
 
 		switch proj.SDK {
 		case constants.SDKIOS, constants.SDKTvOS:
-			if isArchitectureArchiveable(projectConfig.MtouchArchs...) {
-				if xcarchivePth, err := exportLatestXCArchiveFromXcodeArchives(proj.AssemblyName, startTime, endTime); err != nil {
+			projectConfig = builder.simulatorProjectConfig(proj, projectConfig)
+			projectOutputs.Platform = projectConfig.Platform
+
+			if builder.archivesIOSProject(projectConfig) {
+				if xcarchivePth, err := builder.exportXCArchive(walker, proj.AssemblyName, startTime, endTime); err != nil {
 					return ProjectOutputMap{}, err
-				} else if xcarchivePth != "" {
+				} else if xcarchivePth != "" && xcarchiveMatchesSDK(builder.log(), xcarchivePth, proj.SDK) {
 					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
 						Pth:        xcarchivePth,
 						OutputType: constants.OutputTypeXCArchive,
 					})
 				}
 
-				if ipaPth, err := exportLatestIpa(projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+				if ipaPth, err := exportIpa(builder.log(), walker, projectConfig, proj.AssemblyName, startTime, endTime, builder.artifactSelection); err != nil {
 					return ProjectOutputMap{}, err
 				} else if ipaPth != "" {
 					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
@@ -425,9 +680,15 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 					})
 				}
 
-				if dsymPth, err := exportAppDSYM(projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+				if dsymPth, err := exportAppDSYM(builder.log(), walker, projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
 					return ProjectOutputMap{}, err
 				} else if dsymPth != "" {
+					if builder.zipDSYMs {
+						if dsymPth, err = zipDSYM(builder.log(), dsymPth); err != nil {
+							return ProjectOutputMap{}, err
+						}
+					}
+
 					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
 						Pth:        dsymPth,
 						OutputType: constants.OutputTypeDSYM,
@@ -435,17 +696,27 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 				}
 			}
 
-			if appPth, err := exportApp(projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+			if err := builder.verifyEmbeddedBundles(proj, projectOutputs.Outputs); err != nil {
+				return ProjectOutputMap{}, err
+			}
+
+			appOutputType := constants.OutputTypeAPP
+			if builder.isIOSSimulatorBuild(projectConfig) {
+				// simulator builds have no ipa, the .app is installed into the simulator
+				appOutputType = constants.OutputTypeSimulatorAPP
+			}
+
+			if appPth, err := exportApp(builder.log(), walker, projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
 				return ProjectOutputMap{}, err
 			} else if appPth != "" {
 				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
 					Pth:        appPth,
-					OutputType: constants.OutputTypeAPP,
+					OutputType: appOutputType,
 				})
 			}
 		case constants.SDKMacOS:
 			if builder.forceMDTool {
-				if xcarchivePth, err := exportLatestXCArchiveFromXcodeArchives(proj.AssemblyName, startTime, endTime); err != nil {
+				if xcarchivePth, err := builder.exportXCArchive(walker, proj.AssemblyName, startTime, endTime); err != nil {
 					return ProjectOutputMap{}, err
 				} else if xcarchivePth != "" {
 					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
@@ -454,7 +725,7 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 					})
 				}
 			}
-			if appPth, err := exportApp(projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+			if appPth, err := exportApp(builder.log(), walker, projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
 				return ProjectOutputMap{}, err
 			} else if appPth != "" {
 				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
@@ -462,7 +733,7 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 					OutputType: constants.OutputTypeAPP,
 				})
 			}
-			if pkgPth, err := exportPKG(projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+			if pkgPth, err := exportPKG(builder.log(), walker, projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
 				return ProjectOutputMap{}, err
 			} else if pkgPth != "" {
 				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
@@ -470,13 +741,50 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 					OutputType: constants.OutputTypePKG,
 				})
 			}
+
+			if builder.verifyMacOSSigning {
+				projectOutputs.Outputs = builder.verifyOutputSignatures(projectOutputs.Outputs)
+			}
+			if builder.macOSNotarization != nil {
+				projectOutputs.Outputs = builder.notarizeOutputs(projectOutputs.Outputs)
+			}
 		case constants.SDKAndroid:
-			packageName, err := androidPackageName(proj.ManifestPth)
+			manifest, err := builder.variantAndroidManifest(projectConfig.ManifestPth)
 			if err != nil {
 				return ProjectOutputMap{}, err
 			}
+			packageName := manifest.Package
+			projectOutputs.Version = manifest.VersionName
+			projectOutputs.BuildNumber = manifest.VersionCode
+			if builder.androidVersionName != "" {
+				projectOutputs.Version = builder.androidVersionName
+			}
+			if builder.androidVersionCode != "" {
+				projectOutputs.BuildNumber = builder.androidVersionCode
+			}
+
+			abiApks := map[string]string{}
+			if builder.createsPackagePerABI(projectConfig) {
+				if abiApks, err = exportApksPerABI(projectConfig.OutputDir, packageName, startTime, endTime); err != nil {
+					return ProjectOutputMap{}, err
+				}
+			}
 
-			if apkPth, err := exportApk(projectConfig.OutputDir, packageName, startTime, endTime); err != nil {
+			if len(abiApks) > 0 {
+				abis := []string{}
+				for abi := range abiApks {
+					abis = append(abis, abi)
+				}
+				sort.Strings(abis)
+
+				for _, abi := range abis {
+					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+						Pth:        abiApks[abi],
+						OutputType: constants.OutputTypeAPK,
+						ABI:        abi,
+					})
+				}
+			} else if apkPth, err := exportApk(builder.log(), walker, projectConfig.OutputDir, packageName, startTime, endTime); err != nil {
 				return ProjectOutputMap{}, err
 			} else if apkPth != "" {
 				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
@@ -484,13 +792,57 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 					OutputType: constants.OutputTypeAPK,
 				})
 			}
+
+			if aabPth, err := exportAab(walker, projectConfig.OutputDir, packageName, startTime, endTime); err != nil {
+				return ProjectOutputMap{}, err
+			} else if aabPth != "" {
+				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+					Pth:        aabPth,
+					OutputType: constants.OutputTypeAAB,
+				})
+			}
+
+			if builder.signsAndroidPackageAfterBuild() {
+				if projectOutputs.Outputs, err = builder.signAPKOutputs(projectOutputs.Outputs); err != nil {
+					return ProjectOutputMap{}, err
+				}
+			}
+
+			if projectConfig.MonoSymbolArchive {
+				mSYMPths, err := exportMSYMs(builder.log(), projectConfig.OutputDir, packageName, startTime, endTime)
+				if err != nil {
+					return ProjectOutputMap{}, err
+				}
+
+				for _, mSYMPth := range mSYMPths {
+					projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+						Pth:        mSYMPth,
+						OutputType: constants.OutputTypeMSYM,
+					})
+				}
+			}
+		}
+
+		if builder.collectSymbols {
+			if symbolsPth, err := exportSymbols(builder.log(), projectConfig.OutputDir, proj.AssemblyName, startTime, endTime); err != nil {
+				return ProjectOutputMap{}, err
+			} else if symbolsPth != "" {
+				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+					Pth:        symbolsPth,
+					OutputType: constants.OutputTypeSymbols,
+				})
+			}
 		}
 
+		projectOutputs.Outputs = builder.filterSessionOutputs(setCreationTimes(projectOutputs.Outputs))
 		if len(projectOutputs.Outputs) > 0 {
 			projectOutputMap[proj.Name] = projectOutputs
 		}
 	}
 
+	builder.uploadSymbols(projectOutputMap)
+	builder.events.artifacts(projectOutputMap)
+
 	return projectOutputMap, nil
 }
 
@@ -498,6 +850,7 @@ func (builder Model) CollectProjectOutputs(configuration, platform string, start
 func (builder Model) CollectXamarinUITestProjectOutputs(configuration, platform string, startTime, endTime time.Time) (TestProjectOutputMap, []string, error) {
 	testProjectOutputMap := TestProjectOutputMap{}
 	warnings := []string{}
+	walker := builder.newArtifactWalker()
 
 	buildableTestProjects, _, _ := builder.buildableXamarinUITestProjectsAndReferredProjects(configuration, platform)
 
@@ -514,19 +867,11 @@ func (builder Model) CollectXamarinUITestProjectOutputs(configuration, platform
 			continue
 		}
 
-		if dllPth, err := exportDLL(projectConfig.OutputDir, testProj.AssemblyName, startTime, endTime); err != nil {
+		if dllPth, err := exportDLL(builder.log(), walker, projectConfig.OutputDir, testProj.AssemblyName, startTime, endTime); err != nil {
 			return TestProjectOutputMap{}, warnings, err
-		} else if dllPth != "" {
-			referredProjectNames := []string{}
-			referredProjectIDs := testProj.ReferredProjectIDs
-			for _, referredProjectID := range referredProjectIDs {
-				referredProject, ok := builder.solution.ProjectMap[referredProjectID]
-				if !ok {
-					warnings = append(warnings, fmt.Sprintf("project reference exist with project id: %s, but project not found in solution", referredProjectID))
-				}
-
-				referredProjectNames = append(referredProjectNames, referredProject.Name)
-			}
+		} else if dllPth != "" && builder.isSessionArtifact(dllPth) {
+			referredProjectNames, warns := builder.referredProjectNames(testProj)
+			warnings = append(warnings, warns...)
 
 			testProjectOutputMap[testProj.Name] = TestProjectOutputModel{
 				TestFramwork:         testProj.TestFramework,
@@ -541,3 +886,76 @@ func (builder Model) CollectXamarinUITestProjectOutputs(configuration, platform
 
 	return testProjectOutputMap, warnings, nil
 }
+
+// CollectTestProjectOutputs - collects the built test assemblies of the Xamarin.UITest and unit test projects,
+// with the directory of their dependencies
+func (builder Model) CollectTestProjectOutputs(configuration, platform string, startTime, endTime time.Time) (TestProjectOutputMap, []string, error) {
+	testProjectOutputMap := TestProjectOutputMap{}
+	warnings := []string{}
+	walker := builder.newArtifactWalker()
+
+	solutionConfig := utility.ToConfig(configuration, platform)
+
+	for _, testProj := range builder.solution.ProjectMap {
+		if testProj.ProjectType != constants.ProjectTypeXamarinUITest && testProj.ProjectType != constants.ProjectTypeUnitTest {
+			continue
+		}
+
+		if !builder.folderWhitelistAllows(testProj) {
+			continue
+		}
+
+		projectConfigKey, ok := testProj.ConfigMap[solutionConfig]
+		if !ok {
+			continue
+		}
+
+		projectConfig, ok := testProj.Configs[projectConfigKey]
+		if !ok {
+			continue
+		}
+
+		dllPth, err := exportDLL(builder.log(), walker, projectConfig.OutputDir, testProj.AssemblyName, startTime, endTime)
+		if err != nil {
+			return TestProjectOutputMap{}, warnings, err
+		} else if dllPth == "" {
+			warnings = append(warnings, fmt.Sprintf("no test assembly found for project (%s) in (%s)", testProj.Name, projectConfig.OutputDir))
+			continue
+		} else if !builder.isSessionArtifact(dllPth) {
+			warnings = append(warnings, fmt.Sprintf("test assembly (%s) was created before the build session, skipping...", dllPth))
+			continue
+		}
+
+		referredProjectNames, warns := builder.referredProjectNames(testProj)
+		warnings = append(warnings, warns...)
+
+		testProjectOutputMap[testProj.Name] = TestProjectOutputModel{
+			TestFramwork:         testProj.TestFramework,
+			ReferredProjectNames: referredProjectNames,
+			Output: OutputModel{
+				Pth:        dllPth,
+				OutputType: constants.OutputTypeTestDLL,
+			},
+			DependencyDir: filepath.Dir(dllPth),
+		}
+	}
+
+	return testProjectOutputMap, warnings, nil
+}
+
+func (builder Model) referredProjectNames(proj project.Model) ([]string, []string) {
+	referredProjectNames := []string{}
+	warnings := []string{}
+
+	for _, referredProjectID := range proj.ReferredProjectIDs {
+		referredProject, ok := builder.solution.ProjectMap[referredProjectID]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("project reference exist with project id: %s, but project not found in solution", referredProjectID))
+			continue
+		}
+
+		referredProjectNames = append(referredProjectNames, referredProject.Name)
+	}
+
+	return referredProjectNames, warnings
+}
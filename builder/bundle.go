@@ -0,0 +1,108 @@
+package builder
+
+import (
+	"archive/zip"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools/buildtools/bundletool"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// BundleConversionModel - how to generate installable apks from the collected app bundles
+type BundleConversionModel struct {
+	BundletoolPth string // defaults to bundletool.SystemBundletoolPath
+
+	Mode          bundletool.Mode // ModeUniversal for a single universal apk, ModeDefault for a device specific apk set
+	DeviceSpecPth string          // the apk set contains only the apks matching the device spec json
+}
+
+// BuildAPKsFromAABs - generates installable apks from the collected aab outputs by bundletool:
+// a <name>-universal.apk (universal mode) or a <name>.apks apk set per aab, added to the outputs of the aab's project.
+// The apks are signed with the keystore of SetAndroidKeystore, bundletool signs them with the debug keystore otherwise.
+func (builder Model) BuildAPKsFromAABs(outputMap ProjectOutputMap, conversion BundleConversionModel) (ProjectOutputMap, error) {
+	bundletoolPth := conversion.BundletoolPth
+	if bundletoolPth == "" {
+		var err error
+		if bundletoolPth, err = bundletool.SystemBundletoolPath(); err != nil {
+			return ProjectOutputMap{}, err
+		}
+	}
+
+	for projectName, projectOutputs := range outputMap {
+		for _, output := range projectOutputs.Outputs {
+			if output.OutputType != constants.OutputTypeAAB {
+				continue
+			}
+
+			converted, err := builder.buildAPKsFromAAB(bundletoolPth, output.Pth, conversion)
+			if err != nil {
+				return ProjectOutputMap{}, err
+			}
+			converted.CreationTime = artifactModTime(converted.Pth)
+			projectOutputs.Outputs = append(projectOutputs.Outputs, converted)
+		}
+		outputMap[projectName] = projectOutputs
+	}
+
+	return outputMap, nil
+}
+
+func (builder Model) buildAPKsFromAAB(bundletoolPth, aabPth string, conversion BundleConversionModel) (OutputModel, error) {
+	apksPth := strings.TrimSuffix(aabPth, filepath.Ext(aabPth)) + ".apks"
+
+	command, err := bundletool.New(bundletoolPth, aabPth, apksPth)
+	if err != nil {
+		return OutputModel{}, err
+	}
+	command.SetMode(conversion.Mode)
+	command.SetDeviceSpecPth(conversion.DeviceSpecPth)
+	if builder.hasAndroidKeystore() {
+		keystore := builder.androidKeystore
+		command.SetKeystore(keystore.Pth, keystore.Alias, keystore.StorePassword, keystore.KeyPassword)
+	}
+
+	if err := builder.runCommand(command); err != nil {
+		return OutputModel{}, fmt.Errorf("failed to build apks from aab (%s), error: %s", aabPth, err)
+	}
+
+	if conversion.Mode != bundletool.ModeUniversal {
+		return OutputModel{Pth: apksPth, OutputType: constants.OutputTypeAPKs}, nil
+	}
+
+	universalAPKPth := strings.TrimSuffix(aabPth, filepath.Ext(aabPth)) + "-universal.apk"
+	if err := extractUniversalAPK(builder.log(), apksPth, universalAPKPth); err != nil {
+		return OutputModel{}, err
+	}
+	return OutputModel{Pth: universalAPKPth, OutputType: constants.OutputTypeAPK}, nil
+}
+
+// extractUniversalAPK - copies the universal.apk of the apk set to apkPth
+func extractUniversalAPK(logger tools.Logger, apksPth, apkPth string) error {
+	reader, err := zip.OpenReader(apksPth)
+	if err != nil {
+		return fmt.Errorf("failed to open apk set (%s), error: %s", apksPth, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Warnf("Failed to close apk set (%s), error: %s", apksPth, err)
+		}
+	}()
+
+	for _, file := range reader.File {
+		if file.Name != bundletool.UniversalAPKName {
+			continue
+		}
+
+		content, err := readZipFile(logger, file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s of (%s), error: %s", file.Name, apksPth, err)
+		}
+		return fileutil.WriteBytesToFile(apkPth, content)
+	}
+
+	return &ArtifactNotFoundError{OutputType: constants.OutputTypeAPK, Dir: apksPth}
+}
@@ -0,0 +1,43 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractUniversalAPK(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("bundle_test")
+	require.NoError(t, err)
+
+	t.Log("it extracts the universal apk of the apk set")
+	{
+		apksPth := filepath.Join(tmpDir, "com.bitrise.app.apks")
+		createTestAPK(t, apksPth, map[string][]byte{
+			"toc.pb":        []byte("toc"),
+			"universal.apk": []byte("universal"),
+		})
+
+		apkPth := filepath.Join(tmpDir, "com.bitrise.app-universal.apk")
+		require.NoError(t, extractUniversalAPK(tools.NewDefaultLogger(), apksPth, apkPth))
+
+		content, err := fileutil.ReadStringFromFile(apkPth)
+		require.NoError(t, err)
+		require.Equal(t, "universal", content)
+	}
+
+	t.Log("it fails if the apk set has no universal apk")
+	{
+		apksPth := filepath.Join(tmpDir, "split.apks")
+		createTestAPK(t, apksPth, map[string][]byte{
+			"toc.pb":                 []byte("toc"),
+			"splits/base-master.apk": []byte("base"),
+		})
+
+		require.Error(t, extractUniversalAPK(tools.NewDefaultLogger(), apksPth, filepath.Join(tmpDir, "split-universal.apk")))
+	}
+}
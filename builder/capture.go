@@ -0,0 +1,163 @@
+package builder
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCapturedLineLength - longer lines (like base64 encoded resources) are not scanned for artifact paths
+const maxCapturedLineLength = 64 * 1024
+
+// maxCapturedArtifacts - the number of the latest captured artifact paths kept by the session
+const maxCapturedArtifacts = 256
+
+var (
+	// msbuildOutputLineRegexp matches the primary output printed by msbuild (and xbuild) for each project,
+	// like: "  Sample.iOS -> /Users/bitrise/Sample/bin/iPhone/Release/Sample.iOS.dll"
+	msbuildOutputLineRegexp = regexp.MustCompile(`^\s*[^\s/][^/]*? -> (/.+)$`)
+	// mdtoolArchiveLineRegexp matches the archive printed by mdtool archive,
+	// like: "Archive: /Users/bitrise/Library/Developer/Xcode/Archives/2024-01-01/Sample 1-01-24 12.00 PM.xcarchive"
+	mdtoolArchiveLineRegexp = regexp.MustCompile(`(?i)^\s*archive[^/]*?(/.+\.xcarchive)$`)
+	// artifactPathRegexp matches the printed paths of the exported artifact types
+	artifactPathRegexp = regexp.MustCompile(`(?i)\.(ipa|apk|aab|xcarchive|pkg|dsym|app|dll)$`)
+)
+
+// artifactCapture records the artifact paths printed by the commands of the build session,
+// it is shared by the copies of the builder Model, only the latest maxCapturedArtifacts paths are kept
+type artifactCapture struct {
+	mutex sync.Mutex
+	pths  []string
+	known map[string]bool
+}
+
+func (capture *artifactCapture) add(pth string) {
+	capture.mutex.Lock()
+	defer capture.mutex.Unlock()
+
+	if capture.known == nil {
+		capture.known = map[string]bool{}
+	}
+	if capture.known[pth] {
+		return
+	}
+	if len(capture.pths) == maxCapturedArtifacts {
+		delete(capture.known, capture.pths[0])
+		capture.pths = capture.pths[1:]
+	}
+	capture.known[pth] = true
+	capture.pths = append(capture.pths, pth)
+}
+
+// captured returns the recorded paths in the order of their first occurrence
+func (capture *artifactCapture) captured() []string {
+	if capture == nil {
+		return nil
+	}
+
+	capture.mutex.Lock()
+	defer capture.mutex.Unlock()
+
+	return append([]string{}, capture.pths...)
+}
+
+// capturedArtifactPath returns the artifact path printed by msbuild or mdtool archive in the line, if any
+func capturedArtifactPath(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+
+	var matches []string
+	if strings.Contains(line, " -> ") {
+		matches = msbuildOutputLineRegexp.FindStringSubmatch(line)
+	} else {
+		matches = mdtoolArchiveLineRegexp.FindStringSubmatch(line)
+	}
+	if len(matches) != 2 {
+		return "", false
+	}
+
+	pth := strings.Trim(strings.TrimSpace(matches[1]), `'"`)
+	if !artifactPathRegexp.MatchString(pth) {
+		return "", false
+	}
+	return pth, true
+}
+
+// artifactCaptureWriter scans the written output line by line for artifact paths
+type artifactCaptureWriter struct {
+	capture *artifactCapture
+	buffer  []byte
+}
+
+func (capture *artifactCapture) newWriter() *artifactCaptureWriter {
+	return &artifactCaptureWriter{capture: capture}
+}
+
+// Write ...
+func (writer *artifactCaptureWriter) Write(p []byte) (int, error) {
+	writer.buffer = append(writer.buffer, p...)
+
+	for {
+		idx := bytes.IndexByte(writer.buffer, '\n')
+		if idx < 0 {
+			break
+		}
+		writer.scan(writer.buffer[:idx])
+		writer.buffer = writer.buffer[idx+1:]
+	}
+
+	if len(writer.buffer) > maxCapturedLineLength {
+		writer.buffer = nil
+	}
+
+	return len(p), nil
+}
+
+// Flush scans the last, not terminated line
+func (writer *artifactCaptureWriter) Flush() {
+	if len(writer.buffer) > 0 {
+		writer.scan(writer.buffer)
+		writer.buffer = nil
+	}
+}
+
+func (writer *artifactCaptureWriter) scan(line []byte) {
+	if len(line) > maxCapturedLineLength || !bytes.ContainsRune(line, '/') {
+		return
+	}
+	if pth, ok := capturedArtifactPath(string(line)); ok {
+		writer.capture.add(pth)
+	}
+}
+
+// capturedArtifacts returns the captured artifacts within the dir, matching the pattern and modified within the time interval,
+// or none, like if the tool did not print the artifact's path
+func (walker *artifactWalker) capturedArtifacts(dir string, startTime, endTime time.Time, pattern string) []artifactEntryModel {
+	entries := []artifactEntryModel{}
+	if len(walker.captured) == 0 {
+		return entries
+	}
+
+	dir = filepath.Clean(dir)
+	re := regexp.MustCompile(pattern)
+
+	for _, pth := range walker.captured {
+		pth = filepath.Clean(pth)
+		if !strings.HasPrefix(pth, dir+string(filepath.Separator)) {
+			continue
+		}
+		if re.FindString(pth) == "" {
+			continue
+		}
+
+		info, err := os.Stat(pth)
+		if err != nil || !isInTimeInterval(info.ModTime(), startTime, endTime) {
+			continue
+		}
+		entries = append(entries, artifactEntryModel{pth: pth, modTime: info.ModTime()})
+	}
+	return entries
+}
@@ -0,0 +1,117 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturedArtifactPath(t *testing.T) {
+	t.Log("it finds the paths printed by msbuild")
+	{
+		pth, ok := capturedArtifactPath("  Sample -> /Users/bitrise/Sample/bin/Release/Sample.dll")
+		require.Equal(t, true, ok)
+		require.Equal(t, "/Users/bitrise/Sample/bin/Release/Sample.dll", pth)
+
+		pth, ok = capturedArtifactPath("  Sample.iOS -> /Users/bitrise/My Apps/Sample.iOS/bin/iPhone/Release/Sample.iOS.ipa\r")
+		require.Equal(t, true, ok)
+		require.Equal(t, "/Users/bitrise/My Apps/Sample.iOS/bin/iPhone/Release/Sample.iOS.ipa", pth)
+	}
+
+	t.Log("it finds the archive printed by mdtool")
+	{
+		pth, ok := capturedArtifactPath("Archive: /Archives/2024-01-01/Sample 1-01-24 12.00 PM.xcarchive")
+		require.Equal(t, true, ok)
+		require.Equal(t, "/Archives/2024-01-01/Sample 1-01-24 12.00 PM.xcarchive", pth)
+	}
+
+	t.Log("it skips the other lines")
+	{
+		for _, line := range []string{
+			"Copying /tmp/Sample.app/Info.plist",
+			`Creating "/tmp/out/Sample.ipa".`,
+			"Compiling bin/Release/Sample.dll",
+			"  Sample -> /Users/bitrise/Sample/bin/Release/Sample.pdb",
+			"Archive: Sample.xcarchive",
+		} {
+			_, ok := capturedArtifactPath(line)
+			require.Equal(t, false, ok, line)
+		}
+	}
+}
+
+func TestArtifactCapture(t *testing.T) {
+	t.Log("it keeps the latest paths")
+	{
+		capture := artifactCapture{}
+		for i := 0; i < maxCapturedArtifacts+2; i++ {
+			capture.add(fmt.Sprintf("/tmp/Sample%d.ipa", i))
+			capture.add("/tmp/Sample0.ipa")
+		}
+
+		captured := capture.captured()
+		require.Equal(t, maxCapturedArtifacts, len(captured))
+		require.Equal(t, "/tmp/Sample3.ipa", captured[0])
+		require.Equal(t, fmt.Sprintf("/tmp/Sample%d.ipa", maxCapturedArtifacts+1), captured[len(captured)-1])
+	}
+}
+
+func TestCapturedArtifacts(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("capture_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	startTime := time.Now().Add(-time.Minute)
+
+	printedIpa := filepath.Join(tmpDir, "Sample 1.0", "Sample.iOS.ipa")
+	latestIpa := filepath.Join(tmpDir, "Sample 1.1", "Sample.iOS.ipa")
+	for _, pth := range []string{printedIpa, latestIpa} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0755))
+		require.NoError(t, fileutil.WriteStringToFile(pth, ""))
+	}
+	require.NoError(t, os.Chtimes(latestIpa, time.Now().Add(time.Second), time.Now().Add(time.Second)))
+
+	endTime := time.Now().Add(time.Minute)
+
+	t.Log("it exports the artifact printed by the build")
+	{
+		builder := Model{session: &buildSession{}}
+		require.NoError(t, builder.runProjectCommand("Sample.iOS", &logTestCommand{printable: "msbuild", output: fmt.Sprintf("  Sample.iOS -> %s\n", printedIpa)}))
+
+		ipaPth, err := exportLatestIpa(tools.NewDefaultLogger(), builder.newArtifactWalker(), tmpDir, "Sample.iOS", startTime, endTime, ArtifactSelectionBuildStart)
+		require.NoError(t, err)
+		require.Equal(t, printedIpa, ipaPth)
+	}
+
+	t.Log("it falls back to the walk, if the printed artifact is not found")
+	{
+		builder := Model{session: &buildSession{}}
+		require.NoError(t, builder.runProjectCommand("Sample.iOS", &logTestCommand{printable: "msbuild", output: fmt.Sprintf("  Sample.iOS -> %s\n", filepath.Join(tmpDir, "missing.ipa"))}))
+
+		ipaPth, err := exportLatestIpa(tools.NewDefaultLogger(), builder.newArtifactWalker(), tmpDir, "Sample.iOS", startTime, endTime, ArtifactSelectionNewest)
+		require.NoError(t, err)
+		require.Equal(t, latestIpa, ipaPth)
+	}
+
+	t.Log("it selects from the printed artifacts by the strategy")
+	{
+		builder := Model{session: &buildSession{}}
+		require.NoError(t, builder.runProjectCommand("Sample.iOS", &logTestCommand{printable: "msbuild", output: fmt.Sprintf("  Sample.iOS -> %s\n  Sample.iOS -> %s\n", latestIpa, printedIpa)}))
+
+		ipaPth, err := exportLatestIpa(tools.NewDefaultLogger(), builder.newArtifactWalker(), tmpDir, "Sample.iOS", startTime, endTime, ArtifactSelectionNewest)
+		require.NoError(t, err)
+		require.Equal(t, latestIpa, ipaPth)
+
+		_, err = exportLatestIpa(tools.NewDefaultLogger(), builder.newArtifactWalker(), tmpDir, "Sample.iOS", startTime, endTime, ArtifactSelectionBuildStart)
+		require.Equal(t, AmbiguousArtifactError{Strategy: ArtifactSelectionBuildStart, Candidates: []string{printedIpa, latestIpa}}, err)
+	}
+}
@@ -0,0 +1,165 @@
+package builder
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-tools/go-xamarin/constants"
+)
+
+var (
+	pkgutilCertificatePattern = regexp.MustCompile(`^\s*1\.\s+(.+)$`)
+	authorityTeamPattern      = regexp.MustCompile(`\(([A-Z0-9]{10})\)$`)
+)
+
+// SignatureModel - code signing status of a macOS .app or .pkg output
+type SignatureModel struct {
+	Signed         bool
+	Authority      string // signing identity, like: Developer ID Application: Bitrise Ltd (72SA8V3WYL)
+	TeamIdentifier string
+
+	Verified bool   // signed and, if an identity was expected, signed with it
+	Message  string // reason of the failed verification
+}
+
+// verifySignature - verifies the .app by codesign and the .pkg by pkgutil,
+// the expected identity matches the authority or the team identifier
+func verifySignature(output OutputModel, expectedIdentity string) SignatureModel {
+	var signature SignatureModel
+	switch output.OutputType {
+	case constants.OutputTypeAPP:
+		signature = verifyAppSignature(output.Pth)
+	case constants.OutputTypePKG:
+		signature = verifyPKGSignature(output.Pth)
+	default:
+		return SignatureModel{Message: fmt.Sprintf("signature verification is not supported for %s outputs", output.OutputType)}
+	}
+
+	if !signature.Signed {
+		return signature
+	}
+
+	if expectedIdentity != "" && !signatureMatchesIdentity(signature, expectedIdentity) {
+		signature.Verified = false
+		signature.Message = fmt.Sprintf("signed by (%s), instead of (%s)", signature.Authority, expectedIdentity)
+	}
+
+	return signature
+}
+
+func verifyAppSignature(appPth string) SignatureModel {
+	// codesign prints the signing information to the stderr
+	out, err := command.RunCommandAndReturnCombinedStdoutAndStderr("codesign", "-dvv", appPth)
+	if err != nil {
+		if strings.Contains(out, "not signed") {
+			return SignatureModel{Message: "not signed"}
+		}
+		return SignatureModel{Message: fmt.Sprintf("codesign failed, output: %s, error: %s", out, err)}
+	}
+
+	signature := parseCodesignOutput(out)
+
+	if out, err := command.RunCommandAndReturnCombinedStdoutAndStderr("codesign", "--verify", "--deep", "--strict", appPth); err != nil {
+		signature.Message = fmt.Sprintf("invalid signature: %s", out)
+		return signature
+	}
+	signature.Verified = true
+
+	return signature
+}
+
+func verifyPKGSignature(pkgPth string) SignatureModel {
+	out, err := command.RunCommandAndReturnCombinedStdoutAndStderr("pkgutil", "--check-signature", pkgPth)
+	signature := parsePkgutilOutput(out)
+	if err != nil {
+		if signature.Message == "" {
+			signature.Message = fmt.Sprintf("pkgutil failed, output: %s, error: %s", out, err)
+		}
+		return signature
+	}
+
+	signature.Verified = signature.Signed
+	return signature
+}
+
+// parseCodesignOutput - parses the output of: codesign -dvv <app>
+func parseCodesignOutput(out string) SignatureModel {
+	signature := SignatureModel{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "Authority=") && signature.Authority == "" {
+			// the first authority is the leaf certificate
+			signature.Authority = strings.TrimPrefix(line, "Authority=")
+		} else if strings.HasPrefix(line, "TeamIdentifier=") {
+			signature.TeamIdentifier = strings.TrimPrefix(line, "TeamIdentifier=")
+			if signature.TeamIdentifier == "not set" {
+				signature.TeamIdentifier = ""
+			}
+		} else if strings.HasPrefix(line, "Signature=adhoc") {
+			signature.Message = "ad-hoc signed"
+		}
+	}
+
+	signature.Signed = signature.Authority != ""
+	return signature
+}
+
+// parsePkgutilOutput - parses the output of: pkgutil --check-signature <pkg>
+func parsePkgutilOutput(out string) SignatureModel {
+	signature := SignatureModel{}
+
+	inCertificateChain := false
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "Status:") {
+			status := strings.TrimSpace(strings.TrimPrefix(trimmed, "Status:"))
+			signature.Signed = strings.HasPrefix(status, "signed")
+			if !signature.Signed {
+				signature.Message = status
+			}
+		} else if strings.HasPrefix(trimmed, "Certificate Chain:") {
+			inCertificateChain = true
+		} else if inCertificateChain && signature.Authority == "" {
+			if match := pkgutilCertificatePattern.FindStringSubmatch(line); len(match) == 2 {
+				signature.Authority = strings.TrimSpace(match[1])
+			}
+		}
+	}
+
+	if match := authorityTeamPattern.FindStringSubmatch(signature.Authority); len(match) == 2 {
+		signature.TeamIdentifier = match[1]
+	}
+
+	return signature
+}
+
+func signatureMatchesIdentity(signature SignatureModel, identity string) bool {
+	if signature.TeamIdentifier != "" && signature.TeamIdentifier == identity {
+		return true
+	}
+	return signature.Authority == identity || strings.HasPrefix(signature.Authority, identity)
+}
+
+// verifyOutputSignatures - sets the signature of the .app and .pkg outputs
+func (builder Model) verifyOutputSignatures(outputs []OutputModel) []OutputModel {
+	for i, output := range outputs {
+		if output.OutputType != constants.OutputTypeAPP && output.OutputType != constants.OutputTypePKG {
+			continue
+		}
+
+		signature := verifySignature(output, builder.expectedSigningIdentity)
+		if !signature.Verified {
+			builder.log().Warnf("Signature verification of (%s) failed: %s", output.Pth, signature.Message)
+		}
+		outputs[i].Signature = &signature
+	}
+	return outputs
+}
@@ -0,0 +1,80 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+const codesignTestOutput = `Executable=/Users/bitrise/Mac.app/Contents/MacOS/Mac
+Identifier=com.bitrise.mac
+Format=app bundle with Mach-O thin (x86_64)
+CodeDirectory v=20200 size=1230 flags=0x0(none) hashes=32+5 location=embedded
+Signature size=8915
+Authority=Developer ID Application: Bitrise Ltd (72SA8V3WYL)
+Authority=Developer ID Certification Authority
+Authority=Apple Root CA
+Timestamp=2017. Jan 10. 12:00:00
+Info.plist entries=22
+TeamIdentifier=72SA8V3WYL
+Sealed Resources version=2 rules=12 files=40
+Internal requirements count=1 size=204`
+
+const pkgutilTestOutput = `Package "Mac-1.0.pkg":
+   Status: signed by a certificate trusted by Mac OS X
+   Certificate Chain:
+    1. Developer ID Installer: Bitrise Ltd (72SA8V3WYL)
+       SHA1 fingerprint: 00 11 22 33 44 55 66 77 88 99 AA BB CC DD EE FF 00 11 22 33
+       -----------------------------------------------------------------------------
+    2. Developer ID Certification Authority
+       SHA1 fingerprint: 00 11 22 33 44 55 66 77 88 99 AA BB CC DD EE FF 00 11 22 33`
+
+func TestParseCodesignOutput(t *testing.T) {
+	t.Log("it reads the leaf authority and the team")
+	{
+		signature := parseCodesignOutput(codesignTestOutput)
+		require.Equal(t, true, signature.Signed)
+		require.Equal(t, "Developer ID Application: Bitrise Ltd (72SA8V3WYL)", signature.Authority)
+		require.Equal(t, "72SA8V3WYL", signature.TeamIdentifier)
+	}
+
+	t.Log("ad-hoc signature has no authority")
+	{
+		signature := parseCodesignOutput("Signature=adhoc\nTeamIdentifier=not set")
+		require.Equal(t, false, signature.Signed)
+		require.Equal(t, "", signature.TeamIdentifier)
+		require.Equal(t, "ad-hoc signed", signature.Message)
+	}
+}
+
+func TestParsePkgutilOutput(t *testing.T) {
+	t.Log("it reads the leaf certificate of the chain")
+	{
+		signature := parsePkgutilOutput(pkgutilTestOutput)
+		require.Equal(t, true, signature.Signed)
+		require.Equal(t, "Developer ID Installer: Bitrise Ltd (72SA8V3WYL)", signature.Authority)
+		require.Equal(t, "72SA8V3WYL", signature.TeamIdentifier)
+	}
+
+	t.Log("it reports unsigned pkg")
+	{
+		signature := parsePkgutilOutput("Package \"Mac-1.0.pkg\":\n   Status: no signature")
+		require.Equal(t, false, signature.Signed)
+		require.Equal(t, "no signature", signature.Message)
+	}
+}
+
+func TestSignatureMatchesIdentity(t *testing.T) {
+	signature := parseCodesignOutput(codesignTestOutput)
+	require.Equal(t, true, signatureMatchesIdentity(signature, "72SA8V3WYL"))
+	require.Equal(t, true, signatureMatchesIdentity(signature, "Developer ID Application: Bitrise Ltd (72SA8V3WYL)"))
+	require.Equal(t, true, signatureMatchesIdentity(signature, "Developer ID Application"))
+	require.Equal(t, false, signatureMatchesIdentity(signature, "Apple Distribution"))
+
+	t.Log("unsupported outputs are not verified")
+	{
+		signature := verifySignature(OutputModel{Pth: "Mac.dll", OutputType: constants.OutputTypeDLL}, "")
+		require.Equal(t, false, signature.Verified)
+	}
+}
@@ -2,6 +2,10 @@ package builder
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/bitrise-tools/go-xamarin/analyzers/project"
 	"github.com/bitrise-tools/go-xamarin/constants"
@@ -12,6 +16,111 @@ import (
 	"github.com/bitrise-tools/go-xamarin/utility"
 )
 
+func (builder Model) runCommand(command tools.Runnable) error {
+	return builder.runProjectCommand("", command)
+}
+
+// runProjectCommand runs the command, its output is archived into the project's log, if the log dir is set
+func (builder Model) runProjectCommand(projectName string, command tools.Runnable) (err error) {
+	builder.session.start()
+
+	if timeoutable, ok := command.(tools.Timeoutable); ok && builder.timeout > 0 {
+		timeoutable.SetTimeout(builder.timeout)
+		timeoutable.SetKillGracePeriod(builder.killGracePeriod)
+	}
+
+	var stdout, stderr io.Writer = os.Stdout, os.Stderr
+	redirectable, isRedirectable := command.(tools.OutputRedirectable)
+
+	var commandLog *projectLog
+	if isRedirectable {
+		commandLog = builder.logArchive.open(projectName, builder.printableCommand(command), builder.log())
+	}
+	if commandLog != nil {
+		defer func(start time.Time) {
+			commandLog.finish(err, time.Since(start))
+		}(time.Now())
+	}
+
+	if quietable, ok := command.(tools.Quietable); ok && builder.quiet {
+		if !isRedirectable {
+			quietable.SetQuiet(true)
+		} else {
+			// the log and the artifact capture get the full output, only the console output is filtered
+			quietStdout, quietStderr := tools.NewQuietWriter(stdout), tools.NewQuietWriter(stderr)
+			defer func() {
+				if err := quietStdout.Flush(); err != nil {
+					builder.log().Warnf("Failed to write output, error: %s", err)
+				}
+				if err := quietStderr.Flush(); err != nil {
+					builder.log().Warnf("Failed to write output, error: %s", err)
+				}
+			}()
+			stdout, stderr = quietStdout, quietStderr
+		}
+	}
+
+	if isRedirectable {
+		// the failed command's last output lines are kept for the BuildFailedError
+		tail := tools.NewOutputTail(tools.DefaultOutputTailLines)
+		defer func() {
+			if err != nil {
+				err = &commandOutputError{err: err, tail: tail.Lines()}
+			}
+		}()
+		stdout, stderr = io.MultiWriter(stdout, tail), io.MultiWriter(stderr, tail)
+	}
+
+	if isRedirectable && builder.session != nil {
+		// the artifact paths printed by the tools are preferred by the output collection
+		captureStdout, captureStderr := builder.session.artifacts.newWriter(), builder.session.artifacts.newWriter()
+		defer func() {
+			captureStdout.Flush()
+			captureStderr.Flush()
+		}()
+		stdout, stderr = io.MultiWriter(stdout, captureStdout), io.MultiWriter(stderr, captureStderr)
+	}
+
+	if commandLog != nil {
+		stdout, stderr = io.MultiWriter(stdout, commandLog), io.MultiWriter(stderr, commandLog)
+	}
+
+	if isRedirectable {
+		redirectable.SetStdout(stdout)
+		redirectable.SetStderr(stderr)
+	}
+
+	if loggable, ok := command.(tools.Loggable); ok && builder.logger != nil {
+		loggable.SetLogger(builder.logger)
+	}
+
+	if builder.secrets == nil || builder.secrets.Empty() {
+		return tools.RunWithHooks(command, builder.commandHooks...)
+	}
+
+	if isRedirectable {
+		maskedStdout, maskedStderr := tools.NewMaskingWriter(stdout, builder.secrets), tools.NewMaskingWriter(stderr, builder.secrets)
+		defer func() {
+			if err := maskedStdout.Flush(); err != nil {
+				builder.log().Warnf("Failed to write output, error: %s", err)
+			}
+			if err := maskedStderr.Flush(); err != nil {
+				builder.log().Warnf("Failed to write output, error: %s", err)
+			}
+		}()
+		redirectable.SetStdout(maskedStdout)
+		redirectable.SetStderr(maskedStderr)
+	}
+
+	return tools.RunWithHooks(tools.NewMaskedCommand(command, builder.secrets), builder.commandHooks...)
+}
+
+func (builder Model) setBuildProperties(command *xbuild.Model) {
+	for name, value := range builder.buildProperties {
+		command.SetProperty(name, value)
+	}
+}
+
 func (builder Model) buildSolutionCommand(configuration, platform string) (tools.Runnable, error) {
 	var buildCommand tools.Runnable
 
@@ -30,6 +139,7 @@ func (builder Model) buildSolutionCommand(configuration, platform string) (tools
 		if err != nil {
 			return tools.EmptyCommand{}, err
 		}
+		builder.setBuildProperties(command)
 
 		command.SetTarget("Build")
 		command.SetConfiguration(configuration)
@@ -58,8 +168,35 @@ func (builder Model) buildProjectCommand(configuration, platform string, proj pr
 	// Prepare build commands
 	buildCommands := []tools.Runnable{}
 
+	if isLibraryProjectType(proj.ProjectType) {
+		command, err := xbuild.New(builder.solution.Pth, proj.Pth)
+		if err != nil {
+			return []tools.Runnable{}, warnings, err
+		}
+		builder.setBuildProperties(command)
+
+		command.SetTarget("Build")
+		command.SetConfiguration(projectConfig.Configuration)
+
+		if !isPlatformAnyCPU(projectConfig.Platform) {
+			command.SetPlatform(projectConfig.Platform)
+		}
+
+		return append(buildCommands, command), warnings, nil
+	}
+
 	switch proj.SDK {
 	case constants.SDKIOS, constants.SDKTvOS:
+		projectConfig = builder.simulatorProjectConfig(proj, projectConfig)
+		warnings = append(warnings, builder.validateAppleArchitectures(proj, projectConfig)...)
+
+		companionCommands, companionWarnings, err := builder.companionBuildCommands(proj, solutionConfig)
+		warnings = append(warnings, companionWarnings...)
+		if err != nil {
+			return []tools.Runnable{}, warnings, err
+		}
+		buildCommands = append(buildCommands, companionCommands...)
+
 		if builder.forceMDTool {
 			command, err := mdtool.New(builder.solution.Pth)
 			if err != nil {
@@ -73,7 +210,7 @@ func (builder Model) buildProjectCommand(configuration, platform string, proj pr
 
 			buildCommands = append(buildCommands, command)
 
-			if isArchitectureArchiveable(projectConfig.MtouchArchs...) {
+			if builder.archivesIOSProject(projectConfig) {
 				command, err := mdtool.New(builder.solution.Pth)
 				if err != nil {
 					return []tools.Runnable{}, warnings, err
@@ -86,19 +223,37 @@ func (builder Model) buildProjectCommand(configuration, platform string, proj pr
 
 				buildCommands = append(buildCommands, command)
 			}
+		} else if builder.iosSimulatorBuild {
+			// simulator builds are not signed, the project is built alone, as the solution platform is a device platform
+			command, err := xbuild.New(builder.solution.Pth, proj.Pth)
+			if err != nil {
+				return []tools.Runnable{}, warnings, err
+			}
+			builder.setBuildProperties(command)
+			builder.setIOSSimulatorProperties(command, proj.SDK)
+
+			command.SetTarget("Build")
+			command.SetConfiguration(projectConfig.Configuration)
+			command.SetPlatform(simulatorPlatform)
+
+			buildCommands = append(buildCommands, command)
 		} else {
 			command, err := xbuild.New(builder.solution.Pth, "")
 			if err != nil {
 				return []tools.Runnable{}, warnings, err
 			}
+			builder.setBuildProperties(command)
+			warnings = append(warnings, builder.setIOSCodesignProperties(command, proj, projectConfig)...)
+			builder.setCodesignProperties(command, proj.SDK)
 
 			command.SetTarget("Build")
 			command.SetConfiguration(configuration)
 			command.SetPlatform(platform)
 
-			if isArchitectureArchiveable(projectConfig.MtouchArchs...) {
+			if builder.archivesIOSProject(projectConfig) {
 				command.SetBuildIpa(true)
 				command.SetArchiveOnBuild(true)
+				command.SetArchiveBasePath(builder.archiveBasePath)
 			}
 
 			buildCommands = append(buildCommands, command)
@@ -133,11 +288,14 @@ func (builder Model) buildProjectCommand(configuration, platform string, proj pr
 			if err != nil {
 				return []tools.Runnable{}, warnings, err
 			}
+			builder.setBuildProperties(command)
+			builder.setCodesignProperties(command, proj.SDK)
 
 			command.SetTarget("Build")
 			command.SetConfiguration(configuration)
 			command.SetPlatform(platform)
 			command.SetArchiveOnBuild(true)
+			command.SetArchiveBasePath(builder.archiveBasePath)
 
 			buildCommands = append(buildCommands, command)
 		}
@@ -146,8 +304,41 @@ func (builder Model) buildProjectCommand(configuration, platform string, proj pr
 		if err != nil {
 			return []tools.Runnable{}, warnings, err
 		}
+		builder.setBuildProperties(command)
+		builder.setAndroidBuildProperties(command)
+		warnings = append(warnings, builder.setAndroidABIProperties(command)...)
+		warnings = append(warnings, builder.setAndroidSDKProperties(command, proj)...)
+
+		manifestPatches := []androidManifestPatch{}
+		versionPatch, err := builder.setAndroidVersionProperties(command, proj)
+		if err != nil {
+			return []tools.Runnable{}, warnings, err
+		}
+		if versionPatch != nil {
+			manifestPatches = append(manifestPatches, versionPatch)
+		}
+		releaseWarnings, releasePatch := builder.guardAndroidRelease(command, proj, projectConfig)
+		warnings = append(warnings, releaseWarnings...)
+		if releasePatch != nil {
+			manifestPatches = append(manifestPatches, releasePatch)
+		}
+		if variantPatch := builder.androidManifestVariantPatch(); variantPatch != nil {
+			manifestPatches = append(manifestPatches, variantPatch)
+		}
+		if err := setAndroidManifestProperty(command, proj, projectConfig, manifestPatches); err != nil {
+			return []tools.Runnable{}, warnings, err
+		}
+
+		if builder.signsAndroidPackageAfterBuild() {
+			command.SetTarget("PackageForAndroid")
+		} else if builder.hasAndroidKeystore() {
+			builder.setAndroidKeystoreProperties(command)
+			command.SetTarget("SignAndroidPackage")
+		} else if projectConfig.SignAndroid {
+			if missing := projectConfig.MissingAndroidSigningProperties(); len(missing) > 0 {
+				warnings = append(warnings, fmt.Sprintf("project (%s) signs the android package in config (%s), but keystore properties are not set: %s", proj.Name, projectConfig.Configuration, strings.Join(missing, ", ")))
+			}
 
-		if projectConfig.SignAndroid {
 			command.SetTarget("SignAndroidPackage")
 		} else {
 			command.SetTarget("PackageForAndroid")
@@ -0,0 +1,73 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+var (
+	// ErrSolutionNotFound - the solution file does not exist
+	ErrSolutionNotFound = errors.New("solution not exist")
+	// ErrInvalidSolutionConfig - the solution has no such Configuration|Platform
+	ErrInvalidSolutionConfig = errors.New("invalid solution config")
+	// ErrToolNotInstalled - a required tool (like the nunit console) is not installed, or its path is not set
+	ErrToolNotInstalled = tools.ErrToolNotInstalled
+)
+
+// BuildFailedError - a build command of the project failed, the project is empty for solution builds
+type BuildFailedError struct {
+	Project    string
+	ExitCode   int      // -1 if the command did not exit normally, like on timeout
+	OutputTail []string // the last lines of the failed command's output, if it was captured
+	Err        error
+}
+
+func newBuildFailedError(projectName string, err error) error {
+	buildFailedErr := &BuildFailedError{Project: projectName, ExitCode: tools.ExitCode(err), Err: err}
+
+	var outputErr *commandOutputError
+	if errors.As(err, &outputErr) {
+		buildFailedErr.OutputTail = outputErr.tail
+	}
+	return buildFailedErr
+}
+
+func (err *BuildFailedError) Error() string {
+	if err.Project == "" {
+		return fmt.Sprintf("build failed, exit code: %d, error: %s", err.ExitCode, err.Err)
+	}
+	return fmt.Sprintf("build of project (%s) failed, exit code: %d, error: %s", err.Project, err.ExitCode, err.Err)
+}
+
+// Unwrap ...
+func (err *BuildFailedError) Unwrap() error {
+	return err.Err
+}
+
+// commandOutputError keeps the last lines of the failed command's output, the message is the command's error
+type commandOutputError struct {
+	err  error
+	tail []string
+}
+
+func (err *commandOutputError) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap ...
+func (err *commandOutputError) Unwrap() error {
+	return err.err
+}
+
+// ArtifactNotFoundError - no artifact of the output type was found in the directory (or archive)
+type ArtifactNotFoundError struct {
+	OutputType constants.OutputType
+	Dir        string
+}
+
+func (err *ArtifactNotFoundError) Error() string {
+	return fmt.Sprintf("no %s found in: %s", err.OutputType, err.Dir)
+}
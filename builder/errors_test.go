@@ -0,0 +1,71 @@
+package builder
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/solution"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrors(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("errors_test")
+	require.NoError(t, err)
+
+	t.Log("missing solution")
+	{
+		pth := filepath.Join(tmpDir, "Missing.sln")
+		err := validateSolutionPth(pth)
+		require.True(t, errors.Is(err, ErrSolutionNotFound))
+		require.Equal(t, "solution not exist at: "+pth, err.Error())
+	}
+
+	t.Log("invalid solution config")
+	{
+		err := validateSolutionConfig(solution.Model{ConfigMap: map[string]string{"Release|iPhone": "Release|iPhone"}}, "Debug", "iPhone")
+		require.True(t, errors.Is(err, ErrInvalidSolutionConfig))
+		require.Equal(t, "invalid solution config, available: [Release|iPhone]", err.Error())
+	}
+
+	t.Log("failed build command")
+	{
+		runErr := exec.Command("sh", "-c", "exit 3").Run()
+		err := newBuildFailedError("Sample.iOS", runErr)
+
+		var buildFailedErr *BuildFailedError
+		require.True(t, errors.As(err, &buildFailedErr))
+		require.Equal(t, "Sample.iOS", buildFailedErr.Project)
+		require.Equal(t, 3, buildFailedErr.ExitCode)
+		require.Equal(t, runErr, errors.Unwrap(err))
+		require.Equal(t, "build of project (Sample.iOS) failed, exit code: 3, error: exit status 3", err.Error())
+
+		require.Equal(t, "build failed, exit code: -1, error: timeout", newBuildFailedError("", errors.New("timeout")).Error())
+	}
+
+	t.Log("failed build command with captured output")
+	{
+		builder := Model{}
+		runErr := builder.runProjectCommand("Sample.iOS", &logTestCommand{printable: "msbuild", output: "compiling\nerror CS1002: ; expected", exitCode: 1})
+		err := newBuildFailedError("Sample.iOS", runErr)
+
+		var buildFailedErr *BuildFailedError
+		require.True(t, errors.As(err, &buildFailedErr))
+		require.Equal(t, 1, buildFailedErr.ExitCode)
+		require.Equal(t, []string{"compiling", "error CS1002: ; expected"}, buildFailedErr.OutputTail)
+		require.Equal(t, "build of project (Sample.iOS) failed, exit code: 1, error: exit status 1", err.Error())
+	}
+
+	t.Log("missing artifact")
+	{
+		_, err := findIPA(tmpDir)
+
+		var artifactErr *ArtifactNotFoundError
+		require.True(t, errors.As(err, &artifactErr))
+		require.Equal(t, constants.OutputTypeIPA, artifactErr.OutputType)
+		require.Equal(t, tmpDir, artifactErr.Dir)
+	}
+}
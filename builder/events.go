@@ -0,0 +1,155 @@
+package builder
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// EventType - the type of the progress events
+type EventType string
+
+const (
+	// EventProjectStarted - the build of a project started
+	EventProjectStarted EventType = "project_started"
+	// EventCommandStarted ...
+	EventCommandStarted EventType = "command_started"
+	// EventCommandFinished ...
+	EventCommandFinished EventType = "command_finished"
+	// EventWarning ...
+	EventWarning EventType = "warning"
+	// EventArtifactFound - an output was collected
+	EventArtifactFound EventType = "artifact_found"
+)
+
+// EventModel - a progress event, written as a JSON line
+type EventModel struct {
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+
+	Solution string        `json:"solution,omitempty"`
+	Project  string        `json:"project,omitempty"`
+	SDK      constants.SDK `json:"sdk,omitempty"`
+
+	Command  string  `json:"command,omitempty"`
+	ExitCode *int    `json:"exit_code,omitempty"`
+	Duration float64 `json:"duration,omitempty"` // seconds
+
+	Message string `json:"message,omitempty"`
+
+	OutputType constants.OutputType `json:"output_type,omitempty"`
+	Pth        string               `json:"path,omitempty"`
+}
+
+// eventWriter writes the events as JSON lines, it is shared by the copies of the builder Model
+type eventWriter struct {
+	mutex    sync.Mutex
+	writer   io.Writer
+	solution string
+	logger   tools.Logger
+}
+
+// SetEventWriter - the builder writes its progress (project started, command started and finished, warning, artifact found)
+// as JSON lines to the given writer, for GUI tools and CI parsers
+func (builder *Model) SetEventWriter(writer io.Writer) *Model {
+	events := &eventWriter{writer: writer, solution: builder.solution.Name, logger: builder.logger}
+	builder.events = events
+	return builder.AddCommandHook(events)
+}
+
+func (events *eventWriter) write(event EventModel) {
+	if events == nil {
+		return
+	}
+
+	events.mutex.Lock()
+	defer events.mutex.Unlock()
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	event.Solution = events.solution
+
+	content, err := json.Marshal(event)
+	if err != nil {
+		tools.LoggerOrDefault(events.logger).Warnf("Failed to encode event, error: %s", err)
+		return
+	}
+	if _, err := events.writer.Write(append(content, '\n')); err != nil {
+		tools.LoggerOrDefault(events.logger).Warnf("Failed to write event, error: %s", err)
+	}
+}
+
+func (events *eventWriter) setLogger(logger tools.Logger) {
+	if events == nil {
+		return
+	}
+
+	events.mutex.Lock()
+	defer events.mutex.Unlock()
+
+	events.logger = logger
+}
+
+func (events *eventWriter) projectStarted(proj project.Model) {
+	events.write(EventModel{Type: EventProjectStarted, Project: proj.Name, SDK: proj.SDK})
+}
+
+func (events *eventWriter) warnings(warnings []string) {
+	for _, warning := range warnings {
+		events.write(EventModel{Type: EventWarning, Message: warning})
+	}
+}
+
+// artifacts writes the outputs sorted by project name
+func (events *eventWriter) artifacts(projectOutputMap ProjectOutputMap) {
+	if events == nil {
+		return
+	}
+
+	projectNames := []string{}
+	for projectName := range projectOutputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	for _, projectName := range projectNames {
+		projectOutput := projectOutputMap[projectName]
+		for _, output := range projectOutput.Outputs {
+			events.write(EventModel{
+				Type:       EventArtifactFound,
+				Project:    projectName,
+				SDK:        projectOutput.ProjectType,
+				OutputType: output.OutputType,
+				Pth:        output.Pth,
+			})
+		}
+	}
+}
+
+// CommandStarted ...
+func (events *eventWriter) CommandStarted(command string, startTime time.Time) {
+	events.write(EventModel{Time: startTime, Type: EventCommandStarted, Command: command})
+}
+
+// CommandFinished ...
+func (events *eventWriter) CommandFinished(event tools.CommandEvent) {
+	exitCode := event.ExitCode
+	finished := EventModel{
+		Time:     event.EndTime,
+		Type:     EventCommandFinished,
+		Command:  event.Command,
+		ExitCode: &exitCode,
+		Duration: event.Duration.Seconds(),
+	}
+	if event.Err != nil {
+		finished.Message = event.Err.Error()
+	}
+	events.write(finished)
+}
@@ -0,0 +1,92 @@
+package builder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/analyzers/solution"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, content []byte) []EventModel {
+	events := []EventModel{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		var event EventModel
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestEventWriter(t *testing.T) {
+	t.Log("it writes the events as json lines")
+	{
+		var out bytes.Buffer
+		builder := Model{solution: solution.Model{Name: "Sample"}}
+		builder.SetEventWriter(&out)
+		require.Equal(t, 1, len(builder.commandHooks))
+
+		startTime := time.Now()
+		builder.events.projectStarted(project.Model{Name: "Sample.iOS", SDK: constants.SDKIOS})
+		builder.events.CommandStarted("xbuild Sample.sln", startTime)
+		builder.events.CommandFinished(tools.CommandEvent{Command: "xbuild Sample.sln", StartTime: startTime, EndTime: startTime.Add(2 * time.Second), Duration: 2 * time.Second, ExitCode: 1, Err: errors.New("exit status 1")})
+		builder.events.warnings([]string{"no config"})
+		builder.events.artifacts(ProjectOutputMap{
+			"Sample.iOS":   ProjectOutputModel{ProjectType: constants.SDKIOS, Outputs: []OutputModel{{Pth: "/bin/Sample.ipa", OutputType: constants.OutputTypeIPA}}},
+			"Sample.Droid": ProjectOutputModel{ProjectType: constants.SDKAndroid, Outputs: []OutputModel{{Pth: "/bin/com.sample.apk", OutputType: constants.OutputTypeAPK}}},
+		})
+
+		events := readEvents(t, out.Bytes())
+		require.Equal(t, 6, len(events))
+		for _, event := range events {
+			require.Equal(t, "Sample", event.Solution)
+			require.False(t, event.Time.IsZero())
+		}
+
+		require.Equal(t, EventProjectStarted, events[0].Type)
+		require.Equal(t, "Sample.iOS", events[0].Project)
+		require.Equal(t, constants.SDKIOS, events[0].SDK)
+
+		require.Equal(t, EventCommandStarted, events[1].Type)
+		require.Equal(t, "xbuild Sample.sln", events[1].Command)
+		require.Nil(t, events[1].ExitCode)
+
+		require.Equal(t, EventCommandFinished, events[2].Type)
+		require.Equal(t, 1, *events[2].ExitCode)
+		require.Equal(t, 2.0, events[2].Duration)
+		require.Equal(t, "exit status 1", events[2].Message)
+
+		require.Equal(t, EventWarning, events[3].Type)
+		require.Equal(t, "no config", events[3].Message)
+
+		require.Equal(t, EventArtifactFound, events[4].Type)
+		require.Equal(t, "Sample.Droid", events[4].Project)
+		require.Equal(t, constants.OutputTypeAPK, events[4].OutputType)
+		require.Equal(t, "/bin/com.sample.apk", events[4].Pth)
+		require.Equal(t, "Sample.iOS", events[5].Project)
+	}
+
+	t.Log("it writes successful exit codes")
+	{
+		var out bytes.Buffer
+		events := &eventWriter{writer: &out}
+		events.CommandFinished(tools.CommandEvent{Command: "xbuild"})
+		require.Contains(t, out.String(), `"exit_code":0`)
+	}
+
+	t.Log("it does nothing without event writer")
+	{
+		builder := Model{}
+		builder.events.projectStarted(project.Model{Name: "Sample.iOS"})
+		builder.events.artifacts(ProjectOutputMap{"Sample.iOS": ProjectOutputModel{}})
+	}
+}
@@ -0,0 +1,185 @@
+package builder
+
+import (
+	"archive/zip"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/mdtool"
+)
+
+// companionProjects returns the app extension and watch projects embedded into the app,
+// the nested ones (like the WatchKit extension of the watch app) first
+func (builder Model) companionProjects(proj project.Model) []project.Model {
+	return builder.collectCompanionProjects(proj, map[string]bool{})
+}
+
+func (builder Model) collectCompanionProjects(proj project.Model, visited map[string]bool) []project.Model {
+	companions := []project.Model{}
+	for _, referredProjectID := range proj.ReferredProjectIDs {
+		referredProject, ok := builder.solution.ProjectMap[referredProjectID]
+		if !ok || !isEmbeddedProjectType(referredProject.ProjectType) || visited[referredProject.ID] {
+			continue
+		}
+		visited[referredProject.ID] = true
+
+		companions = append(companions, builder.collectCompanionProjects(referredProject, visited)...)
+		companions = append(companions, referredProject)
+	}
+	return companions
+}
+
+// companionBuildCommands returns the mdtool build commands of the companion projects, to build them before the app.
+// xbuild builds the companion projects by the project references, so only the missing configs are reported.
+func (builder Model) companionBuildCommands(proj project.Model, solutionConfig string) ([]tools.Runnable, []string, error) {
+	buildCommands := []tools.Runnable{}
+	warnings := []string{}
+
+	for _, companion := range builder.companionProjects(proj) {
+		projectConfig, ok := companion.Configs[companion.ConfigMap[solutionConfig]]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("companion project (%s) of (%s) do not have config for solution config (%s), it will not be embedded into the app", companion.Name, proj.Name, solutionConfig))
+			continue
+		}
+
+		if !builder.forceMDTool {
+			continue
+		}
+
+		command, err := mdtool.New(builder.solution.Pth)
+		if err != nil {
+			return []tools.Runnable{}, warnings, err
+		}
+
+		command.SetTarget("build")
+		command.SetConfiguration(projectConfig.Configuration)
+		command.SetPlatform(projectConfig.Platform)
+		command.SetProjectName(companion.Name)
+
+		buildCommands = append(buildCommands, command)
+	}
+
+	return buildCommands, warnings, nil
+}
+
+// embeddedBundlePths returns the paths of the companion bundles relative to the app bundle,
+// like: PlugIns/Share.appex, Watch/Watch.app/PlugIns/WatchExtension.appex
+func (builder Model) embeddedBundlePths(proj project.Model) []string {
+	return builder.collectEmbeddedBundlePths(proj, map[string]bool{})
+}
+
+func (builder Model) collectEmbeddedBundlePths(proj project.Model, visited map[string]bool) []string {
+	bundlePths := []string{}
+	for _, referredProjectID := range proj.ReferredProjectIDs {
+		referredProject, ok := builder.solution.ProjectMap[referredProjectID]
+		if !ok || !isEmbeddedProjectType(referredProject.ProjectType) || visited[referredProject.ID] {
+			continue
+		}
+		visited[referredProject.ID] = true
+
+		bundleName := referredProject.AssemblyName
+		if bundleName == "" {
+			bundleName = referredProject.Name
+		}
+
+		bundlePth := path.Join("PlugIns", bundleName+".appex")
+		if referredProject.ProjectType == constants.ProjectTypeWatchApp {
+			bundlePth = path.Join("Watch", bundleName+".app")
+		}
+
+		bundlePths = append(bundlePths, bundlePth)
+		for _, nestedPth := range builder.collectEmbeddedBundlePths(referredProject, visited) {
+			bundlePths = append(bundlePths, path.Join(bundlePth, nestedPth))
+		}
+	}
+	return bundlePths
+}
+
+// verifyEmbeddedBundles fails if any of the companion bundles is missing from the xcarchive or ipa outputs
+func (builder Model) verifyEmbeddedBundles(proj project.Model, outputs []OutputModel) error {
+	bundlePths := builder.embeddedBundlePths(proj)
+	if len(bundlePths) == 0 {
+		return nil
+	}
+
+	for _, output := range outputs {
+		var missing []string
+		var err error
+
+		switch output.OutputType {
+		case constants.OutputTypeXCArchive:
+			missing, err = missingXCArchiveBundles(output.Pth, bundlePths)
+		case constants.OutputTypeIPA:
+			missing, err = missingIPABundles(builder.log(), output.Pth, bundlePths)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("companion bundles of project (%s) are not embedded into (%s): %s", proj.Name, output.Pth, strings.Join(missing, ", "))
+		}
+	}
+
+	return nil
+}
+
+func missingXCArchiveBundles(xcarchivePth string, bundlePths []string) ([]string, error) {
+	appPth, err := xcarchiveAppPth(xcarchivePth)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []string{}
+	for _, bundlePth := range bundlePths {
+		if exist, err := pathutil.IsDirExists(filepath.Join(appPth, filepath.FromSlash(bundlePth))); err != nil {
+			return nil, err
+		} else if !exist {
+			missing = append(missing, bundlePth)
+		}
+	}
+	return missing, nil
+}
+
+func missingIPABundles(logger tools.Logger, ipaPth string, bundlePths []string) ([]string, error) {
+	reader, err := zip.OpenReader(ipaPth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ipa (%s), error: %s", ipaPth, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Warnf("Failed to close ipa (%s), error: %s", ipaPth, err)
+		}
+	}()
+
+	// entries are like: Payload/Sample.app/PlugIns/Share.appex/Info.plist
+	embedded := map[string]bool{}
+	for _, file := range reader.File {
+		split := strings.SplitN(file.Name, "/", 3)
+		if len(split) < 3 || split[0] != "Payload" || !strings.HasSuffix(split[1], ".app") {
+			continue
+		}
+
+		for _, bundlePth := range bundlePths {
+			if strings.HasPrefix(split[2], bundlePth+"/") {
+				embedded[bundlePth] = true
+			}
+		}
+	}
+
+	missing := []string{}
+	for _, bundlePth := range bundlePths {
+		if !embedded[bundlePth] {
+			missing = append(missing, bundlePth)
+		}
+	}
+	return missing, nil
+}
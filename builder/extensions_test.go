@@ -0,0 +1,112 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/analyzers/solution"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func testCompanionBuilder() (Model, project.Model) {
+	app := project.Model{ID: "APP", Name: "iOS", AssemblyName: "iOS", SDK: constants.SDKIOS, ReferredProjectIDs: []string{"WATCH", "SHARE", "LIB"}}
+	watchApp := project.Model{ID: "WATCH", Name: "Watch", AssemblyName: "Watch", ProjectType: constants.ProjectTypeWatchApp, ReferredProjectIDs: []string{"WATCHEXT"},
+		ConfigMap: map[string]string{"Release|iPhone": "Release|iPhone"},
+		Configs:   map[string]project.ConfigurationPlatformModel{"Release|iPhone": {Configuration: "Release", Platform: "iPhone"}},
+	}
+	watchExtension := project.Model{ID: "WATCHEXT", Name: "WatchExtension", AssemblyName: "WatchExtension", ProjectType: constants.ProjectTypeWatchExtension,
+		ConfigMap: map[string]string{"Release|iPhone": "Release|iPhone"},
+		Configs:   map[string]project.ConfigurationPlatformModel{"Release|iPhone": {Configuration: "Release", Platform: "iPhone"}},
+	}
+	share := project.Model{ID: "SHARE", Name: "Share", ProjectType: constants.ProjectTypeAppExtension}
+	library := project.Model{ID: "LIB", Name: "Core", ProjectType: constants.ProjectTypeLibrary}
+
+	builder := Model{solution: solution.Model{Pth: "/solution.sln", ProjectMap: map[string]project.Model{
+		app.ID:            app,
+		watchApp.ID:       watchApp,
+		watchExtension.ID: watchExtension,
+		share.ID:          share,
+		library.ID:        library,
+	}}}
+	return builder, app
+}
+
+func TestCompanionProjects(t *testing.T) {
+	builder, app := testCompanionBuilder()
+
+	t.Log("it returns the embedded projects, the nested ones first")
+	{
+		names := []string{}
+		for _, companion := range builder.companionProjects(app) {
+			names = append(names, companion.Name)
+		}
+		require.Equal(t, []string{"WatchExtension", "Watch", "Share"}, names)
+	}
+
+	t.Log("it returns the embedded bundle paths")
+	{
+		require.Equal(t, []string{"Watch/Watch.app", "Watch/Watch.app/PlugIns/WatchExtension.appex", "PlugIns/Share.appex"}, builder.embeddedBundlePths(app))
+	}
+
+	t.Log("it builds the companion projects first with mdtool")
+	{
+		commands, warnings, err := builder.companionBuildCommands(app, "Release|iPhone")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(commands))
+		require.Equal(t, 1, len(warnings))
+		require.Contains(t, warnings[0], "companion project (Share)")
+
+		builder.forceMDTool = true
+		commands, _, err = builder.companionBuildCommands(app, "Release|iPhone")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(commands))
+		require.Contains(t, commands[0].PrintableCommand(), `"-p:WatchExtension"`)
+		require.Contains(t, commands[1].PrintableCommand(), `"-p:Watch"`)
+	}
+}
+
+func TestVerifyEmbeddedBundles(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("extensions_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	builder, app := testCompanionBuilder()
+
+	xcarchivePth := filepath.Join(tmpDir, "iOS.xcarchive")
+	writeTestInfoPlist(t, filepath.Join(xcarchivePth, "Info.plist"), `<key>ApplicationProperties</key>
+<dict>
+	<key>ApplicationPath</key>
+	<string>Applications/iOS.app</string>
+</dict>`)
+	appPth := filepath.Join(xcarchivePth, "Products", "Applications", "iOS.app")
+	writeTestInfoPlist(t, filepath.Join(appPth, "PlugIns", "Share.appex", "Info.plist"), "")
+	writeTestInfoPlist(t, filepath.Join(appPth, "Watch", "Watch.app", "PlugIns", "WatchExtension.appex", "Info.plist"), "")
+
+	ipaPth := filepath.Join(tmpDir, "iOS.ipa")
+	createTestIPA(t, ipaPth, map[string]string{
+		"Payload/iOS.app/Info.plist":                "",
+		"Payload/iOS.app/PlugIns/Share.appex/Share": "",
+	})
+
+	t.Log("it accepts the archive embedding every companion")
+	{
+		require.NoError(t, builder.verifyEmbeddedBundles(app, []OutputModel{{Pth: xcarchivePth, OutputType: constants.OutputTypeXCArchive}}))
+	}
+
+	t.Log("it reports the missing bundles of the ipa")
+	{
+		err := builder.verifyEmbeddedBundles(app, []OutputModel{{Pth: ipaPth, OutputType: constants.OutputTypeIPA}})
+		require.EqualError(t, err, "companion bundles of project (iOS) are not embedded into ("+ipaPth+"): Watch/Watch.app, Watch/Watch.app/PlugIns/WatchExtension.appex")
+	}
+
+	t.Log("it skips the apps without companions")
+	{
+		require.NoError(t, builder.verifyEmbeddedBundles(project.Model{Name: "Core"}, []OutputModel{{Pth: ipaPth, OutputType: constants.OutputTypeIPA}}))
+	}
+}
@@ -0,0 +1,27 @@
+package builder
+
+import (
+	"github.com/brandonrisell/go-xamarin/analyzers/solution"
+)
+
+// DependencyGraph - the dependency graph of the solution's projects, the projects to build
+// with the given solution config are numbered by the planned build order.
+// Without configuration and platform no build order is set.
+func (builder Model) DependencyGraph(configuration, platform string) (solution.GraphModel, []string, error) {
+	graph := builder.solution.Graph()
+	if configuration == "" && platform == "" {
+		return graph, []string{}, nil
+	}
+
+	if err := validateSolutionConfig(builder.solution, configuration, platform); err != nil {
+		return solution.GraphModel{}, []string{}, err
+	}
+
+	projects, warnings := builder.buildableProjects(configuration, platform)
+
+	projectIDs := []string{}
+	for _, proj := range projects {
+		projectIDs = append(projectIDs, proj.ID)
+	}
+	return graph.SetBuildOrder(projectIDs), warnings, nil
+}
@@ -0,0 +1,214 @@
+package builder
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/ipaexporter"
+)
+
+// IPAExportModel - a distribution flavor exported from the collected xcarchives
+type IPAExportModel struct {
+	DistributionType profiles.DistributionType
+
+	// bundle id - provisioning profile uuid of the app and its extensions,
+	// defaults to the best installed profile of the distribution type, see: SetIOSProvisioningProfileSelection
+	ProvisioningProfiles map[string]string
+
+	TeamID         string // defaults to the team of the selected profiles
+	CompileBitcode bool   // non app-store exports only
+	UploadSymbols  bool   // app-store exports only
+}
+
+// ExportIPAsFromXCArchives - exports an ipa for each distribution flavor from the collected xcarchive outputs
+// by xcodebuild -exportArchive, like: one ad-hoc ipa for testers and one app-store ipa for the release.
+// The ipas are written next to the xcarchive, named <archive name>-<distribution type>.ipa,
+// and added to the outputs of the xcarchive's project.
+func (builder Model) ExportIPAsFromXCArchives(outputMap ProjectOutputMap, exports ...IPAExportModel) (ProjectOutputMap, error) {
+	for projectName, projectOutputs := range outputMap {
+		for _, output := range projectOutputs.Outputs {
+			if output.OutputType != constants.OutputTypeXCArchive {
+				continue
+			}
+
+			for _, export := range exports {
+				ipaPth, err := builder.exportIPAFromXCArchive(output.Pth, export)
+				if err != nil {
+					return ProjectOutputMap{}, err
+				}
+
+				projectOutputs.Outputs = append(projectOutputs.Outputs, OutputModel{
+					Pth:              ipaPth,
+					OutputType:       constants.OutputTypeIPA,
+					DistributionType: string(export.DistributionType),
+					CreationTime:     artifactModTime(ipaPth),
+				})
+			}
+		}
+		outputMap[projectName] = projectOutputs
+	}
+
+	return outputMap, nil
+}
+
+func (builder Model) exportIPAFromXCArchive(xcarchivePth string, export IPAExportModel) (string, error) {
+	options, err := builder.ipaExportOptions(xcarchivePth, export)
+	if err != nil {
+		return "", err
+	}
+
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("ipa_export")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			builder.log().Warnf("Failed to remove (%s), error: %s", tmpDir, err)
+		}
+	}()
+
+	exportOptionsPth := filepath.Join(tmpDir, "exportOptions.plist")
+	if err := ipaexporter.WriteExportOptions(options, exportOptionsPth); err != nil {
+		return "", err
+	}
+
+	exportDir := filepath.Join(tmpDir, "export")
+	if err := builder.runCommand(ipaexporter.New(xcarchivePth, exportOptionsPth, exportDir)); err != nil {
+		return "", fmt.Errorf("failed to export %s ipa from (%s), error: %s", export.DistributionType, xcarchivePth, err)
+	}
+
+	exportedIPAPth, err := findIPA(exportDir)
+	if err != nil {
+		return "", err
+	}
+
+	archiveName := strings.TrimSuffix(filepath.Base(xcarchivePth), filepath.Ext(xcarchivePth))
+	ipaPth := filepath.Join(filepath.Dir(xcarchivePth), fmt.Sprintf("%s-%s.ipa", archiveName, export.DistributionType))
+	if err := moveFile(exportedIPAPth, ipaPth); err != nil {
+		return "", err
+	}
+	return ipaPth, nil
+}
+
+// ipaExportOptions returns the export options, with the provisioning profiles selected for the archived bundle ids
+func (builder Model) ipaExportOptions(xcarchivePth string, export IPAExportModel) (ipaexporter.ExportOptionsModel, error) {
+	options := ipaexporter.ExportOptionsModel{
+		Method:               ipaexporter.Method(export.DistributionType),
+		TeamID:               export.TeamID,
+		ProvisioningProfiles: export.ProvisioningProfiles,
+		CompileBitcode:       export.CompileBitcode,
+		UploadSymbols:        export.UploadSymbols,
+	}
+	if len(options.ProvisioningProfiles) > 0 {
+		return options, nil
+	}
+
+	bundleIDs, err := xcarchiveBundleIDs(xcarchivePth)
+	if err != nil {
+		return ipaexporter.ExportOptionsModel{}, err
+	}
+
+	profilesDir := builder.provisioningProfilesDir
+	if profilesDir == "" {
+		profilesDir = profiles.DefaultDir()
+	}
+	availableProfiles, err := profiles.List(profilesDir)
+	if err != nil {
+		return ipaexporter.ExportOptionsModel{}, err
+	}
+
+	options.ProvisioningProfiles = map[string]string{}
+	for _, bundleID := range bundleIDs {
+		profile, ok := profiles.Select(availableProfiles, bundleID, export.DistributionType, time.Now())
+		if !ok {
+			return ipaexporter.ExportOptionsModel{}, fmt.Errorf("no valid %s provisioning profile found for bundle identifier: %s", export.DistributionType, bundleID)
+		}
+		options.ProvisioningProfiles[bundleID] = profile.UUID
+		if options.TeamID == "" {
+			options.TeamID = profile.TeamID
+		}
+	}
+
+	return options, nil
+}
+
+// xcarchiveAppPth returns the path of the archived app
+func xcarchiveAppPth(xcarchivePth string) (string, error) {
+	archiveInfo, err := plist.New(filepath.Join(xcarchivePth, "Info.plist"))
+	if err != nil {
+		return "", err
+	}
+
+	applicationProperties, _ := archiveInfo.GetDict("ApplicationProperties")
+	applicationPth, ok := applicationProperties.GetString("ApplicationPath")
+	if !ok {
+		return "", &ArtifactNotFoundError{OutputType: constants.OutputTypeAPP, Dir: xcarchivePth}
+	}
+	return filepath.Join(xcarchivePth, "Products", applicationPth), nil
+}
+
+// xcarchiveBundleIDs returns the bundle id of the archived app, followed by its extensions' bundle ids
+func xcarchiveBundleIDs(xcarchivePth string) ([]string, error) {
+	appPth, err := xcarchiveAppPth(xcarchivePth)
+	if err != nil {
+		return nil, err
+	}
+
+	infoPlistPths := []string{filepath.Join(appPth, "Info.plist")}
+	extensionPths, err := filepath.Glob(filepath.Join(appPth, "PlugIns", "*.appex", "Info.plist"))
+	if err != nil {
+		return nil, err
+	}
+	infoPlistPths = append(infoPlistPths, extensionPths...)
+
+	bundleIDs := []string{}
+	for _, infoPlistPth := range infoPlistPths {
+		infoPlist, err := plist.New(infoPlistPth)
+		if err != nil {
+			return nil, err
+		}
+		if bundleID, ok := infoPlist.GetString("CFBundleIdentifier"); ok {
+			bundleIDs = append(bundleIDs, bundleID)
+		}
+	}
+
+	return bundleIDs, nil
+}
+
+func findIPA(dir string) (string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to list exported files (%s), error: %s", dir, err)
+	}
+
+	for _, info := range infos {
+		if strings.EqualFold(filepath.Ext(info.Name()), ".ipa") {
+			return filepath.Join(dir, info.Name()), nil
+		}
+	}
+	return "", &ArtifactNotFoundError{OutputType: constants.OutputTypeIPA, Dir: dir}
+}
+
+// moveFile renames the file, or copies it if the destination is on an other volume
+func moveFile(sourcePth, destinationPth string) error {
+	if err := os.Rename(sourcePth, destinationPth); err == nil {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(sourcePth)
+	if err != nil {
+		return fmt.Errorf("failed to read (%s), error: %s", sourcePth, err)
+	}
+	if err := ioutil.WriteFile(destinationPth, content, 0644); err != nil {
+		return fmt.Errorf("failed to write (%s), error: %s", destinationPth, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/tools/ipaexporter"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestInfoPlist(t *testing.T, pth, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0777))
+	require.NoError(t, fileutil.WriteStringToFile(pth, `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+`+content+`
+</dict>
+</plist>`))
+}
+
+func TestIPAExportOptions(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("ipaexport_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	xcarchivePth := filepath.Join(tmpDir, "iOS.xcarchive")
+	writeTestInfoPlist(t, filepath.Join(xcarchivePth, "Info.plist"), `<key>ApplicationProperties</key>
+<dict>
+	<key>ApplicationPath</key>
+	<string>Applications/iOS.app</string>
+</dict>`)
+	appPth := filepath.Join(xcarchivePth, "Products", "Applications", "iOS.app")
+	writeTestInfoPlist(t, filepath.Join(appPth, "Info.plist"), `<key>CFBundleIdentifier</key><string>com.bitrise.sampleapp</string>`)
+	writeTestInfoPlist(t, filepath.Join(appPth, "PlugIns", "Today.appex", "Info.plist"), `<key>CFBundleIdentifier</key><string>com.bitrise.sampleapp.today</string>`)
+
+	t.Log("it reads the bundle ids of the app and its extensions")
+	{
+		bundleIDs, err := xcarchiveBundleIDs(xcarchivePth)
+		require.NoError(t, err)
+		require.Equal(t, []string{"com.bitrise.sampleapp", "com.bitrise.sampleapp.today"}, bundleIDs)
+	}
+
+	t.Log("it uses the given provisioning profiles")
+	{
+		builder := Model{}
+		options, err := builder.ipaExportOptions(xcarchivePth, IPAExportModel{
+			DistributionType:     profiles.DistributionTypeAdHoc,
+			ProvisioningProfiles: map[string]string{"com.bitrise.sampleapp": "app-uuid"},
+			TeamID:               "72SA8V3WYL",
+		})
+		require.NoError(t, err)
+		require.Equal(t, ipaexporter.MethodAdHoc, options.Method)
+		require.Equal(t, "72SA8V3WYL", options.TeamID)
+		require.Equal(t, map[string]string{"com.bitrise.sampleapp": "app-uuid"}, options.ProvisioningProfiles)
+	}
+
+	t.Log("it fails if no installed profile matches")
+	{
+		profilesDir := filepath.Join(tmpDir, "Provisioning Profiles")
+		require.NoError(t, os.MkdirAll(profilesDir, 0777))
+
+		builder := Model{}
+		builder.SetIOSProvisioningProfileSelection(profiles.DistributionTypeAppStore, profilesDir)
+		_, err := builder.ipaExportOptions(xcarchivePth, IPAExportModel{DistributionType: profiles.DistributionTypeAppStore})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "com.bitrise.sampleapp")
+	}
+
+	t.Log("it finds the exported ipa")
+	{
+		exportDir := filepath.Join(tmpDir, "export")
+		createTestFile(t, exportDir, "DistributionSummary.plist")
+		createTestFile(t, exportDir, "iOS.ipa")
+
+		ipaPth, err := findIPA(exportDir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(exportDir, "iOS.ipa"), ipaPth)
+
+		_, err = findIPA(xcarchivePth)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,71 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/brandonrisell/go-xamarin/tools/keychain"
+)
+
+// SetCodesignIdentity - iOS, tvOS and macOS projects are signed with the given identity (CodesignKey, CodeSigningKey for macOS),
+// like: iPhone Distribution: Bitrise Ltd (72SA8V3WYL), overriding the projects' and the selected provisioning profile's identity
+func (builder *Model) SetCodesignIdentity(identity string) *Model {
+	builder.codesignIdentity = identity
+	return builder
+}
+
+// SetKeychain - the signing identities are looked up in the given keychain (CodesignKeychain),
+// which is unlocked before building the iOS, tvOS and macOS projects, the password is masked
+func (builder *Model) SetKeychain(keychainPth, password string) *Model {
+	builder.keychainPth = keychainPth
+	builder.keychainPassword = password
+	return builder.AddSecret(password)
+}
+
+// setCodesignProperties sets the signing identity and keychain of the Apple projects
+func (builder Model) setCodesignProperties(command *xbuild.Model, sdk constants.SDK) {
+	if builder.codesignIdentity != "" {
+		if sdk == constants.SDKMacOS {
+			command.SetProperty("CodeSigningKey", builder.codesignIdentity)
+		} else {
+			command.SetProperty("CodesignKey", builder.codesignIdentity)
+		}
+	}
+	if builder.keychainPth != "" {
+		command.SetProperty("CodesignKeychain", builder.keychainPth)
+	}
+}
+
+// unlockKeychain unlocks the keychain of SetKeychain, if any of the projects is an Apple project
+func (builder Model) unlockKeychain(projects []project.Model, callback BuildCommandCallback) error {
+	if builder.keychainPth == "" {
+		return nil
+	}
+
+	signsApple := false
+	for _, proj := range projects {
+		if proj.SDK == constants.SDKIOS || proj.SDK == constants.SDKTvOS || proj.SDK == constants.SDKMacOS {
+			signsApple = true
+			break
+		}
+	}
+	if !signsApple {
+		return nil
+	}
+
+	command, err := keychain.New(builder.keychainPth, builder.keychainPassword)
+	if err != nil {
+		return err
+	}
+
+	if callback != nil {
+		callback(builder.solution.Name, "", constants.SDKUnknown, constants.TestFrameworkUnknown, builder.printableCommand(command), false)
+	}
+
+	if err := builder.runCommand(command); err != nil {
+		return fmt.Errorf("failed to unlock keychain (%s), error: %s", builder.keychainPth, err)
+	}
+	return nil
+}
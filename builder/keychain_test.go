@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodesignProperties(t *testing.T) {
+	t.Log("it sets nothing by default")
+	{
+		builder := Model{}
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+
+		builder.setCodesignProperties(command, constants.SDKIOS)
+		require.NotContains(t, command.PrintableCommand(), "Codesign")
+	}
+
+	t.Log("it sets the identity and the keychain")
+	{
+		builder := Model{}
+		builder.SetCodesignIdentity("iPhone Distribution: Bitrise Ltd (72SA8V3WYL)").SetKeychain("/build.keychain", "keychain-secret")
+
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+		builder.setCodesignProperties(command, constants.SDKIOS)
+		require.Contains(t, command.PrintableCommand(), `"/p:CodesignKey=iPhone Distribution: Bitrise Ltd (72SA8V3WYL)"`)
+		require.Contains(t, command.PrintableCommand(), `"/p:CodesignKeychain=/build.keychain"`)
+
+		command, err = xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+		builder.setCodesignProperties(command, constants.SDKMacOS)
+		require.Contains(t, command.PrintableCommand(), `"/p:CodeSigningKey=iPhone Distribution: Bitrise Ltd (72SA8V3WYL)"`)
+
+		require.Equal(t, "unlock-keychain -p ***", builder.MaskSecrets("unlock-keychain -p keychain-secret"))
+	}
+
+	t.Log("it unlocks the keychain only for apple projects")
+	{
+		builder := Model{}
+		builder.SetKeychain("/build.keychain", "keychain-secret")
+
+		called := false
+		callback := func(solutionName string, projectName string, sdk constants.SDK, testFramework constants.TestFramework, commandStr string, alreadyPerformed bool) {
+			called = true
+		}
+		require.NoError(t, builder.unlockKeychain([]project.Model{{SDK: constants.SDKAndroid}}, callback))
+		require.Equal(t, false, called)
+	}
+}
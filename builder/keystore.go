@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools/androidsigner"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// AndroidKeystoreModel - keystore used to sign the Android packages, overriding the projects' signing properties
+type AndroidKeystoreModel struct {
+	Pth           string
+	Alias         string
+	StorePassword string
+	KeyPassword   string // defaults to the StorePassword
+}
+
+// SetAndroidKeystore - Android projects are built by the SignAndroidPackage target with the given keystore,
+// the passwords are masked in the printed commands and in the callbacks
+func (builder *Model) SetAndroidKeystore(keystore AndroidKeystoreModel) *Model {
+	builder.androidKeystore = keystore
+	return builder.AddSecret(keystore.StorePassword, keystore.KeyPassword)
+}
+
+// SetAndroidPostBuildSigning - Android projects are packaged by the PackageForAndroid target,
+// then the unsigned apks are zipaligned and signed by apksigner (or jarsigner) with the keystore of SetAndroidKeystore,
+// when collecting the outputs, which reports the signed apks
+func (builder *Model) SetAndroidPostBuildSigning(enabled bool) *Model {
+	builder.androidPostBuildSigning = enabled
+	return builder
+}
+
+func (builder Model) hasAndroidKeystore() bool {
+	return builder.androidKeystore.Pth != ""
+}
+
+func (builder Model) setAndroidKeystoreProperties(command *xbuild.Model) {
+	keystore := builder.androidKeystore
+
+	keyPassword := keystore.KeyPassword
+	if keyPassword == "" {
+		keyPassword = keystore.StorePassword
+	}
+
+	command.SetProperty("AndroidKeyStore", "true")
+	command.SetProperty("AndroidSigningKeyStore", keystore.Pth)
+	command.SetProperty("AndroidSigningKeyAlias", keystore.Alias)
+	command.SetSecretProperty("AndroidSigningStorePass", keystore.StorePassword)
+	command.SetSecretProperty("AndroidSigningKeyPass", keyPassword)
+}
+
+func (builder Model) signsAndroidPackageAfterBuild() bool {
+	return builder.androidPostBuildSigning && builder.hasAndroidKeystore()
+}
+
+func isSignedAPKName(apkPth string) bool {
+	return strings.HasSuffix(strings.ToLower(apkPth), "-signed.apk")
+}
+
+// signAPK zipaligns and signs the unsigned apk into <name>-Signed.apk next to it
+func (builder Model) signAPK(apkPth string) (string, error) {
+	buildToolsDir, err := androidsigner.SystemBuildToolsDir()
+	if err != nil {
+		return "", err
+	}
+
+	signedAPKPth := strings.TrimSuffix(apkPth, filepath.Ext(apkPth)) + "-Signed.apk"
+
+	signer, err := androidsigner.New(buildToolsDir, apkPth, signedAPKPth)
+	if err != nil {
+		return "", err
+	}
+
+	keystore := builder.androidKeystore
+	signer.SetKeystore(keystore.Pth, keystore.Alias, keystore.StorePassword, keystore.KeyPassword)
+
+	if err := builder.runCommand(signer); err != nil {
+		return "", fmt.Errorf("failed to sign apk (%s), error: %s", apkPth, err)
+	}
+
+	return signedAPKPth, nil
+}
+
+// signAPKOutputs - replaces the unsigned apk outputs with their signed version
+func (builder Model) signAPKOutputs(outputs []OutputModel) ([]OutputModel, error) {
+	for i, output := range outputs {
+		if output.OutputType != constants.OutputTypeAPK || isSignedAPKName(output.Pth) {
+			continue
+		}
+
+		signedAPKPth, err := builder.signAPK(output.Pth)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i].Pth = signedAPKPth
+	}
+	return outputs, nil
+}
@@ -0,0 +1,56 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAndroidKeystore(t *testing.T) {
+	t.Log("it is not set by default")
+	{
+		builder := Model{}
+		require.Equal(t, false, builder.hasAndroidKeystore())
+	}
+
+	t.Log("it sets the signing properties with masked passwords")
+	{
+		builder := Model{}
+		builder.SetAndroidKeystore(AndroidKeystoreModel{
+			Pth:           "/keystores/release.keystore",
+			Alias:         "release",
+			StorePassword: "store-secret",
+		})
+		require.Equal(t, true, builder.hasAndroidKeystore())
+
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/Droid/Droid.csproj")
+		require.NoError(t, err)
+		builder.setAndroidKeystoreProperties(command)
+
+		printableCommand := command.PrintableCommand()
+		require.Contains(t, printableCommand, `"/p:AndroidKeyStore=true"`)
+		require.Contains(t, printableCommand, `"/p:AndroidSigningKeyStore=/keystores/release.keystore"`)
+		require.Contains(t, printableCommand, `"/p:AndroidSigningKeyAlias=release"`)
+		require.Contains(t, printableCommand, `"/p:AndroidSigningStorePass=***"`)
+		require.Contains(t, printableCommand, `"/p:AndroidSigningKeyPass=***"`)
+		require.NotContains(t, printableCommand, "store-secret")
+	}
+
+	t.Log("post-build signing requires the keystore")
+	{
+		builder := Model{}
+		builder.SetAndroidPostBuildSigning(true)
+		require.Equal(t, false, builder.signsAndroidPackageAfterBuild())
+
+		builder.SetAndroidKeystore(AndroidKeystoreModel{Pth: "/keystores/release.keystore", Alias: "release"})
+		require.Equal(t, true, builder.signsAndroidPackageAfterBuild())
+	}
+
+	t.Log("it detects signed apk names")
+	{
+		require.Equal(t, true, isSignedAPKName("/bin/Release/com.bitrise.app-Signed.apk"))
+		require.Equal(t, false, isSignedAPKName("/bin/Release/com.bitrise.app.apk"))
+	}
+}
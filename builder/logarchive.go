@@ -0,0 +1,203 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// LogIndexFileName - the index of the archived logs, written into the log directory
+const LogIndexFileName = "index.json"
+
+// solutionLogName - the log of the commands which do not belong to a single project, like the solution build
+const solutionLogName = "solution"
+
+// LogIndexModel - lists the archived logs, like: Sample.iOS.log
+type LogIndexModel struct {
+	Solution string            `json:"solution"`
+	Logs     []ProjectLogModel `json:"logs"`
+}
+
+// ProjectLogModel - the archived log of a project, the project is empty for the solution level commands
+type ProjectLogModel struct {
+	Project  string            `json:"project,omitempty"`
+	Pth      string            `json:"path"` // relative to the log directory
+	Failed   bool              `json:"failed"`
+	Commands []CommandLogModel `json:"commands"`
+}
+
+// CommandLogModel - a command, whose output is archived in the project's log
+type CommandLogModel struct {
+	Command  string  `json:"command"`
+	ExitCode int     `json:"exit_code"`
+	Duration float64 `json:"duration"` // seconds
+}
+
+// logArchive writes the command outputs into the per-project logs, it is shared by the copies of the builder Model
+type logArchive struct {
+	mutex sync.Mutex
+	dir   string
+	index LogIndexModel
+}
+
+// projectLog is the log file of a running command
+type projectLog struct {
+	archive *logArchive
+	file    *os.File
+	project string
+	command string
+	logger  tools.Logger
+}
+
+// SetLogDir - the full output of every command is written into per-project log files in the given directory,
+// like: Sample.iOS.log, the commands which do not belong to a single project (like the solution build) go into solution.log.
+// The logs, with their commands and exit codes, are listed in the index.json of the directory,
+// so the logs of the failed project can be attached to the CI build.
+func (builder *Model) SetLogDir(dir string) *Model {
+	builder.logArchive = &logArchive{dir: dir, index: LogIndexModel{Solution: builder.solution.Name, Logs: []ProjectLogModel{}}}
+	return builder
+}
+
+var logNameRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// logFileName returns the name of the project's log, like: Sample.iOS.log
+func logFileName(projectName string) string {
+	if projectName == "" {
+		projectName = solutionLogName
+	}
+	return logNameRegexp.ReplaceAllString(projectName, "_") + ".log"
+}
+
+func (archive *logArchive) find(projectName string) int {
+	for i, entry := range archive.index.Logs {
+		if entry.Project == projectName {
+			return i
+		}
+	}
+	return -1
+}
+
+// open opens the project's log, the log is truncated by the first command of the project,
+// returns nil if the logs are not archived or the log can not be opened
+func (archive *logArchive) open(projectName, printableCommand string, logger tools.Logger) *projectLog {
+	if archive == nil {
+		return nil
+	}
+
+	archive.mutex.Lock()
+	defer archive.mutex.Unlock()
+
+	if err := os.MkdirAll(archive.dir, 0755); err != nil {
+		logger.Warnf("Failed to create log dir (%s), error: %s", archive.dir, err)
+		return nil
+	}
+
+	idx := archive.find(projectName)
+	flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if idx < 0 {
+		flag |= os.O_TRUNC
+	}
+
+	name := logFileName(projectName)
+	pth := filepath.Join(archive.dir, name)
+	file, err := os.OpenFile(pth, flag, 0644)
+	if err != nil {
+		logger.Warnf("Failed to open log (%s), error: %s", pth, err)
+		return nil
+	}
+
+	if idx < 0 {
+		archive.index.Logs = append(archive.index.Logs, ProjectLogModel{Project: projectName, Pth: name, Commands: []CommandLogModel{}})
+	}
+
+	if _, err := fmt.Fprintf(file, "$ %s\n\n", printableCommand); err != nil {
+		logger.Warnf("Failed to write log (%s), error: %s", pth, err)
+	}
+
+	return &projectLog{archive: archive, file: file, project: projectName, command: printableCommand, logger: logger}
+}
+
+// Write ...
+func (commandLog *projectLog) Write(p []byte) (int, error) {
+	return commandLog.file.Write(p)
+}
+
+// finish closes the log and adds the command to the index
+func (commandLog *projectLog) finish(err error, duration time.Duration) {
+	if commandLog == nil {
+		return
+	}
+
+	exitCode := tools.ExitCode(err)
+	if _, writeErr := fmt.Fprintf(commandLog.file, "\nexit code: %d\n\n", exitCode); writeErr != nil {
+		commandLog.logger.Warnf("Failed to write log (%s), error: %s", commandLog.file.Name(), writeErr)
+	}
+	if closeErr := commandLog.file.Close(); closeErr != nil {
+		commandLog.logger.Warnf("Failed to close log (%s), error: %s", commandLog.file.Name(), closeErr)
+	}
+
+	archive := commandLog.archive
+	archive.mutex.Lock()
+	defer archive.mutex.Unlock()
+
+	idx := archive.find(commandLog.project)
+	if idx < 0 {
+		return
+	}
+	entry := &archive.index.Logs[idx]
+	entry.Commands = append(entry.Commands, CommandLogModel{Command: commandLog.command, ExitCode: exitCode, Duration: duration.Seconds()})
+	if err != nil {
+		entry.Failed = true
+	}
+
+	if err := archive.writeIndex(); err != nil {
+		commandLog.logger.Warnf("%s", err)
+	}
+}
+
+// writeIndex writes the index, it is rewritten after every command, so it is up to date if the build fails
+func (archive *logArchive) writeIndex() error {
+	content, err := json.MarshalIndent(archive.index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize log index, error: %s", err)
+	}
+
+	pth := filepath.Join(archive.dir, LogIndexFileName)
+	if err := fileutil.WriteBytesToFile(pth, content); err != nil {
+		return fmt.Errorf("failed to write log index (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+// ReadLogIndex - reads the index.json of the log directory
+func ReadLogIndex(dir string) (LogIndexModel, error) {
+	pth := filepath.Join(dir, LogIndexFileName)
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return LogIndexModel{}, fmt.Errorf("failed to read log index (%s), error: %s", pth, err)
+	}
+
+	var index LogIndexModel
+	if err := json.Unmarshal(content, &index); err != nil {
+		return LogIndexModel{}, fmt.Errorf("failed to parse log index (%s), error: %s", pth, err)
+	}
+	return index, nil
+}
+
+// FailedLogPths - the paths of the failed projects' logs
+func (index LogIndexModel) FailedLogPths(dir string) []string {
+	pths := []string{}
+	for _, entry := range index.Logs {
+		if entry.Failed {
+			pths = append(pths, filepath.Join(dir, entry.Pth))
+		}
+	}
+	return pths
+}
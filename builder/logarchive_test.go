@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/stretchr/testify/require"
+)
+
+type logTestCommand struct {
+	printable string
+	output    string
+	exitCode  int
+	stdout    io.Writer
+}
+
+func (command *logTestCommand) PrintableCommand() string           { return command.printable }
+func (command *logTestCommand) SetCustomOptions(options ...string) {}
+func (command *logTestCommand) SetStdout(out io.Writer)            { command.stdout = out }
+func (command *logTestCommand) SetStderr(err io.Writer)            {}
+func (command *logTestCommand) Run() error {
+	if _, err := fmt.Fprint(command.stdout, command.output); err != nil {
+		return err
+	}
+	if command.exitCode != 0 {
+		return exec.Command("/bin/bash", "-c", fmt.Sprintf("exit %d", command.exitCode)).Run()
+	}
+	return nil
+}
+
+func TestLogArchive(t *testing.T) {
+	ios := project.Model{ID: "1", Name: "Sample.iOS"}
+	droid := project.Model{ID: "2", Name: "Sample.Droid"}
+
+	t.Log("it writes the output of the commands into the project logs")
+	{
+		logDir, err := pathutil.NormalizedOSTempDirPath("logarchive_test")
+		require.NoError(t, err)
+
+		builder := Model{}
+		builder.SetLogDir(logDir).AddSecret("secret-password")
+
+		steps := []buildStep{
+			{project: ios, command: &logTestCommand{printable: "xbuild Sample.iOS.csproj", output: "ios build\n"}},
+			{project: ios, command: &logTestCommand{printable: "xbuild Sample.iOS.csproj /t:Archive", output: "ios archive\n"}},
+			{project: droid, command: &logTestCommand{printable: "xbuild Sample.Droid.csproj", output: "password: secret-password\nbuild failed\n", exitCode: 3}},
+		}
+
+		err = builder.runBuildSteps(steps, nil)
+		require.Error(t, err)
+
+		iosLog, err := fileutil.ReadStringFromFile(filepath.Join(logDir, "Sample.iOS.log"))
+		require.NoError(t, err)
+		require.Equal(t, "$ xbuild Sample.iOS.csproj\n\nios build\n\nexit code: 0\n\n$ xbuild Sample.iOS.csproj /t:Archive\n\nios archive\n\nexit code: 0\n\n", iosLog)
+
+		droidLog, err := fileutil.ReadStringFromFile(filepath.Join(logDir, "Sample.Droid.log"))
+		require.NoError(t, err)
+		require.Contains(t, droidLog, "build failed")
+		require.Contains(t, droidLog, "exit code: 3")
+		require.NotContains(t, droidLog, "secret-password")
+
+		index, err := ReadLogIndex(logDir)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(index.Logs))
+		require.Equal(t, "Sample.iOS", index.Logs[0].Project)
+		require.Equal(t, false, index.Logs[0].Failed)
+		require.Equal(t, 2, len(index.Logs[0].Commands))
+		require.Equal(t, "Sample.Droid.log", index.Logs[1].Pth)
+		require.Equal(t, 3, index.Logs[1].Commands[0].ExitCode)
+		require.Equal(t, []string{filepath.Join(logDir, "Sample.Droid.log")}, index.FailedLogPths(logDir))
+	}
+
+	t.Log("it truncates the logs of a previous build")
+	{
+		logDir, err := pathutil.NormalizedOSTempDirPath("logarchive_test")
+		require.NoError(t, err)
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(logDir, "Sample.iOS.log"), "previous build\n"))
+
+		builder := Model{}
+		builder.SetLogDir(logDir)
+
+		steps := []buildStep{{project: ios, command: &logTestCommand{printable: "xbuild Sample.iOS.csproj", output: "ios build\n"}}}
+		require.NoError(t, builder.runBuildSteps(steps, nil))
+
+		iosLog, err := fileutil.ReadStringFromFile(filepath.Join(logDir, "Sample.iOS.log"))
+		require.NoError(t, err)
+		require.NotContains(t, iosLog, "previous build")
+	}
+
+	t.Log("it names the log of the solution level commands solution.log")
+	{
+		require.Equal(t, "solution.log", logFileName(""))
+		require.Equal(t, "Sample_App_.iOS.log", logFileName("Sample App (.iOS"))
+	}
+}
@@ -0,0 +1,157 @@
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// ManifestSchemaVersion - version of the artifact manifest format, increased on breaking changes
+const ManifestSchemaVersion = 1
+
+// ManifestModel - machine-readable description of the collected artifacts
+type ManifestModel struct {
+	SchemaVersion int                     `json:"schema_version"`
+	Artifacts     []ManifestArtifactModel `json:"artifacts"`
+}
+
+// ManifestArtifactModel ...
+type ManifestArtifactModel struct {
+	Pth        string `json:"path"`
+	OutputType string `json:"type"`
+	ABI        string `json:"abi,omitempty"`
+	Size       int64  `json:"size"`
+	SHA256     string `json:"sha256"`
+
+	Project       string `json:"project"`
+	SDK           string `json:"sdk"`
+	Configuration string `json:"configuration,omitempty"`
+	Platform      string `json:"platform,omitempty"`
+
+	Version     string `json:"version,omitempty"`
+	BuildNumber string `json:"build_number,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+// Manifest - describes the artifacts, sorted by project name and path.
+// The size and checksum of directory artifacts (like .app and .xcarchive) cover their files.
+func (projectOutputMap ProjectOutputMap) Manifest() (ManifestModel, error) {
+	manifest := ManifestModel{
+		SchemaVersion: ManifestSchemaVersion,
+		Artifacts:     []ManifestArtifactModel{},
+	}
+
+	for _, artifact := range projectOutputMap.Artifacts() {
+		size, checksum, err := artifactChecksum(tools.NewDefaultLogger(), artifact.Pth)
+		if err != nil {
+			return ManifestModel{}, fmt.Errorf("failed to calculate checksum of (%s), error: %s", artifact.Pth, err)
+		}
+
+		manifestArtifact := ManifestArtifactModel{
+			Pth:           artifact.Pth,
+			OutputType:    string(artifact.OutputType),
+			ABI:           artifact.ABI,
+			Size:          size,
+			SHA256:        checksum,
+			Project:       artifact.Project,
+			SDK:           string(artifact.SDK),
+			Configuration: artifact.Configuration,
+			Platform:      artifact.Platform,
+			Version:       artifact.Version,
+			BuildNumber:   artifact.BuildNumber,
+		}
+		if !artifact.CreationTime.IsZero() {
+			manifestArtifact.CreatedAt = artifact.CreationTime.UTC().Format(time.RFC3339)
+		}
+
+		manifest.Artifacts = append(manifest.Artifacts, manifestArtifact)
+	}
+
+	return manifest, nil
+}
+
+// WriteManifest - writes the JSON manifest of the artifacts to the given path
+func (projectOutputMap ProjectOutputMap) WriteManifest(pth string) error {
+	manifest, err := projectOutputMap.Manifest()
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize manifest, error: %s", err)
+	}
+
+	if err := fileutil.WriteBytesToFile(pth, content); err != nil {
+		return fmt.Errorf("failed to write manifest (%s), error: %s", pth, err)
+	}
+
+	return nil
+}
+
+// artifactChecksum returns the size and the SHA-256 checksum of the file,
+// for directories the checksum covers the relative paths and the contents of the files in lexical order
+func artifactChecksum(logger tools.Logger, pth string) (int64, string, error) {
+	info, err := os.Stat(pth)
+	if err != nil {
+		return 0, "", err
+	}
+
+	hash := sha256.New()
+	if !info.IsDir() {
+		size, err := hashFile(logger, hash, pth)
+		if err != nil {
+			return 0, "", err
+		}
+		return size, hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	var size int64
+	// filepath.Walk visits the files in lexical order
+	if err := filepath.Walk(pth, func(filePth string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		relPth, err := filepath.Rel(pth, filePth)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(hash, filepath.ToSlash(relPth)+"\x00"); err != nil {
+			return err
+		}
+
+		fileSize, err := hashFile(logger, hash, filePth)
+		size += fileSize
+		return err
+	}); err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hashFile(logger tools.Logger, writer io.Writer, pth string) (int64, error) {
+	file, err := os.Open(pth)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Warnf("Failed to close file (%s), error: %s", pth, err)
+		}
+	}()
+
+	return io.Copy(writer, file)
+}
@@ -0,0 +1,87 @@
+package builder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteManifest(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("manifest_test")
+	require.NoError(t, err)
+
+	createTestFile(t, tmpDir, "Android/com.bitrise.app-Signed.apk")
+	createTestFile(t, tmpDir, "iOS/App.ipa")
+	createTestFile(t, tmpDir, "iOS/App.app.dSYM/Contents/Info.plist")
+	createTestFile(t, tmpDir, "iOS/App.app.dSYM/Contents/Resources/DWARF/App")
+
+	projectOutputMap := ProjectOutputMap{
+		"App.iOS": ProjectOutputModel{
+			ProjectType: constants.SDKIOS,
+			Outputs: []OutputModel{
+				{Pth: filepath.Join(tmpDir, "iOS/App.ipa"), OutputType: constants.OutputTypeIPA},
+				{Pth: filepath.Join(tmpDir, "iOS/App.app.dSYM"), OutputType: constants.OutputTypeDSYM},
+			},
+			Configuration: "Release",
+			Platform:      "iPhone",
+		},
+		"App.Droid": ProjectOutputModel{
+			ProjectType: constants.SDKAndroid,
+			Outputs: []OutputModel{
+				{Pth: filepath.Join(tmpDir, "Android/com.bitrise.app-Signed.apk"), OutputType: constants.OutputTypeAPK},
+			},
+			Configuration: "Release",
+			Platform:      "AnyCPU",
+		},
+	}
+
+	t.Log("it describes the artifacts sorted by project")
+	{
+		manifest, err := projectOutputMap.Manifest()
+		require.NoError(t, err)
+		require.Equal(t, ManifestSchemaVersion, manifest.SchemaVersion)
+		require.Equal(t, 3, len(manifest.Artifacts))
+
+		apk := manifest.Artifacts[0]
+		require.Equal(t, "App.Droid", apk.Project)
+		require.Equal(t, "apk", apk.OutputType)
+		require.Equal(t, "android", apk.SDK)
+		require.Equal(t, "Release", apk.Configuration)
+		require.Equal(t, "AnyCPU", apk.Platform)
+		require.Equal(t, int64(4), apk.Size)
+		// sha256 of "test"
+		require.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", apk.SHA256)
+
+		require.Equal(t, filepath.Join(tmpDir, "iOS/App.app.dSYM"), manifest.Artifacts[1].Pth)
+		require.Equal(t, int64(8), manifest.Artifacts[1].Size)
+		require.Equal(t, 64, len(manifest.Artifacts[1].SHA256))
+		require.Equal(t, filepath.Join(tmpDir, "iOS/App.ipa"), manifest.Artifacts[2].Pth)
+	}
+
+	t.Log("it writes the manifest as json")
+	{
+		pth := filepath.Join(tmpDir, "manifest.json")
+		require.NoError(t, projectOutputMap.WriteManifest(pth))
+
+		content, err := ioutil.ReadFile(pth)
+		require.NoError(t, err)
+
+		var manifest ManifestModel
+		require.NoError(t, json.Unmarshal(content, &manifest))
+		require.Equal(t, 3, len(manifest.Artifacts))
+		require.Equal(t, "iPhone", manifest.Artifacts[2].Platform)
+	}
+
+	t.Log("it fails for missing artifacts")
+	{
+		missing := ProjectOutputMap{
+			"App": ProjectOutputModel{Outputs: []OutputModel{{Pth: filepath.Join(tmpDir, "missing.ipa")}}},
+		}
+		require.Error(t, missing.WriteManifest(filepath.Join(tmpDir, "missing.json")))
+	}
+}
@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/notarytool"
+)
+
+// NotarizationModel - notarization status of a macOS .app or .pkg output
+type NotarizationModel struct {
+	SubmissionID string
+	Status       string // Accepted, Invalid, Rejected, empty if the submission failed
+	Stapled      bool
+
+	Notarized bool   // accepted and the ticket is stapled
+	Message   string // reason of the failed notarization
+}
+
+// SetMacOSNotarization - the collected signed macOS .app and .pkg outputs are notarized by notarytool
+// and the ticket is stapled to them, the status is reported in the outputs' Notarization,
+// the password is masked
+func (builder *Model) SetMacOSNotarization(credentials notarytool.CredentialsModel) *Model {
+	builder.macOSNotarization = &credentials
+	return builder.AddSecret(credentials.Password)
+}
+
+// notarizeOutputs sets the notarization of the .app and .pkg outputs, unsigned outputs are not submitted
+func (builder Model) notarizeOutputs(outputs []OutputModel) []OutputModel {
+	for i, output := range outputs {
+		if output.OutputType != constants.OutputTypeAPP && output.OutputType != constants.OutputTypePKG {
+			continue
+		}
+
+		if output.Signature != nil && !output.Signature.Signed {
+			outputs[i].Notarization = &NotarizationModel{Message: "not signed"}
+			continue
+		}
+
+		notarization := builder.notarize(output.Pth)
+		if !notarization.Notarized {
+			builder.log().Warnf("Notarization of (%s) failed: %s", output.Pth, notarization.Message)
+		}
+		outputs[i].Notarization = &notarization
+	}
+	return outputs
+}
+
+func (builder Model) notarize(pth string) NotarizationModel {
+	command, err := notarytool.New(pth, *builder.macOSNotarization)
+	if err != nil {
+		return NotarizationModel{Message: err.Error()}
+	}
+
+	runErr := builder.runCommand(command)
+
+	submission := command.Submission()
+	notarization := NotarizationModel{
+		SubmissionID: submission.ID,
+		Status:       submission.Status,
+		Stapled:      submission.Stapled,
+		Notarized:    runErr == nil,
+	}
+	if runErr != nil {
+		notarization.Message = runErr.Error()
+	}
+	return notarization
+}
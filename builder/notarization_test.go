@@ -0,0 +1,35 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/notarytool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotarizeOutputs(t *testing.T) {
+	t.Log("it skips the unsigned and the non macOS outputs")
+	{
+		builder := Model{}
+		builder.SetMacOSNotarization(notarytool.CredentialsModel{AppleID: "bot@bitrise.io", TeamID: "72SA8V3WYL", Password: "app-secret"})
+
+		outputs := builder.notarizeOutputs([]OutputModel{
+			{Pth: "/bin/Mac.app", OutputType: constants.OutputTypeAPP, Signature: &SignatureModel{Signed: false}},
+			{Pth: "/bin/Mac.dll", OutputType: constants.OutputTypeDLL},
+		})
+		require.Equal(t, &NotarizationModel{Message: "not signed"}, outputs[0].Notarization)
+		require.Nil(t, outputs[1].Notarization)
+		require.Equal(t, "--password ***", builder.MaskSecrets("--password app-secret"))
+	}
+
+	t.Log("it reports the invalid credentials")
+	{
+		builder := Model{}
+		builder.SetMacOSNotarization(notarytool.CredentialsModel{AppleID: "bot@bitrise.io"})
+
+		outputs := builder.notarizeOutputs([]OutputModel{{Pth: "/bin/Mac.pkg", OutputType: constants.OutputTypePKG}})
+		require.Equal(t, false, outputs[0].Notarization.Notarized)
+		require.Contains(t, outputs[0].Notarization.Message, "required")
+	}
+}
@@ -0,0 +1,43 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+)
+
+// PreBuildHook - called with every project to build, before the build commands run, e.g. to patch the project's files
+type PreBuildHook func(proj project.Model) error
+
+// AddPreBuildHook - registers a hook, which is called before the build
+func (builder *Model) AddPreBuildHook(hook PreBuildHook) *Model {
+	builder.preBuildHooks = append(builder.preBuildHooks, hook)
+	return builder
+}
+
+func (builder Model) runPreBuildHooks(projects []project.Model) error {
+	for _, proj := range projects {
+		for _, hook := range builder.preBuildHooks {
+			if err := hook(proj); err != nil {
+				return fmt.Errorf("pre-build hook of project (%s) failed, error: %s", proj.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// InfoPlistVersionHook - returns a pre-build hook, which sets the CFBundleVersion and CFBundleShortVersionString
+// in the Info.plist of the iOS, tvOS and macOS projects, an empty value keeps the project's one
+func InfoPlistVersionHook(bundleVersion, shortVersion string) PreBuildHook {
+	return func(proj project.Model) error {
+		if proj.SDK != constants.SDKIOS && proj.SDK != constants.SDKTvOS && proj.SDK != constants.SDKMacOS {
+			return nil
+		}
+		if proj.InfoPlistPth == "" {
+			return nil
+		}
+		return plist.SetVersion(proj.InfoPlistPth, bundleVersion, shortVersion)
+	}
+}
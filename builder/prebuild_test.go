@@ -0,0 +1,60 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoPlistVersionHook(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("prebuild_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	infoPlistPth := filepath.Join(tmpDir, "Info.plist")
+	require.NoError(t, fileutil.WriteStringToFile(infoPlistPth, `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleVersion</key>
+	<string>1</string>
+	<key>CFBundleShortVersionString</key>
+	<string>1.0</string>
+</dict>
+</plist>`))
+
+	t.Log("it sets the versions of the iOS project's Info.plist")
+	{
+		builder := Model{}
+		builder.AddPreBuildHook(InfoPlistVersionHook("42", ""))
+		require.NoError(t, builder.runPreBuildHooks([]project.Model{
+			{Name: "Android", SDK: constants.SDKAndroid, InfoPlistPth: filepath.Join(tmpDir, "not-existing.plist")},
+			{Name: "iOS", SDK: constants.SDKIOS, InfoPlistPth: infoPlistPth},
+		}))
+
+		infoPlist, err := plist.New(infoPlistPth)
+		require.NoError(t, err)
+		bundleVersion, _ := infoPlist.GetString(plist.BundleVersionKey)
+		require.Equal(t, "42", bundleVersion)
+		shortVersion, _ := infoPlist.GetString(plist.BundleShortVersionKey)
+		require.Equal(t, "1.0", shortVersion)
+	}
+
+	t.Log("it fails if a hook fails")
+	{
+		builder := Model{}
+		builder.AddPreBuildHook(func(proj project.Model) error {
+			return fmt.Errorf("failed")
+		})
+		require.EqualError(t, builder.runPreBuildHooks([]project.Model{{Name: "iOS"}}), "pre-build hook of project (iOS) failed, error: failed")
+	}
+}
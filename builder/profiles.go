@@ -0,0 +1,93 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+)
+
+// SetIOSProvisioningProfileSelection - iOS and tvOS device builds are signed with the best installed provisioning profile
+// matching the project's bundle identifier and the distribution type (CodesignProvision and CodesignKey properties),
+// profiles are read from profilesDir, defaults to profiles.DefaultDir.
+// Signing properties set by SetBuildProperty are not overridden.
+func (builder *Model) SetIOSProvisioningProfileSelection(distributionType profiles.DistributionType, profilesDir string) *Model {
+	if profilesDir == "" {
+		profilesDir = profiles.DefaultDir()
+	}
+
+	builder.iosDistributionType = distributionType
+	builder.provisioningProfilesDir = profilesDir
+	return builder
+}
+
+// setIOSCodesignProperties sets the signing properties of the selected profile,
+// and returns a warning if no profile matches
+func (builder Model) setIOSCodesignProperties(command *xbuild.Model, proj project.Model, projectConfig project.ConfigurationPlatformModel) []string {
+	if builder.iosDistributionType == profiles.DistributionTypeUnknown || !builder.archivesIOSProject(projectConfig) {
+		return []string{}
+	}
+	if _, ok := builder.buildProperties["CodesignProvision"]; ok {
+		return []string{}
+	}
+
+	availableProfiles, err := profiles.List(builder.provisioningProfilesDir)
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	now := time.Now()
+	profile, err := selectIOSProvisioningProfile(availableProfiles, proj.BundleIdentifier, builder.iosDistributionType, now)
+	if err != nil {
+		return []string{fmt.Sprintf("project (%s): %s", proj.Name, err)}
+	}
+
+	for name, value := range iosCodesignProperties(profile, now) {
+		command.SetProperty(name, value)
+	}
+	return entitlementsWarnings(proj, projectConfig, profile)
+}
+
+// selectIOSProvisioningProfile returns the profile selected for the bundle id
+func selectIOSProvisioningProfile(availableProfiles []profiles.Model, bundleID string, distributionType profiles.DistributionType, now time.Time) (profiles.Model, error) {
+	if bundleID == "" {
+		return profiles.Model{}, fmt.Errorf("no bundle identifier found to select %s provisioning profile", distributionType)
+	}
+
+	profile, ok := profiles.Select(availableProfiles, bundleID, distributionType, now)
+	if !ok {
+		return profiles.Model{}, fmt.Errorf("no valid %s provisioning profile found for bundle identifier: %s", distributionType, bundleID)
+	}
+	return profile, nil
+}
+
+// iosCodesignProperties returns the CodesignProvision (profile uuid) and CodesignKey (certificate common name)
+// of the selected profile
+func iosCodesignProperties(profile profiles.Model, now time.Time) map[string]string {
+	return map[string]string{
+		"CodesignProvision": profile.UUID,
+		"CodesignKey":       profile.ValidCertificates(now)[0].CommonName,
+	}
+}
+
+// entitlementsWarnings validates the entitlements of the project configuration (CodesignEntitlements) against the profile,
+// so the mismatches are reported before codesign fails at the end of the archive
+func entitlementsWarnings(proj project.Model, projectConfig project.ConfigurationPlatformModel, profile profiles.Model) []string {
+	if projectConfig.CodesignEntitlements == "" {
+		return []string{}
+	}
+
+	entitlements, err := plist.New(projectConfig.CodesignEntitlements)
+	if err != nil {
+		return []string{fmt.Sprintf("project (%s): %s", proj.Name, err)}
+	}
+
+	warnings := []string{}
+	for _, mismatch := range profile.ValidateEntitlements(entitlements) {
+		warnings = append(warnings, fmt.Sprintf("project (%s): %s", proj.Name, mismatch))
+	}
+	return warnings
+}
@@ -0,0 +1,115 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIOSCodesignProperties(t *testing.T) {
+	now := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	availableProfiles := []profiles.Model{
+		{
+			UUID:           "app-store-uuid",
+			BundleID:       "com.bitrise.*",
+			Type:           profiles.DistributionTypeAppStore,
+			ExpirationDate: now.AddDate(1, 0, 0),
+			Certificates: []profiles.CertificateModel{
+				{CommonName: "iPhone Distribution: Expired (72SA8V3WYL)", NotAfter: now.AddDate(0, -1, 0)},
+				{CommonName: "iPhone Distribution: Bitrise Ltd (72SA8V3WYL)", NotAfter: now.AddDate(1, 0, 0)},
+			},
+		},
+	}
+
+	t.Log("it returns the signing properties of the selected profile")
+	{
+		profile, err := selectIOSProvisioningProfile(availableProfiles, "com.bitrise.sampleapp", profiles.DistributionTypeAppStore, now)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"CodesignProvision": "app-store-uuid",
+			"CodesignKey":       "iPhone Distribution: Bitrise Ltd (72SA8V3WYL)",
+		}, iosCodesignProperties(profile, now))
+	}
+
+	t.Log("it fails if no profile matches")
+	{
+		_, err := selectIOSProvisioningProfile(availableProfiles, "com.bitrise.sampleapp", profiles.DistributionTypeAdHoc, now)
+		require.Error(t, err)
+
+		_, err = selectIOSProvisioningProfile(availableProfiles, "", profiles.DistributionTypeAppStore, now)
+		require.Error(t, err)
+	}
+
+	t.Log("it skips the simulator builds and the explicit signing properties")
+	{
+		builder := Model{}
+		builder.SetIOSProvisioningProfileSelection(profiles.DistributionTypeAppStore, "/not/existing/dir")
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{}, builder.setIOSCodesignProperties(command, project.Model{}, project.ConfigurationPlatformModel{MtouchArchs: []string{"x86_64"}}))
+
+		builder.SetBuildProperty("CodesignProvision", "manual-uuid")
+		require.Equal(t, []string{}, builder.setIOSCodesignProperties(command, project.Model{}, project.ConfigurationPlatformModel{MtouchArchs: []string{"ARM64"}}))
+
+		builder = Model{}
+		builder.SetIOSProvisioningProfileSelection(profiles.DistributionTypeAppStore, "/not/existing/dir")
+		require.Equal(t, 1, len(builder.setIOSCodesignProperties(command, project.Model{}, project.ConfigurationPlatformModel{MtouchArchs: []string{"ARM64"}})))
+		require.NotContains(t, command.PrintableCommand(), "Codesign")
+	}
+}
+
+func TestEntitlementsWarnings(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("profiles_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	entitlementsPth := filepath.Join(tmpDir, "Entitlements.plist")
+	require.NoError(t, fileutil.WriteStringToFile(entitlementsPth, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>aps-environment</key>
+	<string>development</string>
+	<key>com.apple.security.application-groups</key>
+	<array>
+		<string>group.com.bitrise.sampleapp</string>
+	</array>
+</dict>
+</plist>`))
+
+	proj := project.Model{Name: "iOS"}
+	profile := profiles.Model{Name: "Sample", BundleID: "com.bitrise.sampleapp", Entitlements: plist.Model{
+		"com.apple.security.application-groups": []interface{}{"group.com.bitrise.sampleapp"},
+	}}
+
+	t.Log("it reports the entitlements missing from the profile")
+	{
+		warnings := entitlementsWarnings(proj, project.ConfigurationPlatformModel{CodesignEntitlements: entitlementsPth}, profile)
+		require.Equal(t, 1, len(warnings))
+		require.Contains(t, warnings[0], "project (iOS): entitlement (aps-environment)")
+	}
+
+	t.Log("it skips the configs without entitlements")
+	{
+		require.Equal(t, []string{}, entitlementsWarnings(proj, project.ConfigurationPlatformModel{}, profile))
+	}
+
+	t.Log("it warns if the entitlements can not be read")
+	{
+		warnings := entitlementsWarnings(proj, project.ConfigurationPlatformModel{CodesignEntitlements: filepath.Join(tmpDir, "not-existing.plist")}, profile)
+		require.Equal(t, 1, len(warnings))
+	}
+}
@@ -0,0 +1,117 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// ProgressPhase - the state of a build step
+type ProgressPhase string
+
+const (
+	// ProgressPhaseStarted - the step's command started
+	ProgressPhaseStarted ProgressPhase = "started"
+	// ProgressPhaseFinished - the step's command finished successfully
+	ProgressPhaseFinished ProgressPhase = "finished"
+	// ProgressPhaseSkipped - the step's command was already performed by an earlier step
+	ProgressPhaseSkipped ProgressPhase = "skipped"
+)
+
+// ProgressModel - the progress of the build, steps are the planned build commands
+type ProgressModel struct {
+	Step    int // 1 based index of the current step
+	Total   int // number of the planned steps
+	Project string
+	Phase   ProgressPhase
+}
+
+// ProgressCallback ...
+type ProgressCallback func(progress ProgressModel)
+
+// SetProgressCallback - the callback is notified about the start and end of every build command,
+// with the step index and the number of the planned commands, like for rendering a progress bar
+func (builder *Model) SetProgressCallback(callback ProgressCallback) *Model {
+	builder.progressCallback = callback
+	return builder
+}
+
+// buildStep - a planned build command of a project
+type buildStep struct {
+	project          project.Model
+	command          tools.Runnable
+	alreadyPerformed bool
+}
+
+// planBuildSteps creates the build commands of the projects, the commands are passed to the prepareCallback,
+// the ones repeating an earlier command are marked as already performed
+func (builder Model) planBuildSteps(configuration, platform string, projects []project.Model, prepareCallback PrepareCommandCallback) ([]buildStep, []string, error) {
+	warnings := []string{}
+	steps := []buildStep{}
+	plannedCommands := []tools.Printable{}
+
+	for _, proj := range projects {
+		buildCommands, warns, err := builder.buildProjectCommand(configuration, platform, proj)
+		warnings = append(warnings, warns...)
+		builder.events.warnings(warns)
+		if err != nil {
+			return nil, warnings, fmt.Errorf("Failed to create build command, error: %w", err)
+		}
+
+		for _, buildCommand := range buildCommands {
+			// Callback to let the caller to modify the command
+			if prepareCallback != nil {
+				editabeCommand := tools.Editable(buildCommand)
+				prepareCallback(builder.solution.Name, proj.Name, proj.SDK, proj.TestFramework, &editabeCommand)
+			}
+
+			// Check if same command was already planned
+			alreadyPerformed := tools.PrintableSliceContains(plannedCommands, buildCommand)
+			if !alreadyPerformed {
+				plannedCommands = append(plannedCommands, buildCommand)
+			}
+
+			steps = append(steps, buildStep{project: proj, command: buildCommand, alreadyPerformed: alreadyPerformed})
+		}
+	}
+
+	return steps, warnings, nil
+}
+
+// runBuildSteps runs the planned commands, and reports the progress
+func (builder Model) runBuildSteps(steps []buildStep, callback BuildCommandCallback) error {
+	for i, step := range steps {
+		proj := step.project
+		if i == 0 || steps[i-1].project.ID != proj.ID {
+			builder.events.projectStarted(proj)
+		}
+
+		// Callback to notify the caller about next running command
+		if callback != nil {
+			callback(builder.solution.Name, proj.Name, proj.SDK, proj.TestFramework, builder.printableCommand(step.command), step.alreadyPerformed)
+		}
+
+		progress := ProgressModel{Step: i + 1, Total: len(steps), Project: proj.Name}
+		if step.alreadyPerformed {
+			builder.reportProgress(progress, ProgressPhaseSkipped)
+			continue
+		}
+
+		builder.reportProgress(progress, ProgressPhaseStarted)
+		if err := builder.runProjectCommand(proj.Name, step.command); err != nil {
+			return newBuildFailedError(proj.Name, err)
+		}
+		builder.reportProgress(progress, ProgressPhaseFinished)
+	}
+
+	return nil
+}
+
+func (builder Model) reportProgress(progress ProgressModel, phase ProgressPhase) {
+	if builder.progressCallback == nil {
+		return
+	}
+	progress.Phase = phase
+	builder.progressCallback(progress)
+}
@@ -0,0 +1,75 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/stretchr/testify/require"
+)
+
+type progressTestCommand struct {
+	printable string
+	err       error
+	runs      *int
+}
+
+func (command progressTestCommand) PrintableCommand() string           { return command.printable }
+func (command progressTestCommand) SetCustomOptions(options ...string) {}
+func (command progressTestCommand) Run() error {
+	*command.runs++
+	return command.err
+}
+
+func TestRunBuildSteps(t *testing.T) {
+	ios := project.Model{ID: "1", Name: "Sample.iOS"}
+	droid := project.Model{ID: "2", Name: "Sample.Droid"}
+
+	t.Log("it reports the progress of the steps")
+	{
+		runs := 0
+		steps := []buildStep{
+			{project: ios, command: progressTestCommand{printable: "xbuild Sample.iOS.csproj", runs: &runs}},
+			{project: droid, command: progressTestCommand{printable: "xbuild Sample.sln", runs: &runs}},
+			{project: droid, command: progressTestCommand{printable: "xbuild Sample.sln", runs: &runs}, alreadyPerformed: true},
+		}
+
+		progresses := []ProgressModel{}
+		builder := Model{}
+		builder.SetProgressCallback(func(progress ProgressModel) {
+			progresses = append(progresses, progress)
+		})
+
+		require.NoError(t, builder.runBuildSteps(steps, nil))
+		require.Equal(t, 2, runs)
+		require.Equal(t, []ProgressModel{
+			{Step: 1, Total: 3, Project: "Sample.iOS", Phase: ProgressPhaseStarted},
+			{Step: 1, Total: 3, Project: "Sample.iOS", Phase: ProgressPhaseFinished},
+			{Step: 2, Total: 3, Project: "Sample.Droid", Phase: ProgressPhaseStarted},
+			{Step: 2, Total: 3, Project: "Sample.Droid", Phase: ProgressPhaseFinished},
+			{Step: 3, Total: 3, Project: "Sample.Droid", Phase: ProgressPhaseSkipped},
+		}, progresses)
+	}
+
+	t.Log("it stops at the failed step")
+	{
+		runs := 0
+		steps := []buildStep{
+			{project: ios, command: progressTestCommand{printable: "xbuild Sample.iOS.csproj", err: errors.New("exit status 1"), runs: &runs}},
+			{project: droid, command: progressTestCommand{printable: "xbuild Sample.Droid.csproj", runs: &runs}},
+		}
+
+		progresses := []ProgressModel{}
+		builder := Model{}
+		builder.SetProgressCallback(func(progress ProgressModel) {
+			progresses = append(progresses, progress)
+		})
+
+		err := builder.runBuildSteps(steps, nil)
+		var buildFailedErr *BuildFailedError
+		require.True(t, errors.As(err, &buildFailedErr))
+		require.Equal(t, "Sample.iOS", buildFailedErr.Project)
+		require.Equal(t, 1, runs)
+		require.Equal(t, []ProgressModel{{Step: 1, Total: 2, Project: "Sample.iOS", Phase: ProgressPhaseStarted}}, progresses)
+	}
+}
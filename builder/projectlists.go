@@ -2,21 +2,61 @@ package builder
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+	"github.com/bitrise-tools/go-xamarin/analyzers/solution"
 	"github.com/bitrise-tools/go-xamarin/constants"
 	"github.com/bitrise-tools/go-xamarin/utility"
 )
 
+// orderedProjects returns the solution's projects ordered so, that every project comes after its dependencies,
+// or ordered by name if the dependency graph contains a cycle
+func (builder Model) orderedProjects() []project.Model {
+	projects := []project.Model{}
+
+	order, err := builder.solution.DependencyOrder()
+	if err != nil {
+		builder.log().Debugf("Ordering projects by name: %s", err)
+
+		for _, proj := range builder.solution.ProjectMap {
+			projects = append(projects, proj)
+		}
+		sort.Slice(projects, func(i, j int) bool {
+			return projects[i].Name < projects[j].Name
+		})
+		return projects
+	}
+
+	for _, projectID := range order {
+		projects = append(projects, builder.solution.ProjectMap[projectID])
+	}
+	return projects
+}
+
 func (builder Model) whitelistedProjects() []project.Model {
 	projects := []project.Model{}
 
-	for _, proj := range builder.solution.ProjectMap {
+	for _, proj := range builder.orderedProjects() {
+		// Shared projects are compiled as part of the importing projects
+		if proj.ProjectType == constants.ProjectTypeShared {
+			continue
+		}
+
+		// App extensions and watch apps are built and archived as part of their container app
+		if isEmbeddedProjectType(proj.ProjectType) {
+			continue
+		}
+
 		if !whitelistAllows(proj.SDK, builder.projectTypeWhitelist...) {
 			continue
 		}
 
-		if proj.SDK != constants.SDKUnknown {
+		if !builder.folderWhitelistAllows(proj) {
+			continue
+		}
+
+		if proj.SDK != constants.SDKUnknown || (builder.buildLibraries && proj.ProjectType == constants.ProjectTypeLibrary) {
 			projects = append(projects, proj)
 		}
 	}
@@ -24,6 +64,32 @@ func (builder Model) whitelistedProjects() []project.Model {
 	return projects
 }
 
+func isLibraryProjectType(projectType constants.ProjectType) bool {
+	return projectType == constants.ProjectTypeLibrary ||
+		projectType == constants.ProjectTypeiOSBinding ||
+		projectType == constants.ProjectTypeAndroidBinding
+}
+
+func isEmbeddedProjectType(projectType constants.ProjectType) bool {
+	return projectType == constants.ProjectTypeAppExtension ||
+		projectType == constants.ProjectTypeWatchApp ||
+		projectType == constants.ProjectTypeWatchExtension
+}
+
+func (builder Model) folderWhitelistAllows(proj project.Model) bool {
+	if len(builder.folderWhitelist) == 0 {
+		return true
+	}
+
+	folderPth := builder.solution.FolderPath(proj.ID)
+	for _, folder := range builder.folderWhitelist {
+		if solution.IsInFolder(folderPth, folder) {
+			return true
+		}
+	}
+	return false
+}
+
 func (builder Model) buildableProjects(configuration, platform string) ([]project.Model, []string) {
 	projects := []project.Model{}
 	warnings := []string{}
@@ -41,6 +107,16 @@ func (builder Model) buildableProjects(configuration, platform string) ([]projec
 			continue
 		}
 
+		if isLibraryProjectType(proj.ProjectType) {
+			if !builder.buildLibraries {
+				warnings = append(warnings, fmt.Sprintf("Project (%s) is a library project, skipping...", proj.Name))
+				continue
+			}
+
+			projects = append(projects, proj)
+			continue
+		}
+
 		if (proj.SDK == constants.SDKIOS ||
 			proj.SDK == constants.SDKMacOS ||
 			proj.SDK == constants.SDKTvOS) &&
@@ -72,7 +148,11 @@ func (builder Model) buildableXamarinUITestProjectsAndReferredProjects(configura
 
 	for _, proj := range builder.solution.ProjectMap {
 		// Check if is XamarinUITest project
-		if proj.TestFramework != constants.TestFrameworkXamarinUITest {
+		if proj.ProjectType != constants.ProjectTypeXamarinUITest {
+			continue
+		}
+
+		if !builder.folderWhitelistAllows(proj) {
 			continue
 		}
 
@@ -84,12 +164,13 @@ func (builder Model) buildableXamarinUITestProjectsAndReferredProjects(configura
 		}
 
 		// Collect referred projects
-		if len(proj.ReferredProjectIDs) == 0 {
+		referredProjectIDs := builder.solution.Dependencies(proj.ID)
+		if len(referredProjectIDs) == 0 {
 			warnings = append(warnings, fmt.Sprintf("No referred projects found for test project: %s, skipping...", proj.Name))
 			continue
 		}
 
-		for _, projectID := range proj.ReferredProjectIDs {
+		for _, projectID := range referredProjectIDs {
 			referredProj, ok := builder.solution.ProjectMap[projectID]
 			if !ok {
 				warnings = append(warnings, fmt.Sprintf("Project reference exist with project id: %s, but project not found in solution", projectID))
@@ -130,6 +211,10 @@ func (builder Model) buildableNunitTestProjects(configuration, platform string)
 			continue
 		}
 
+		if !builder.folderWhitelistAllows(proj) {
+			continue
+		}
+
 		// Check if contains config mapping
 		_, ok := proj.ConfigMap[solutionConfig]
 		if !ok {
@@ -0,0 +1,27 @@
+package builder
+
+import (
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// AddSecret - the given values are masked in the printed commands, in the callbacks and in the output of the commands,
+// while the commands run with the real values
+func (builder *Model) AddSecret(values ...string) *Model {
+	if builder.secrets == nil {
+		builder.secrets = tools.NewSecrets()
+	}
+	builder.secrets.Add(values...)
+	return builder
+}
+
+// MaskSecrets - replaces the registered secrets in the given string
+func (builder Model) MaskSecrets(str string) string {
+	if builder.secrets == nil {
+		return str
+	}
+	return builder.secrets.Mask(str)
+}
+
+func (builder Model) printableCommand(command tools.Printable) string {
+	return builder.MaskSecrets(command.PrintableCommand())
+}
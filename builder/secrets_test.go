@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecrets(t *testing.T) {
+	t.Log("it does not mask without secrets")
+	{
+		builder := Model{}
+		require.Equal(t, "/p:Pass=secret", builder.MaskSecrets("/p:Pass=secret"))
+	}
+
+	t.Log("it masks the registered secrets in printable commands")
+	{
+		builder := Model{}
+		builder.AddSecret("api-token")
+
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+		command.SetProperty("ApiToken", "api-token")
+
+		require.Contains(t, command.PrintableCommand(), "api-token")
+		require.NotContains(t, builder.printableCommand(command), "api-token")
+		require.Contains(t, builder.printableCommand(command), `"/p:ApiToken=***"`)
+	}
+
+	t.Log("keystore passwords are registered as secrets")
+	{
+		builder := Model{}
+		builder.SetAndroidKeystore(AndroidKeystoreModel{Pth: "/release.keystore", Alias: "release", StorePassword: "store-secret", KeyPassword: "key-secret"})
+		require.Equal(t, "*** ***", builder.MaskSecrets("store-secret key-secret"))
+	}
+}
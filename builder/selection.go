@@ -0,0 +1,143 @@
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// ArtifactSelectionStrategy - defines which artifact is exported, if more than one matches
+type ArtifactSelectionStrategy string
+
+const (
+	// ArtifactSelectionNewest - the newest artifact modified during the build, or the newest one if none was modified during the build,
+	// artifacts with equal modification time are resolved by their path's lexicographic order
+	ArtifactSelectionNewest ArtifactSelectionStrategy = "newest"
+	// ArtifactSelectionLexicographic - the lexicographically last artifact modified during the build, or the lexicographically last one
+	// if none was modified during the build, like for versioned or timestamped artifact names
+	ArtifactSelectionLexicographic ArtifactSelectionStrategy = "lexicographic"
+	// ArtifactSelectionBuildStart - the only artifact modified since the build started, artifacts of previous builds are never exported
+	ArtifactSelectionBuildStart ArtifactSelectionStrategy = "build-start"
+)
+
+// ParseArtifactSelectionStrategy ...
+func ParseArtifactSelectionStrategy(strategy string) (ArtifactSelectionStrategy, error) {
+	switch strategy {
+	case "newest":
+		return ArtifactSelectionNewest, nil
+	case "lexicographic":
+		return ArtifactSelectionLexicographic, nil
+	case "build-start":
+		return ArtifactSelectionBuildStart, nil
+	default:
+		return "", fmt.Errorf("invalid artifact selection strategy: %s", strategy)
+	}
+}
+
+// AmbiguousArtifactError - more than one artifact matches, and the selection strategy can not choose between them
+type AmbiguousArtifactError struct {
+	Strategy   ArtifactSelectionStrategy
+	Candidates []string
+}
+
+// Error ...
+func (err AmbiguousArtifactError) Error() string {
+	return fmt.Sprintf("%d artifacts match with selection strategy (%s), candidates: %s", len(err.Candidates), err.Strategy, strings.Join(err.Candidates, ", "))
+}
+
+// selectArtifact selects the artifact by the strategy from the outputDir,
+// the patterns are in priority order: the first pattern with a matching artifact is used.
+// The artifacts printed by the build and matching the first pattern are preferred, the strategy selects from them, if any.
+func selectArtifact(logger tools.Logger, walker *artifactWalker, outputDir string, startTime, endTime time.Time, strategy ArtifactSelectionStrategy, patterns ...string) (string, error) {
+	if len(patterns) > 0 {
+		if captured := walker.capturedArtifacts(outputDir, startTime, endTime, patterns[0]); len(captured) > 0 {
+			return selectCapturedArtifact(strategy, captured)
+		}
+	}
+
+	switch strategy {
+	case ArtifactSelectionLexicographic:
+		candidates, err := findArtifacts(walker, outputDir, startTime, endTime, true, patterns...)
+		if err != nil {
+			return "", err
+		}
+		if len(candidates) == 0 {
+			if candidates, err = findArtifacts(walker, outputDir, startTime, endTime, false, patterns...); err != nil {
+				return "", err
+			}
+			if len(candidates) > 0 {
+				logger.Warnf("No artifact generated during build")
+				logger.Debugf("Exporting lexicographically last artifact: %s", candidates[len(candidates)-1])
+			}
+		}
+		if len(candidates) == 0 {
+			return "", nil
+		}
+		return candidates[len(candidates)-1], nil
+	case ArtifactSelectionBuildStart:
+		candidates, err := findArtifacts(walker, outputDir, startTime, endTime, true, patterns...)
+		if err != nil {
+			return "", err
+		}
+		if len(candidates) > 1 {
+			return "", AmbiguousArtifactError{Strategy: strategy, Candidates: candidates}
+		}
+		if len(candidates) == 0 {
+			return "", nil
+		}
+		return candidates[0], nil
+	default:
+		if artifactToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, patterns...); err != nil {
+			return "", err
+		} else if artifactToExport.path != "" {
+			return artifactToExport.path, nil
+		} else if latestPath, err := artifactToExport.exportLatest(); err != nil {
+			return "", err
+		} else if latestPath != "" {
+			logger.Warnf("No artifact generated during build")
+			logger.Debugf("Exporting latest generated artifact: %s", latestPath)
+			return latestPath, nil
+		}
+		return "", nil
+	}
+}
+
+// selectCapturedArtifact selects the artifact by the strategy from the artifacts printed by the build,
+// all of them were modified during the build
+func selectCapturedArtifact(strategy ArtifactSelectionStrategy, entries []artifactEntryModel) (string, error) {
+	pths := []string{}
+	for _, entry := range entries {
+		pths = append(pths, entry.pth)
+	}
+	sort.Strings(pths)
+
+	switch strategy {
+	case ArtifactSelectionLexicographic:
+		return pths[len(pths)-1], nil
+	case ArtifactSelectionBuildStart:
+		if len(pths) > 1 {
+			return "", AmbiguousArtifactError{Strategy: strategy, Candidates: pths}
+		}
+		return pths[0], nil
+	default:
+		return latestEntry(entries), nil
+	}
+}
+
+// findArtifacts returns the sorted paths matching the first pattern with any match,
+// if inInterval is set, only the artifacts modified within the time interval are returned
+func findArtifacts(walker *artifactWalker, outputDir string, startTime, endTime time.Time, inInterval bool, patterns ...string) ([]string, error) {
+	entries := walker.find(outputDir, func(entry artifactEntryModel) bool {
+		return !inInterval || isInTimeInterval(entry.modTime, startTime, endTime)
+	}, patterns...)
+
+	pths := []string{}
+	for _, entry := range entries {
+		pths = append(pths, entry.pth)
+	}
+	sort.Strings(pths)
+	return pths, nil
+}
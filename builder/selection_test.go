@@ -0,0 +1,84 @@
+package builder
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArtifactSelectionStrategy(t *testing.T) {
+	t.Log("it parses the strategies")
+	{
+		strategy, err := ParseArtifactSelectionStrategy("newest")
+		require.NoError(t, err)
+		require.Equal(t, ArtifactSelectionNewest, strategy)
+
+		strategy, err = ParseArtifactSelectionStrategy("lexicographic")
+		require.NoError(t, err)
+		require.Equal(t, ArtifactSelectionLexicographic, strategy)
+
+		strategy, err = ParseArtifactSelectionStrategy("build-start")
+		require.NoError(t, err)
+		require.Equal(t, ArtifactSelectionBuildStart, strategy)
+	}
+
+	t.Log("it fails for unknown strategy")
+	{
+		_, err := ParseArtifactSelectionStrategy("oldest")
+		require.Error(t, err)
+	}
+}
+
+func TestSelectArtifact(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+	require.NoError(t, err)
+
+	for _, ipa := range []string{"1.0/Multiplatform.iOS.ipa", "1.1/Multiplatform.iOS.ipa", "Other.ipa"} {
+		createTestFile(t, tmpDir, ipa)
+	}
+
+	startTime := time.Now().Add(-time.Minute)
+	endTime := time.Now().Add(time.Minute)
+
+	t.Log("lexicographic strategy")
+	{
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", startTime, endTime, ArtifactSelectionLexicographic)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "1.1", "Multiplatform.iOS.ipa"), output)
+	}
+
+	t.Log("lexicographic strategy falls back to the artifacts of previous builds")
+	{
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", endTime, endTime, ArtifactSelectionLexicographic)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "1.1", "Multiplatform.iOS.ipa"), output)
+	}
+
+	t.Log("build start strategy fails if more than one artifact matches")
+	{
+		_, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", startTime, endTime, ArtifactSelectionBuildStart)
+		require.Error(t, err)
+
+		ambiguousErr, ok := err.(AmbiguousArtifactError)
+		require.True(t, ok)
+		require.Equal(t, []string{
+			filepath.Join(tmpDir, "1.0", "Multiplatform.iOS.ipa"),
+			filepath.Join(tmpDir, "1.1", "Multiplatform.iOS.ipa"),
+		}, ambiguousErr.Candidates)
+	}
+
+	t.Log("build start strategy")
+	{
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Other", startTime, endTime, ArtifactSelectionBuildStart)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "Other.ipa"), output)
+
+		output, err = exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Other", endTime, endTime, ArtifactSelectionBuildStart)
+		require.NoError(t, err)
+		require.Equal(t, "", output)
+	}
+}
@@ -0,0 +1,106 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// buildSession records the start of the first command run by the builder,
+// it is shared by the copies of the builder Model
+type buildSession struct {
+	mutex     sync.Mutex
+	startTime time.Time
+
+	artifacts artifactCapture
+}
+
+// capturedArtifacts returns the artifact paths printed by the commands of the session
+func (session *buildSession) capturedArtifacts() []string {
+	if session == nil {
+		return nil
+	}
+	return session.artifacts.captured()
+}
+
+func (session *buildSession) start() {
+	if session == nil {
+		return
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	if session.startTime.IsZero() {
+		session.startTime = time.Now()
+	}
+}
+
+func (session *buildSession) started() time.Time {
+	if session == nil {
+		return time.Time{}
+	}
+
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+
+	return session.startTime
+}
+
+// SetSessionFilter - if enabled (default), only the artifacts created since the builder ran its first command are collected,
+// the artifacts of previous builds on persistent build machines are dropped
+func (builder *Model) SetSessionFilter(enabled bool) *Model {
+	builder.disableSessionFilter = !enabled
+	return builder
+}
+
+// SessionStartTime - returns the start time of the builder's first command, or zero time if no command was run yet
+func (builder Model) SessionStartTime() time.Time {
+	return builder.session.started()
+}
+
+// isSessionArtifact returns false, if the session filter is enabled and the artifact was not modified since the session started
+func (builder Model) isSessionArtifact(pth string) bool {
+	if builder.disableSessionFilter {
+		return true
+	}
+
+	startTime := builder.session.started()
+	if startTime.IsZero() {
+		return true
+	}
+
+	// file systems may store the modification time with a second precision
+	return !artifactModTime(pth).Before(startTime.Truncate(time.Second))
+}
+
+func (builder Model) filterSessionOutputs(outputs []OutputModel) []OutputModel {
+	filtered := []OutputModel{}
+	for _, output := range outputs {
+		if !builder.isSessionArtifact(output.Pth) {
+			builder.log().Warnf("Artifact (%s) was created before the build session, skipping...", output.Pth)
+			continue
+		}
+		filtered = append(filtered, output)
+	}
+	return filtered
+}
+
+// artifactModTime returns the modification time of the file, or the latest modification time of the directory's content,
+// like for .app and .xcarchive bundles
+func artifactModTime(pth string) time.Time {
+	var modTime time.Time
+	if err := filepath.Walk(pth, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+		return nil
+	}); err != nil {
+		return time.Time{}
+	}
+	return modTime
+}
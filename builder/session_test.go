@@ -0,0 +1,76 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionFilter(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("session_test")
+	require.NoError(t, err)
+
+	createTestFile(t, tmpDir, "Old.ipa")
+	createTestFile(t, tmpDir, "New.app/New")
+	createTestFile(t, tmpDir, "New.ipa")
+
+	oldTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "Old.ipa"), oldTime, oldTime))
+	require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "New.app"), oldTime, oldTime))
+
+	outputs := []OutputModel{
+		{Pth: filepath.Join(tmpDir, "Old.ipa"), OutputType: constants.OutputTypeIPA},
+		{Pth: filepath.Join(tmpDir, "New.app"), OutputType: constants.OutputTypeAPP},
+		{Pth: filepath.Join(tmpDir, "New.ipa"), OutputType: constants.OutputTypeIPA},
+	}
+
+	t.Log("it does not filter before the session started")
+	{
+		builder := Model{session: &buildSession{}}
+		require.True(t, builder.SessionStartTime().IsZero())
+		require.Equal(t, outputs, builder.filterSessionOutputs(outputs))
+	}
+
+	t.Log("it drops the artifacts of previous builds")
+	{
+		builder := Model{session: &buildSession{startTime: time.Now().Add(-time.Minute)}}
+		require.Equal(t, outputs[1:], builder.filterSessionOutputs(outputs))
+	}
+
+	t.Log("the filter can be disabled")
+	{
+		builder := Model{session: &buildSession{startTime: time.Now().Add(-time.Minute)}}
+		builder.SetSessionFilter(false)
+		require.Equal(t, outputs, builder.filterSessionOutputs(outputs))
+	}
+}
+
+type recordingLogger struct {
+	warnings []string
+}
+
+func (logger *recordingLogger) Debugf(format string, v ...interface{}) {}
+func (logger *recordingLogger) Infof(format string, v ...interface{})  {}
+func (logger *recordingLogger) Errorf(format string, v ...interface{}) {}
+func (logger *recordingLogger) Warnf(format string, v ...interface{}) {
+	logger.warnings = append(logger.warnings, fmt.Sprintf(format, v...))
+}
+
+func TestSessionFilterLogger(t *testing.T) {
+	t.Log("it reports the dropped artifacts to the logger")
+	{
+		logger := &recordingLogger{}
+		builder := Model{session: &buildSession{startTime: time.Now().Add(time.Minute)}}
+		builder.SetLogger(logger)
+
+		outputs := []OutputModel{{Pth: "/not/exist/Old.ipa", OutputType: constants.OutputTypeIPA}}
+		require.Equal(t, []OutputModel{}, builder.filterSessionOutputs(outputs))
+		require.Equal(t, []string{"Artifact (/not/exist/Old.ipa) was created before the build session, skipping..."}, logger.warnings)
+	}
+}
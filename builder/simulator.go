@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/brandonrisell/go-xamarin/utility"
+)
+
+const simulatorPlatform = "iPhoneSimulator"
+
+// defaultSimulatorArchitectures - the simulator architecture of the iOS and tvOS builds, if no architectures given
+var defaultSimulatorArchitectures = []string{"x86_64"}
+
+// SetIOSSimulatorBuild - iOS and tvOS projects are built for the simulator (iPhoneSimulator platform) in their mapped configuration,
+// regardless of the solution platform: no ipa and xcarchive is created, the project is built for the given architectures
+// (MtouchArch, defaults to x86_64) and the .app is collected as simulator-app output, like for UI testing
+func (builder *Model) SetIOSSimulatorBuild(enabled bool, architectures ...string) *Model {
+	if len(architectures) == 0 {
+		architectures = defaultSimulatorArchitectures
+	}
+
+	builder.iosSimulatorBuild = enabled
+	builder.iosSimulatorArchitectures = architectures
+	return builder
+}
+
+// isIOSSimulatorBuild - the project configuration is built for the simulator,
+// either by the simulator build mode or by its platform and architectures
+func (builder Model) isIOSSimulatorBuild(projectConfig project.ConfigurationPlatformModel) bool {
+	return builder.iosSimulatorBuild || isSimulatorBuild(projectConfig.Platform, projectConfig.MtouchArchs...)
+}
+
+// archivesIOSProject - device builds are archived and packaged into an ipa
+func (builder Model) archivesIOSProject(projectConfig project.ConfigurationPlatformModel) bool {
+	return !builder.isIOSSimulatorBuild(projectConfig) && isArchitectureArchiveable(projectConfig.MtouchArchs...)
+}
+
+// simulatorProjectConfig returns the simulator configuration of the project in simulator build mode,
+// the project's Configuration|iPhoneSimulator, or the mapped configuration with the default simulator output dir
+func (builder Model) simulatorProjectConfig(proj project.Model, projectConfig project.ConfigurationPlatformModel) project.ConfigurationPlatformModel {
+	if !builder.iosSimulatorBuild {
+		return projectConfig
+	}
+
+	if simulatorConfig, ok := proj.Configs[utility.ToConfig(projectConfig.Configuration, simulatorPlatform)]; ok {
+		simulatorConfig.MtouchArchs = builder.simulatorArchitectures(proj.SDK)
+		return simulatorConfig
+	}
+
+	simulatorConfig := projectConfig
+	simulatorConfig.Platform = simulatorPlatform
+	simulatorConfig.MtouchArchs = builder.simulatorArchitectures(proj.SDK)
+	simulatorConfig.OutputDir = filepath.Join(filepath.Dir(proj.Pth), "bin", simulatorPlatform, projectConfig.Configuration)
+	return simulatorConfig
+}
+
+// setIOSSimulatorProperties sets the simulator architectures supported by the SDK,
+// msbuild separates properties by comma, so the architectures' separator is escaped
+func (builder Model) setIOSSimulatorProperties(command *xbuild.Model, sdk constants.SDK) {
+	command.SetProperty("MtouchArch", strings.Join(builder.simulatorArchitectures(sdk), "%2C"))
+}
@@ -0,0 +1,66 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/xbuild"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIOSSimulatorBuild(t *testing.T) {
+	deviceConfig := project.ConfigurationPlatformModel{
+		Configuration: "Release",
+		Platform:      "iPhone",
+		OutputDir:     "/iOS/bin/iPhone/Release",
+		MtouchArchs:   []string{"ARM64"},
+	}
+
+	t.Log("it archives the device builds")
+	{
+		builder := Model{}
+		require.Equal(t, true, builder.archivesIOSProject(deviceConfig))
+		require.Equal(t, false, builder.archivesIOSProject(project.ConfigurationPlatformModel{Platform: "iPhoneSimulator"}))
+		require.Equal(t, false, builder.archivesIOSProject(project.ConfigurationPlatformModel{Platform: "iPhone", MtouchArchs: []string{"x86_64"}}))
+		require.Equal(t, deviceConfig, builder.simulatorProjectConfig(project.Model{}, deviceConfig))
+	}
+
+	t.Log("it builds the device configurations for the simulator")
+	{
+		builder := Model{}
+		builder.SetIOSSimulatorBuild(true)
+		require.Equal(t, false, builder.archivesIOSProject(deviceConfig))
+		require.Equal(t, true, builder.isIOSSimulatorBuild(deviceConfig))
+
+		simulatorConfig := builder.simulatorProjectConfig(project.Model{Pth: "/iOS/iOS.csproj"}, deviceConfig)
+		require.Equal(t, "iPhoneSimulator", simulatorConfig.Platform)
+		require.Equal(t, "/iOS/bin/iPhoneSimulator/Release", simulatorConfig.OutputDir)
+		require.Equal(t, []string{"x86_64"}, simulatorConfig.MtouchArchs)
+
+		proj := project.Model{
+			Pth: "/iOS/iOS.csproj",
+			Configs: map[string]project.ConfigurationPlatformModel{
+				"Release|iPhoneSimulator": {Configuration: "Release", Platform: "iPhoneSimulator", OutputDir: "/iOS/out/sim"},
+			},
+		}
+		require.Equal(t, "/iOS/out/sim", builder.simulatorProjectConfig(proj, deviceConfig).OutputDir)
+	}
+
+	t.Log("it sets the simulator architectures")
+	{
+		builder := Model{}
+		builder.SetIOSSimulatorBuild(true, "i386", "x86_64")
+		command, err := xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/iOS/iOS.csproj")
+		require.NoError(t, err)
+
+		builder.setIOSSimulatorProperties(command, constants.SDKIOS)
+		require.Contains(t, command.PrintableCommand(), `"/p:MtouchArch=i386%2Cx86_64"`)
+
+		command, err = xbuild.NewWithBuildTool(constants.XbuildPath, "/solution.sln", "/tvOS/tvOS.csproj")
+		require.NoError(t, err)
+
+		builder.setIOSSimulatorProperties(command, constants.SDKTvOS)
+		require.Contains(t, command.PrintableCommand(), `"/p:MtouchArch=x86_64"`)
+	}
+}
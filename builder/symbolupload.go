@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// SymbolUploader - uploads the crash symbols to a symbol server, see the implementations of the tools/symbolupload package
+type SymbolUploader interface {
+	UploadSymbols(pth string, outputType constants.OutputType) error
+}
+
+// AddSymbolUploader - the collected dSYM and mSYM outputs are uploaded by the uploader at the end of CollectProjectOutputs,
+// the bundle directories are uploaded zipped, failed uploads are reported as warnings
+func (builder *Model) AddSymbolUploader(uploader SymbolUploader) *Model {
+	builder.symbolUploaders = append(builder.symbolUploaders, uploader)
+	return builder
+}
+
+func (builder Model) uploadSymbols(outputMap ProjectOutputMap) {
+	if len(builder.symbolUploaders) == 0 {
+		return
+	}
+
+	for _, projectOutputs := range outputMap {
+		for _, output := range projectOutputs.Outputs {
+			if output.OutputType != constants.OutputTypeDSYM && output.OutputType != constants.OutputTypeMSYM {
+				continue
+			}
+
+			pth, cleanup, err := symbolsUploadPth(builder.log(), output.Pth)
+			if err != nil {
+				builder.log().Warnf("Failed to upload symbols (%s), error: %s", output.Pth, err)
+				continue
+			}
+
+			for _, uploader := range builder.symbolUploaders {
+				if loggable, ok := uploader.(tools.Loggable); ok && builder.logger != nil {
+					loggable.SetLogger(builder.logger)
+				}
+				if err := uploader.UploadSymbols(pth, output.OutputType); err != nil {
+					builder.log().Warnf("Failed to upload symbols (%s), error: %s", output.Pth, err)
+				}
+			}
+			cleanup()
+		}
+	}
+}
+
+// symbolsUploadPth returns the symbols zip, bundle directories are zipped into a temporary directory
+func symbolsUploadPth(logger tools.Logger, pth string) (string, func(), error) {
+	if exist, err := pathutil.IsDirExists(pth); err != nil {
+		return "", nil, err
+	} else if !exist {
+		return pth, func() {}, nil
+	}
+
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("symbols")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			logger.Warnf("Failed to remove (%s), error: %s", tmpDir, err)
+		}
+	}
+
+	zipPth := filepath.Join(tmpDir, filepath.Base(pth)+".zip")
+	if err := zipDir(logger, pth, zipPth); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return zipPth, cleanup, nil
+}
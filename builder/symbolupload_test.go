@@ -0,0 +1,95 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSymbolUploader struct {
+	uploaded []string
+	err      error
+	logger   tools.Logger
+}
+
+func (uploader *recordingSymbolUploader) SetLogger(logger tools.Logger) {
+	uploader.logger = logger
+}
+
+func (uploader *recordingSymbolUploader) UploadSymbols(pth string, outputType constants.OutputType) error {
+	exist, err := pathutil.IsPathExists(pth)
+	if err != nil {
+		return err
+	}
+	uploader.uploaded = append(uploader.uploaded, fmt.Sprintf("%s %s %t", outputType, filepath.Base(pth), exist))
+	return uploader.err
+}
+
+func TestUploadSymbols(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("symbolupload_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	dsymPth := filepath.Join(tmpDir, "iOS.app.dSYM")
+	require.NoError(t, os.MkdirAll(filepath.Join(dsymPth, "Contents"), 0777))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dsymPth, "Contents", "Info.plist"), "dsym"))
+	msymPth := filepath.Join(tmpDir, "com.bitrise.app.mSYM.zip")
+	require.NoError(t, fileutil.WriteStringToFile(msymPth, "msym"))
+
+	outputMap := ProjectOutputMap{
+		"iOS": ProjectOutputModel{Outputs: []OutputModel{
+			{Pth: dsymPth, OutputType: constants.OutputTypeDSYM},
+			{Pth: filepath.Join(tmpDir, "iOS.ipa"), OutputType: constants.OutputTypeIPA},
+		}},
+		"Android": ProjectOutputModel{Outputs: []OutputModel{
+			{Pth: msymPth, OutputType: constants.OutputTypeMSYM},
+		}},
+	}
+
+	t.Log("it uploads the zipped symbols to every uploader")
+	{
+		first, second := &recordingSymbolUploader{}, &recordingSymbolUploader{err: fmt.Errorf("failed")}
+		builder := Model{}
+		builder.AddSymbolUploader(first).AddSymbolUploader(second)
+
+		builder.uploadSymbols(outputMap)
+		sort.Strings(first.uploaded)
+		sort.Strings(second.uploaded)
+		require.Equal(t, []string{"dsym iOS.app.dSYM.zip true", "msym com.bitrise.app.mSYM.zip true"}, first.uploaded)
+		require.Equal(t, first.uploaded, second.uploaded)
+		require.Nil(t, first.logger)
+	}
+
+	t.Log("it passes the builder's logger to the uploaders")
+	{
+		logger := &recordingLogger{}
+		uploader := &recordingSymbolUploader{}
+		builder := Model{}
+		builder.SetLogger(logger)
+		builder.AddSymbolUploader(uploader)
+
+		builder.uploadSymbols(outputMap)
+		require.Equal(t, logger, uploader.logger)
+	}
+
+	t.Log("it keeps the collected bundle")
+	{
+		exist, err := pathutil.IsDirExists(dsymPth)
+		require.NoError(t, err)
+		require.Equal(t, true, exist)
+
+		exist, err = pathutil.IsPathExists(dsymPth + ".zip")
+		require.NoError(t, err)
+		require.Equal(t, false, exist)
+	}
+}
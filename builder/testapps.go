@@ -0,0 +1,198 @@
+package builder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/utility"
+)
+
+// buildableTestAppProjects returns the NUnitLite test app projects of the SDK
+func (builder Model) buildableTestAppProjects(configuration, platform string, sdk constants.SDK) ([]project.Model, []string) {
+	testProjects := []project.Model{}
+	warnings := []string{}
+
+	solutionConfig := utility.ToConfig(configuration, platform)
+
+	for _, proj := range builder.solution.ProjectMap {
+		if proj.SDK != sdk || proj.TestFramework != constants.TestFrameworkNunitLiteTest {
+			continue
+		}
+
+		if !builder.folderWhitelistAllows(proj) {
+			continue
+		}
+
+		if _, ok := proj.ConfigMap[solutionConfig]; !ok {
+			warnings = append(warnings, fmt.Sprintf("Project (%s) do not have config for solution config (%s), skipping...", proj.Name, solutionConfig))
+			continue
+		}
+
+		testProjects = append(testProjects, proj)
+	}
+
+	return testProjects, warnings
+}
+
+func (builder Model) buildTestApps(configuration, platform string, testProjects []project.Model, prepareCallback PrepareCommandCallback, callback BuildCommandCallback) ([]string, error) {
+	compatibilityWarnings, err := builder.checkXcodeCompatibility(testProjects)
+	warnings := compatibilityWarnings
+	if err != nil {
+		return warnings, err
+	}
+
+	if err := builder.runPreBuildHooks(testProjects); err != nil {
+		return warnings, err
+	}
+
+	perfomedCommands := []tools.Printable{}
+
+	for _, testProj := range testProjects {
+		buildCommands, warns, err := builder.buildProjectCommand(configuration, platform, testProj)
+		warnings = append(warnings, warns...)
+		if err != nil {
+			return warnings, fmt.Errorf("Failed to create build command, error: %w", err)
+		}
+
+		for _, buildCommand := range buildCommands {
+			// Callback to let the caller to modify the command
+			if prepareCallback != nil {
+				editabeCommand := tools.Editable(buildCommand)
+				prepareCallback(builder.solution.Name, testProj.Name, testProj.SDK, testProj.TestFramework, &editabeCommand)
+			}
+
+			// Check if same command was already performed
+			alreadyPerformed := false
+			if tools.PrintableSliceContains(perfomedCommands, buildCommand) {
+				alreadyPerformed = true
+			}
+
+			// Callback to notify the caller about next running command
+			if callback != nil {
+				callback(builder.solution.Name, testProj.Name, testProj.SDK, testProj.TestFramework, builder.printableCommand(buildCommand), alreadyPerformed)
+			}
+
+			if !alreadyPerformed {
+				if err := builder.runProjectCommand(testProj.Name, buildCommand); err != nil {
+					return warnings, newBuildFailedError(testProj.Name, err)
+				}
+				perfomedCommands = append(perfomedCommands, buildCommand)
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// BuildTouchUnitTestApps - builds the iOS NUnitLite (Touch.Unit) test app projects for the simulator,
+// and collects their .app bundles to run them in a simulator
+func (builder Model) BuildTouchUnitTestApps(configuration, platform string, prepareCallback PrepareCommandCallback, callback BuildCommandCallback) (TestProjectOutputMap, []string, error) {
+	if err := validateSolutionConfig(builder.solution, configuration, platform); err != nil {
+		return TestProjectOutputMap{}, nil, err
+	}
+
+	builder.SetIOSSimulatorBuild(true, builder.iosSimulatorArchitectures...)
+
+	testProjects, warnings := builder.buildableTestAppProjects(configuration, platform, constants.SDKIOS)
+	if len(testProjects) == 0 {
+		return TestProjectOutputMap{}, warnings, fmt.Errorf("No project to build found")
+	}
+
+	startTime := time.Now()
+
+	buildWarnings, err := builder.buildTestApps(configuration, platform, testProjects, prepareCallback, callback)
+	warnings = append(warnings, buildWarnings...)
+	if err != nil {
+		return TestProjectOutputMap{}, warnings, err
+	}
+
+	endTime := time.Now()
+	solutionConfig := utility.ToConfig(configuration, platform)
+	testProjectOutputMap := TestProjectOutputMap{}
+	walker := builder.newArtifactWalker()
+
+	for _, testProj := range testProjects {
+		projectConfig, ok := testProj.Configs[testProj.ConfigMap[solutionConfig]]
+		if !ok {
+			continue
+		}
+		projectConfig = builder.simulatorProjectConfig(testProj, projectConfig)
+
+		appPth, err := exportApp(builder.log(), walker, projectConfig.OutputDir, testProj.AssemblyName, startTime, endTime)
+		if err != nil {
+			return TestProjectOutputMap{}, warnings, err
+		} else if appPth == "" || !builder.isSessionArtifact(appPth) {
+			warnings = append(warnings, fmt.Sprintf("no test app found for project (%s) in (%s)", testProj.Name, projectConfig.OutputDir))
+			continue
+		}
+
+		testProjectOutputMap[testProj.Name] = TestProjectOutputModel{
+			TestFramwork: testProj.TestFramework,
+			Output: OutputModel{
+				Pth:        appPth,
+				OutputType: constants.OutputTypeSimulatorAPP,
+			},
+		}
+	}
+
+	return testProjectOutputMap, warnings, nil
+}
+
+// BuildAndroidTestApps - builds the Xamarin.Android NUnitLite test app projects,
+// and collects their apks to install them on an emulator or device
+func (builder Model) BuildAndroidTestApps(configuration, platform string, prepareCallback PrepareCommandCallback, callback BuildCommandCallback) (TestProjectOutputMap, []string, error) {
+	if err := validateSolutionConfig(builder.solution, configuration, platform); err != nil {
+		return TestProjectOutputMap{}, nil, err
+	}
+
+	testProjects, warnings := builder.buildableTestAppProjects(configuration, platform, constants.SDKAndroid)
+	if len(testProjects) == 0 {
+		return TestProjectOutputMap{}, warnings, fmt.Errorf("No project to build found")
+	}
+
+	startTime := time.Now()
+
+	buildWarnings, err := builder.buildTestApps(configuration, platform, testProjects, prepareCallback, callback)
+	warnings = append(warnings, buildWarnings...)
+	if err != nil {
+		return TestProjectOutputMap{}, warnings, err
+	}
+
+	endTime := time.Now()
+	solutionConfig := utility.ToConfig(configuration, platform)
+	testProjectOutputMap := TestProjectOutputMap{}
+	walker := builder.newArtifactWalker()
+
+	for _, testProj := range testProjects {
+		projectConfig, ok := testProj.Configs[testProj.ConfigMap[solutionConfig]]
+		if !ok {
+			continue
+		}
+
+		manifest, err := builder.variantAndroidManifest(projectConfig.ManifestPth)
+		if err != nil {
+			return TestProjectOutputMap{}, warnings, err
+		}
+
+		apkPth, err := exportApk(builder.log(), walker, projectConfig.OutputDir, manifest.Package, startTime, endTime)
+		if err != nil {
+			return TestProjectOutputMap{}, warnings, err
+		} else if apkPth == "" || !builder.isSessionArtifact(apkPth) {
+			warnings = append(warnings, fmt.Sprintf("no test apk found for project (%s) in (%s)", testProj.Name, projectConfig.OutputDir))
+			continue
+		}
+
+		testProjectOutputMap[testProj.Name] = TestProjectOutputModel{
+			TestFramwork: testProj.TestFramework,
+			Output: OutputModel{
+				Pth:        apkPth,
+				OutputType: constants.OutputTypeAPK,
+			},
+		}
+	}
+
+	return testProjectOutputMap, warnings, nil
+}
@@ -0,0 +1,140 @@
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+)
+
+// UITestAppModel - an app artifact tested by a Xamarin.UITest assembly
+type UITestAppModel struct {
+	ProjectName string
+	SDK         constants.SDK
+	Pth         string
+	OutputType  constants.OutputType // simulator .app or ipa for iOS and tvOS, apk for Android
+}
+
+// UITestArtifactModel - a Xamarin.UITest assembly and the apps it tests
+type UITestArtifactModel struct {
+	TestAssemblyPth string
+	DependencyDir   string // Directory of the test assembly and its dependencies, the build dir of the test cloud uploads
+	Apps            []UITestAppModel
+}
+
+// UITestArtifactMap ...
+type UITestArtifactMap map[string]UITestArtifactModel // Test Project Name - UITestArtifactModel
+
+// BuildAndCollectUITestArtifacts - builds the Xamarin.UITest projects and the app projects they refer to,
+// then pairs the test assemblies with the built app artifacts
+func (builder Model) BuildAndCollectUITestArtifacts(configuration, platform string, prepareCallback PrepareCommandCallback, callback BuildCommandCallback) (UITestArtifactMap, []string, error) {
+	startTime := time.Now()
+
+	warnings, err := builder.BuildAllUITestableProjects(configuration, platform, prepareCallback, callback)
+	if err != nil {
+		return UITestArtifactMap{}, warnings, err
+	}
+
+	endTime := time.Now()
+
+	projectOutputMap, err := builder.CollectProjectOutputs(configuration, platform, startTime, endTime)
+	if err != nil {
+		return UITestArtifactMap{}, warnings, err
+	}
+
+	testProjectOutputMap, warns, err := builder.CollectTestProjectOutputs(configuration, platform, startTime, endTime)
+	warnings = append(warnings, warns...)
+	if err != nil {
+		return UITestArtifactMap{}, warnings, err
+	}
+
+	artifactMap, warns := uiTestArtifacts(testProjectOutputMap, projectOutputMap)
+	warnings = append(warnings, warns...)
+	if len(artifactMap) == 0 {
+		return artifactMap, warnings, fmt.Errorf("no Xamarin.UITest assembly with app artifact found")
+	}
+
+	return artifactMap, warnings, nil
+}
+
+// uiTestArtifacts pairs the Xamarin.UITest assemblies with the app artifacts of their referred projects
+func uiTestArtifacts(testProjectOutputMap TestProjectOutputMap, projectOutputMap ProjectOutputMap) (UITestArtifactMap, []string) {
+	artifactMap := UITestArtifactMap{}
+	warnings := []string{}
+
+	testProjectNames := []string{}
+	for testProjectName, testProjectOutput := range testProjectOutputMap {
+		if testProjectOutput.TestFramwork == constants.TestFrameworkXamarinUITest {
+			testProjectNames = append(testProjectNames, testProjectName)
+		}
+	}
+	sort.Strings(testProjectNames)
+
+	for _, testProjectName := range testProjectNames {
+		testProjectOutput := testProjectOutputMap[testProjectName]
+
+		apps := []UITestAppModel{}
+		for _, projectName := range testProjectOutput.ReferredProjectNames {
+			projectOutput, ok := projectOutputMap[projectName]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("no outputs found for project (%s) referred by test project (%s)", projectName, testProjectName))
+				continue
+			}
+
+			app, ok := uiTestableApp(projectOutput)
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("no testable app found for project (%s) referred by test project (%s)", projectName, testProjectName))
+				continue
+			}
+
+			app.ProjectName = projectName
+			apps = append(apps, app)
+		}
+
+		if len(apps) == 0 {
+			warnings = append(warnings, fmt.Sprintf("no app artifact found for test project (%s)", testProjectName))
+			continue
+		}
+
+		artifactMap[testProjectName] = UITestArtifactModel{
+			TestAssemblyPth: testProjectOutput.Output.Pth,
+			DependencyDir:   testProjectOutput.DependencyDir,
+			Apps:            apps,
+		}
+	}
+
+	return artifactMap, warnings
+}
+
+// uiTestableApp selects the app artifact to test: the simulator .app, then the ipa of iOS and tvOS projects,
+// the universal apk, then the first per-ABI apk of Android projects
+func uiTestableApp(projectOutput ProjectOutputModel) (UITestAppModel, bool) {
+	var preferredTypes []constants.OutputType
+	switch projectOutput.ProjectType {
+	case constants.SDKIOS, constants.SDKTvOS:
+		preferredTypes = []constants.OutputType{constants.OutputTypeSimulatorAPP, constants.OutputTypeIPA}
+	case constants.SDKAndroid:
+		preferredTypes = []constants.OutputType{constants.OutputTypeAPK}
+	default:
+		return UITestAppModel{}, false
+	}
+
+	for _, outputType := range preferredTypes {
+		var selected *OutputModel
+		for i, output := range projectOutput.Outputs {
+			if output.OutputType != outputType {
+				continue
+			}
+			if selected == nil || (selected.ABI != "" && output.ABI == "") {
+				selected = &projectOutput.Outputs[i]
+			}
+		}
+
+		if selected != nil {
+			return UITestAppModel{SDK: projectOutput.ProjectType, Pth: selected.Pth, OutputType: selected.OutputType}, true
+		}
+	}
+
+	return UITestAppModel{}, false
+}
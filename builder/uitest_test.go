@@ -0,0 +1,96 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUITestArtifacts(t *testing.T) {
+	projectOutputMap := ProjectOutputMap{
+		"iOS": ProjectOutputModel{
+			ProjectType: constants.SDKIOS,
+			Outputs: []OutputModel{
+				{Pth: "/bin/iPhone/Release/iOS.ipa", OutputType: constants.OutputTypeIPA},
+				{Pth: "/bin/iPhone/Release/iOS.app.dSYM", OutputType: constants.OutputTypeDSYM},
+			},
+		},
+		"Droid": ProjectOutputModel{
+			ProjectType: constants.SDKAndroid,
+			Outputs: []OutputModel{
+				{Pth: "/bin/Release/com.bitrise.sample-arm64-v8a.apk", OutputType: constants.OutputTypeAPK, ABI: "arm64-v8a"},
+				{Pth: "/bin/Release/com.bitrise.sample.apk", OutputType: constants.OutputTypeAPK},
+			},
+		},
+		"Core": ProjectOutputModel{
+			ProjectType: constants.SDKUnknown,
+			Outputs: []OutputModel{
+				{Pth: "/bin/Release/Core.dll", OutputType: constants.OutputTypeDLL},
+			},
+		},
+	}
+
+	t.Log("it pairs the test assembly with the apps of the referred projects")
+	{
+		testProjectOutputMap := TestProjectOutputMap{
+			"UITests": TestProjectOutputModel{
+				TestFramwork:         constants.TestFrameworkXamarinUITest,
+				ReferredProjectNames: []string{"iOS", "Droid"},
+				Output:               OutputModel{Pth: "/UITests/bin/Release/UITests.dll", OutputType: constants.OutputTypeTestDLL},
+				DependencyDir:        "/UITests/bin/Release",
+			},
+			"UnitTests": TestProjectOutputModel{
+				TestFramwork:         constants.TestFrameworkNunitTest,
+				ReferredProjectNames: []string{"Core"},
+				Output:               OutputModel{Pth: "/UnitTests/bin/Release/UnitTests.dll", OutputType: constants.OutputTypeTestDLL},
+			},
+		}
+
+		artifactMap, warnings := uiTestArtifacts(testProjectOutputMap, projectOutputMap)
+		require.Equal(t, 0, len(warnings))
+		require.Equal(t, UITestArtifactMap{
+			"UITests": UITestArtifactModel{
+				TestAssemblyPth: "/UITests/bin/Release/UITests.dll",
+				DependencyDir:   "/UITests/bin/Release",
+				Apps: []UITestAppModel{
+					{ProjectName: "iOS", SDK: constants.SDKIOS, Pth: "/bin/iPhone/Release/iOS.ipa", OutputType: constants.OutputTypeIPA},
+					{ProjectName: "Droid", SDK: constants.SDKAndroid, Pth: "/bin/Release/com.bitrise.sample.apk", OutputType: constants.OutputTypeAPK},
+				},
+			},
+		}, artifactMap)
+	}
+
+	t.Log("it prefers the simulator app")
+	{
+		app, ok := uiTestableApp(ProjectOutputModel{
+			ProjectType: constants.SDKIOS,
+			Outputs: []OutputModel{
+				{Pth: "/bin/iPhone/Release/iOS.ipa", OutputType: constants.OutputTypeIPA},
+				{Pth: "/bin/iPhoneSimulator/Debug/iOS.app", OutputType: constants.OutputTypeSimulatorAPP},
+			},
+		})
+		require.True(t, ok)
+		require.Equal(t, "/bin/iPhoneSimulator/Debug/iOS.app", app.Pth)
+		require.Equal(t, constants.OutputTypeSimulatorAPP, app.OutputType)
+	}
+
+	t.Log("it warns about the referred projects without app artifact")
+	{
+		testProjectOutputMap := TestProjectOutputMap{
+			"UITests": TestProjectOutputModel{
+				TestFramwork:         constants.TestFrameworkXamarinUITest,
+				ReferredProjectNames: []string{"Core", "Missing"},
+				Output:               OutputModel{Pth: "/UITests/bin/Release/UITests.dll", OutputType: constants.OutputTypeTestDLL},
+			},
+		}
+
+		artifactMap, warnings := uiTestArtifacts(testProjectOutputMap, projectOutputMap)
+		require.Equal(t, 0, len(artifactMap))
+		require.Equal(t, []string{
+			"no testable app found for project (Core) referred by test project (UITests)",
+			"no outputs found for project (Missing) referred by test project (UITests)",
+			"no app artifact found for test project (UITests)",
+		}, warnings)
+	}
+}
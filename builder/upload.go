@@ -0,0 +1,96 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/altool"
+)
+
+// AppStoreUploadModel - how to upload the outputs to App Store Connect
+type AppStoreUploadModel struct {
+	Tool        altool.Tool // defaults to altool.ToolAltool
+	Credentials altool.CredentialsModel
+}
+
+// UploadModel - App Store Connect upload status of an ipa or pkg output
+type UploadModel struct {
+	Uploaded bool
+	Message  string // success message of the upload, or the reason of the failure
+	Errors   []altool.ErrorModel
+}
+
+// UploadToAppStoreConnect - uploads the collected ipa and pkg outputs to App Store Connect,
+// ipas re-exported for other than app-store distribution are skipped.
+// The status is reported in the outputs' Upload, the call fails if any of the uploads failed.
+func (builder Model) UploadToAppStoreConnect(outputMap ProjectOutputMap, upload AppStoreUploadModel) (ProjectOutputMap, error) {
+	if upload.Tool == "" {
+		upload.Tool = altool.ToolAltool
+	}
+	builder.AddSecret(upload.Credentials.Password)
+
+	failed := []string{}
+	for projectName, projectOutputs := range outputMap {
+		platformType, ok := uploadPlatformType(projectOutputs.ProjectType)
+		if !ok {
+			continue
+		}
+
+		for i, output := range projectOutputs.Outputs {
+			if output.OutputType != constants.OutputTypeIPA && output.OutputType != constants.OutputTypePKG {
+				continue
+			}
+			if output.DistributionType != "" && output.DistributionType != string(profiles.DistributionTypeAppStore) {
+				continue
+			}
+
+			result := builder.uploadToAppStoreConnect(output.Pth, platformType, upload)
+			if !result.Uploaded {
+				builder.log().Warnf("Upload of (%s) failed: %s", output.Pth, result.Message)
+				failed = append(failed, output.Pth)
+			}
+			projectOutputs.Outputs[i].Upload = &result
+		}
+		outputMap[projectName] = projectOutputs
+	}
+
+	if len(failed) > 0 {
+		return outputMap, fmt.Errorf("failed to upload to App Store Connect: %s", strings.Join(failed, ", "))
+	}
+	return outputMap, nil
+}
+
+func uploadPlatformType(sdk constants.SDK) (altool.PlatformType, bool) {
+	switch sdk {
+	case constants.SDKIOS:
+		return altool.PlatformTypeIOS, true
+	case constants.SDKTvOS:
+		return altool.PlatformTypeTvOS, true
+	case constants.SDKMacOS:
+		return altool.PlatformTypeMacOS, true
+	default:
+		return "", false
+	}
+}
+
+func (builder Model) uploadToAppStoreConnect(pth string, platformType altool.PlatformType, upload AppStoreUploadModel) UploadModel {
+	command, err := altool.New(upload.Tool, pth, platformType, upload.Credentials)
+	if err != nil {
+		return UploadModel{Message: err.Error()}
+	}
+
+	runErr := builder.runCommand(command)
+
+	result := command.Result()
+	status := UploadModel{
+		Uploaded: runErr == nil,
+		Message:  result.Message,
+		Errors:   result.Errors,
+	}
+	if runErr != nil {
+		status.Message = runErr.Error()
+	}
+	return status
+}
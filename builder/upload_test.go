@@ -0,0 +1,44 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/altool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadToAppStoreConnect(t *testing.T) {
+	t.Log("it skips the non app-store ipas and the non Apple projects")
+	{
+		outputMap := ProjectOutputMap{
+			"iOS": ProjectOutputModel{ProjectType: constants.SDKIOS, Outputs: []OutputModel{
+				{Pth: "/bin/iOS-ad-hoc.ipa", OutputType: constants.OutputTypeIPA, DistributionType: "ad-hoc"},
+				{Pth: "/bin/iOS.xcarchive", OutputType: constants.OutputTypeXCArchive},
+			}},
+			"Android": ProjectOutputModel{ProjectType: constants.SDKAndroid, Outputs: []OutputModel{
+				{Pth: "/bin/app.apk", OutputType: constants.OutputTypeAPK},
+			}},
+		}
+
+		outputMap, err := Model{}.UploadToAppStoreConnect(outputMap, AppStoreUploadModel{})
+		require.NoError(t, err)
+		require.Nil(t, outputMap["iOS"].Outputs[0].Upload)
+		require.Nil(t, outputMap["iOS"].Outputs[1].Upload)
+		require.Nil(t, outputMap["Android"].Outputs[0].Upload)
+	}
+
+	t.Log("it reports the invalid credentials")
+	{
+		outputMap := ProjectOutputMap{
+			"Mac": ProjectOutputModel{ProjectType: constants.SDKMacOS, Outputs: []OutputModel{
+				{Pth: "/bin/Mac.pkg", OutputType: constants.OutputTypePKG},
+			}},
+		}
+
+		outputMap, err := Model{}.UploadToAppStoreConnect(outputMap, AppStoreUploadModel{Credentials: altool.CredentialsModel{Username: "bot@bitrise.io"}})
+		require.EqualError(t, err, "failed to upload to App Store Connect: /bin/Mac.pkg")
+		require.Equal(t, false, outputMap["Mac"].Outputs[0].Upload.Uploaded)
+		require.Contains(t, outputMap["Mac"].Outputs[0].Upload.Message, "required")
+	}
+}
@@ -1,19 +1,19 @@
 package builder
 
 import (
-	"encoding/xml"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/bitrise-io/go-utils/fileutil"
-	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
 	"github.com/bitrise-tools/go-xamarin/analyzers/solution"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 	"github.com/bitrise-tools/go-xamarin/utility"
 )
 
@@ -22,6 +22,7 @@ type Export struct {
 	path      string
 	patterns  []string
 	outputDir string
+	walker    *artifactWalker
 }
 
 func validateSolutionPth(pth string) error {
@@ -32,7 +33,7 @@ func validateSolutionPth(pth string) error {
 	if exist, err := pathutil.IsPathExists(pth); err != nil {
 		return err
 	} else if !exist {
-		return fmt.Errorf("solution not exist at: %s", pth)
+		return fmt.Errorf("%w at: %s", ErrSolutionNotFound, pth)
 	}
 	return nil
 }
@@ -40,7 +41,7 @@ func validateSolutionPth(pth string) error {
 func validateSolutionConfig(solution solution.Model, configuration, platform string) error {
 	config := utility.ToConfig(configuration, platform)
 	if _, ok := solution.ConfigMap[config]; !ok {
-		return fmt.Errorf("invalid solution config, available: %v", solution.ConfigList())
+		return fmt.Errorf("%w, available: %v", ErrInvalidSolutionConfig, solution.ConfigList())
 	}
 	return nil
 }
@@ -90,51 +91,32 @@ func isArchitectureArchiveable(architectures ...string) bool {
 	return true
 }
 
-func isPlatformAnyCPU(platform string) bool {
-	return (platform == "Any CPU" || platform == "AnyCPU")
-}
-
-func androidPackageName(manifestPth string) (string, error) {
-	content, err := fileutil.ReadStringFromFile(manifestPth)
-	if err != nil {
-		return "", err
+// isSimulatorBuild - iOS and tvOS simulator builds use the iPhoneSimulator platform and intel architectures
+func isSimulatorBuild(platform string, architectures ...string) bool {
+	if strings.EqualFold(platform, "iPhoneSimulator") {
+		return true
 	}
-
-	return androidPackageNameFromManifestContent(content)
+	return len(architectures) > 0 && !isArchitectureArchiveable(architectures...)
 }
 
-func androidPackageNameFromManifestContent(manifestContent string) (string, error) {
-	// package is attribute of the rott xml element
-	manifestContent = "<a>" + manifestContent + "</a>"
-
-	type Manifest struct {
-		Package string `xml:"package,attr"`
-	}
-
-	type Result struct {
-		Manifest Manifest `xml:"manifest"`
-	}
-
-	var result Result
-	if err := xml.Unmarshal([]byte(manifestContent), &result); err != nil {
-		return "", err
-	}
+func isReleaseConfiguration(configuration string) bool {
+	return strings.Contains(strings.ToLower(configuration), "release")
+}
 
-	return result.Manifest.Package, nil
+func isPlatformAnyCPU(platform string) bool {
+	return (platform == "Any CPU" || platform == "AnyCPU")
 }
 
-func exportApk(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if apkToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s.*signed\.apk$`, assemblyName), fmt.Sprintf(`(?i)%s\.apk$`, assemblyName), `(?i)signed\.apk$`, `(?i)\.apk$`); err == nil && apkToExport.path != "" {
+func exportApk(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
+	if apkToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s.*signed\.apk$`, assemblyName), fmt.Sprintf(`(?i)%s\.apk$`, assemblyName), `(?i)signed\.apk$`, `(?i)\.apk$`); err == nil && apkToExport.path != "" {
 		return apkToExport.path, err
 	} else if latestPath, err := apkToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No apk generated during build")
-		log.Printf("Exporting latest generated apk: %s", latestPath)
+		logger.Warnf("No apk generated during build")
+		logger.Debugf("Exporting latest generated apk: %s", latestPath)
 		return latestPath, nil
 	}
 
-	log.Printf("")
-	log.Warnf("Switching to legacy exporter")
-	log.Printf("")
+	logger.Warnf("Switching to legacy exporter")
 
 	apks, err := filepath.Glob(filepath.Join(outputDir, "*.apk"))
 	if err != nil {
@@ -167,36 +149,96 @@ func exportApk(outputDir, assemblyName string, startTime, endTime time.Time) (st
 	}
 
 	if len(filteredApks) == 0 {
-		log.Errorf("Legacy exporter failed to find apk in (%s)", outputDir)
+		logger.Errorf("Legacy exporter failed to find apk in (%s)", outputDir)
 		return "", nil
 	}
 
 	return filteredApks[0], nil
 }
 
-func exportLatestIpa(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if ipaToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.ipa$`, assemblyName), `(?i)\.ipa$`); err == nil && ipaToExport.path != "" {
-		return ipaToExport.path, err
-	} else if latestPath, err := ipaToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No ipa generated during build")
-		log.Printf("Exporting latest generated ipa: %s", latestPath)
-		return latestPath, nil
+// exportAab - returns the app bundle generated by AndroidPackageFormat=aab, the signed one is preferred,
+// or an empty path if no aab generated during the build
+func exportAab(walker *artifactWalker, outputDir, packageName string, startTime, endTime time.Time) (string, error) {
+	aabToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s.*signed\.aab$`, regexp.QuoteMeta(packageName)), fmt.Sprintf(`(?i)%s\.aab$`, regexp.QuoteMeta(packageName)))
+	if err != nil {
+		return "", fmt.Errorf("failed to find aab, error: %s", err)
 	}
-	return "", nil
+	return aabToExport.path, nil
 }
 
-func exportLatestXCArchive(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if archiveToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s.*\.xcarchive$`, assemblyName), `(?i)\.xcarchive$`); err == nil && archiveToExport.path != "" {
-		return archiveToExport.path, err
-	} else if latestPath, err := archiveToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No xcarchive generated during build")
-		log.Printf("Exporting latest generated xcarchive: %s", latestPath)
-		return latestPath, nil
+// androidABIs - the ABIs of the per-ABI split apks, x86_64 precedes x86 for the pattern matching
+var androidABIs = []string{"arm64-v8a", "armeabi-v7a", "armeabi", "x86_64", "x86"}
+
+// exportApksPerABI exports the per-ABI split apks (com.company.app-arm64-v8a-Signed.apk) keyed by the ABI,
+// the signed apks and the apks generated during the build are preferred
+func exportApksPerABI(outputDir, packageName string, startTime, endTime time.Time) (map[string]string, error) {
+	pattern := filepath.Join(outputDir, "*.apk")
+	apks, err := filepath.Glob(pattern)
+	if err != nil {
+		return map[string]string{}, fmt.Errorf("failed to find apk with pattern (%s), error: %s", pattern, err)
+	}
+
+	abiPattern := regexp.MustCompile(fmt.Sprintf(`(?i)^%s-(?P<abi>%s)(?P<signed>-signed)?\.apk$`, regexp.QuoteMeta(packageName), strings.Join(androidABIs, "|")))
+
+	type abiApk struct {
+		pth       string
+		signed    bool
+		generated bool
 	}
-	return "", nil
+
+	selected := map[string]abiApk{}
+	for _, apk := range apks {
+		matches := abiPattern.FindStringSubmatch(filepath.Base(apk))
+		if len(matches) != 3 {
+			continue
+		}
+
+		candidate := abiApk{pth: apk, signed: matches[2] != ""}
+		if info, err := os.Stat(apk); err == nil {
+			candidate.generated = isInTimeInterval(info.ModTime(), startTime, endTime)
+		}
+
+		abi := strings.ToLower(matches[1])
+		current, ok := selected[abi]
+		if !ok ||
+			(candidate.generated && !current.generated) ||
+			(candidate.generated == current.generated && candidate.signed && !current.signed) {
+			selected[abi] = candidate
+		}
+	}
+
+	abiApks := map[string]string{}
+	for abi, apk := range selected {
+		abiApks[abi] = apk.pth
+	}
+	return abiApks, nil
 }
 
-func exportLatestXCArchiveFromXcodeArchives(assemblyName string, startTime, endTime time.Time) (string, error) {
+func exportLatestIpa(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time, strategy ArtifactSelectionStrategy) (string, error) {
+	return selectArtifact(logger, walker, outputDir, startTime, endTime, strategy, fmt.Sprintf(`(?i)%s\.ipa$`, assemblyName), `(?i)\.ipa$`)
+}
+
+// exportIpa exports the ipa from the configuration's IpaPackageDir, if set, otherwise from the OutputDir
+func exportIpa(logger tools.Logger, walker *artifactWalker, projectConfig project.ConfigurationPlatformModel, assemblyName string, startTime, endTime time.Time, strategy ArtifactSelectionStrategy) (string, error) {
+	ipaName := assemblyName
+	if projectConfig.IpaPackageName != "" {
+		ipaName = strings.TrimSuffix(projectConfig.IpaPackageName, filepath.Ext(projectConfig.IpaPackageName))
+	}
+
+	if projectConfig.IpaPackageDir != "" {
+		if ipaPth, err := exportLatestIpa(logger, walker, projectConfig.IpaPackageDir, ipaName, startTime, endTime, strategy); err != nil || ipaPth != "" {
+			return ipaPth, err
+		}
+	}
+
+	return exportLatestIpa(logger, walker, projectConfig.OutputDir, ipaName, startTime, endTime, strategy)
+}
+
+func exportLatestXCArchive(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time, strategy ArtifactSelectionStrategy) (string, error) {
+	return selectArtifact(logger, walker, outputDir, startTime, endTime, strategy, fmt.Sprintf(`(?i)%s.*\.xcarchive$`, assemblyName), `(?i)\.xcarchive$`)
+}
+
+func exportLatestXCArchiveFromXcodeArchives(logger tools.Logger, walker *artifactWalker, assemblyName string, startTime, endTime time.Time, strategy ArtifactSelectionStrategy) (string, error) {
 	userHomeDir := os.Getenv("HOME")
 	if userHomeDir == "" {
 		return "", fmt.Errorf("failed to get user home dir")
@@ -208,80 +250,47 @@ func exportLatestXCArchiveFromXcodeArchives(assemblyName string, startTime, endT
 		return "", fmt.Errorf("no default Xcode archive path found at: %s", xcodeArchivesDir)
 	}
 
-	return exportLatestXCArchive(xcodeArchivesDir, assemblyName, startTime, endTime)
+	return exportLatestXCArchive(logger, walker, xcodeArchivesDir, assemblyName, startTime, endTime, strategy)
 }
 
-func (export *Export) exportLatest() (string, error) {
-	var lastModTime time.Time
-	var latestPth string
-
-	for _, pattern := range export.patterns {
-		if latestPth != "" {
-			break
-		}
-		re := regexp.MustCompile(pattern)
-		if err := filepath.Walk(export.outputDir, func(path string, info os.FileInfo, err error) error {
-			if re.FindString(path) != "" {
-				if latestPth == "" {
-					lastModTime = info.ModTime()
-				} else if lastModTime.After(info.ModTime()) {
-					return nil
-				}
-				lastModTime = info.ModTime()
-				latestPth = path
-			}
-			return nil
-		}); err != nil {
-			return "", err
-		}
+func (builder Model) exportXCArchive(walker *artifactWalker, assemblyName string, startTime, endTime time.Time) (string, error) {
+	if builder.archiveBasePath != "" && !builder.forceMDTool {
+		return exportLatestXCArchive(builder.log(), walker, builder.archiveBasePath, assemblyName, startTime, endTime, builder.artifactSelection)
 	}
-	return latestPth, nil
+	return exportLatestXCArchiveFromXcodeArchives(builder.log(), walker, assemblyName, startTime, endTime, builder.artifactSelection)
 }
 
-func exportLatestModifiedWithinTimeInterval(outputDir string, startTime, endTime time.Time, patterns ...string) (*Export, error) {
-	var lastModTime time.Time
-	var latestPth string
-
-	for _, pattern := range patterns {
+func (export *Export) exportLatest() (string, error) {
+	return latestEntry(export.walker.find(export.outputDir, nil, export.patterns...)), nil
+}
 
-		if latestPth != "" {
-			break
-		}
-		re := regexp.MustCompile(pattern)
-		if err := filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
-			if re.FindString(path) != "" && isInTimeInterval(info.ModTime(), startTime, endTime) {
-				if latestPth == "" {
-					lastModTime = info.ModTime()
-				} else if lastModTime.After(info.ModTime()) {
-					return nil
-				}
-				lastModTime = info.ModTime()
-				latestPth = path
-			}
-			return nil
-		}); err != nil {
-			return nil, err
+func exportLatestModifiedWithinTimeInterval(walker *artifactWalker, outputDir string, startTime, endTime time.Time, patterns ...string) (*Export, error) {
+	if len(patterns) > 0 {
+		if captured := walker.capturedArtifacts(outputDir, startTime, endTime, patterns[0]); len(captured) > 0 {
+			return &Export{path: latestEntry(captured), patterns: patterns, outputDir: outputDir, walker: walker}, nil
 		}
 	}
-	return &Export{path: latestPth, patterns: patterns, outputDir: outputDir}, nil
+
+	latestPth := latestEntry(walker.find(outputDir, func(entry artifactEntryModel) bool {
+		return isInTimeInterval(entry.modTime, startTime, endTime)
+	}, patterns...))
+	return &Export{path: latestPth, patterns: patterns, outputDir: outputDir, walker: walker}, nil
 }
 
 func isInTimeInterval(modTime, startTime, endTime time.Time) bool {
 	return (modTime.After(startTime) || modTime.Equal(startTime)) && (modTime.Before(endTime) || modTime.Equal(endTime))
 }
 
-func exportAppDSYM(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if appDSYMToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.app\.dSYM$`, assemblyName), `(?i)\.app\.dSYM$`); err == nil && appDSYMToExport.path != "" {
+func exportAppDSYM(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
+	if appDSYMToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.app\.dSYM$`, assemblyName), `(?i)\.app\.dSYM$`); err == nil && appDSYMToExport.path != "" {
 		return appDSYMToExport.path, err
 	} else if latestPath, err := appDSYMToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No app.dSYM generated during build")
-		log.Printf("Exporting latest generated app.dSYM: %s", latestPath)
+		logger.Warnf("No app.dSYM generated during build")
+		logger.Debugf("Exporting latest generated app.dSYM: %s", latestPath)
 		return latestPath, nil
 	}
 
-	log.Printf("")
-	log.Warnf("Switching to legacy exporter")
-	log.Printf("")
+	logger.Warnf("Switching to legacy exporter")
 
 	pattern := filepath.Join(outputDir, "*.app.dSYM")
 	dSYMs, err := filepath.Glob(pattern)
@@ -307,7 +316,7 @@ func exportAppDSYM(outputDir, assemblyName string, startTime, endTime time.Time)
 	}
 
 	if len(filteredDsyms) == 0 {
-		log.Errorf("Legacy exporter failed to find app.dSYM in (%s)", outputDir)
+		logger.Errorf("Legacy exporter failed to find app.dSYM in (%s)", outputDir)
 		return "", nil
 	}
 
@@ -324,18 +333,139 @@ func exportFrameworkDSYMs(outputDir string) ([]string, error) {
 	return dSYMs, nil
 }
 
-func exportPKG(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if pkgToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.pkg$`, assemblyName), `(?i)\.pkg$`); err == nil && pkgToExport.path != "" {
+// exportMSYMs exports the .mSYM symbol archives of the android package,
+// the archives generated during the build are preferred
+func exportMSYMs(logger tools.Logger, outputDir, packageName string, startTime, endTime time.Time) ([]string, error) {
+	// Droid/bin/Release/com.company.app.apk.mSYM
+	pattern := filepath.Join(outputDir, "*.mSYM")
+	mSYMs, err := filepath.Glob(pattern)
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to find msym with pattern (%s), error: %s", pattern, err)
+	}
+
+	packageMSYMs := []string{}
+	for _, mSYM := range mSYMs {
+		if strings.HasPrefix(strings.ToLower(filepath.Base(mSYM)), strings.ToLower(packageName)) {
+			packageMSYMs = append(packageMSYMs, mSYM)
+		}
+	}
+	if len(packageMSYMs) == 0 {
+		packageMSYMs = mSYMs
+	}
+
+	generatedMSYMs := []string{}
+	for _, mSYM := range packageMSYMs {
+		if info, err := os.Stat(mSYM); err == nil && isInTimeInterval(info.ModTime(), startTime, endTime) {
+			generatedMSYMs = append(generatedMSYMs, mSYM)
+		}
+	}
+	if len(generatedMSYMs) == 0 && len(packageMSYMs) > 0 {
+		logger.Warnf("No msym generated during build")
+		logger.Debugf("Exporting previously generated msyms: %s", strings.Join(packageMSYMs, ", "))
+		return packageMSYMs, nil
+	}
+
+	return generatedMSYMs, nil
+}
+
+// exportSymbols zips the debug symbol files (.pdb and .mdb) of the assemblies in the output directory
+// into <assembly name>.symbols.zip, the symbols generated during the build are preferred
+func exportSymbols(logger tools.Logger, outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
+	symbols := []string{}
+	for _, ext := range []string{"*.pdb", "*.mdb"} {
+		pattern := filepath.Join(outputDir, ext)
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", fmt.Errorf("failed to find symbols with pattern (%s), error: %s", pattern, err)
+		}
+		symbols = append(symbols, matches...)
+	}
+	if len(symbols) == 0 {
+		return "", nil
+	}
+	sort.Strings(symbols)
+
+	generatedSymbols := []string{}
+	for _, symbol := range symbols {
+		if info, err := os.Stat(symbol); err == nil && isInTimeInterval(info.ModTime(), startTime, endTime) {
+			generatedSymbols = append(generatedSymbols, symbol)
+		}
+	}
+	if len(generatedSymbols) == 0 {
+		logger.Warnf("No symbols generated during build")
+		logger.Debugf("Exporting previously generated symbols: %s", strings.Join(symbols, ", "))
+		generatedSymbols = symbols
+	}
+
+	zipPth := filepath.Join(outputDir, assemblyName+".symbols.zip")
+	if err := zipFiles(logger, generatedSymbols, zipPth); err != nil {
+		return "", fmt.Errorf("failed to zip symbols, error: %s", err)
+	}
+	return zipPth, nil
+}
+
+// nuPkgDirs returns the directories, where the NuGet packages of the project are generated:
+// the PackageOutputPath, if set, otherwise the OutputDir, its parent for the target framework specific OutputDirs
+// and the project directory for nuget pack
+func nuPkgDirs(proj project.Model, projectConfig project.ConfigurationPlatformModel) []string {
+	if projectConfig.PackageOutputDir != "" {
+		return []string{projectConfig.PackageOutputDir}
+	}
+	return []string{projectConfig.OutputDir, filepath.Dir(projectConfig.OutputDir), filepath.Dir(proj.Pth)}
+}
+
+// exportNuPkgs exports the NuGet packages (App.Bindings.1.0.0.nupkg) of the given package id,
+// the packages generated during the build are preferred
+func exportNuPkgs(logger tools.Logger, dirs []string, packageID string, startTime, endTime time.Time) ([]string, error) {
+	re := regexp.MustCompile(fmt.Sprintf(`(?i)^%s\.\d.*\.nupkg$`, regexp.QuoteMeta(packageID)))
+
+	nupkgs := []string{}
+	visited := map[string]bool{}
+	for _, dir := range dirs {
+		if visited[dir] {
+			continue
+		}
+		visited[dir] = true
+
+		pattern := filepath.Join(dir, "*.nupkg")
+		pths, err := filepath.Glob(pattern)
+		if err != nil {
+			return []string{}, fmt.Errorf("failed to find nupkg with pattern (%s), error: %s", pattern, err)
+		}
+
+		for _, pth := range pths {
+			name := filepath.Base(pth)
+			if re.MatchString(name) && !strings.HasSuffix(strings.ToLower(name), ".symbols.nupkg") {
+				nupkgs = append(nupkgs, pth)
+			}
+		}
+	}
+
+	generatedNupkgs := []string{}
+	for _, nupkg := range nupkgs {
+		if info, err := os.Stat(nupkg); err == nil && isInTimeInterval(info.ModTime(), startTime, endTime) {
+			generatedNupkgs = append(generatedNupkgs, nupkg)
+		}
+	}
+	if len(generatedNupkgs) == 0 && len(nupkgs) > 0 {
+		logger.Warnf("No nupkg generated during build")
+		logger.Debugf("Exporting previously generated nupkgs: %s", strings.Join(nupkgs, ", "))
+		return nupkgs, nil
+	}
+
+	return generatedNupkgs, nil
+}
+
+func exportPKG(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
+	if pkgToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.pkg$`, assemblyName), `(?i)\.pkg$`); err == nil && pkgToExport.path != "" {
 		return pkgToExport.path, err
 	} else if latestPath, err := pkgToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No pkg generated during build")
-		log.Printf("Exporting latest generated pkg: %s", latestPath)
+		logger.Warnf("No pkg generated during build")
+		logger.Debugf("Exporting latest generated pkg: %s", latestPath)
 		return latestPath, nil
 	}
 
-	log.Printf("")
-	log.Warnf("Switching to legacy exporter")
-	log.Printf("")
+	logger.Warnf("Switching to legacy exporter")
 
 	pattern := filepath.Join(outputDir, "*.pkg")
 	pkgs, err := filepath.Glob(pattern)
@@ -361,25 +491,23 @@ func exportPKG(outputDir, assemblyName string, startTime, endTime time.Time) (st
 	}
 
 	if len(filteredPKGs) == 0 {
-		log.Errorf("Legacy exporter failed to find pkg in (%s)", outputDir)
+		logger.Errorf("Legacy exporter failed to find pkg in (%s)", outputDir)
 		return "", nil
 	}
 
 	return filteredPKGs[0], nil
 }
 
-func exportApp(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if appToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.app$`, assemblyName), `(?i)\.app$`); err == nil && appToExport.path != "" {
+func exportApp(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
+	if appToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.app$`, assemblyName), `(?i)\.app$`); err == nil && appToExport.path != "" {
 		return appToExport.path, err
 	} else if latestPath, err := appToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No app generated during build")
-		log.Printf("Exporting latest generated app: %s", latestPath)
+		logger.Warnf("No app generated during build")
+		logger.Debugf("Exporting latest generated app: %s", latestPath)
 		return latestPath, nil
 	}
 
-	log.Printf("")
-	log.Warnf("Switching to legacy exporter")
-	log.Printf("")
+	logger.Warnf("Switching to legacy exporter")
 
 	pattern := filepath.Join(outputDir, "*.app")
 	apps, err := filepath.Glob(pattern)
@@ -405,25 +533,23 @@ func exportApp(outputDir, assemblyName string, startTime, endTime time.Time) (st
 	}
 
 	if len(filteredAPPs) == 0 {
-		log.Errorf("Legacy exporter failed to find app in (%s)", outputDir)
+		logger.Errorf("Legacy exporter failed to find app in (%s)", outputDir)
 		return "", nil
 	}
 
 	return filteredAPPs[0], nil
 }
 
-func exportDLL(outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
-	if dllToExport, err := exportLatestModifiedWithinTimeInterval(outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.dll$`, assemblyName), `(?i)\.dll$`); err == nil && dllToExport.path != "" {
+func exportDLL(logger tools.Logger, walker *artifactWalker, outputDir, assemblyName string, startTime, endTime time.Time) (string, error) {
+	if dllToExport, err := exportLatestModifiedWithinTimeInterval(walker, outputDir, startTime, endTime, fmt.Sprintf(`(?i)%s\.dll$`, assemblyName), `(?i)\.dll$`); err == nil && dllToExport.path != "" {
 		return dllToExport.path, err
 	} else if latestPath, err := dllToExport.exportLatest(); err == nil && latestPath != "" {
-		log.Warnf("No dll generated during build")
-		log.Printf("Exporting latest generated dll: %s", latestPath)
+		logger.Warnf("No dll generated during build")
+		logger.Debugf("Exporting latest generated dll: %s", latestPath)
 		return latestPath, nil
 	}
 
-	log.Printf("")
-	log.Warnf("Switching to legacy exporter")
-	log.Printf("")
+	logger.Warnf("Switching to legacy exporter")
 
 	pattern := filepath.Join(outputDir, "*.dll")
 	dlls, err := filepath.Glob(pattern)
@@ -449,7 +575,7 @@ func exportDLL(outputDir, assemblyName string, startTime, endTime time.Time) (st
 	}
 
 	if len(filteredDLLs) == 0 {
-		log.Errorf("Legacy exporter failed to find DLL in (%s)", outputDir)
+		logger.Errorf("Legacy exporter failed to find DLL in (%s)", outputDir)
 		return "", nil
 	}
 
@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"archive/zip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,8 +9,10 @@ import (
 
 	"github.com/bitrise-io/go-utils/fileutil"
 	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
 	"github.com/bitrise-tools/go-xamarin/analyzers/solution"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 	"github.com/bitrise-tools/go-xamarin/utility"
 	"github.com/stretchr/testify/require"
 )
@@ -130,6 +133,25 @@ func TestIsArchitectureArchiveablet(t *testing.T) {
 	}
 }
 
+func TestIsSimulatorBuild(t *testing.T) {
+	t.Log("iPhoneSimulator platform is a simulator build")
+	{
+		require.Equal(t, true, isSimulatorBuild("iPhoneSimulator"))
+		require.Equal(t, true, isSimulatorBuild("iphonesimulator", "x86_64"))
+	}
+
+	t.Log("intel architectures are simulator builds")
+	{
+		require.Equal(t, true, isSimulatorBuild("", "i386", "x86_64"))
+	}
+
+	t.Log("iPhone platform is a device build")
+	{
+		require.Equal(t, false, isSimulatorBuild("iPhone"))
+		require.Equal(t, false, isSimulatorBuild("iPhone", "ARM64"))
+	}
+}
+
 func TestIsPlatformAnyCPU(t *testing.T) {
 	t.Log("true for Any CPU")
 	{
@@ -147,15 +169,6 @@ func TestIsPlatformAnyCPU(t *testing.T) {
 	}
 }
 
-func TestAndroidPackageNameFromManifestContent(t *testing.T) {
-	t.Log("it finds package name in manifest")
-	{
-		packageName, err := androidPackageNameFromManifestContent(manifestFileContent)
-		require.NoError(t, err)
-		require.Equal(t, "hu.bitrise.test", packageName)
-	}
-}
-
 func createTestFile(t *testing.T, tmpDir, relPth string) {
 	pth := filepath.Join(tmpDir, relPth)
 	dirPth := filepath.Dir(pth)
@@ -169,7 +182,7 @@ func TestExportApk(t *testing.T) {
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportApk(tmpDir, "com.bitrise.xamarin.sampleapp", time.Now(), time.Now())
+		output, err := exportApk(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "com.bitrise.xamarin.sampleapp", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -196,11 +209,11 @@ func TestExportApk(t *testing.T) {
 
 		createTestFile(t, tmpDir, "com.bitrise.xamarin.sampleapp2.apk")
 
-		output, err := exportApk(tmpDir, "com.bitrise.xamarin.sampleapp1", startTime, endTime)
+		output, err := exportApk(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "com.bitrise.xamarin.sampleapp1", startTime, endTime)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "com.bitrise.xamarin.sampleapp1.apk"), output)
 
-		output, err = exportApk(tmpDir, "com.bitrise.xamarin.sampleapp2", startTime, endTime)
+		output, err = exportApk(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "com.bitrise.xamarin.sampleapp2", startTime, endTime)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "com.bitrise.xamarin.sampleapp2.apk"), output)
 	}
@@ -220,7 +233,7 @@ func TestExportApk(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportApk(tmpDir, "com.bitrise.xamarin.sampleapp", time.Now(), time.Now())
+		output, err := exportApk(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "com.bitrise.xamarin.sampleapp", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "com.bitrise.xamarin.sampleapp.apk"), output)
 	}
@@ -240,7 +253,7 @@ func TestExportApk(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportApk(tmpDir, "", time.Now(), time.Now())
+		output, err := exportApk(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "com.bitrise.xamarin.sampleapp.apk"), output)
 	}
@@ -260,7 +273,7 @@ func TestExportApk(t *testing.T) {
 			time.Sleep(1 * time.Second)
 		}
 
-		output, err := exportApk(tmpDir, "", time.Now(), time.Now())
+		output, err := exportApk(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "com.bitrise.xamarin.sampleapp-Signed.apk"), output)
 	}
@@ -272,7 +285,7 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportLatestXCArchive(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -291,7 +304,7 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestXCArchive(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "2016-07-10/XamarinSampleApp.iOS 10-07-16 3.41 AM.xcarchive"), output)
 	}
@@ -310,7 +323,7 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestXCArchive(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "2016-07-10/XamarinSampleApp.iOS 10-07-16 4.41 PM.xcarchive"), output)
 	}
@@ -331,7 +344,7 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestXCArchive(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "2016-07-10/XamarinSampleApp.iOS 10-07-16 4.41 PM.xcarchive"), output)
 	}
@@ -352,7 +365,7 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 			time.Sleep(1 * time.Second)
 		}
 
-		output, err := exportLatestXCArchive(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "2016-07-10/XamarinSampleApp.iOS 10-07-16 4.41 PM 2.xcarchive"), output)
 	}
@@ -373,7 +386,7 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestXCArchive(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "2016-07-10/XamarinSampleApp.iOS 10-07-16 4.41 PM.xcarchive"), output)
 	}
@@ -395,19 +408,61 @@ func TestExportLatestXCArchiveFromXcodeArchives(t *testing.T) {
 			time.Sleep(1 * time.Second)
 		}
 
-		output, err := exportLatestXCArchive(tmpDir, "", time.Now(), time.Now())
+		output, err := exportLatestXCArchive(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "2016-07-10/a 10-07-16 3.45 PM.xcarchive"), output)
 	}
 }
 
+func TestExportIpa(t *testing.T) {
+	t.Log("it exports the ipa from the IpaPackageDir")
+	{
+		outputDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+		ipaPackageDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		createTestFile(t, outputDir, "Multiplatform.iOS 2016-09-06 11-45-23/Multiplatform.iOS.ipa")
+		createTestFile(t, ipaPackageDir, "Multiplatform-1.0.ipa")
+
+		config := project.ConfigurationPlatformModel{
+			OutputDir:      outputDir,
+			IpaPackageDir:  ipaPackageDir,
+			IpaPackageName: "Multiplatform-1.0.ipa",
+		}
+
+		output, err := exportIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), config, "Multiplatform.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(ipaPackageDir, "Multiplatform-1.0.ipa"), output)
+	}
+
+	t.Log("it falls back to the OutputDir")
+	{
+		outputDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+		ipaPackageDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		createTestFile(t, outputDir, "Multiplatform.iOS 2016-09-06 11-45-23/Multiplatform.iOS.ipa")
+
+		config := project.ConfigurationPlatformModel{
+			OutputDir:     outputDir,
+			IpaPackageDir: ipaPackageDir,
+		}
+
+		output, err := exportIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), config, "Multiplatform.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(outputDir, "Multiplatform.iOS 2016-09-06 11-45-23/Multiplatform.iOS.ipa"), output)
+	}
+}
+
 func TestExportLatestIpa(t *testing.T) {
 	t.Log("it retruns empty path if no ipa found")
 	{
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportLatestIpa(tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "XamarinSampleApp.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -427,7 +482,7 @@ func TestExportLatestIpa(t *testing.T) {
 			time.Sleep(1 * time.Second)
 		}
 
-		output, err := exportLatestIpa(tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS 2016-09-06 11-45-23 2/Multiplatform.iOS.ipa"), output)
 	}
@@ -448,7 +503,7 @@ func TestExportLatestIpa(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestIpa(tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS 2016-10-06 11-45-23/Multiplatform.iOS.ipa"), output)
 	}
@@ -467,7 +522,7 @@ func TestExportLatestIpa(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestIpa(tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS 2016-10-06 11-45-23 2/Multiplatform.iOS.ipa"), output)
 	}
@@ -490,7 +545,7 @@ func TestExportLatestIpa(t *testing.T) {
 		time.Sleep(1 * time.Second)
 		createTestFile(t, tmpDir, "a 2016-10-06 11-45-25/Multiplatform.iOS.ipa")
 
-		output, err := exportLatestIpa(tmpDir, "", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "a 2016-10-06 11-45-25/Multiplatform.iOS.ipa"), output)
 	}
@@ -509,7 +564,7 @@ func TestExportLatestIpa(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestIpa(tmpDir, "", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "a 2017-01-02 11-45-25/Multiplatform.iOS.ipa"), output)
 	}
@@ -527,7 +582,7 @@ func TestExportLatestIpa(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportLatestIpa(tmpDir, "", time.Now(), time.Now())
+		output, err := exportLatestIpa(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now(), ArtifactSelectionNewest)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS.ipa"), output)
 	}
@@ -539,7 +594,7 @@ func TestExportAppDsym(t *testing.T) {
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportAppDSYM(tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
+		output, err := exportAppDSYM(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -558,7 +613,7 @@ func TestExportAppDsym(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportAppDSYM(tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
+		output, err := exportAppDSYM(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS.app.dSYM"), output)
 	}
@@ -580,7 +635,7 @@ func TestExportAppDsym(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportAppDSYM(tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
+		output, err := exportAppDSYM(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.iOS", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS.app.dSYM"), output)
 	}
@@ -601,7 +656,7 @@ func TestExportAppDsym(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportAppDSYM(tmpDir, "", time.Now(), time.Now())
+		output, err := exportAppDSYM(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.iOS.app.dSYM"), output)
 	}
@@ -647,7 +702,7 @@ func TestExportPKG(t *testing.T) {
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportPKG(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportPKG(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -666,7 +721,7 @@ func TestExportPKG(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportPKG(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportPKG(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac-1.0.pkg"), output)
 	}
@@ -686,7 +741,7 @@ func TestExportPKG(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportPKG(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportPKG(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac-1.0.pkg"), output)
 	}
@@ -706,7 +761,7 @@ func TestExportPKG(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportPKG(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportPKG(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac-1.0.pkg"), output)
 	}
@@ -718,7 +773,7 @@ func TestExportApp(t *testing.T) {
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportApp(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportApp(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -737,7 +792,7 @@ func TestExportApp(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportApp(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportApp(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac.app"), output)
 	}
@@ -757,7 +812,7 @@ func TestExportApp(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportApp(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportApp(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac.app"), output)
 	}
@@ -777,7 +832,7 @@ func TestExportApp(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportApp(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportApp(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac.app"), output)
 	}
@@ -789,7 +844,7 @@ func TestExportDLL(t *testing.T) {
 		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
 		require.NoError(t, err)
 
-		output, err := exportDLL(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportDLL(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, "", output)
 	}
@@ -808,7 +863,7 @@ func TestExportDLL(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportDLL(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportDLL(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac.dll"), output)
 	}
@@ -828,7 +883,7 @@ func TestExportDLL(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportDLL(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportDLL(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac.dll"), output)
 	}
@@ -848,8 +903,168 @@ func TestExportDLL(t *testing.T) {
 			createTestFile(t, tmpDir, archive)
 		}
 
-		output, err := exportDLL(tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
+		output, err := exportDLL(tools.NewDefaultLogger(), newArtifactWalker(DefaultArtifactSearch()), tmpDir, "Multiplatform.Mac", time.Now(), time.Now())
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(tmpDir, "Multiplatform.Mac.dll"), output)
 	}
 }
+
+func TestExportMSYMs(t *testing.T) {
+	t.Log("it returns empty list if no msym found")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		output, err := exportMSYMs(tools.NewDefaultLogger(), tmpDir, "com.bitrise.app", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.Equal(t, 0, len(output))
+	}
+
+	t.Log("it finds the package's msym")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		startTime := time.Now().Add(-time.Minute)
+
+		createTestFile(t, tmpDir, "com.bitrise.app.apk.mSYM/manifest.xml")
+		createTestFile(t, tmpDir, "com.bitrise.other.apk.mSYM/manifest.xml")
+		createTestFile(t, tmpDir, "com.bitrise.app-Signed.apk")
+
+		output, err := exportMSYMs(tools.NewDefaultLogger(), tmpDir, "com.bitrise.app", startTime, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(tmpDir, "com.bitrise.app.apk.mSYM")}, output)
+	}
+
+	t.Log("it falls back to the previously generated msyms")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		createTestFile(t, tmpDir, "com.bitrise.app.apk.mSYM/manifest.xml")
+
+		output, err := exportMSYMs(tools.NewDefaultLogger(), tmpDir, "com.bitrise.app", time.Now().Add(time.Hour), time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(tmpDir, "com.bitrise.app.apk.mSYM")}, output)
+	}
+}
+
+func TestExportSymbols(t *testing.T) {
+	t.Log("it returns empty path if no symbols found")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		createTestFile(t, tmpDir, "App.dll")
+
+		output, err := exportSymbols(tools.NewDefaultLogger(), tmpDir, "App", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.Equal(t, "", output)
+	}
+
+	t.Log("it zips the pdb and mdb files")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		startTime := time.Now().Add(-time.Minute)
+
+		createTestFile(t, tmpDir, "App.exe")
+		createTestFile(t, tmpDir, "App.pdb")
+		createTestFile(t, tmpDir, "App.Core.dll.mdb")
+		createTestFile(t, tmpDir, "Old.pdb")
+
+		oldTime := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "Old.pdb"), oldTime, oldTime))
+
+		output, err := exportSymbols(tools.NewDefaultLogger(), tmpDir, "App", startTime, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "App.symbols.zip"), output)
+
+		reader, err := zip.OpenReader(output)
+		require.NoError(t, err)
+		names := []string{}
+		for _, file := range reader.File {
+			names = append(names, file.Name)
+		}
+		require.NoError(t, reader.Close())
+		require.Equal(t, []string{"App.Core.dll.mdb", "App.pdb"}, names)
+	}
+}
+
+func TestExportApksPerABI(t *testing.T) {
+	t.Log("it returns empty map if no split apk found")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		createTestFile(t, tmpDir, "com.bitrise.app-Signed.apk")
+
+		output, err := exportApksPerABI(tmpDir, "com.bitrise.app", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.Equal(t, 0, len(output))
+	}
+
+	t.Log("it finds the signed apk per ABI")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		apks := []string{
+			"com.bitrise.app-arm64-v8a.apk",
+			"com.bitrise.app-arm64-v8a-Signed.apk",
+			"com.bitrise.app-armeabi-v7a-Signed.apk",
+			"com.bitrise.app-x86_64-Signed.apk",
+			"com.bitrise.app-x86.apk",
+			"com.bitrise.other-x86-Signed.apk",
+		}
+		for _, apk := range apks {
+			createTestFile(t, tmpDir, apk)
+		}
+
+		output, err := exportApksPerABI(tmpDir, "com.bitrise.app", time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"arm64-v8a":   filepath.Join(tmpDir, "com.bitrise.app-arm64-v8a-Signed.apk"),
+			"armeabi-v7a": filepath.Join(tmpDir, "com.bitrise.app-armeabi-v7a-Signed.apk"),
+			"x86_64":      filepath.Join(tmpDir, "com.bitrise.app-x86_64-Signed.apk"),
+			"x86":         filepath.Join(tmpDir, "com.bitrise.app-x86.apk"),
+		}, output)
+	}
+}
+
+func TestExportNuPkgs(t *testing.T) {
+	t.Log("it returns empty list if no nupkg found")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		output, err := exportNuPkgs(tools.NewDefaultLogger(), []string{tmpDir}, "App.Bindings", time.Now(), time.Now())
+		require.NoError(t, err)
+		require.Equal(t, 0, len(output))
+	}
+
+	t.Log("it finds the package's nupkgs")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+		require.NoError(t, err)
+
+		nupkgs := []string{
+			"bin/Release/App.Bindings.1.2.0.nupkg",
+			"bin/Release/App.Bindings.1.2.0.symbols.nupkg",
+			"bin/Release/App.Bindings.Extra.1.2.0.nupkg",
+			"App.Bindings.1.2.0-beta.nupkg",
+		}
+		for _, nupkg := range nupkgs {
+			createTestFile(t, tmpDir, nupkg)
+		}
+
+		dirs := []string{filepath.Join(tmpDir, "bin", "Release"), filepath.Join(tmpDir, "bin"), tmpDir, tmpDir}
+		output, err := exportNuPkgs(tools.NewDefaultLogger(), dirs, "App.Bindings", time.Now().Add(-time.Minute), time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			filepath.Join(tmpDir, "bin", "Release", "App.Bindings.1.2.0.nupkg"),
+			filepath.Join(tmpDir, "App.Bindings.1.2.0-beta.nupkg"),
+		}, output)
+	}
+}
@@ -0,0 +1,313 @@
+package builder
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/plist"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// profileExpirationWarningPeriod - a warning is reported, if the embedded profile expires within this period
+const profileExpirationWarningPeriod = 7 * 24 * time.Hour
+
+var ipaAppBundlePattern = regexp.MustCompile(`^Payload/[^/]+\.app/$`)
+
+// VerificationSeverity ...
+type VerificationSeverity string
+
+const (
+	// VerificationSeverityError - the artifact will be rejected, like an expired profile
+	VerificationSeverityError VerificationSeverity = "error"
+	// VerificationSeverityWarning - the artifact is usable, but likely not as intended
+	VerificationSeverityWarning VerificationSeverity = "warning"
+)
+
+// Verification checks
+const (
+	VerificationCheckProfileMissing    = "profile-missing"
+	VerificationCheckProfileExpired    = "profile-expired"
+	VerificationCheckProfileExpiring   = "profile-expiring"
+	VerificationCheckBundleIDMismatch  = "bundle-id-mismatch"
+	VerificationCheckMinimumOSMissing  = "minimum-os-missing"
+	VerificationCheckMinimumOSMismatch = "minimum-os-mismatch"
+)
+
+// VerificationFindingModel ...
+type VerificationFindingModel struct {
+	Check    string
+	Severity VerificationSeverity
+	Message  string
+}
+
+// IPAVerificationModel - the verified properties of an ipa and the problems found
+type IPAVerificationModel struct {
+	Pth string
+
+	BundleIdentifier string
+	MinimumOSVersion string
+
+	ProfileName                  string
+	ProfileApplicationIdentifier string // application-identifier entitlement, like: TEAMID.com.bitrise.app
+	ProfileExpirationDate        time.Time
+
+	Findings []VerificationFindingModel
+}
+
+// HasErrors ...
+func (verification IPAVerificationModel) HasErrors() bool {
+	for _, finding := range verification.Findings {
+		if finding.Severity == VerificationSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (verification *IPAVerificationModel) addFinding(check string, severity VerificationSeverity, format string, v ...interface{}) {
+	verification.Findings = append(verification.Findings, VerificationFindingModel{
+		Check:    check,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, v...),
+	})
+}
+
+// VerifyIPA - checks the embedded provisioning profile's expiry and application identifier against the bundle id,
+// and the minimum OS version against the expected one, if not empty
+func VerifyIPA(ipaPth, expectedMinimumOSVersion string) (IPAVerificationModel, error) {
+	return verifyIPA(tools.NewDefaultLogger(), ipaPth, expectedMinimumOSVersion, time.Now())
+}
+
+func verifyIPA(logger tools.Logger, ipaPth, expectedMinimumOSVersion string, now time.Time) (IPAVerificationModel, error) {
+	verification := IPAVerificationModel{Pth: ipaPth, Findings: []VerificationFindingModel{}}
+
+	infoPlist, profileContent, err := readIPAContent(logger, ipaPth)
+	if err != nil {
+		return IPAVerificationModel{}, fmt.Errorf("failed to read ipa (%s), error: %s", ipaPth, err)
+	}
+
+	verification.BundleIdentifier, _ = infoPlist.GetString("CFBundleIdentifier")
+	verification.MinimumOSVersion = plistVersionString(infoPlist, "MinimumOSVersion")
+
+	if verification.MinimumOSVersion == "" {
+		verification.addFinding(VerificationCheckMinimumOSMissing, VerificationSeverityWarning,
+			"MinimumOSVersion is not set in the Info.plist")
+	} else if expectedMinimumOSVersion != "" && !versionsEqual(verification.MinimumOSVersion, expectedMinimumOSVersion) {
+		verification.addFinding(VerificationCheckMinimumOSMismatch, VerificationSeverityWarning,
+			"minimum OS version (%s) differs from the project's (%s)", verification.MinimumOSVersion, expectedMinimumOSVersion)
+	}
+
+	if profileContent == nil {
+		verification.addFinding(VerificationCheckProfileMissing, VerificationSeverityError,
+			"embedded.mobileprovision not found")
+		return verification, nil
+	}
+
+	profile, err := parseProvisioningProfile(profileContent)
+	if err != nil {
+		return IPAVerificationModel{}, fmt.Errorf("failed to parse embedded provisioning profile of (%s), error: %s", ipaPth, err)
+	}
+
+	verification.ProfileName, _ = profile.GetString("Name")
+	if entitlements, ok := profile.GetDict("Entitlements"); ok {
+		verification.ProfileApplicationIdentifier, _ = entitlements.GetString("application-identifier")
+	}
+	if expirationDate, ok := profile.GetString("ExpirationDate"); ok {
+		if verification.ProfileExpirationDate, err = time.Parse(time.RFC3339, expirationDate); err != nil {
+			return IPAVerificationModel{}, fmt.Errorf("invalid profile expiration date (%s), error: %s", expirationDate, err)
+		}
+	}
+
+	if !verification.ProfileExpirationDate.IsZero() {
+		if !now.Before(verification.ProfileExpirationDate) {
+			verification.addFinding(VerificationCheckProfileExpired, VerificationSeverityError,
+				"provisioning profile (%s) expired at %s", verification.ProfileName, verification.ProfileExpirationDate)
+		} else if verification.ProfileExpirationDate.Sub(now) < profileExpirationWarningPeriod {
+			verification.addFinding(VerificationCheckProfileExpiring, VerificationSeverityWarning,
+				"provisioning profile (%s) expires at %s", verification.ProfileName, verification.ProfileExpirationDate)
+		}
+	}
+
+	if !applicationIdentifierMatches(verification.ProfileApplicationIdentifier, verification.BundleIdentifier) {
+		verification.addFinding(VerificationCheckBundleIDMismatch, VerificationSeverityError,
+			"provisioning profile (%s) application identifier (%s) does not match the bundle id (%s)",
+			verification.ProfileName, verification.ProfileApplicationIdentifier, verification.BundleIdentifier)
+	}
+
+	return verification, nil
+}
+
+// VerifyIPAs - verifies the collected ipas, the minimum OS version is compared to the project's
+func (builder Model) VerifyIPAs(projectOutputMap ProjectOutputMap) ([]IPAVerificationModel, error) {
+	projectNames := []string{}
+	for projectName := range projectOutputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	verifications := []IPAVerificationModel{}
+	for _, projectName := range projectNames {
+		expectedMinimumOSVersion := ""
+		for _, proj := range builder.solution.ProjectMap {
+			if proj.Name == projectName {
+				expectedMinimumOSVersion = proj.MinimumOSVersion
+				break
+			}
+		}
+
+		for _, output := range projectOutputMap[projectName].Outputs {
+			if output.OutputType != constants.OutputTypeIPA {
+				continue
+			}
+
+			verification, err := verifyIPA(builder.log(), output.Pth, expectedMinimumOSVersion, time.Now())
+			if err != nil {
+				return nil, err
+			}
+
+			for _, finding := range verification.Findings {
+				if finding.Severity == VerificationSeverityError {
+					builder.log().Errorf("%s: %s", projectName, finding.Message)
+				} else {
+					builder.log().Warnf("%s: %s", projectName, finding.Message)
+				}
+			}
+
+			verifications = append(verifications, verification)
+		}
+	}
+
+	return verifications, nil
+}
+
+// readIPAContent returns the Info.plist and the embedded.mobileprovision content (nil if missing) of the app in the ipa's Payload
+func readIPAContent(logger tools.Logger, ipaPth string) (plist.Model, []byte, error) {
+	reader, err := zip.OpenReader(ipaPth)
+	if err != nil {
+		return plist.Model{}, nil, err
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Warnf("Failed to close ipa (%s), error: %s", ipaPth, err)
+		}
+	}()
+
+	appDir := ""
+	files := map[string]*zip.File{}
+	for _, file := range reader.File {
+		files[file.Name] = file
+
+		dir := path.Dir(file.Name) + "/"
+		if appDir == "" && ipaAppBundlePattern.MatchString(dir) {
+			appDir = dir
+		}
+	}
+	if appDir == "" {
+		return plist.Model{}, nil, fmt.Errorf("no app bundle found in Payload")
+	}
+
+	infoPlistFile, ok := files[appDir+"Info.plist"]
+	if !ok {
+		return plist.Model{}, nil, fmt.Errorf("no Info.plist found in %s", appDir)
+	}
+	infoPlistContent, err := readZipFile(logger, infoPlistFile)
+	if err != nil {
+		return plist.Model{}, nil, err
+	}
+	infoPlist, err := plist.Parse(infoPlistContent)
+	if err != nil {
+		return plist.Model{}, nil, fmt.Errorf("failed to parse Info.plist, error: %s", err)
+	}
+
+	profileFile, ok := files[appDir+"embedded.mobileprovision"]
+	if !ok {
+		return infoPlist, nil, nil
+	}
+	profileContent, err := readZipFile(logger, profileFile)
+	if err != nil {
+		return plist.Model{}, nil, err
+	}
+
+	return infoPlist, profileContent, nil
+}
+
+func readZipFile(logger tools.Logger, file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logger.Warnf("Failed to close (%s), error: %s", file.Name, err)
+		}
+	}()
+
+	return ioutil.ReadAll(reader)
+}
+
+// parseProvisioningProfile - the provisioning profile is a CMS signed message, with the plist as its content
+func parseProvisioningProfile(content []byte) (plist.Model, error) {
+	start := bytes.Index(content, []byte("<?xml"))
+	end := bytes.LastIndex(content, []byte("</plist>"))
+	if start < 0 || end < start {
+		return plist.Model{}, fmt.Errorf("no plist found in the profile")
+	}
+
+	return plist.Parse(content[start : end+len("</plist>")])
+}
+
+// applicationIdentifierMatches - the application identifier is prefixed with the team id and may end with a wildcard,
+// like: TEAMID.com.bitrise.* or TEAMID.*
+func applicationIdentifierMatches(applicationIdentifier, bundleID string) bool {
+	split := strings.SplitN(applicationIdentifier, ".", 2)
+	if len(split) != 2 || bundleID == "" {
+		return false
+	}
+
+	pattern := split[1]
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(bundleID, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == bundleID
+}
+
+// plistVersionString - version values are strings, but real values are accepted as well
+func plistVersionString(infoPlist plist.Model, key string) string {
+	switch value := infoPlist[key].(type) {
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(value, 10)
+	}
+	return ""
+}
+
+// versionsEqual compares dot separated versions, missing components are zeros: 10 == 10.0 == 10.0.0
+func versionsEqual(version, other string) bool {
+	components := strings.Split(version, ".")
+	otherComponents := strings.Split(other, ".")
+	for len(components) < len(otherComponents) {
+		components = append(components, "0")
+	}
+	for len(otherComponents) < len(components) {
+		otherComponents = append(otherComponents, "0")
+	}
+
+	for i := range components {
+		if strings.TrimLeft(components[i], "0") != strings.TrimLeft(otherComponents[i], "0") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package builder
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+const verifyTestInfoPlistContent = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.bitrise.sampleapp</string>
+	<key>MinimumOSVersion</key>
+	<string>10.0</string>
+</dict>
+</plist>`
+
+const verifyTestProfileContent = "\x30\x82\x1b\x2d\x06\x09signature" + `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>Name</key>
+	<string>Sample App Store</string>
+	<key>ExpirationDate</key>
+	<date>2017-05-10T12:00:00Z</date>
+	<key>Entitlements</key>
+	<dict>
+		<key>application-identifier</key>
+		<string>TEAMID.com.bitrise.sampleapp</string>
+	</dict>
+</dict>
+</plist>` + "\xa0\x82certificates"
+
+func createTestIPA(t *testing.T, pth string, files map[string]string) {
+	file, err := os.Create(pth)
+	require.NoError(t, err)
+
+	writer := zip.NewWriter(file)
+	for name, content := range files {
+		entryWriter, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entryWriter.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+	require.NoError(t, file.Close())
+}
+
+func TestVerifyIPA(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("verify_test")
+	require.NoError(t, err)
+
+	ipaPth := filepath.Join(tmpDir, "SampleApp.ipa")
+	createTestIPA(t, ipaPth, map[string]string{
+		"Payload/SampleApp.app/Info.plist":               verifyTestInfoPlistContent,
+		"Payload/SampleApp.app/embedded.mobileprovision": verifyTestProfileContent,
+		"Payload/SampleApp.app/SampleApp":                "binary",
+	})
+
+	t.Log("it reads the bundle and profile properties")
+	{
+		verification, err := verifyIPA(tools.NewDefaultLogger(), ipaPth, "10", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, "com.bitrise.sampleapp", verification.BundleIdentifier)
+		require.Equal(t, "10.0", verification.MinimumOSVersion)
+		require.Equal(t, "Sample App Store", verification.ProfileName)
+		require.Equal(t, "TEAMID.com.bitrise.sampleapp", verification.ProfileApplicationIdentifier)
+		require.Equal(t, time.Date(2017, 5, 10, 12, 0, 0, 0, time.UTC), verification.ProfileExpirationDate.UTC())
+		require.Equal(t, []VerificationFindingModel{}, verification.Findings)
+		require.Equal(t, false, verification.HasErrors())
+	}
+
+	t.Log("it reports expiring and expired profiles")
+	{
+		verification, err := verifyIPA(tools.NewDefaultLogger(), ipaPth, "", time.Date(2017, 5, 8, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(verification.Findings))
+		require.Equal(t, VerificationCheckProfileExpiring, verification.Findings[0].Check)
+		require.Equal(t, false, verification.HasErrors())
+
+		verification, err = verifyIPA(tools.NewDefaultLogger(), ipaPth, "", time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(verification.Findings))
+		require.Equal(t, VerificationCheckProfileExpired, verification.Findings[0].Check)
+		require.Equal(t, true, verification.HasErrors())
+	}
+
+	t.Log("it reports minimum OS version mismatch")
+	{
+		verification, err := verifyIPA(tools.NewDefaultLogger(), ipaPth, "11.0", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(verification.Findings))
+		require.Equal(t, VerificationCheckMinimumOSMismatch, verification.Findings[0].Check)
+		require.Equal(t, VerificationSeverityWarning, verification.Findings[0].Severity)
+	}
+
+	t.Log("it reports bundle id mismatch and missing profile")
+	{
+		otherIpaPth := filepath.Join(tmpDir, "Other.ipa")
+		createTestIPA(t, otherIpaPth, map[string]string{
+			"Payload/Other.app/Info.plist":               `<plist><dict><key>CFBundleIdentifier</key><string>com.bitrise.other</string></dict></plist>`,
+			"Payload/Other.app/embedded.mobileprovision": verifyTestProfileContent,
+		})
+
+		verification, err := verifyIPA(tools.NewDefaultLogger(), otherIpaPth, "", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(verification.Findings))
+		require.Equal(t, VerificationCheckMinimumOSMissing, verification.Findings[0].Check)
+		require.Equal(t, VerificationCheckBundleIDMismatch, verification.Findings[1].Check)
+
+		noProfileIpaPth := filepath.Join(tmpDir, "NoProfile.ipa")
+		createTestIPA(t, noProfileIpaPth, map[string]string{
+			"Payload/NoProfile.app/Info.plist": verifyTestInfoPlistContent,
+		})
+
+		verification, err = verifyIPA(tools.NewDefaultLogger(), noProfileIpaPth, "", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.Equal(t, 1, len(verification.Findings))
+		require.Equal(t, VerificationCheckProfileMissing, verification.Findings[0].Check)
+	}
+
+	t.Log("it fails for invalid ipa")
+	{
+		_, err := VerifyIPA(filepath.Join(tmpDir, "Missing.ipa"), "")
+		require.Error(t, err)
+
+		invalidIpaPth := filepath.Join(tmpDir, "Invalid.ipa")
+		createTestIPA(t, invalidIpaPth, map[string]string{"Payload/README": "no app"})
+		_, err = VerifyIPA(invalidIpaPth, "")
+		require.Error(t, err)
+	}
+}
+
+func TestApplicationIdentifierMatches(t *testing.T) {
+	require.Equal(t, true, applicationIdentifierMatches("TEAMID.com.bitrise.app", "com.bitrise.app"))
+	require.Equal(t, true, applicationIdentifierMatches("TEAMID.com.bitrise.*", "com.bitrise.app"))
+	require.Equal(t, true, applicationIdentifierMatches("TEAMID.*", "com.bitrise.app"))
+	require.Equal(t, false, applicationIdentifierMatches("TEAMID.com.bitrise.other", "com.bitrise.app"))
+	require.Equal(t, false, applicationIdentifierMatches("", "com.bitrise.app"))
+}
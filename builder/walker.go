@@ -0,0 +1,165 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ArtifactSearchModel - bounds the walks of the output directories (and of the Xcode archives) searching for the artifacts
+type ArtifactSearchModel struct {
+	MaxDepth         int      // directory levels walked below the searched directory, 0 means unlimited
+	Extensions       []string // only the paths with these extensions are matched, like: .ipa, empty means any path
+	IgnoredDirs      []string // names of the directories which are not walked, like: node_modules
+	BundleExtensions []string // extensions of the bundle directories, which are matched, but not walked, like: .app
+}
+
+// DefaultArtifactSearch - walks 8 levels deep, matches the exported artifact types only,
+// skips the dependency and intermediate directories and the bundle contents
+func DefaultArtifactSearch() ArtifactSearchModel {
+	return ArtifactSearchModel{
+		MaxDepth:         8,
+		Extensions:       []string{".apk", ".aab", ".ipa", ".xcarchive", ".dSYM", ".app", ".pkg", ".dll"},
+		IgnoredDirs:      []string{"node_modules", "Pods", "obj", ".git"},
+		BundleExtensions: []string{".app", ".appex", ".framework", ".xcarchive", ".dSYM", ".mSYM"},
+	}
+}
+
+// SetArtifactSearch - bounds the walks searching for the artifacts, defaults to DefaultArtifactSearch
+func (builder *Model) SetArtifactSearch(search ArtifactSearchModel) *Model {
+	builder.artifactSearch = search
+	return builder
+}
+
+type artifactEntryModel struct {
+	pth     string
+	modTime time.Time
+}
+
+// artifactWalker walks every searched directory once, the subsequent searches match the memoized entries,
+// a walker is used for a single output collection, as the entries are not refreshed
+type artifactWalker struct {
+	search   ArtifactSearchModel
+	entries  map[string][]artifactEntryModel // walked dir - entries in walk (lexical) order
+	captured []string                        // artifact paths printed by the build, preferred over the walked entries
+}
+
+func newArtifactWalker(search ArtifactSearchModel) *artifactWalker {
+	return &artifactWalker{
+		search:  search,
+		entries: map[string][]artifactEntryModel{},
+	}
+}
+
+func hasExtension(pth string, extensions []string) bool {
+	ext := filepath.Ext(pth)
+	for _, extension := range extensions {
+		if strings.EqualFold(ext, extension) {
+			return true
+		}
+	}
+	return false
+}
+
+func (walker *artifactWalker) isIgnoredDir(name string) bool {
+	for _, ignored := range walker.search.IgnoredDirs {
+		if name == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// walk returns the entries of the dir, unreadable paths are skipped, like by the former per pattern walks
+func (walker *artifactWalker) walk(dir string) []artifactEntryModel {
+	dir = filepath.Clean(dir)
+	if entries, ok := walker.entries[dir]; ok {
+		return entries
+	}
+
+	entries := []artifactEntryModel{}
+	// the walk function never returns an error
+	_ = filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		depth := 0
+		if pth != dir {
+			if rel, err := filepath.Rel(dir, pth); err == nil {
+				depth = strings.Count(rel, string(filepath.Separator)) + 1
+			}
+		}
+
+		if info.IsDir() && pth != dir && walker.isIgnoredDir(info.Name()) {
+			return filepath.SkipDir
+		}
+
+		if len(walker.search.Extensions) == 0 || hasExtension(pth, walker.search.Extensions) {
+			entries = append(entries, artifactEntryModel{pth: pth, modTime: info.ModTime()})
+		}
+
+		if info.IsDir() && pth != dir {
+			if walker.search.MaxDepth > 0 && depth >= walker.search.MaxDepth {
+				return filepath.SkipDir
+			}
+			if hasExtension(pth, walker.search.BundleExtensions) {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+
+	walker.entries[dir] = entries
+	return entries
+}
+
+// find returns the entries of the dir matching the first pattern with any match, which are accepted by the filter,
+// in walk order
+func (walker *artifactWalker) find(dir string, filter func(artifactEntryModel) bool, patterns ...string) []artifactEntryModel {
+	entries := walker.walk(dir)
+
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(pattern)
+
+		matches := []artifactEntryModel{}
+		for _, entry := range entries {
+			if re.FindString(entry.pth) == "" {
+				continue
+			}
+			if filter != nil && !filter(entry) {
+				continue
+			}
+			matches = append(matches, entry)
+		}
+
+		if len(matches) > 0 {
+			return matches
+		}
+	}
+	return []artifactEntryModel{}
+}
+
+// latestEntry returns the latest modified entry's path, entries with equal modification time are resolved by the walk order
+func latestEntry(entries []artifactEntryModel) string {
+	var lastModTime time.Time
+	var latestPth string
+
+	for _, entry := range entries {
+		if latestPth != "" && lastModTime.After(entry.modTime) {
+			continue
+		}
+		lastModTime = entry.modTime
+		latestPth = entry.pth
+	}
+	return latestPth
+}
+
+// newArtifactWalker returns the walker of an output collection, with the artifact paths printed by the build session
+func (builder Model) newArtifactWalker() *artifactWalker {
+	walker := newArtifactWalker(builder.artifactSearch)
+	walker.captured = builder.session.capturedArtifacts()
+	return walker
+}
@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactWalker(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("walker_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	for _, pth := range []string{
+		"bin/Release/Sample.ipa",
+		"bin/Release/Sample.app/Frameworks/Inner.framework/Inner.dll",
+		"bin/Release/Sample.app/Sample.dll",
+		"bin/Release/notes.txt",
+		"node_modules/package/Sample.ipa",
+		"obj/Release/Sample.dll",
+		"a/b/c/d/Deep.ipa",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, filepath.Dir(pth)), 0755))
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, pth), ""))
+	}
+
+	t.Log("it skips the ignored dirs and the bundle contents")
+	{
+		walker := newArtifactWalker(DefaultArtifactSearch())
+
+		pths, err := findArtifacts(walker, tmpDir, time.Now(), time.Now(), false, `(?i)\.ipa$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(tmpDir, "a/b/c/d/Deep.ipa"), filepath.Join(tmpDir, "bin/Release/Sample.ipa")}, pths)
+
+		pths, err = findArtifacts(walker, tmpDir, time.Now(), time.Now(), false, `(?i)\.dll$`, `(?i)\.app$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(tmpDir, "bin/Release/Sample.app")}, pths)
+
+		pths, err = findArtifacts(walker, tmpDir, time.Now(), time.Now(), false, `(?i)\.txt$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, pths)
+	}
+
+	t.Log("it limits the depth")
+	{
+		walker := newArtifactWalker(ArtifactSearchModel{MaxDepth: 3})
+
+		pths, err := findArtifacts(walker, tmpDir, time.Now(), time.Now(), false, `(?i)\.ipa$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(tmpDir, "bin/Release/Sample.ipa"), filepath.Join(tmpDir, "node_modules/package/Sample.ipa")}, pths)
+	}
+
+	t.Log("it memoizes the walked dirs")
+	{
+		walker := newArtifactWalker(DefaultArtifactSearch())
+
+		pths, err := findArtifacts(walker, tmpDir, time.Now(), time.Now(), false, `(?i)\.pkg$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, pths)
+
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "Sample.pkg"), ""))
+
+		pths, err = findArtifacts(walker, tmpDir, time.Now(), time.Now(), false, `(?i)\.pkg$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{}, pths)
+
+		pths, err = findArtifacts(newArtifactWalker(DefaultArtifactSearch()), tmpDir, time.Now(), time.Now(), false, `(?i)\.pkg$`)
+		require.NoError(t, err)
+		require.Equal(t, []string{filepath.Join(tmpDir, "Sample.pkg")}, pths)
+	}
+}
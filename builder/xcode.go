@@ -0,0 +1,67 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/xcode"
+)
+
+// XcodeCompatibilityCheck - how to handle the selected Xcode not supported by the installed Xamarin.iOS
+type XcodeCompatibilityCheck string
+
+const (
+	// XcodeCompatibilityCheckOff - the compatibility is not checked
+	XcodeCompatibilityCheckOff XcodeCompatibilityCheck = ""
+	// XcodeCompatibilityCheckWarn - the mismatch is reported as build warning
+	XcodeCompatibilityCheckWarn XcodeCompatibilityCheck = "warn"
+	// XcodeCompatibilityCheckFail - the build fails before running any command
+	XcodeCompatibilityCheckFail XcodeCompatibilityCheck = "fail"
+)
+
+// SetXcodeCompatibilityCheck - before building iOS and tvOS projects, the selected Xcode (xcode-select)
+// is checked against the Xcode version supported by the installed Xamarin.iOS
+func (builder *Model) SetXcodeCompatibilityCheck(check XcodeCompatibilityCheck) *Model {
+	builder.xcodeCompatibilityCheck = check
+	return builder
+}
+
+// checkXcodeCompatibility returns the compatibility warnings, or an error in fail mode, if any of the projects is an iOS or tvOS project
+func (builder Model) checkXcodeCompatibility(projects []project.Model) ([]string, error) {
+	if builder.xcodeCompatibilityCheck == XcodeCompatibilityCheckOff {
+		return []string{}, nil
+	}
+
+	buildsXamariniOS := false
+	for _, proj := range projects {
+		if proj.SDK == constants.SDKIOS || proj.SDK == constants.SDKTvOS {
+			buildsXamariniOS = true
+			break
+		}
+	}
+	if !buildsXamariniOS {
+		return []string{}, nil
+	}
+
+	selectedXcode, err := xcode.Selected()
+	if err != nil {
+		return []string{fmt.Sprintf("failed to detect the selected Xcode, error: %s", err)}, nil
+	}
+	xamariniOSVersion, err := xcode.XamariniOSVersion(xcode.XamariniOSVersionPth)
+	if err != nil {
+		return []string{err.Error()}, nil
+	}
+
+	return builder.xcodeCompatibilityResult(xcode.CheckCompatibility(selectedXcode.Version, xamariniOSVersion))
+}
+
+func (builder Model) xcodeCompatibilityResult(compatibility xcode.CompatibilityModel) ([]string, error) {
+	if compatibility.Compatible {
+		return []string{}, nil
+	}
+	if builder.xcodeCompatibilityCheck == XcodeCompatibilityCheckFail {
+		return []string{}, fmt.Errorf("incompatible Xcode: %s", compatibility.Message)
+	}
+	return []string{compatibility.Message}, nil
+}
@@ -0,0 +1,51 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/project"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/xcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestXcodeCompatibility(t *testing.T) {
+	incompatible := xcode.CheckCompatibility("10.0", "11.12.0.4")
+
+	t.Log("it does not check by default and without iOS projects")
+	{
+		builder := Model{}
+		warnings, err := builder.checkXcodeCompatibility([]project.Model{{SDK: constants.SDKIOS}})
+		require.NoError(t, err)
+		require.Equal(t, []string{}, warnings)
+
+		builder.SetXcodeCompatibilityCheck(XcodeCompatibilityCheckFail)
+		warnings, err = builder.checkXcodeCompatibility([]project.Model{{SDK: constants.SDKAndroid}, {SDK: constants.SDKMacOS}})
+		require.NoError(t, err)
+		require.Equal(t, []string{}, warnings)
+	}
+
+	t.Log("it warns about the incompatible Xcode")
+	{
+		builder := Model{}
+		builder.SetXcodeCompatibilityCheck(XcodeCompatibilityCheckWarn)
+
+		warnings, err := builder.xcodeCompatibilityResult(incompatible)
+		require.NoError(t, err)
+		require.Equal(t, []string{incompatible.Message}, warnings)
+
+		warnings, err = builder.xcodeCompatibilityResult(xcode.CheckCompatibility("9.4", "11.12.0.4"))
+		require.NoError(t, err)
+		require.Equal(t, []string{}, warnings)
+	}
+
+	t.Log("it fails for the incompatible Xcode")
+	{
+		builder := Model{}
+		builder.SetXcodeCompatibilityCheck(XcodeCompatibilityCheckFail)
+
+		_, err := builder.xcodeCompatibilityResult(incompatible)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Xcode 10.0")
+	}
+}
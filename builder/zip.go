@@ -0,0 +1,116 @@
+package builder
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// zipDSYM - zips the dSYM bundle into <bundle>.zip next to the bundle and returns the zip path,
+// the bundle directory is the root entry of the zip, as crash reporting services expect it
+func zipDSYM(logger tools.Logger, dsymPth string) (string, error) {
+	zipPth := dsymPth + ".zip"
+	if err := zipDir(logger, dsymPth, zipPth); err != nil {
+		if removeErr := os.Remove(zipPth); removeErr != nil && !os.IsNotExist(removeErr) {
+			logger.Warnf("Failed to remove (%s), error: %s", zipPth, removeErr)
+		}
+		return "", fmt.Errorf("failed to zip dsym (%s), error: %s", dsymPth, err)
+	}
+	return zipPth, nil
+}
+
+// zipDir - zips the directory with its name as the root entry, keeping file modes and modification times
+func zipDir(logger tools.Logger, dirPth, zipPth string) error {
+	return writeZip(zipPth, func(writer *zip.Writer) error {
+		baseDir := filepath.Dir(dirPth)
+		return filepath.Walk(dirPth, func(pth string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPth, err := filepath.Rel(baseDir, pth)
+			if err != nil {
+				return err
+			}
+			return addZipEntry(logger, writer, pth, filepath.ToSlash(relPth), info)
+		})
+	})
+}
+
+// zipFiles - zips the files as root entries
+func zipFiles(logger tools.Logger, pths []string, zipPth string) error {
+	return writeZip(zipPth, func(writer *zip.Writer) error {
+		for _, pth := range pths {
+			info, err := os.Stat(pth)
+			if err != nil {
+				return err
+			}
+			if err := addZipEntry(logger, writer, pth, filepath.Base(pth), info); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func writeZip(zipPth string, addEntries func(writer *zip.Writer) error) (err error) {
+	zipFile, err := os.Create(zipPth)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := zipFile.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	writer := zip.NewWriter(zipFile)
+	defer func() {
+		if closeErr := writer.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	return addEntries(writer)
+}
+
+func addZipEntry(logger tools.Logger, writer *zip.Writer, pth, name string, info os.FileInfo) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+
+	if info.IsDir() {
+		header.Name += "/"
+		_, err := writer.CreateHeader(header)
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		// symlinks and other special files are skipped
+		return nil
+	}
+
+	header.Method = zip.Deflate
+	entryWriter, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(pth)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Warnf("Failed to close file (%s), error: %s", pth, err)
+		}
+	}()
+
+	_, err = io.Copy(entryWriter, file)
+	return err
+}
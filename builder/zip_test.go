@@ -0,0 +1,57 @@
+package builder
+
+import (
+	"archive/zip"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipDSYM(t *testing.T) {
+	t.Log("it zips the dsym bundle with the bundle as root entry")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("zip_test")
+		require.NoError(t, err)
+
+		createTestFile(t, tmpDir, "App.app.dSYM/Contents/Info.plist")
+		createTestFile(t, tmpDir, "App.app.dSYM/Contents/Resources/DWARF/App")
+
+		zipPth, err := zipDSYM(tools.NewDefaultLogger(), filepath.Join(tmpDir, "App.app.dSYM"))
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "App.app.dSYM.zip"), zipPth)
+
+		reader, err := zip.OpenReader(zipPth)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, reader.Close())
+		}()
+
+		names := []string{}
+		for _, file := range reader.File {
+			names = append(names, file.Name)
+		}
+		require.Equal(t, []string{
+			"App.app.dSYM/",
+			"App.app.dSYM/Contents/",
+			"App.app.dSYM/Contents/Info.plist",
+			"App.app.dSYM/Contents/Resources/",
+			"App.app.dSYM/Contents/Resources/DWARF/",
+			"App.app.dSYM/Contents/Resources/DWARF/App",
+		}, names)
+	}
+
+	t.Log("it fails for missing dsym")
+	{
+		tmpDir, err := pathutil.NormalizedOSTempDirPath("zip_test")
+		require.NoError(t, err)
+
+		_, err = zipDSYM(tools.NewDefaultLogger(), filepath.Join(tmpDir, "Missing.app.dSYM"))
+		require.Error(t, err)
+		exist, err := pathutil.IsPathExists(filepath.Join(tmpDir, "Missing.app.dSYM.zip"))
+		require.NoError(t, err)
+		require.Equal(t, false, exist)
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitrise-tools/go-xamarin/analyzers/solution"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/urfave/cli"
+)
+
+// AnalyzeProjectOutputModel ...
+type AnalyzeProjectOutputModel struct {
+	Name          string                  `json:"name" yaml:"name"`
+	Pth           string                  `json:"path" yaml:"path"`
+	ProjectType   constants.ProjectType   `json:"project_type" yaml:"project_type"`
+	SDK           constants.SDK           `json:"sdk" yaml:"sdk"`
+	TestFramework constants.TestFramework `json:"test_framework,omitempty" yaml:"test_framework,omitempty"`
+	OutputType    string                  `json:"output_type,omitempty" yaml:"output_type,omitempty"`
+	Configs       []string                `json:"configs" yaml:"configs"`
+}
+
+// AnalyzeOutputModel ...
+type AnalyzeOutputModel struct {
+	Solution string                      `json:"solution" yaml:"solution"`
+	Configs  []string                    `json:"configs" yaml:"configs"`
+	Projects []AnalyzeProjectOutputModel `json:"projects" yaml:"projects"`
+}
+
+func newAnalyzeOutput(solution solution.Model) AnalyzeOutputModel {
+	configs := solution.ConfigList()
+	sort.Strings(configs)
+
+	projects := []AnalyzeProjectOutputModel{}
+	for _, proj := range solution.ProjectMap {
+		projectConfigs := []string{}
+		for config := range proj.Configs {
+			projectConfigs = append(projectConfigs, config)
+		}
+		sort.Strings(projectConfigs)
+
+		projects = append(projects, AnalyzeProjectOutputModel{
+			Name:          proj.Name,
+			Pth:           proj.Pth,
+			ProjectType:   proj.ProjectType,
+			SDK:           proj.SDK,
+			TestFramework: proj.TestFramework,
+			OutputType:    proj.OutputType,
+			Configs:       projectConfigs,
+		})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+
+	return AnalyzeOutputModel{
+		Solution: solution.Pth,
+		Configs:  configs,
+		Projects: projects,
+	}
+}
+
+func printAnalyzeOutput(output AnalyzeOutputModel, format string) {
+	if format == FormatJSON || format == FormatYML {
+		printFormatted(output, format)
+		return
+	}
+
+	fmt.Printf("solution: %s\n", output.Solution)
+	fmt.Printf("configs: %s\n", strings.Join(output.Configs, ", "))
+	for _, proj := range output.Projects {
+		fmt.Println()
+		fmt.Printf("project: %s\n", proj.Name)
+		fmt.Printf("  path: %s\n", proj.Pth)
+		fmt.Printf("  type: %s\n", proj.ProjectType)
+		fmt.Printf("  sdk: %s\n", proj.SDK)
+		if proj.TestFramework != "" {
+			fmt.Printf("  test framework: %s\n", proj.TestFramework)
+		}
+		if proj.OutputType != "" {
+			fmt.Printf("  output type: %s\n", proj.OutputType)
+		}
+		fmt.Printf("  configs: %s\n", strings.Join(proj.Configs, ", "))
+	}
+}
+
+func analyzeCmd(c *cli.Context) error {
+	solutionPth := c.String(solutionFilePathKey)
+	if solutionPth == "" {
+		return fmt.Errorf("missing required input: %s", solutionFilePathKey)
+	}
+
+	solution, err := solutionAnalyzer(c).New(solutionPth, true)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	printAnalyzeOutput(newAnalyzeOutput(solution), c.String(formatKey))
+
+	return nil
+}
@@ -2,12 +2,15 @@ package cli
 
 import (
 	"fmt"
-
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/bitrise-io/go-utils/log"
 	"github.com/bitrise-tools/go-xamarin/builder"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/report"
 	"github.com/urfave/cli"
 )
 
@@ -16,6 +19,9 @@ func buildCmd(c *cli.Context) error {
 	solutionConfiguration := c.String(solutionConfigurationKey)
 	solutionPlatform := c.String(solutionPlatformKey)
 	forceMdtool := c.Bool(forceMDToolKey)
+	eventsPth := c.String(eventsKey)
+	logDir := c.String(logDirKey)
+	reportPth := c.String(reportKey)
 
 	fmt.Println()
 	log.Infof("Config:")
@@ -23,6 +29,9 @@ func buildCmd(c *cli.Context) error {
 	log.Printf("- configuration: %s", solutionConfiguration)
 	log.Printf("- platform: %s", solutionPlatform)
 	log.Printf("- force-mdtool: %v", forceMdtool)
+	log.Printf("- events: %s", eventsPth)
+	log.Printf("- log-dir: %s", logDir)
+	log.Printf("- report: %s", reportPth)
 
 	if solutionPth == "" {
 		return fmt.Errorf("missing required input: %s", solutionFilePathKey)
@@ -34,11 +43,33 @@ func buildCmd(c *cli.Context) error {
 		return fmt.Errorf("missing required input: %s", solutionPlatformKey)
 	}
 
-	buildHandler, err := builder.New(solutionPth, nil, forceMdtool)
+	buildHandler, err := builder.NewWithAnalyzer(solutionPth, nil, forceMdtool, solutionAnalyzer(c))
 	if err != nil {
 		return cli.NewExitError(err.Error(), 1)
 	}
 
+	if eventsPth != "" {
+		eventsFile, err := os.Create(eventsPth)
+		if err != nil {
+			return fmt.Errorf("failed to create events file (%s), error: %s", eventsPth, err)
+		}
+		defer func() {
+			if err := eventsFile.Close(); err != nil {
+				log.Warnf("Failed to close events file (%s), error: %s", eventsPth, err)
+			}
+		}()
+
+		buildHandler.SetEventWriter(eventsFile)
+	}
+
+	if logDir != "" {
+		buildHandler.SetLogDir(logDir)
+	}
+
+	solutionName := strings.TrimSuffix(filepath.Base(solutionPth), filepath.Ext(solutionPth))
+	recorder := report.NewRecorder(solutionName, solutionConfiguration, solutionPlatform)
+	buildHandler.SetProgressCallback(recorder.Progress)
+
 	fmt.Println()
 	log.Infof("Building all projects in solution: %s", solutionPth)
 
@@ -61,6 +92,12 @@ func buildCmd(c *cli.Context) error {
 		log.Warnf(warning)
 	}
 	if err != nil {
+		if logDir != "" {
+			printFailedLogs(logDir)
+		}
+		if reportPth != "" {
+			writeReport(reportPth, report.New(recorder.Result(warnings, err), builder.ProjectOutputMap{}))
+		}
 		return cli.NewExitError(err.Error(), 1)
 	}
 
@@ -74,14 +111,33 @@ func buildCmd(c *cli.Context) error {
 		return err
 	}
 
-	for projectName, projectOutput := range outputMap {
-		fmt.Println()
-		log.Infof("%s outputs:", projectName)
+	printOutputs(newOutputsOutput(outputMap), FormatRaw)
 
-		for _, output := range projectOutput.Outputs {
-			log.Donef("%s: %s", output.OutputType, output.Pth)
-		}
+	if reportPth != "" {
+		writeReport(reportPth, report.New(recorder.Result(warnings, nil), outputMap))
 	}
 
 	return nil
 }
+
+func writeReport(pth string, buildReport report.Model) {
+	if err := buildReport.Write(pth); err != nil {
+		log.Warnf("%s", err)
+		return
+	}
+	log.Donef("Build report: %s", pth)
+}
+
+func printFailedLogs(logDir string) {
+	index, err := builder.ReadLogIndex(logDir)
+	if err != nil {
+		log.Warnf("%s", err)
+		return
+	}
+
+	fmt.Println()
+	log.Errorf("Logs of the failed projects:")
+	for _, pth := range index.FailedLogPths(logDir) {
+		log.Printf("- %s", pth)
+	}
+}
@@ -21,7 +21,7 @@ func cleanCmd(c *cli.Context) error {
 		return fmt.Errorf("missing required input: %s", solutionFilePathKey)
 	}
 
-	builder, err := builder.New(solutionPth, nil, false)
+	builder, err := builder.NewWithAnalyzer(solutionPth, nil, false, solutionAnalyzer(c))
 	if err != nil {
 		return err
 	}
@@ -4,17 +4,39 @@ import (
 	"os"
 
 	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-tools/go-xamarin/analyzers/project"
+	"github.com/bitrise-tools/go-xamarin/analyzers/solution"
 	"github.com/bitrise-tools/go-xamarin/version"
 	"github.com/urfave/cli"
 )
 
+// solutionAnalyzer returns the solution analyzer configured by the global flags
+func solutionAnalyzer(c *cli.Context) *solution.Analyzer {
+	analyzer := solution.NewAnalyzer()
+	if cacheDir := c.GlobalString(cacheDirKey); cacheDir != "" && !c.GlobalBool(noCacheKey) {
+		analyzer.SetProjectCache(project.NewCache(cacheDir))
+	}
+	return analyzer
+}
+
 // Run ...
 func Run() {
 	app := cli.NewApp()
-	app.Name = "xamarin-builder"
+	app.Name = "go-xamarin"
 	app.Usage = "Build xamarin projects"
 	app.Version = version.VERSION
 
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:   cacheDirKey,
+			Usage:  "Cache the analyzed project files in the given directory",
+			EnvVar: project.CacheDirEnvKey,
+		},
+		cli.BoolFlag{
+			Name:  noCacheKey,
+			Usage: "Analyze the project files, without reading or writing the cache",
+		},
+	}
 	app.Commands = commands
 
 	if err := app.Run(os.Args); err != nil {
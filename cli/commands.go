@@ -8,6 +8,35 @@ const (
 	solutionPlatformKey      string = "platform"
 
 	forceMDToolKey string = "force-mdtool"
+
+	eventsKey string = "events"
+	logDirKey string = "log-dir"
+	reportKey string = "report"
+	formatKey string = "format"
+	sinceKey  string = "since"
+
+	cacheDirKey string = "cache-dir"
+	noCacheKey  string = "no-cache"
+)
+
+var (
+	solutionFilePathFlag = cli.StringFlag{
+		Name:  solutionFilePathKey + ", solution",
+		Usage: "Solution file path",
+	}
+	solutionConfigurationFlag = cli.StringFlag{
+		Name:  solutionConfigurationKey + ", config",
+		Usage: "Solution configuration",
+	}
+	solutionPlatformFlag = cli.StringFlag{
+		Name:  solutionPlatformKey,
+		Usage: "Solution platform",
+	}
+	formatFlag = cli.StringFlag{
+		Name:  formatKey,
+		Usage: "Output format: raw, json or yml",
+		Value: FormatRaw,
+	}
 )
 
 var commands = []cli.Command{
@@ -16,21 +45,24 @@ var commands = []cli.Command{
 		Usage:  "Build xamarin projects",
 		Action: buildCmd,
 		Flags: []cli.Flag{
-			cli.StringFlag{
-				Name:  solutionFilePathKey,
-				Usage: "Solution file path",
+			solutionFilePathFlag,
+			solutionConfigurationFlag,
+			solutionPlatformFlag,
+			cli.BoolFlag{
+				Name:  forceMDToolKey,
+				Usage: "Force use mdtool",
 			},
 			cli.StringFlag{
-				Name:  solutionConfigurationKey,
-				Usage: "Solution configuration",
+				Name:  eventsKey,
+				Usage: "Write the build progress as JSON lines to the given file",
 			},
 			cli.StringFlag{
-				Name:  solutionPlatformKey,
-				Usage: "Solution platform",
+				Name:  logDirKey,
+				Usage: "Write the output of the commands into per-project log files in the given directory",
 			},
-			cli.BoolFlag{
-				Name:  forceMDToolKey,
-				Usage: "Force use mdtool",
+			cli.StringFlag{
+				Name:  reportKey,
+				Usage: "Write the build report to the given file, as HTML if its extension is .html, as Markdown otherwise",
 			},
 		},
 	},
@@ -39,9 +71,45 @@ var commands = []cli.Command{
 		Usage:  "Clean xamarin projects",
 		Action: cleanCmd,
 		Flags: []cli.Flag{
+			solutionFilePathFlag,
+		},
+	},
+	{
+		Name:   "analyze",
+		Usage:  "Print the projects and configurations of the solution",
+		Action: analyzeCmd,
+		Flags: []cli.Flag{
+			solutionFilePathFlag,
+			formatFlag,
+		},
+	},
+	{
+		Name:   "outputs",
+		Usage:  "Print the outputs of a previous build",
+		Action: outputsCmd,
+		Flags: []cli.Flag{
+			solutionFilePathFlag,
+			solutionConfigurationFlag,
+			solutionPlatformFlag,
+			cli.DurationFlag{
+				Name:  sinceKey,
+				Usage: "Only collect the outputs generated within the given duration, like: 1h (all outputs by default)",
+			},
+			formatFlag,
+		},
+	},
+	{
+		Name:   "graph",
+		Usage:  "Print the project dependency graph, with the build order of the given configuration and platform",
+		Action: graphCmd,
+		Flags: []cli.Flag{
+			solutionFilePathFlag,
+			solutionConfigurationFlag,
+			solutionPlatformFlag,
 			cli.StringFlag{
-				Name:  solutionFilePathKey,
-				Usage: "Solution file path",
+				Name:  formatKey,
+				Usage: "Output format: dot or json",
+				Value: FormatDOT,
 			},
 		},
 	},
@@ -49,5 +117,8 @@ var commands = []cli.Command{
 		Name:   "version",
 		Usage:  "Prints version",
 		Action: versionCmd,
+		Flags: []cli.Flag{
+			formatFlag,
+		},
 	},
 }
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/bitrise-tools/go-xamarin/builder"
+	"github.com/urfave/cli"
+)
+
+// FormatDOT - Graphviz DOT format of the dependency graph
+const FormatDOT = "dot"
+
+func graphCmd(c *cli.Context) error {
+	solutionPth := c.String(solutionFilePathKey)
+	if solutionPth == "" {
+		return fmt.Errorf("missing required input: %s", solutionFilePathKey)
+	}
+
+	format := c.String(formatKey)
+	if format != FormatDOT && format != FormatJSON {
+		return fmt.Errorf("invalid format (%s), available: %s, %s", format, FormatDOT, FormatJSON)
+	}
+
+	buildHandler, err := builder.NewWithAnalyzer(solutionPth, nil, false, solutionAnalyzer(c))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	graph, warnings, err := buildHandler.DependencyGraph(c.String(solutionConfigurationKey), c.String(solutionPlatformKey))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+	// the graph is printed to the stdout
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	if format == FormatJSON {
+		content, err := graph.JSON()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", content)
+		return nil
+	}
+
+	fmt.Print(graph.DOT())
+	return nil
+}
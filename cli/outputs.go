@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-tools/go-xamarin/builder"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/urfave/cli"
+)
+
+// OutputOutputModel ...
+type OutputOutputModel struct {
+	Project    string               `json:"project" yaml:"project"`
+	OutputType constants.OutputType `json:"output_type" yaml:"output_type"`
+	Pth        string               `json:"path" yaml:"path"`
+}
+
+func newOutputsOutput(outputMap builder.ProjectOutputMap) []OutputOutputModel {
+	projectNames := []string{}
+	for projectName := range outputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	outputs := []OutputOutputModel{}
+	for _, projectName := range projectNames {
+		for _, output := range outputMap[projectName].Outputs {
+			outputs = append(outputs, OutputOutputModel{
+				Project:    projectName,
+				OutputType: output.OutputType,
+				Pth:        output.Pth,
+			})
+		}
+	}
+	return outputs
+}
+
+func printOutputs(outputs []OutputOutputModel, format string) {
+	if format == FormatJSON || format == FormatYML {
+		printFormatted(outputs, format)
+		return
+	}
+
+	projectName := ""
+	for _, output := range outputs {
+		if output.Project != projectName {
+			projectName = output.Project
+			fmt.Println()
+			log.Infof("%s outputs:", projectName)
+		}
+		log.Donef("%s: %s", output.OutputType, output.Pth)
+	}
+}
+
+func outputsCmd(c *cli.Context) error {
+	solutionPth := c.String(solutionFilePathKey)
+	solutionConfiguration := c.String(solutionConfigurationKey)
+	solutionPlatform := c.String(solutionPlatformKey)
+
+	if solutionPth == "" {
+		return fmt.Errorf("missing required input: %s", solutionFilePathKey)
+	}
+	if solutionConfiguration == "" {
+		return fmt.Errorf("missing required input: %s", solutionConfigurationKey)
+	}
+	if solutionPlatform == "" {
+		return fmt.Errorf("missing required input: %s", solutionPlatformKey)
+	}
+
+	buildHandler, err := builder.NewWithAnalyzer(solutionPth, nil, false, solutionAnalyzer(c))
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	endTime := time.Now()
+	startTime := time.Time{}
+	if since := c.Duration(sinceKey); since > 0 {
+		startTime = endTime.Add(-since)
+	}
+
+	outputMap, err := buildHandler.CollectProjectOutputs(solutionConfiguration, solutionPlatform, startTime, endTime)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	printOutputs(newOutputsOutput(outputMap), c.String(formatKey))
+
+	return nil
+}
@@ -29,28 +29,31 @@ const (
 
 // Print ...
 func print(versionOutput VersionOutputModel, format string) {
-	switch format {
-	case FormatJSON:
-		serBytes, err := json.Marshal(versionOutput)
-		if err != nil {
-			log.Errorf("failed to print output, error: %s", err)
-			return
-		}
-		fmt.Printf("%s\n", serBytes)
-	case FormatYML:
-		serBytes, err := yaml.Marshal(versionOutput)
-		if err != nil {
-			log.Errorf("failed to print output, error: %s", err)
-			return
-		}
-		fmt.Printf("%s\n", serBytes)
-	default:
-		fmt.Printf("version: %s\nbuild number: %s\ncommit: %s\n", versionOutput.Version, versionOutput.BuildNumber, versionOutput.Commit)
+	if format == FormatJSON || format == FormatYML {
+		printFormatted(versionOutput, format)
+		return
 	}
+	fmt.Printf("version: %s\nbuild number: %s\ncommit: %s\n", versionOutput.Version, versionOutput.BuildNumber, versionOutput.Commit)
+}
+
+// printFormatted - prints the output in json or yml format
+func printFormatted(output interface{}, format string) {
+	var serBytes []byte
+	var err error
+	if format == FormatYML {
+		serBytes, err = yaml.Marshal(output)
+	} else {
+		serBytes, err = json.Marshal(output)
+	}
+	if err != nil {
+		log.Errorf("failed to print output, error: %s", err)
+		return
+	}
+	fmt.Printf("%s\n", serBytes)
 }
 
 func versionCmd(c *cli.Context) error {
-	format := c.String("format")
+	format := c.String(formatKey)
 
 	versionOutput := VersionOutputModel{
 		Version:     version.VERSION,
@@ -0,0 +1,9 @@
+package main
+
+import (
+	"github.com/bitrise-tools/go-xamarin/cli"
+)
+
+func main() {
+	cli.Run()
+}
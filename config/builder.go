@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/builder"
+)
+
+// NewBuilder - creates the builder of the spec's solution, with the spec's project filters, tool, properties and signing
+func NewBuilder(config Model) (builder.Model, error) {
+	sdks, err := config.SDKs()
+	if err != nil {
+		return builder.Model{}, err
+	}
+
+	buildHandler, err := builder.New(config.Solution, sdks, config.Tool == ToolMDTool)
+	if err != nil {
+		return builder.Model{}, err
+	}
+
+	if len(config.Projects.Folders) > 0 {
+		buildHandler.SetFolderWhitelist(config.Projects.Folders...)
+	}
+	buildHandler.SetBuildLibraries(config.Projects.BuildLibraries)
+
+	for name, value := range config.Properties {
+		buildHandler.SetBuildProperty(name, value)
+	}
+
+	if config.Timeout != "" {
+		timeout, err := time.ParseDuration(config.Timeout)
+		if err != nil {
+			return builder.Model{}, fmt.Errorf("invalid timeout (%s), error: %s", config.Timeout, err)
+		}
+		buildHandler.SetTimeout(timeout)
+	}
+
+	if ios := config.Signing.IOS; ios != nil {
+		if ios.CodesignIdentity != "" {
+			buildHandler.SetCodesignIdentity(ios.CodesignIdentity)
+		}
+		if ios.Keychain != "" {
+			buildHandler.SetKeychain(ios.Keychain, ios.KeychainPassword)
+		}
+		if ios.DistributionType != "" {
+			distributionType, err := profiles.ParseDistributionType(ios.DistributionType)
+			if err != nil {
+				return builder.Model{}, err
+			}
+			buildHandler.SetIOSProvisioningProfileSelection(distributionType, ios.ProfilesDir)
+		}
+	}
+
+	if android := config.Signing.Android; android != nil {
+		buildHandler.SetAndroidKeystore(builder.AndroidKeystoreModel{
+			Pth:           android.Keystore,
+			Alias:         android.Alias,
+			StorePassword: android.StorePassword,
+			KeyPassword:   android.KeyPassword,
+		})
+	}
+
+	return buildHandler, nil
+}
+
+// ExportOutputs - copies the outputs into the spec's output dir, the returned map points to the copies,
+// the outputs are returned as is if no output dir is set
+func (config Model) ExportOutputs(outputMap builder.ProjectOutputMap) (builder.ProjectOutputMap, error) {
+	if config.OutputDir == "" {
+		return outputMap, nil
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return builder.ProjectOutputMap{}, fmt.Errorf("failed to create output dir (%s), error: %s", config.OutputDir, err)
+	}
+
+	exported := builder.ProjectOutputMap{}
+	for projectName, projectOutput := range outputMap {
+		outputs := []builder.OutputModel{}
+		for _, output := range projectOutput.Outputs {
+			exportPth := filepath.Join(config.OutputDir, filepath.Base(output.Pth))
+
+			if err := os.RemoveAll(exportPth); err != nil {
+				return builder.ProjectOutputMap{}, fmt.Errorf("failed to remove previous export (%s), error: %s", exportPth, err)
+			}
+			// cp -R keeps the symlinks of the .app and .xcarchive bundles
+			if err := command.RunCommand("cp", "-R", output.Pth, exportPth); err != nil {
+				return builder.ProjectOutputMap{}, fmt.Errorf("failed to export output (%s), error: %s", output.Pth, err)
+			}
+
+			output.Pth = exportPth
+			outputs = append(outputs, output)
+		}
+
+		projectOutput.Outputs = outputs
+		exported[projectName] = projectOutput
+	}
+
+	return exported, nil
+}
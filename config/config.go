@@ -0,0 +1,212 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/profiles"
+	"github.com/brandonrisell/go-xamarin/constants"
+)
+
+// Tool - the build tool of the projects
+type Tool string
+
+const (
+	// ToolXbuild - xbuild or msbuild, the default
+	ToolXbuild Tool = "xbuild"
+	// ToolMDTool ...
+	ToolMDTool Tool = "mdtool"
+)
+
+// ConfigurationModel - a solution Configuration|Platform to build
+type ConfigurationModel struct {
+	Configuration string `json:"configuration" yaml:"configuration"`
+	Platform      string `json:"platform" yaml:"platform"`
+}
+
+// ProjectFilterModel - selects the projects to build, every app project is built by default
+type ProjectFilterModel struct {
+	SDKs           []string `json:"sdks,omitempty" yaml:"sdks,omitempty"`       // like: ios, android
+	Folders        []string `json:"folders,omitempty" yaml:"folders,omitempty"` // solution folders, like: Apps/iOS
+	BuildLibraries bool     `json:"build_libraries,omitempty" yaml:"build_libraries,omitempty"`
+}
+
+// IOSSigningModel ...
+type IOSSigningModel struct {
+	CodesignIdentity string `json:"codesign_identity,omitempty" yaml:"codesign_identity,omitempty"`
+	Keychain         string `json:"keychain,omitempty" yaml:"keychain,omitempty"`
+	KeychainPassword string `json:"keychain_password,omitempty" yaml:"keychain_password,omitempty"`
+	DistributionType string `json:"distribution_type,omitempty" yaml:"distribution_type,omitempty"` // selects the provisioning profiles, like: app-store
+	ProfilesDir      string `json:"profiles_dir,omitempty" yaml:"profiles_dir,omitempty"`
+}
+
+// AndroidSigningModel ...
+type AndroidSigningModel struct {
+	Keystore      string `json:"keystore,omitempty" yaml:"keystore,omitempty"`
+	Alias         string `json:"alias,omitempty" yaml:"alias,omitempty"`
+	StorePassword string `json:"store_password,omitempty" yaml:"store_password,omitempty"`
+	KeyPassword   string `json:"key_password,omitempty" yaml:"key_password,omitempty"`
+}
+
+// SigningModel ...
+type SigningModel struct {
+	IOS     *IOSSigningModel     `json:"ios,omitempty" yaml:"ios,omitempty"`
+	Android *AndroidSigningModel `json:"android,omitempty" yaml:"android,omitempty"`
+}
+
+// Model - the build spec, relative paths are relative to the spec file,
+// the passwords are expanded from the environment, like: $KEYSTORE_PASSWORD
+type Model struct {
+	Solution   string               `json:"solution" yaml:"solution"`
+	Matrix     []ConfigurationModel `json:"matrix" yaml:"matrix"`
+	Projects   ProjectFilterModel   `json:"projects,omitempty" yaml:"projects,omitempty"`
+	Tool       Tool                 `json:"tool,omitempty" yaml:"tool,omitempty"`
+	Properties map[string]string    `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Signing    SigningModel         `json:"signing,omitempty" yaml:"signing,omitempty"`
+	OutputDir  string               `json:"output_dir,omitempty" yaml:"output_dir,omitempty"`
+	Timeout    string               `json:"timeout,omitempty" yaml:"timeout,omitempty"` // of every build command, like: 30m
+}
+
+// New - reads the spec from a .json, .yml or .yaml file and validates it
+func New(pth string) (Model, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to read build config (%s), error: %s", pth, err)
+	}
+
+	var config Model
+	if strings.ToLower(filepath.Ext(pth)) == ".json" {
+		config, err = ParseJSON(content)
+	} else {
+		config, err = ParseYML(content)
+	}
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to parse build config (%s), error: %s", pth, err)
+	}
+
+	absPth, err := filepath.Abs(pth)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to expand path (%s), error: %s", pth, err)
+	}
+	config.resolvePaths(filepath.Dir(absPth))
+
+	if err := config.Validate(); err != nil {
+		return Model{}, fmt.Errorf("invalid build config (%s): %s", pth, err)
+	}
+
+	return config, nil
+}
+
+// ParseJSON ...
+func ParseJSON(content []byte) (Model, error) {
+	var config Model
+	if err := json.Unmarshal(content, &config); err != nil {
+		return Model{}, err
+	}
+	config.expandSecrets()
+	return config, nil
+}
+
+// ParseYML ...
+func ParseYML(content []byte) (Model, error) {
+	var config Model
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return Model{}, err
+	}
+	config.expandSecrets()
+	return config, nil
+}
+
+func (config *Model) expandSecrets() {
+	if ios := config.Signing.IOS; ios != nil {
+		ios.KeychainPassword = os.ExpandEnv(ios.KeychainPassword)
+	}
+	if android := config.Signing.Android; android != nil {
+		android.StorePassword = os.ExpandEnv(android.StorePassword)
+		android.KeyPassword = os.ExpandEnv(android.KeyPassword)
+	}
+}
+
+func resolvePath(dir, pth string) string {
+	if pth == "" || filepath.IsAbs(pth) {
+		return pth
+	}
+	return filepath.Join(dir, pth)
+}
+
+// resolvePaths makes the relative paths relative to the given dir
+func (config *Model) resolvePaths(dir string) {
+	config.Solution = resolvePath(dir, config.Solution)
+	config.OutputDir = resolvePath(dir, config.OutputDir)
+	if ios := config.Signing.IOS; ios != nil {
+		ios.Keychain = resolvePath(dir, ios.Keychain)
+		ios.ProfilesDir = resolvePath(dir, ios.ProfilesDir)
+	}
+	if android := config.Signing.Android; android != nil {
+		android.Keystore = resolvePath(dir, android.Keystore)
+	}
+}
+
+// Validate ...
+func (config Model) Validate() error {
+	if config.Solution == "" {
+		return fmt.Errorf("missing solution")
+	}
+
+	if len(config.Matrix) == 0 {
+		return fmt.Errorf("missing matrix")
+	}
+	for i, configuration := range config.Matrix {
+		if configuration.Configuration == "" || configuration.Platform == "" {
+			return fmt.Errorf("configuration and platform are required, matrix item: %d", i)
+		}
+	}
+
+	if _, err := config.SDKs(); err != nil {
+		return err
+	}
+
+	if config.Tool != "" && config.Tool != ToolXbuild && config.Tool != ToolMDTool {
+		return fmt.Errorf("unknown tool: %s", config.Tool)
+	}
+
+	if config.Timeout != "" {
+		if _, err := time.ParseDuration(config.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout (%s), error: %s", config.Timeout, err)
+		}
+	}
+
+	if ios := config.Signing.IOS; ios != nil && ios.DistributionType != "" {
+		if _, err := profiles.ParseDistributionType(ios.DistributionType); err != nil {
+			return err
+		}
+	}
+
+	if android := config.Signing.Android; android != nil {
+		if android.Keystore == "" || android.Alias == "" || android.StorePassword == "" {
+			return fmt.Errorf("keystore, alias and store password are required for android signing")
+		}
+	}
+
+	return nil
+}
+
+// SDKs - the sdks of the project filter
+func (config Model) SDKs() ([]constants.SDK, error) {
+	sdks := []constants.SDK{}
+	for _, sdk := range config.Projects.SDKs {
+		parsed, err := constants.ParseSDK(sdk)
+		if err != nil {
+			return nil, err
+		}
+		sdks = append(sdks, parsed)
+	}
+	return sdks, nil
+}
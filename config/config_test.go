@@ -0,0 +1,192 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+const ymlConfigContent = `solution: ./Sample.sln
+matrix:
+- configuration: Release
+  platform: iPhone
+- configuration: Release
+  platform: Any CPU
+projects:
+  sdks:
+  - ios
+  - android
+  folders:
+  - Apps
+tool: xbuild
+properties:
+  MtouchLink: SdkOnly
+signing:
+  android:
+    keystore: keys/release.keystore
+    alias: release
+    store_password: $CONFIG_TEST_STORE_PASSWORD
+output_dir: /tmp/outputs
+timeout: 30m
+`
+
+const jsonConfigContent = `{
+	"solution": "/project/Sample.sln",
+	"matrix": [{"configuration": "Debug", "platform": "iPhoneSimulator"}],
+	"tool": "mdtool",
+	"signing": {"ios": {"codesign_identity": "iPhone Developer", "distribution_type": "development"}}
+}`
+
+func TestParse(t *testing.T) {
+	t.Log("it parses the yml spec and expands the passwords")
+	{
+		require.NoError(t, os.Setenv("CONFIG_TEST_STORE_PASSWORD", "secret"))
+		defer func() {
+			require.NoError(t, os.Unsetenv("CONFIG_TEST_STORE_PASSWORD"))
+		}()
+
+		config, err := ParseYML([]byte(ymlConfigContent))
+		require.NoError(t, err)
+		require.Equal(t, "./Sample.sln", config.Solution)
+		require.Equal(t, []ConfigurationModel{{"Release", "iPhone"}, {"Release", "Any CPU"}}, config.Matrix)
+		require.Equal(t, []string{"Apps"}, config.Projects.Folders)
+		require.Equal(t, ToolXbuild, config.Tool)
+		require.Equal(t, map[string]string{"MtouchLink": "SdkOnly"}, config.Properties)
+		require.Equal(t, "secret", config.Signing.Android.StorePassword)
+		require.Nil(t, config.Signing.IOS)
+		require.Equal(t, "30m", config.Timeout)
+
+		sdks, err := config.SDKs()
+		require.NoError(t, err)
+		require.Equal(t, []constants.SDK{constants.SDKIOS, constants.SDKAndroid}, sdks)
+		require.NoError(t, config.Validate())
+	}
+
+	t.Log("it parses the json spec")
+	{
+		config, err := ParseJSON([]byte(jsonConfigContent))
+		require.NoError(t, err)
+		require.Equal(t, "/project/Sample.sln", config.Solution)
+		require.Equal(t, []ConfigurationModel{{"Debug", "iPhoneSimulator"}}, config.Matrix)
+		require.Equal(t, ToolMDTool, config.Tool)
+		require.Equal(t, "development", config.Signing.IOS.DistributionType)
+		require.NoError(t, config.Validate())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := Model{Solution: "Sample.sln", Matrix: []ConfigurationModel{{"Release", "iPhone"}}}
+	require.NoError(t, valid.Validate())
+
+	t.Log("it fails for a missing solution or matrix")
+	{
+		config := valid
+		config.Solution = ""
+		require.Error(t, config.Validate())
+
+		config = valid
+		config.Matrix = []ConfigurationModel{{Configuration: "Release"}}
+		require.Error(t, config.Validate())
+	}
+
+	t.Log("it fails for unknown values")
+	{
+		config := valid
+		config.Tool = "make"
+		require.Error(t, config.Validate())
+
+		config = valid
+		config.Projects.SDKs = []string{"windows"}
+		require.Error(t, config.Validate())
+
+		config = valid
+		config.Timeout = "soon"
+		require.Error(t, config.Validate())
+
+		config = valid
+		config.Signing.IOS = &IOSSigningModel{DistributionType: "store"}
+		require.Error(t, config.Validate())
+	}
+
+	t.Log("it fails for incomplete android signing")
+	{
+		config := valid
+		config.Signing.Android = &AndroidSigningModel{Keystore: "release.keystore"}
+		require.Error(t, config.Validate())
+	}
+}
+
+func TestNew(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("config")
+	require.NoError(t, err)
+
+	t.Log("it resolves the paths relative to the spec file")
+	{
+		require.NoError(t, os.Setenv("CONFIG_TEST_STORE_PASSWORD", "secret"))
+		defer func() {
+			require.NoError(t, os.Unsetenv("CONFIG_TEST_STORE_PASSWORD"))
+		}()
+
+		pth := filepath.Join(tmpDir, "build.yml")
+		require.NoError(t, fileutil.WriteStringToFile(pth, ymlConfigContent))
+
+		config, err := New(pth)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "Sample.sln"), config.Solution)
+		require.Equal(t, filepath.Join(tmpDir, "keys", "release.keystore"), config.Signing.Android.Keystore)
+		require.Equal(t, "/tmp/outputs", config.OutputDir)
+	}
+
+	t.Log("it fails for an invalid spec")
+	{
+		pth := filepath.Join(tmpDir, "build.json")
+		require.NoError(t, fileutil.WriteStringToFile(pth, `{"solution": "Sample.sln"}`))
+
+		_, err := New(pth)
+		require.Error(t, err)
+	}
+}
+
+func TestExportOutputs(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("config")
+	require.NoError(t, err)
+
+	apkPth := filepath.Join(tmpDir, "bin", "com.sample.apk")
+	require.NoError(t, os.MkdirAll(filepath.Dir(apkPth), 0755))
+	require.NoError(t, fileutil.WriteStringToFile(apkPth, "apk"))
+
+	outputMap := builder.ProjectOutputMap{
+		"Sample.Droid": builder.ProjectOutputModel{
+			ProjectType: constants.SDKAndroid,
+			Outputs:     []builder.OutputModel{{Pth: apkPth, OutputType: constants.OutputTypeAPK}},
+		},
+	}
+
+	t.Log("it returns the outputs as is without output dir")
+	{
+		exported, err := Model{}.ExportOutputs(outputMap)
+		require.NoError(t, err)
+		require.Equal(t, outputMap, exported)
+	}
+
+	t.Log("it copies the outputs into the output dir")
+	{
+		outputDir := filepath.Join(tmpDir, "outputs")
+		exported, err := Model{OutputDir: outputDir}.ExportOutputs(outputMap)
+		require.NoError(t, err)
+
+		exportedPth := filepath.Join(outputDir, "com.sample.apk")
+		require.Equal(t, exportedPth, exported["Sample.Droid"].Outputs[0].Pth)
+		require.Equal(t, apkPth, outputMap["Sample.Droid"].Outputs[0].Pth)
+
+		content, err := fileutil.ReadStringFromFile(exportedPth)
+		require.NoError(t, err)
+		require.Equal(t, "apk", content)
+	}
+}
@@ -1,6 +1,10 @@
 package constants
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 const (
 	// MDToolPath ...
@@ -66,6 +70,10 @@ const (
 	TestFrameworkNunitTest TestFramework = "nunit-test"
 	// TestFrameworkNunitLiteTest ...
 	TestFrameworkNunitLiteTest TestFramework = "nunit-lite-test"
+	// TestFrameworkXunitTest ...
+	TestFrameworkXunitTest TestFramework = "xunit-test"
+	// TestFrameworkMSTest ...
+	TestFrameworkMSTest TestFramework = "mstest-test"
 )
 
 // ParseTestFramwork ...
@@ -77,11 +85,67 @@ func ParseTestFramwork(testFramwork string) (TestFramework, error) {
 		return TestFrameworkNunitTest, nil
 	case "nunit-lite-test":
 		return TestFrameworkNunitLiteTest, nil
+	case "xunit-test":
+		return TestFrameworkXunitTest, nil
+	case "mstest-test":
+		return TestFrameworkMSTest, nil
 	default:
 		return TestFrameworkUnknown, fmt.Errorf("invalid test framwork: %s", testFramwork)
 	}
 }
 
+// ProjectType ...
+type ProjectType string
+
+const (
+	// ProjectTypeUnknown ...
+	ProjectTypeUnknown ProjectType = "unknown"
+	// ProjectTypeShared - shared project (.shproj), its sources are compiled into the importing projects
+	ProjectTypeShared ProjectType = "shared"
+	// ProjectTypeXamarinUITest - test project referencing Xamarin.UITest
+	ProjectTypeXamarinUITest ProjectType = "xamarin-uitest"
+	// ProjectTypeUnitTest - NUnit, xUnit or MSTest test assembly
+	ProjectTypeUnitTest ProjectType = "unit-test"
+	// ProjectTypeAppExtension - iOS app extension, embedded into its container app
+	ProjectTypeAppExtension ProjectType = "app-extension"
+	// ProjectTypeWatchApp - watchOS app, embedded into its container iOS app
+	ProjectTypeWatchApp ProjectType = "watch-app"
+	// ProjectTypeWatchExtension - WatchKit extension, embedded into its watchOS app
+	ProjectTypeWatchExtension ProjectType = "watch-extension"
+	// ProjectTypeiOSBinding - Xamarin.iOS binding library of a native framework or library
+	ProjectTypeiOSBinding ProjectType = "ios-binding"
+	// ProjectTypeAndroidBinding - Xamarin.Android binding library of a java library
+	ProjectTypeAndroidBinding ProjectType = "android-binding"
+	// ProjectTypeLibrary - class library
+	ProjectTypeLibrary ProjectType = "library"
+)
+
+// ParseProjectType ...
+func ParseProjectType(projectType string) (ProjectType, error) {
+	switch projectType {
+	case "shared":
+		return ProjectTypeShared, nil
+	case "xamarin-uitest":
+		return ProjectTypeXamarinUITest, nil
+	case "unit-test":
+		return ProjectTypeUnitTest, nil
+	case "app-extension":
+		return ProjectTypeAppExtension, nil
+	case "watch-app":
+		return ProjectTypeWatchApp, nil
+	case "watch-extension":
+		return ProjectTypeWatchExtension, nil
+	case "ios-binding":
+		return ProjectTypeiOSBinding, nil
+	case "android-binding":
+		return ProjectTypeAndroidBinding, nil
+	case "library":
+		return ProjectTypeLibrary, nil
+	default:
+		return ProjectTypeUnknown, fmt.Errorf("invalid project type: %s", projectType)
+	}
+}
+
 // ParseProjectTypeGUID ...
 func ParseProjectTypeGUID(guid string) (SDK, error) {
 	switch guid {
@@ -93,7 +157,9 @@ func ParseProjectTypeGUID(guid string) (SDK, error) {
 		"F5B4F3BC-B597-4E2B-B552-EF5D8A32436F",
 		"FEACFBD2-3405-455C-9665-78FE426C6842",
 		"8FFB629D-F513-41CE-95D2-7ECE97B6EEEC",
-		"EE2C853D-36AF-4FDB-B1AD-8E90477E2198":
+		"EE2C853D-36AF-4FDB-B1AD-8E90477E2198",
+		"FC940695-DFE0-4552-9F25-99AF4A5619A1", // XamarinWatchOSApp
+		"1E2E965C-F6D2-49ED-B86E-418A60C69EEF": // XamarinWatchOSExtension
 		return SDKIOS, nil
 	case "06FA79CB-D6CD-4721-BB4B-1BD202089C55": // XamarinProjectTypeTvOS
 		return SDKTvOS, nil
@@ -107,6 +173,64 @@ func ParseProjectTypeGUID(guid string) (SDK, error) {
 	}
 }
 
+// ParseEmbeddedProjectTypeGUID - identifies the app extension and watch project type guids
+func ParseEmbeddedProjectTypeGUID(guid string) (ProjectType, error) {
+	switch guid {
+	case "EE2C853D-36AF-4FDB-B1AD-8E90477E2198": // XamarinIOSExtension
+		return ProjectTypeAppExtension, nil
+	case "FC940695-DFE0-4552-9F25-99AF4A5619A1": // XamarinWatchOSApp
+		return ProjectTypeWatchApp, nil
+	case "1E2E965C-F6D2-49ED-B86E-418A60C69EEF": // XamarinWatchOSExtension
+		return ProjectTypeWatchExtension, nil
+	default:
+		return ProjectTypeUnknown, fmt.Errorf("Not an embedded project guid: %s", guid)
+	}
+}
+
+// ParseBindingProjectTypeGUID - identifies the binding project type guids
+func ParseBindingProjectTypeGUID(guid string) (ProjectType, error) {
+	switch guid {
+	case "8FFB629D-F513-41CE-95D2-7ECE97B6EEEC", // XamarinIOSBinding
+		"F5B4F3BC-B597-4E2B-B552-EF5D8A32436F": // MonoTouchBinding
+		return ProjectTypeiOSBinding, nil
+	case "10368E6C-D01B-4462-8E8B-01FC667A7035": // XamarinAndroidBinding
+		return ProjectTypeAndroidBinding, nil
+	default:
+		return ProjectTypeUnknown, fmt.Errorf("Not a binding project guid: %s", guid)
+	}
+}
+
+var (
+	netTargetFrameworkPattern    = regexp.MustCompile(`^net\d+\.\d+-(?P<platform>android|ios|tvos|macos|maccatalyst)(\d+(\.\d+)*)?$`)
+	legacyTargetFrameworkPattern = regexp.MustCompile(`^(?P<platform>monoandroid|xamarin\.?ios|xamarin\.?tvos|xamarin\.?mac)\d*$`)
+)
+
+// ParseTargetFramework - parses SDK-style (net6.0-android, net7.0-ios, ...)
+// and legacy Xamarin (monoandroid90, xamarinios10, ...) target framework monikers
+func ParseTargetFramework(targetFramework string) (SDK, error) {
+	tfm := strings.ToLower(strings.TrimSpace(targetFramework))
+
+	platform := ""
+	if matches := netTargetFrameworkPattern.FindStringSubmatch(tfm); len(matches) > 1 {
+		platform = matches[1]
+	} else if matches := legacyTargetFrameworkPattern.FindStringSubmatch(tfm); len(matches) > 1 {
+		platform = strings.Replace(matches[1], ".", "", -1)
+	}
+
+	switch platform {
+	case "android", "monoandroid":
+		return SDKAndroid, nil
+	case "ios", "xamarinios":
+		return SDKIOS, nil
+	case "tvos", "xamarintvos":
+		return SDKTvOS, nil
+	case "macos", "maccatalyst", "xamarinmac":
+		return SDKMacOS, nil
+	default:
+		return SDKUnknown, fmt.Errorf("Can not identify target framework: %s", targetFramework)
+	}
+}
+
 // OutputType ...
 type OutputType string
 
@@ -115,6 +239,10 @@ const (
 	OutputTypeUnknown OutputType = "unknown"
 	// OutputTypeAPK ...
 	OutputTypeAPK OutputType = "apk"
+	// OutputTypeAAB - Android App Bundle, the publishing format of Google Play
+	OutputTypeAAB OutputType = "aab"
+	// OutputTypeAPKs - device specific apk set generated by bundletool from an aab
+	OutputTypeAPKs OutputType = "apks"
 	// OutputTypeXCArchive ...
 	OutputTypeXCArchive OutputType = "xcarchive"
 	// OutputTypeIPA ...
@@ -125,8 +253,18 @@ const (
 	OutputTypePKG OutputType = "pkg"
 	// OutputTypeAPP ...
 	OutputTypeAPP OutputType = "app"
+	// OutputTypeSimulatorAPP - .app bundle of an iOS or tvOS simulator build, installable into a simulator
+	OutputTypeSimulatorAPP OutputType = "simulator-app"
 	// OutputTypeDLL ...
 	OutputTypeDLL OutputType = "dll"
+	// OutputTypeTestDLL - test assembly of a Xamarin.UITest or unit test project
+	OutputTypeTestDLL OutputType = "test-dll"
+	// OutputTypeNuPkg - NuGet package of a library or binding project
+	OutputTypeNuPkg OutputType = "nupkg"
+	// OutputTypeMSYM - Xamarin.Android symbol archive (.mSYM directory) for crash reporting
+	OutputTypeMSYM OutputType = "msym"
+	// OutputTypeSymbols - zip of the .pdb and .mdb debug symbol files of the built assemblies
+	OutputTypeSymbols OutputType = "symbols"
 )
 
 // ParseOutputType ...
@@ -134,6 +272,10 @@ func ParseOutputType(outputType string) (OutputType, error) {
 	switch outputType {
 	case "apk":
 		return OutputTypeAPK, nil
+	case "aab":
+		return OutputTypeAAB, nil
+	case "apks":
+		return OutputTypeAPKs, nil
 	case "xcarchive":
 		return OutputTypeXCArchive, nil
 	case "ipa":
@@ -144,8 +286,18 @@ func ParseOutputType(outputType string) (OutputType, error) {
 		return OutputTypePKG, nil
 	case "app":
 		return OutputTypeAPP, nil
+	case "simulator-app":
+		return OutputTypeSimulatorAPP, nil
 	case "dll":
 		return OutputTypeDLL, nil
+	case "test-dll":
+		return OutputTypeTestDLL, nil
+	case "nupkg":
+		return OutputTypeNuPkg, nil
+	case "msym":
+		return OutputTypeMSYM, nil
+	case "symbols":
+		return OutputTypeSymbols, nil
 	default:
 		return OutputTypeUnknown, fmt.Errorf("invalid output type: %s", outputType)
 	}
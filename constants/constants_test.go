@@ -65,6 +65,20 @@ func TestParseTestFramwork(t *testing.T) {
 		require.Equal(t, TestFrameworkNunitLiteTest, projectType)
 	}
 
+	t.Log("it parses xunit-test")
+	{
+		projectType, err := ParseTestFramwork("xunit-test")
+		require.NoError(t, err)
+		require.Equal(t, TestFrameworkXunitTest, projectType)
+	}
+
+	t.Log("it parses mstest-test")
+	{
+		projectType, err := ParseTestFramwork("mstest-test")
+		require.NoError(t, err)
+		require.Equal(t, TestFrameworkMSTest, projectType)
+	}
+
 	t.Log("it failes for unknown type")
 	{
 		projectType, err := ParseTestFramwork("go")
@@ -73,6 +87,95 @@ func TestParseTestFramwork(t *testing.T) {
 	}
 }
 
+func TestParseProjectType(t *testing.T) {
+	t.Log("it parses shared project type")
+	{
+		projectType, err := ParseProjectType("shared")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeShared, projectType)
+	}
+
+	t.Log("it parses xamarin-uitest project type")
+	{
+		projectType, err := ParseProjectType("xamarin-uitest")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeXamarinUITest, projectType)
+	}
+
+	t.Log("it parses unit-test project type")
+	{
+		projectType, err := ParseProjectType("unit-test")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeUnitTest, projectType)
+	}
+
+	t.Log("it parses binding and library project types")
+	{
+		projectType, err := ParseProjectType("ios-binding")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeiOSBinding, projectType)
+
+		projectType, err = ParseProjectType("android-binding")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeAndroidBinding, projectType)
+
+		projectType, err = ParseProjectType("library")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeLibrary, projectType)
+	}
+
+	t.Log("it failes for unknown project type")
+	{
+		projectType, err := ParseProjectType("go")
+		require.Error(t, err)
+		require.Equal(t, ProjectTypeUnknown, projectType)
+	}
+}
+
+func TestParseEmbeddedProjectTypeGUID(t *testing.T) {
+	t.Log("it parses app extension and watch GUIDs")
+	{
+		projectType, err := ParseEmbeddedProjectTypeGUID("EE2C853D-36AF-4FDB-B1AD-8E90477E2198")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeAppExtension, projectType)
+
+		projectType, err = ParseEmbeddedProjectTypeGUID("FC940695-DFE0-4552-9F25-99AF4A5619A1")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeWatchApp, projectType)
+
+		projectType, err = ParseEmbeddedProjectTypeGUID("1E2E965C-F6D2-49ED-B86E-418A60C69EEF")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeWatchExtension, projectType)
+	}
+
+	t.Log("it returns error for other GUIDs")
+	{
+		projectType, err := ParseEmbeddedProjectTypeGUID("FEACFBD2-3405-455C-9665-78FE426C6842")
+		require.Error(t, err)
+		require.Equal(t, ProjectTypeUnknown, projectType)
+	}
+}
+
+func TestParseBindingProjectTypeGUID(t *testing.T) {
+	t.Log("it parses binding GUIDs")
+	{
+		projectType, err := ParseBindingProjectTypeGUID("8FFB629D-F513-41CE-95D2-7ECE97B6EEEC")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeiOSBinding, projectType)
+
+		projectType, err = ParseBindingProjectTypeGUID("10368E6C-D01B-4462-8E8B-01FC667A7035")
+		require.NoError(t, err)
+		require.Equal(t, ProjectTypeAndroidBinding, projectType)
+	}
+
+	t.Log("it returns error for other GUIDs")
+	{
+		projectType, err := ParseBindingProjectTypeGUID("EFBA0AD7-5A72-4C68-AF49-83D382785DCF")
+		require.Error(t, err)
+		require.Equal(t, ProjectTypeUnknown, projectType)
+	}
+}
+
 func TestParseProjectTypeGUID(t *testing.T) {
 	t.Log("it parses XamarinAndroid GUID")
 	{
@@ -96,6 +199,8 @@ func TestParseProjectTypeGUID(t *testing.T) {
 			"FEACFBD2-3405-455C-9665-78FE426C6842",
 			"8FFB629D-F513-41CE-95D2-7ECE97B6EEEC",
 			"EE2C853D-36AF-4FDB-B1AD-8E90477E2198",
+			"FC940695-DFE0-4552-9F25-99AF4A5619A1",
+			"1E2E965C-F6D2-49ED-B86E-418A60C69EEF",
 		}
 		for _, guid := range xamarinIOSGUIDs {
 			projectType, err := ParseProjectTypeGUID(guid)
@@ -147,6 +252,54 @@ func TestParseProjectTypeGUID(t *testing.T) {
 	}
 }
 
+func TestParseTargetFramework(t *testing.T) {
+	t.Log("it parses SDK-style target frameworks")
+	{
+		tfmSDKMap := map[string]SDK{
+			"net6.0-android":         SDKAndroid,
+			"net7.0-android33.0":     SDKAndroid,
+			"net6.0-ios":             SDKIOS,
+			"NET7.0-iOS16.1":         SDKIOS,
+			"net6.0-tvos":            SDKTvOS,
+			"net6.0-macos":           SDKMacOS,
+			"net7.0-maccatalyst":     SDKMacOS,
+			"net8.0-maccatalyst17.0": SDKMacOS,
+		}
+		for tfm, expectedSDK := range tfmSDKMap {
+			sdk, err := ParseTargetFramework(tfm)
+			require.NoError(t, err, tfm)
+			require.Equal(t, expectedSDK, sdk, tfm)
+		}
+	}
+
+	t.Log("it parses legacy Xamarin target frameworks")
+	{
+		tfmSDKMap := map[string]SDK{
+			"monoandroid90": SDKAndroid,
+			"MonoAndroid10": SDKAndroid,
+			"xamarinios10":  SDKIOS,
+			"xamarin.ios10": SDKIOS,
+			"xamarintvos10": SDKTvOS,
+			"xamarinmac20":  SDKMacOS,
+			"xamarin.mac20": SDKMacOS,
+		}
+		for tfm, expectedSDK := range tfmSDKMap {
+			sdk, err := ParseTargetFramework(tfm)
+			require.NoError(t, err, tfm)
+			require.Equal(t, expectedSDK, sdk, tfm)
+		}
+	}
+
+	t.Log("it fails for platform independent target frameworks")
+	{
+		for _, tfm := range []string{"net6.0", "netstandard2.0", "net48", "net6.0-windows"} {
+			sdk, err := ParseTargetFramework(tfm)
+			require.Error(t, err, tfm)
+			require.Equal(t, SDKUnknown, sdk, tfm)
+		}
+	}
+}
+
 func TestParseOutputType(t *testing.T) {
 	t.Log("it parses apk")
 	{
@@ -197,6 +350,52 @@ func TestParseOutputType(t *testing.T) {
 		require.Equal(t, OutputTypeDLL, outputType)
 	}
 
+	t.Log("it parses test-dll")
+	{
+		outputType, err := ParseOutputType("test-dll")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeTestDLL, outputType)
+	}
+
+	t.Log("it parses nupkg")
+	{
+		outputType, err := ParseOutputType("nupkg")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeNuPkg, outputType)
+	}
+
+	t.Log("it parses msym")
+	{
+		outputType, err := ParseOutputType("msym")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeMSYM, outputType)
+	}
+
+	t.Log("it parses symbols")
+	{
+		outputType, err := ParseOutputType("symbols")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeSymbols, outputType)
+	}
+
+	t.Log("it parses aab and apks")
+	{
+		outputType, err := ParseOutputType("aab")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeAAB, outputType)
+
+		outputType, err = ParseOutputType("apks")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeAPKs, outputType)
+	}
+
+	t.Log("it parses simulator-app")
+	{
+		outputType, err := ParseOutputType("simulator-app")
+		require.NoError(t, err)
+		require.Equal(t, OutputTypeSimulatorAPP, outputType)
+	}
+
 	t.Log("it failes for unknown type")
 	{
 		outputType, err := ParseOutputType("zip")
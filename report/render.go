@@ -0,0 +1,216 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+func (report Model) title() string {
+	status := "succeeded"
+	if !report.Result.Succeeded() {
+		status = "failed"
+	}
+	if report.Result.Solution == "" {
+		return "Build " + status
+	}
+	return fmt.Sprintf("Build of %s %s", report.Result.Solution, status)
+}
+
+func (report Model) config() string {
+	if report.Result.Configuration == "" {
+		return ""
+	}
+	return report.Result.Configuration + "|" + report.Result.Platform
+}
+
+// warnings returns the solution warnings, then the project warnings
+func (report Model) warnings() []string {
+	warnings := append([]string{}, report.Result.Warnings...)
+	for _, project := range report.Result.Projects {
+		warnings = append(warnings, project.Warnings...)
+	}
+	return warnings
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return duration.Round(time.Millisecond).String()
+	}
+	return duration.Round(time.Second).String()
+}
+
+// formatSize returns the size in binary units, like: 12.3 MB
+func formatSize(size int64) string {
+	if size < 0 {
+		return "-"
+	}
+
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit && exp < 3; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+// markdownCell escapes the table cell separators and line breaks
+func markdownCell(text string) string {
+	text = strings.Replace(text, "|", `\|`, -1)
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// Markdown - the report as GitHub flavored Markdown, like for PR comments
+func (report Model) Markdown() string {
+	result := report.Result
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "## %s\n\n", report.title())
+
+	if config := report.config(); config != "" {
+		fmt.Fprintf(&buffer, "Configuration: `%s`, ", config)
+	}
+	fmt.Fprintf(&buffer, "duration: %s\n", formatDuration(result.Duration))
+
+	if result.Error != "" {
+		fmt.Fprintf(&buffer, "\n### Error\n\n```\n%s\n```\n", result.Error)
+		if len(result.ErrorOutput) > 0 {
+			fmt.Fprintf(&buffer, "\nLast lines of the output:\n\n```\n%s\n```\n", strings.Join(result.ErrorOutput, "\n"))
+		}
+	}
+
+	if len(result.Projects) > 0 {
+		buffer.WriteString("\n### Projects\n\n")
+		buffer.WriteString("| Project | Status | Duration | Warnings |\n")
+		buffer.WriteString("| --- | --- | --- | --- |\n")
+		for _, project := range result.Projects {
+			fmt.Fprintf(&buffer, "| %s | %s | %s | %d |\n", markdownCell(project.Name), project.Status, formatDuration(project.Duration), len(project.Warnings))
+		}
+	}
+
+	if warnings := report.warnings(); len(warnings) > 0 {
+		buffer.WriteString("\n### Warnings\n\n")
+		for _, warning := range warnings {
+			fmt.Fprintf(&buffer, "- %s\n", strings.Join(strings.Fields(warning), " "))
+		}
+	}
+
+	if len(report.Artifacts) > 0 {
+		buffer.WriteString("\n### Artifacts\n\n")
+		buffer.WriteString("| Project | Type | File | Size |\n")
+		buffer.WriteString("| --- | --- | --- | ---: |\n")
+		for _, artifact := range report.Artifacts {
+			fmt.Fprintf(&buffer, "| %s | %s | `%s` | %s |\n", markdownCell(artifact.Project), artifact.OutputType, markdownCell(filepath.Base(artifact.Pth)), formatSize(artifact.Size))
+		}
+	}
+
+	return buffer.String()
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": formatDuration,
+	"size":     formatSize,
+	"base":     filepath.Base,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+.failed { color: #c00; }
+.succeeded { color: #080; }
+</style>
+</head>
+<body>
+<h2>{{.Title}}</h2>
+<p>{{if .Config}}Configuration: <code>{{.Config}}</code>, {{end}}duration: {{duration .Result.Duration}}</p>
+{{- if .Result.Error}}
+<h3>Error</h3>
+<pre>{{.Result.Error}}</pre>
+{{- if .Result.ErrorOutput}}
+<p>Last lines of the output:</p>
+<pre>{{range .Result.ErrorOutput}}{{.}}
+{{end}}</pre>
+{{- end}}
+{{- end}}
+{{- if .Result.Projects}}
+<h3>Projects</h3>
+<table>
+<tr><th>Project</th><th>Status</th><th>Duration</th><th>Warnings</th></tr>
+{{- range .Result.Projects}}
+<tr><td>{{.Name}}</td><td class="{{.Status}}">{{.Status}}</td><td>{{duration .Duration}}</td><td>{{len .Warnings}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Warnings}}
+<h3>Warnings</h3>
+<ul>
+{{- range .Warnings}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Artifacts}}
+<h3>Artifacts</h3>
+<table>
+<tr><th>Project</th><th>Type</th><th>File</th><th>Size</th></tr>
+{{- range .Artifacts}}
+<tr><td>{{.Project}}</td><td>{{.OutputType}}</td><td><code>{{base .Pth}}</code></td><td>{{size .Size}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML - the report as a standalone HTML page
+func (report Model) HTML() (string, error) {
+	data := struct {
+		Title     string
+		Config    string
+		Result    BuildResultModel
+		Warnings  []string
+		Artifacts []ArtifactModel
+	}{
+		Title:     report.title(),
+		Config:    report.config(),
+		Result:    report.Result,
+		Warnings:  report.warnings(),
+		Artifacts: report.Artifacts,
+	}
+
+	var buffer bytes.Buffer
+	if err := htmlTemplate.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("failed to render html report, error: %s", err)
+	}
+	return buffer.String(), nil
+}
+
+// Write - writes the report to the path, as HTML if the extension is .html, as Markdown otherwise
+func (report Model) Write(pth string) error {
+	content := report.Markdown()
+	if ext := strings.ToLower(filepath.Ext(pth)); ext == ".html" || ext == ".htm" {
+		var err error
+		if content, err = report.HTML(); err != nil {
+			return err
+		}
+	}
+
+	if err := fileutil.WriteStringToFile(pth, content); err != nil {
+		return fmt.Errorf("failed to write report (%s), error: %s", pth, err)
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+)
+
+// Status - the build status of a project
+type Status string
+
+const (
+	// StatusSucceeded ...
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed ...
+	StatusFailed Status = "failed"
+	// StatusSkipped - all of the project's commands were already performed by an earlier project
+	StatusSkipped Status = "skipped"
+)
+
+// ProjectResultModel - the build result of a project
+type ProjectResultModel struct {
+	Name     string
+	Status   Status
+	Duration time.Duration
+	Warnings []string
+}
+
+// BuildResultModel - the result of a build, like the builder's BuildAllProjects
+type BuildResultModel struct {
+	Solution      string
+	Configuration string
+	Platform      string
+
+	Projects    []ProjectResultModel
+	Warnings    []string // warnings which do not refer to a single project
+	Error       string
+	ErrorOutput []string // the last lines of the failed command's output
+	Duration    time.Duration
+}
+
+// Succeeded ...
+func (result BuildResultModel) Succeeded() bool {
+	if result.Error != "" {
+		return false
+	}
+	for _, project := range result.Projects {
+		if project.Status == StatusFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// Recorder - records the BuildResultModel from the builder's progress, like:
+// buildHandler.SetProgressCallback(recorder.Progress)
+type Recorder struct {
+	mutex   sync.Mutex
+	result  BuildResultModel
+	start   time.Time
+	started map[string]time.Time // project name - start of its running command
+}
+
+// NewRecorder - the build duration is measured from now
+func NewRecorder(solution, configuration, platform string) *Recorder {
+	return &Recorder{
+		result: BuildResultModel{
+			Solution:      solution,
+			Configuration: configuration,
+			Platform:      platform,
+			Projects:      []ProjectResultModel{},
+			Warnings:      []string{},
+		},
+		start:   time.Now(),
+		started: map[string]time.Time{},
+	}
+}
+
+func (recorder *Recorder) project(name string) *ProjectResultModel {
+	for i := range recorder.result.Projects {
+		if recorder.result.Projects[i].Name == name {
+			return &recorder.result.Projects[i]
+		}
+	}
+	recorder.result.Projects = append(recorder.result.Projects, ProjectResultModel{Name: name, Status: StatusSkipped, Warnings: []string{}})
+	return &recorder.result.Projects[len(recorder.result.Projects)-1]
+}
+
+// Progress - the builder's ProgressCallback, the durations of the project's commands are summed
+func (recorder *Recorder) Progress(progress builder.ProgressModel) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	project := recorder.project(progress.Project)
+
+	switch progress.Phase {
+	case builder.ProgressPhaseStarted:
+		recorder.started[progress.Project] = time.Now()
+	case builder.ProgressPhaseFinished:
+		if start, ok := recorder.started[progress.Project]; ok {
+			project.Duration += time.Since(start)
+			delete(recorder.started, progress.Project)
+		}
+		if project.Status != StatusFailed {
+			project.Status = StatusSucceeded
+		}
+	}
+}
+
+// Result - the recorded result, the warnings and error are returned by the build.
+// The project of a not finished command is failed, the warnings mentioning a project, like: (Sample.iOS), are listed at the project.
+func (recorder *Recorder) Result(warnings []string, err error) BuildResultModel {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	for name, start := range recorder.started {
+		project := recorder.project(name)
+		project.Duration += time.Since(start)
+		project.Status = StatusFailed
+	}
+	recorder.started = map[string]time.Time{}
+
+	var buildFailedErr *builder.BuildFailedError
+	isBuildFailedErr := errors.As(err, &buildFailedErr)
+	if isBuildFailedErr && buildFailedErr.Project != "" {
+		recorder.project(buildFailedErr.Project).Status = StatusFailed
+	}
+
+	result := recorder.result
+	result.Projects = append([]ProjectResultModel{}, recorder.result.Projects...)
+	result.Warnings = []string{}
+	for _, warning := range warnings {
+		if idx := projectOfWarning(result.Projects, warning); idx >= 0 {
+			result.Projects[idx].Warnings = append(append([]string{}, result.Projects[idx].Warnings...), warning)
+		} else {
+			result.Warnings = append(result.Warnings, warning)
+		}
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if isBuildFailedErr {
+		result.ErrorOutput = buildFailedErr.OutputTail
+	}
+	result.Duration = time.Since(recorder.start)
+
+	return result
+}
+
+func projectOfWarning(projects []ProjectResultModel, warning string) int {
+	for i, project := range projects {
+		if strings.Contains(warning, "("+project.Name+")") {
+			return i
+		}
+	}
+	return -1
+}
+
+// ArtifactModel - an output of the build
+type ArtifactModel struct {
+	Project    string
+	OutputType constants.OutputType
+	Pth        string
+	Size       int64 // the size of the bundles' (like .app) files summed, -1 if the size is not available
+}
+
+// Artifacts - the outputs of the map, ordered by the project name
+func Artifacts(outputMap builder.ProjectOutputMap) []ArtifactModel {
+	projectNames := []string{}
+	for projectName := range outputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	artifacts := []ArtifactModel{}
+	for _, projectName := range projectNames {
+		for _, output := range outputMap[projectName].Outputs {
+			artifacts = append(artifacts, ArtifactModel{
+				Project:    projectName,
+				OutputType: output.OutputType,
+				Pth:        output.Pth,
+				Size:       artifactSize(output.Pth),
+			})
+		}
+	}
+	return artifacts
+}
+
+func artifactSize(pth string) int64 {
+	info, err := os.Stat(pth)
+	if err != nil {
+		return -1
+	}
+	if !info.IsDir() {
+		return info.Size()
+	}
+
+	var size int64
+	if err := filepath.Walk(pth, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	}); err != nil {
+		return -1
+	}
+	return size
+}
+
+// Model - the build report
+type Model struct {
+	Result    BuildResultModel
+	Artifacts []ArtifactModel
+}
+
+// New ...
+func New(result BuildResultModel, outputMap builder.ProjectOutputMap) Model {
+	return Model{
+		Result:    result,
+		Artifacts: Artifacts(outputMap),
+	}
+}
@@ -0,0 +1,116 @@
+package report
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Log("it records the project statuses from the progress")
+	{
+		recorder := NewRecorder("Sample", "Release", "iPhone")
+		recorder.Progress(builder.ProgressModel{Step: 1, Total: 3, Project: "Sample.iOS", Phase: builder.ProgressPhaseStarted})
+		recorder.Progress(builder.ProgressModel{Step: 1, Total: 3, Project: "Sample.iOS", Phase: builder.ProgressPhaseFinished})
+		recorder.Progress(builder.ProgressModel{Step: 2, Total: 3, Project: "Sample.Lib", Phase: builder.ProgressPhaseSkipped})
+		recorder.Progress(builder.ProgressModel{Step: 3, Total: 3, Project: "Sample.Droid", Phase: builder.ProgressPhaseStarted})
+
+		warnings := []string{"project (Sample.iOS) has no archive config", "no nunit console found"}
+		result := recorder.Result(warnings, errors.New("exit status 1"))
+
+		require.Equal(t, "Sample", result.Solution)
+		require.Equal(t, false, result.Succeeded())
+		require.Equal(t, "exit status 1", result.Error)
+		require.Equal(t, []string{"no nunit console found"}, result.Warnings)
+
+		require.Equal(t, 3, len(result.Projects))
+		require.Equal(t, StatusSucceeded, result.Projects[0].Status)
+		require.Equal(t, []string{"project (Sample.iOS) has no archive config"}, result.Projects[0].Warnings)
+		require.Equal(t, StatusSkipped, result.Projects[1].Status)
+		require.Equal(t, StatusFailed, result.Projects[2].Status)
+	}
+
+	t.Log("it fails the project of the build failed error")
+	{
+		recorder := NewRecorder("Sample", "Release", "iPhone")
+		recorder.Progress(builder.ProgressModel{Step: 1, Total: 1, Project: "Sample.iOS", Phase: builder.ProgressPhaseSkipped})
+
+		result := recorder.Result(nil, &builder.BuildFailedError{Project: "Sample.iOS", ExitCode: 1, OutputTail: []string{"error CS1002: ; expected"}, Err: errors.New("exit status 1")})
+		require.Equal(t, StatusFailed, result.Projects[0].Status)
+		require.Equal(t, []string{"error CS1002: ; expected"}, result.ErrorOutput)
+		require.True(t, strings.Contains(Model{Result: result}.Markdown(), "Last lines of the output:\n\n```\nerror CS1002: ; expected\n```\n"))
+	}
+}
+
+func TestArtifacts(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("report_test")
+	require.NoError(t, err)
+
+	ipaPth := filepath.Join(tmpDir, "Sample.iOS.ipa")
+	require.NoError(t, fileutil.WriteStringToFile(ipaPth, strings.Repeat("a", 2048)))
+	appPth := filepath.Join(tmpDir, "Sample.Mac.app")
+	require.NoError(t, os.MkdirAll(filepath.Join(appPth, "Contents", "MacOS"), 0755))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(appPth, "Contents", "Info.plist"), "info"))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(appPth, "Contents", "MacOS", "Sample"), "binary"))
+
+	outputMap := builder.ProjectOutputMap{
+		"Sample.iOS": builder.ProjectOutputModel{Outputs: []builder.OutputModel{{Pth: ipaPth, OutputType: constants.OutputTypeIPA}}},
+		"Sample.Mac": builder.ProjectOutputModel{Outputs: []builder.OutputModel{{Pth: appPth, OutputType: constants.OutputTypeAPP}}},
+		"Sample.TV":  builder.ProjectOutputModel{Outputs: []builder.OutputModel{{Pth: filepath.Join(tmpDir, "missing.ipa"), OutputType: constants.OutputTypeIPA}}},
+	}
+
+	require.Equal(t, []ArtifactModel{
+		{Project: "Sample.Mac", OutputType: constants.OutputTypeAPP, Pth: appPth, Size: 10},
+		{Project: "Sample.TV", OutputType: constants.OutputTypeIPA, Pth: filepath.Join(tmpDir, "missing.ipa"), Size: -1},
+		{Project: "Sample.iOS", OutputType: constants.OutputTypeIPA, Pth: ipaPth, Size: 2048},
+	}, Artifacts(outputMap))
+}
+
+func TestMarkdown(t *testing.T) {
+	report := Model{
+		Result: BuildResultModel{
+			Solution:      "Sample",
+			Configuration: "Release",
+			Platform:      "iPhone",
+			Projects: []ProjectResultModel{
+				{Name: "Sample.iOS", Status: StatusSucceeded, Duration: 83 * time.Second, Warnings: []string{"project (Sample.iOS) has no archive config"}},
+			},
+			Duration: 90 * time.Second,
+		},
+		Artifacts: []ArtifactModel{{Project: "Sample.iOS", OutputType: constants.OutputTypeIPA, Pth: "/tmp/Sample|iOS.ipa", Size: 12900000}},
+	}
+
+	require.Equal(t, "## Build of Sample succeeded\n\n"+
+		"Configuration: `Release|iPhone`, duration: 1m30s\n\n"+
+		"### Projects\n\n"+
+		"| Project | Status | Duration | Warnings |\n"+
+		"| --- | --- | --- | --- |\n"+
+		"| Sample.iOS | succeeded | 1m23s | 1 |\n\n"+
+		"### Warnings\n\n"+
+		"- project (Sample.iOS) has no archive config\n\n"+
+		"### Artifacts\n\n"+
+		"| Project | Type | File | Size |\n"+
+		"| --- | --- | --- | ---: |\n"+
+		"| Sample.iOS | ipa | `Sample\\|iOS.ipa` | 12.3 MB |\n", report.Markdown())
+
+	html, err := report.HTML()
+	require.NoError(t, err)
+	require.True(t, strings.Contains(html, "<h2>Build of Sample succeeded</h2>"))
+	require.True(t, strings.Contains(html, "<td>12.3 MB</td>"))
+}
+
+func TestFormatSize(t *testing.T) {
+	require.Equal(t, "-", formatSize(-1))
+	require.Equal(t, "512 B", formatSize(512))
+	require.Equal(t, "2.0 KB", formatSize(2048))
+	require.Equal(t, "1.5 GB", formatSize(3*512*1024*1024))
+}
@@ -0,0 +1,191 @@
+package testrunner
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools/androidsdk"
+	"github.com/brandonrisell/go-xamarin/tools/emulator"
+	"github.com/brandonrisell/go-xamarin/tools/instrumentation"
+)
+
+// androidEmulatorBootTimeout - time to wait for the emulator to boot
+const androidEmulatorBootTimeout = 10 * time.Minute
+
+// androidEmulatorShutdownTimeout - time to wait for the emulator to exit before killing it
+const androidEmulatorShutdownTimeout = 30 * time.Second
+
+// SetAndroidDevice - the Xamarin.Android NUnitLite test apps are installed and run on the emulator or device by adb,
+// the only connected one is used if the serial is empty
+func (runner *Model) SetAndroidDevice(adbPth, serial string) *Model {
+	runner.adbPth = adbPth
+	runner.androidSerial = serial
+	runner.androidAVD = ""
+	return runner
+}
+
+// SetAndroidEmulator - the Xamarin.Android NUnitLite test apps are run on a headless emulator of the AVD,
+// the emulator is started after the build and shut down after the tests
+func (runner *Model) SetAndroidEmulator(sdk androidsdk.Model, avd string) *Model {
+	runner.adbPth = sdk.ADBPth()
+	runner.androidSerial = ""
+	runner.androidSDK = sdk
+	runner.androidAVD = avd
+	return runner
+}
+
+// startAndroidEmulator starts the emulator of the AVD and waits for it to boot
+func (runner Model) startAndroidEmulator() (*emulator.Model, error) {
+	log.Printf("Starting emulator (%s)...", runner.androidAVD)
+
+	androidEmulator, err := emulator.Start(runner.androidSDK, runner.androidAVD, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := androidEmulator.WaitForBoot(androidEmulatorBootTimeout); err != nil {
+		if killErr := androidEmulator.Kill(androidEmulatorShutdownTimeout); killErr != nil {
+			log.Warnf("%s", killErr)
+		}
+		return nil, err
+	}
+
+	return androidEmulator, nil
+}
+
+// BuildAndRunAndroidTests - builds the Xamarin.Android NUnitLite test apps, runs their instrumentation
+// on the emulator or device and collects the results and the logcat output
+func (runner Model) BuildAndRunAndroidTests(configuration, platform string, callback builder.BuildCommandCallback) (ProjectResultMap, []string, error) {
+	if runner.adbPth == "" {
+		return nil, nil, fmt.Errorf("no adb set to run the Android tests")
+	}
+
+	testProjectOutputMap, warnings, err := runner.builder.BuildAndroidTestApps(configuration, platform, nil, callback)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	resultDir, err := runner.ensureResultDir()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	if runner.androidAVD != "" {
+		androidEmulator, err := runner.startAndroidEmulator()
+		if err != nil {
+			return nil, warnings, err
+		}
+		defer func() {
+			if err := androidEmulator.Kill(androidEmulatorShutdownTimeout); err != nil {
+				log.Warnf("%s", err)
+			}
+		}()
+		runner.androidSerial = androidEmulator.Serial
+	}
+
+	projectNames := []string{}
+	for projectName := range testProjectOutputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	projectResultMap := ProjectResultMap{}
+	for _, projectName := range projectNames {
+		projectResult, err := runner.runAndroidTests(projectName, testProjectOutputMap[projectName].Output.Pth, resultDir, callback)
+		if err != nil {
+			return projectResultMap, warnings, err
+		}
+		projectResultMap[projectName] = projectResult
+	}
+
+	return projectResultMap, warnings, nil
+}
+
+// testInstrumentation returns the instrumentation declared by the test apk, like: com.sample.tests/app.tests.TestInstrumentation
+func testInstrumentation(metadata builder.APKMetadataModel) (string, error) {
+	if len(metadata.Instrumentations) == 0 {
+		return "", fmt.Errorf("no instrumentation declared in (%s)", metadata.PackageName)
+	}
+
+	className := metadata.Instrumentations[0]
+	if strings.HasPrefix(className, ".") {
+		className = metadata.PackageName + className
+	}
+	return metadata.PackageName + "/" + className, nil
+}
+
+func (runner Model) runAndroidTests(projectName, apkPth, resultDir string, callback builder.BuildCommandCallback) (ProjectResultModel, error) {
+	metadata, err := builder.ReadAPKMetadata(apkPth)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	instrumentationName, err := testInstrumentation(metadata)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	command, err := instrumentation.New(runner.adbPth, runner.androidSerial, apkPth, instrumentationName)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+	command.SetTimeout(runner.timeout)
+
+	// Callback to notify the caller about next running command
+	if callback != nil {
+		callback("", projectName, constants.SDKAndroid, constants.TestFrameworkNunitLiteTest, command.PrintableCommand(), false)
+	}
+
+	runErr := command.Run()
+
+	projectResult := ProjectResultModel{
+		ProjectName:   projectName,
+		TestFramework: constants.TestFrameworkNunitLiteTest,
+		AssemblyPth:   apkPth,
+		ResultPth:     filepath.Join(resultDir, projectName+".xml"),
+		JUnitPth:      filepath.Join(resultDir, projectName+"-junit.xml"),
+		LogcatPth:     filepath.Join(resultDir, projectName+"-logcat.txt"),
+	}
+
+	if err := instrumentation.DumpLogcat(runner.adbPth, runner.androidSerial, projectResult.LogcatPth); err != nil {
+		log.Warnf("Failed to collect logcat of project (%s), error: %s", projectName, err)
+		projectResult.LogcatPth = ""
+	}
+
+	if runErr != nil {
+		return ProjectResultModel{}, fmt.Errorf("Failed to run tests of project (%s), error: %s", projectName, runErr)
+	}
+
+	remoteResultPth := command.Result().Results[instrumentation.ResultKeyNunit2ResultsPath]
+	if remoteResultPth == "" {
+		return ProjectResultModel{}, fmt.Errorf("no test result reported by project (%s)", projectName)
+	}
+
+	output, err := instrumentation.ReadFile(runner.adbPth, runner.androidSerial, metadata.PackageName, remoteResultPth)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	report, err := results.Parse(output)
+	if err != nil {
+		return ProjectResultModel{}, fmt.Errorf("Failed to parse test result of project (%s), error: %s", projectName, err)
+	}
+	projectResult.Result = newResult(report)
+
+	if err := fileutil.WriteBytesToFile(projectResult.ResultPth, output); err != nil {
+		return ProjectResultModel{}, fmt.Errorf("Failed to write test result (%s), error: %s", projectResult.ResultPth, err)
+	}
+	if err := report.WriteJUnit(projectResult.JUnitPth); err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	return projectResult, nil
+}
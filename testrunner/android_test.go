@@ -0,0 +1,30 @@
+package testrunner
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestInstrumentation(t *testing.T) {
+	t.Log("it returns the instrumentation of the test package")
+	{
+		instrumentation, err := testInstrumentation(builder.APKMetadataModel{PackageName: "com.sample.tests", Instrumentations: []string{"app.tests.TestInstrumentation"}})
+		require.NoError(t, err)
+		require.Equal(t, "com.sample.tests/app.tests.TestInstrumentation", instrumentation)
+	}
+
+	t.Log("it expands the relative class name")
+	{
+		instrumentation, err := testInstrumentation(builder.APKMetadataModel{PackageName: "com.sample.tests", Instrumentations: []string{".TestInstrumentation"}})
+		require.NoError(t, err)
+		require.Equal(t, "com.sample.tests/com.sample.tests.TestInstrumentation", instrumentation)
+	}
+
+	t.Log("it fails without instrumentation")
+	{
+		_, err := testInstrumentation(builder.APKMetadataModel{PackageName: "com.sample.tests"})
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,156 @@
+package testrunner
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/tools/buildtools/appcenter"
+	"github.com/brandonrisell/go-xamarin/tools/testcloud"
+)
+
+// CloudService - the device cloud running the Xamarin.UITests
+type CloudService string
+
+const (
+	// CloudServiceAppCenter - App Center Test, by: appcenter test run uitest
+	CloudServiceAppCenter CloudService = "appcenter"
+	// CloudServiceTestCloud - the legacy Xamarin Test Cloud, by: test-cloud.exe submit
+	CloudServiceTestCloud CloudService = "testcloud"
+)
+
+// ParseCloudService ...
+func ParseCloudService(service string) (CloudService, error) {
+	switch service {
+	case "appcenter":
+		return CloudServiceAppCenter, nil
+	case "testcloud":
+		return CloudServiceTestCloud, nil
+	default:
+		return "", fmt.Errorf("unknown cloud service: %s", service)
+	}
+}
+
+// CloudConfigModel - configuration of the device cloud test runs, the fields of the selected service are used
+type CloudConfigModel struct {
+	Service   CloudService
+	Devices   string // App Center device set or selection hash, Test Cloud device selection
+	Series    string
+	Async     bool   // do not wait for the test results
+	ResultDir string // the NUnit results are written into the directory, ignored by async runs
+
+	AppCenterCLIPth string // defaults to the appcenter cli in the PATH
+	AppCenterApp    string // owner/app
+	AppCenterToken  string
+	Locale          string // App Center only, like: en_US
+
+	TestCloudExePth string // test-cloud.exe of the Xamarin.UITest nuget package
+	TestCloudAPIKey string
+	TestCloudUser   string
+}
+
+// CloudTestCommands - creates a cloud test run command for every app tested by the Xamarin.UITest assemblies.
+// Simulator apps can not be tested in the cloud, they are skipped with a warning.
+func CloudTestCommands(artifactMap builder.UITestArtifactMap, config CloudConfigModel) ([]tools.Runnable, []string, error) {
+	if config.Service != CloudServiceAppCenter && config.Service != CloudServiceTestCloud {
+		return nil, nil, fmt.Errorf("unknown cloud service: %s", config.Service)
+	}
+
+	testProjectNames := []string{}
+	for testProjectName := range artifactMap {
+		testProjectNames = append(testProjectNames, testProjectName)
+	}
+	sort.Strings(testProjectNames)
+
+	commands := []tools.Runnable{}
+	warnings := []string{}
+
+	for _, testProjectName := range testProjectNames {
+		artifact := artifactMap[testProjectName]
+
+		for _, app := range artifact.Apps {
+			if app.OutputType != constants.OutputTypeIPA && app.OutputType != constants.OutputTypeAPK {
+				warnings = append(warnings, fmt.Sprintf("%s output (%s) of project (%s) can not be tested in the cloud", app.OutputType, app.Pth, app.ProjectName))
+				continue
+			}
+
+			// the App Center result directory, or the Test Cloud NUnit XML without extension
+			resultPth := ""
+			if config.ResultDir != "" && !config.Async {
+				resultPth = filepath.Join(config.ResultDir, testProjectName+"-"+app.ProjectName)
+			}
+
+			var command tools.Runnable
+			var err error
+			if config.Service == CloudServiceTestCloud {
+				command, err = testCloudCommand(config, artifact, app, resultPth)
+			} else {
+				command, err = appCenterTestCommand(config, artifact, app, resultPth)
+			}
+			if err != nil {
+				return nil, warnings, err
+			}
+
+			commands = append(commands, command)
+		}
+	}
+
+	return commands, warnings, nil
+}
+
+func appCenterTestCommand(config CloudConfigModel, artifact builder.UITestArtifactModel, app builder.UITestAppModel, resultDir string) (tools.Runnable, error) {
+	appcenterPth := config.AppCenterCLIPth
+	if appcenterPth == "" {
+		var err error
+		if appcenterPth, err = appcenter.SystemAppCenterPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	command, err := appcenter.New(appcenterPth, config.AppCenterApp, app.Pth, artifact.DependencyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	command.SetDevices(config.Devices).
+		SetSeries(config.Series).
+		SetLocale(config.Locale).
+		SetAsync(config.Async).
+		SetTestOutputDir(resultDir).
+		SetToken(config.AppCenterToken)
+
+	return command, nil
+}
+
+func testCloudCommand(config CloudConfigModel, artifact builder.UITestArtifactModel, app builder.UITestAppModel, resultPth string) (tools.Runnable, error) {
+	if config.TestCloudExePth == "" || config.TestCloudAPIKey == "" || config.TestCloudUser == "" {
+		return nil, fmt.Errorf("test-cloud.exe path, api key and user are required for Test Cloud")
+	}
+
+	command, err := testcloud.NewModel(config.TestCloudExePth)
+	if err != nil {
+		return nil, err
+	}
+
+	if app.OutputType == constants.OutputTypeIPA {
+		command.SetIPAPth(app.Pth)
+	} else {
+		command.SetAPKPth(app.Pth)
+	}
+
+	command.SetAPIKey(config.TestCloudAPIKey).
+		SetUser(config.TestCloudUser).
+		SetAssemblyDir(artifact.DependencyDir).
+		SetDevices(config.Devices).
+		SetSeries(config.Series).
+		SetIsAsyncJSON(config.Async)
+
+	if resultPth != "" {
+		command.SetNunitXMLPth(resultPth + ".xml")
+	}
+
+	return command, nil
+}
@@ -0,0 +1,70 @@
+package testrunner
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudTestCommands(t *testing.T) {
+	artifactMap := builder.UITestArtifactMap{
+		"UITests": builder.UITestArtifactModel{
+			TestAssemblyPth: "/UITests/bin/Release/UITests.dll",
+			DependencyDir:   "/UITests/bin/Release",
+			Apps: []builder.UITestAppModel{
+				{ProjectName: "iOS", SDK: constants.SDKIOS, Pth: "/bin/iPhoneSimulator/Debug/iOS.app", OutputType: constants.OutputTypeSimulatorAPP},
+				{ProjectName: "Droid", SDK: constants.SDKAndroid, Pth: "/bin/Release/com.bitrise.sample.apk", OutputType: constants.OutputTypeAPK},
+			},
+		},
+	}
+
+	t.Log("it creates App Center test runs")
+	{
+		commands, warnings, err := CloudTestCommands(artifactMap, CloudConfigModel{
+			Service:         CloudServiceAppCenter,
+			Devices:         "bitrise/top-devices",
+			Series:          "master",
+			ResultDir:       "/results",
+			AppCenterCLIPth: "/usr/local/bin/appcenter",
+			AppCenterApp:    "bitrise/Sample-Droid",
+			AppCenterToken:  "api-secret",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"simulator-app output (/bin/iPhoneSimulator/Debug/iOS.app) of project (iOS) can not be tested in the cloud"}, warnings)
+		require.Equal(t, 1, len(commands))
+		require.Equal(t, `"/usr/local/bin/appcenter" "test" "run" "uitest" "--app" "bitrise/Sample-Droid" "--app-path" "/bin/Release/com.bitrise.sample.apk" "--build-dir" "/UITests/bin/Release" "--devices" "bitrise/top-devices" "--test-series" "master" "--test-output-dir" "/results/UITests-Droid" "--token" "***" "--quiet"`, commands[0].PrintableCommand())
+	}
+
+	t.Log("it creates Test Cloud submits")
+	{
+		commands, _, err := CloudTestCommands(artifactMap, CloudConfigModel{
+			Service:         CloudServiceTestCloud,
+			Devices:         "a1b2c3",
+			Series:          "master",
+			ResultDir:       "/results",
+			TestCloudExePth: "/packages/Xamarin.UITest/tools/test-cloud.exe",
+			TestCloudAPIKey: "api-secret",
+			TestCloudUser:   "bot@bitrise.io",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(commands))
+		require.Equal(t, `"`+constants.MonoPath+`" "/packages/Xamarin.UITest/tools/test-cloud.exe" "submit" "/bin/Release/com.bitrise.sample.apk" "***" "--user" "bot@bitrise.io" "--assembly-dir" "/UITests/bin/Release" "--devices" "a1b2c3" "--series" "master" "--nunit-xml" "/results/UITests-Droid.xml"`, commands[0].PrintableCommand())
+	}
+
+	t.Log("it requires the Test Cloud credentials")
+	{
+		_, _, err := CloudTestCommands(artifactMap, CloudConfigModel{Service: CloudServiceTestCloud, TestCloudExePth: "/test-cloud.exe"})
+		require.Error(t, err)
+	}
+
+	t.Log("it fails for unknown service")
+	{
+		_, err := ParseCloudService("firebase")
+		require.Error(t, err)
+
+		_, _, err = CloudTestCommands(artifactMap, CloudConfigModel{})
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,227 @@
+package testrunner
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/coverage"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/tools/altcover"
+	"github.com/brandonrisell/go-xamarin/tools/monocoverage"
+)
+
+// CoverageTool - the tool measuring the code coverage of the test runs
+type CoverageTool string
+
+const (
+	// CoverageToolAltCover - the assemblies are instrumented by AltCover before the test run
+	CoverageToolAltCover CoverageTool = "altcover"
+	// CoverageToolMono - the test runner runs with the coverage of the mono log profiler, Cobertura output only
+	CoverageToolMono CoverageTool = "mono"
+)
+
+// ParseCoverageTool ...
+func ParseCoverageTool(tool string) (CoverageTool, error) {
+	switch tool {
+	case "altcover":
+		return CoverageToolAltCover, nil
+	case "mono":
+		return CoverageToolMono, nil
+	default:
+		return "", fmt.Errorf("unknown coverage tool: %s", tool)
+	}
+}
+
+// CoverageFormat - the format of the coverage report
+type CoverageFormat string
+
+const (
+	// CoverageFormatCobertura ...
+	CoverageFormatCobertura CoverageFormat = "cobertura"
+	// CoverageFormatOpenCover ...
+	CoverageFormatOpenCover CoverageFormat = "opencover"
+)
+
+// ParseCoverageFormat ...
+func ParseCoverageFormat(format string) (CoverageFormat, error) {
+	switch format {
+	case "cobertura":
+		return CoverageFormatCobertura, nil
+	case "opencover":
+		return CoverageFormatOpenCover, nil
+	default:
+		return "", fmt.Errorf("unknown coverage format: %s", format)
+	}
+}
+
+// CoverageConfigModel - configuration of the code coverage, the test assemblies are always excluded
+type CoverageConfigModel struct {
+	Tool   CoverageTool
+	Format CoverageFormat
+
+	Include []string // assembly names, all assemblies are covered if empty
+	Exclude []string // assembly names
+
+	AltCoverPth string // defaults to the AltCover.exe of the ALTCOVER_PATH directory
+}
+
+// SetCoverage - the NUnit and xUnit.net test runs measure the code coverage,
+// the report is written next to the test results
+func (runner *Model) SetCoverage(config CoverageConfigModel) *Model {
+	runner.coverage = &config
+	return runner
+}
+
+// coverageRunModel - the coverage measurement of a test assembly run
+type coverageRunModel struct {
+	config CoverageConfigModel
+
+	testAssemblyPth string // the assembly the tests run against
+	reportPth       string
+
+	assemblyDir     string // AltCover, the built assemblies
+	instrumentedDir string // AltCover
+	openCoverPth    string // AltCover
+
+	mlpdPth         string // mono
+	filterPth       string // mono
+	monoCoveragePth string // mono
+}
+
+func (runner Model) newCoverageRun(projectName, assemblyPth, resultDir string) (*coverageRunModel, error) {
+	if runner.coverage == nil {
+		return nil, nil
+	}
+
+	config := *runner.coverage
+	if config.Format == "" {
+		config.Format = CoverageFormatCobertura
+	}
+	if config.Format != CoverageFormatCobertura && config.Format != CoverageFormatOpenCover {
+		return nil, fmt.Errorf("unknown coverage format: %s", config.Format)
+	}
+
+	testAssemblyName := strings.TrimSuffix(filepath.Base(assemblyPth), filepath.Ext(assemblyPth))
+	config.Exclude = append(append([]string{}, config.Exclude...), testAssemblyName)
+
+	coverageRun := coverageRunModel{
+		config:          config,
+		testAssemblyPth: assemblyPth,
+		reportPth:       filepath.Join(resultDir, projectName+"-coverage.xml"),
+	}
+
+	switch config.Tool {
+	case CoverageToolAltCover:
+		if coverageRun.config.AltCoverPth == "" {
+			altcoverPth, err := altcover.SystemAltCoverPath()
+			if err != nil {
+				return nil, err
+			}
+			coverageRun.config.AltCoverPth = altcoverPth
+		}
+
+		coverageRun.assemblyDir = filepath.Dir(assemblyPth)
+		coverageRun.instrumentedDir = filepath.Join(resultDir, projectName+"-instrumented")
+		coverageRun.testAssemblyPth = filepath.Join(coverageRun.instrumentedDir, filepath.Base(assemblyPth))
+		coverageRun.openCoverPth = coverageRun.reportPth
+		if config.Format == CoverageFormatCobertura {
+			coverageRun.openCoverPth = filepath.Join(resultDir, projectName+"-opencover.xml")
+		}
+	case CoverageToolMono:
+		if config.Format != CoverageFormatCobertura {
+			return nil, fmt.Errorf("%s coverage format is not supported by the mono profiler", config.Format)
+		}
+
+		coverageRun.mlpdPth = filepath.Join(resultDir, projectName+"-coverage.mlpd")
+		coverageRun.filterPth = filepath.Join(resultDir, projectName+"-coverage-filter.txt")
+		coverageRun.monoCoveragePth = filepath.Join(resultDir, projectName+"-coverage-mono.xml")
+	default:
+		return nil, fmt.Errorf("unknown coverage tool: %s", config.Tool)
+	}
+
+	return &coverageRun, nil
+}
+
+// prepareCommands returns the commands to run before the tests
+func (coverageRun coverageRunModel) prepareCommands() ([]tools.Runnable, error) {
+	if coverageRun.config.Tool == CoverageToolMono {
+		return nil, monocoverage.WriteFilterFile(coverageRun.filterPth, coverageRun.config.Include, coverageRun.config.Exclude)
+	}
+
+	command, err := altcover.NewInstrument(coverageRun.config.AltCoverPth, coverageRun.assemblyDir, coverageRun.instrumentedDir, coverageRun.openCoverPth)
+	if err != nil {
+		return nil, err
+	}
+	for _, assembly := range coverageRun.config.Include {
+		command.AddAssemblyFilter(altcover.AssemblyFilter(assembly, true))
+	}
+	for _, assembly := range coverageRun.config.Exclude {
+		command.AddAssemblyFilter(altcover.AssemblyFilter(assembly, false))
+	}
+
+	return []tools.Runnable{command}, nil
+}
+
+// monoOptions returns the options of the mono runtime running the test runner
+func (coverageRun coverageRunModel) monoOptions() []string {
+	if coverageRun.config.Tool != CoverageToolMono {
+		return nil
+	}
+	return []string{monocoverage.ProfilerOption(coverageRun.mlpdPth, coverageRun.filterPth)}
+}
+
+// collectCommands returns the commands to run after the tests
+func (coverageRun coverageRunModel) collectCommands() ([]tools.Runnable, error) {
+	if coverageRun.config.Tool == CoverageToolMono {
+		return []tools.Runnable{monocoverage.New(coverageRun.mlpdPth, coverageRun.monoCoveragePth)}, nil
+	}
+
+	command, err := altcover.NewCollect(coverageRun.config.AltCoverPth, coverageRun.instrumentedDir)
+	if err != nil {
+		return nil, err
+	}
+	if coverageRun.config.Format == CoverageFormatCobertura {
+		command.SetCoberturaPth(coverageRun.reportPth)
+	}
+
+	return []tools.Runnable{command}, nil
+}
+
+// finish converts the mono coverage to Cobertura
+func (coverageRun coverageRunModel) finish() error {
+	if coverageRun.config.Tool != CoverageToolMono {
+		return nil
+	}
+
+	content, err := fileutil.ReadBytesFromFile(coverageRun.monoCoveragePth)
+	if err != nil {
+		return fmt.Errorf("Failed to read mono coverage (%s), error: %s", coverageRun.monoCoveragePth, err)
+	}
+
+	report, err := coverage.ParseMonoCoverage(content)
+	if err != nil {
+		return err
+	}
+	return report.WriteCobertura(coverageRun.reportPth)
+}
+
+func (runner Model) runCoverageCommands(projectName string, testFramework constants.TestFramework, commands []tools.Runnable, output io.Writer, callback builder.BuildCommandCallback) error {
+	for _, command := range commands {
+		redirectOutput(command, output)
+
+		// Callback to notify the caller about next running command
+		if callback != nil {
+			callback("", projectName, constants.SDKUnknown, testFramework, command.PrintableCommand(), false)
+		}
+
+		if err := command.Run(); err != nil {
+			return fmt.Errorf("Failed to measure coverage of project (%s), error: %s", projectName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package testrunner
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverageRun(t *testing.T) {
+	t.Log("it instruments the assemblies by AltCover and converts the report to Cobertura")
+	{
+		runner := New(builder.Model{})
+		runner.SetNunitConsolePth("/nunit/nunit3-console.exe")
+		runner.SetCoverage(CoverageConfigModel{Tool: CoverageToolAltCover, Include: []string{"Sample.Core"}, AltCoverPth: "/altcover/AltCover.exe"})
+
+		coverageRun, err := runner.newCoverageRun("Tests", "/bin/Debug/Tests.dll", "/results")
+		require.NoError(t, err)
+		require.Equal(t, "/results/Tests-coverage.xml", coverageRun.reportPth)
+
+		prepareCommands, err := coverageRun.prepareCommands()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(prepareCommands))
+		require.Equal(t, `"`+constants.MonoPath+`" "/altcover/AltCover.exe" "--inputDirectory=/bin/Debug" "--outputDirectory=/results/Tests-instrumented" "--report=/results/Tests-opencover.xml" "--reportFormat=OpenCover" "--assemblyFilter=?^Sample\\.Core$" "--assemblyFilter=^Tests$" "--save"`, prepareCommands[0].PrintableCommand())
+
+		command, err := runner.testCommand(ProjectResultModel{TestFramework: constants.TestFrameworkNunitTest, AssemblyPth: "/bin/Debug/Tests.dll", ResultPth: "/results/Tests.xml"}, coverageRun)
+		require.NoError(t, err)
+		require.Equal(t, `"`+constants.MonoPath+`" "/nunit/nunit3-console.exe" "/results/Tests-instrumented/Tests.dll" "--result" "/results/Tests.xml"`, command.PrintableCommand())
+
+		collectCommands, err := coverageRun.collectCommands()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(collectCommands))
+		require.Equal(t, `"`+constants.MonoPath+`" "/altcover/AltCover.exe" "runner" "--collect" "--recorderDirectory=/results/Tests-instrumented" "--cobertura=/results/Tests-coverage.xml"`, collectCommands[0].PrintableCommand())
+	}
+
+	t.Log("it runs the tests in process by the mono profiler")
+	{
+		resultDir, err := pathutil.NormalizedOSTempDirPath("coverage_test")
+		require.NoError(t, err)
+
+		runner := New(builder.Model{})
+		runner.SetNunitConsolePth("/nunit/nunit3-console.exe")
+		runner.SetCoverage(CoverageConfigModel{Tool: CoverageToolMono})
+
+		coverageRun, err := runner.newCoverageRun("Tests", "/bin/Debug/Tests.dll", resultDir)
+		require.NoError(t, err)
+
+		prepareCommands, err := coverageRun.prepareCommands()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(prepareCommands))
+		filter, err := fileutil.ReadStringFromFile(filepath.Join(resultDir, "Tests-coverage-filter.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "-[Tests]\n", filter)
+
+		command, err := runner.testCommand(ProjectResultModel{TestFramework: constants.TestFrameworkNunitTest, AssemblyPth: "/bin/Debug/Tests.dll", ResultPth: "/results/Tests.xml"}, coverageRun)
+		require.NoError(t, err)
+		require.Equal(t, `"`+constants.MonoPath+`" "--profile=log:coverage,onlycoverage,output=`+resultDir+`/Tests-coverage.mlpd,covfilter-file=`+resultDir+`/Tests-coverage-filter.txt" "/nunit/nunit3-console.exe" "/bin/Debug/Tests.dll" "--result" "/results/Tests.xml" "--inprocess"`, command.PrintableCommand())
+	}
+
+	t.Log("it does not support OpenCover by the mono profiler")
+	{
+		runner := New(builder.Model{})
+		runner.SetCoverage(CoverageConfigModel{Tool: CoverageToolMono, Format: CoverageFormatOpenCover})
+
+		_, err := runner.newCoverageRun("Tests", "/bin/Debug/Tests.dll", "/results")
+		require.Error(t, err)
+	}
+
+	t.Log("it is disabled by default")
+	{
+		coverageRun, err := New(builder.Model{}).newCoverageRun("Tests", "/bin/Debug/Tests.dll", "/results")
+		require.NoError(t, err)
+		require.Nil(t, coverageRun)
+	}
+}
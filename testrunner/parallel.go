@@ -0,0 +1,146 @@
+package testrunner
+
+import (
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+)
+
+// redirectOutput writes the output of the command into the writer, if given
+func redirectOutput(command tools.Runnable, output io.Writer) {
+	if output == nil {
+		return
+	}
+	if redirectable, ok := command.(tools.OutputRedirectable); ok {
+		redirectable.SetStdout(output)
+		redirectable.SetStderr(output)
+	}
+}
+
+// synchronizedCallback returns a callback safe to call from the parallel runs
+func synchronizedCallback(callback builder.BuildCommandCallback) builder.BuildCommandCallback {
+	if callback == nil {
+		return nil
+	}
+
+	var mutex sync.Mutex
+	return func(solutionName string, projectName string, sdk constants.SDK, testFramwork constants.TestFramework, commandStr string, alreadyPerformed bool) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		callback(solutionName, projectName, sdk, testFramwork, commandStr, alreadyPerformed)
+	}
+}
+
+// runTestProjects runs the test assemblies by the configured number of workers.
+// No new run starts after a failed one, like in the serial runs, and the error of the first failed project is returned.
+func (runner Model) runTestProjects(projectNames []string, testProjectOutputMap builder.TestProjectOutputMap, resultDir string, callback builder.BuildCommandCallback) (ProjectResultMap, error) {
+	parallelism := runner.parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(projectNames) {
+		parallelism = len(projectNames)
+	}
+	if parallelism > 1 {
+		callback = synchronizedCallback(callback)
+	}
+
+	type projectRun struct {
+		result ProjectResultModel
+		err    error
+		done   bool
+	}
+	runs := make([]projectRun, len(projectNames))
+
+	var mutex sync.Mutex
+	failed := false
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				mutex.Lock()
+				skip := failed
+				mutex.Unlock()
+				if skip {
+					continue
+				}
+
+				projectName := projectNames[idx]
+				result, err := runner.runTests(projectName, testProjectOutputMap[projectName], resultDir, callback)
+
+				mutex.Lock()
+				runs[idx] = projectRun{result: result, err: err, done: true}
+				if err != nil {
+					failed = true
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for idx := range projectNames {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	projectResultMap := ProjectResultMap{}
+	for idx, run := range runs {
+		if !run.done {
+			continue
+		}
+		if run.err != nil {
+			return projectResultMap, run.err
+		}
+		projectResultMap[projectNames[idx]] = run.result
+	}
+
+	return projectResultMap, nil
+}
+
+func (projectResultMap ProjectResultMap) projectNames() []string {
+	projectNames := []string{}
+	for projectName := range projectResultMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+	return projectNames
+}
+
+// Summary - the aggregated result of the projects
+func (projectResultMap ProjectResultMap) Summary() ResultModel {
+	summary := ResultModel{}
+	for _, projectName := range projectResultMap.projectNames() {
+		for _, testCase := range projectResultMap[projectName].Result.Cases {
+			summary.add(testCase)
+		}
+	}
+	return summary
+}
+
+// WriteJUnit - writes the results of the projects into one JUnit XML
+func (projectResultMap ProjectResultMap) WriteJUnit(pth string) error {
+	merged := results.ResultModel{}
+
+	for _, projectName := range projectResultMap.projectNames() {
+		report, err := results.New(projectResultMap[projectName].ResultPth)
+		if err != nil {
+			return err
+		}
+
+		merged.Format = report.Format
+		merged.Duration += report.Duration
+		merged.Suites = append(merged.Suites, report.Suites...)
+	}
+
+	return merged.WriteJUnit(pth)
+}
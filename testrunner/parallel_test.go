@@ -0,0 +1,95 @@
+package testrunner
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTestProjects(t *testing.T) {
+	resultDir, err := pathutil.NormalizedOSTempDirPath("parallel_test")
+	require.NoError(t, err)
+
+	testProjectOutputMap := builder.TestProjectOutputMap{
+		"Core.Tests": builder.TestProjectOutputModel{TestFramwork: constants.TestFrameworkNunitTest, Output: builder.OutputModel{Pth: "/missing/Core.Tests.dll"}},
+		"UI.Tests":   builder.TestProjectOutputModel{TestFramwork: constants.TestFrameworkNunitTest, Output: builder.OutputModel{Pth: "/missing/UI.Tests.dll"}},
+	}
+
+	t.Log("it returns the error of the first failed project")
+	{
+		runner := New(builder.Model{})
+		runner.SetNunitConsolePth("/missing/nunit3-console.exe")
+		runner.SetParallelism(2)
+
+		commands := []string{}
+		projectResultMap, err := runner.runTestProjects([]string{"Core.Tests", "UI.Tests"}, testProjectOutputMap, resultDir, func(solutionName string, projectName string, sdk constants.SDK, testFramwork constants.TestFramework, commandStr string, alreadyPerformed bool) {
+			commands = append(commands, projectName)
+		})
+		require.Error(t, err)
+		require.True(t, strings.Contains(err.Error(), "Core.Tests"), err.Error())
+		require.Equal(t, 0, len(projectResultMap))
+		require.True(t, len(commands) > 0)
+
+		exist, err := pathutil.IsPathExists(filepath.Join(resultDir, "Core.Tests.log"))
+		require.NoError(t, err)
+		require.True(t, exist)
+	}
+
+	t.Log("it does not start new runs after a failed serial run")
+	{
+		runner := New(builder.Model{})
+		runner.SetNunitConsolePth("/missing/nunit3-console.exe")
+
+		commands := []string{}
+		_, err := runner.runTestProjects([]string{"Core.Tests", "UI.Tests"}, testProjectOutputMap, resultDir, func(solutionName string, projectName string, sdk constants.SDK, testFramwork constants.TestFramework, commandStr string, alreadyPerformed bool) {
+			commands = append(commands, projectName)
+		})
+		require.Error(t, err)
+		require.Equal(t, []string{"Core.Tests"}, commands)
+	}
+}
+
+func TestProjectResultMapAggregation(t *testing.T) {
+	resultDir, err := pathutil.NormalizedOSTempDirPath("aggregation_test")
+	require.NoError(t, err)
+
+	nunitResultPth := filepath.Join(resultDir, "Core.Tests.xml")
+	require.NoError(t, fileutil.WriteStringToFile(nunitResultPth, nunit3ResultContent))
+	xunitResultPth := filepath.Join(resultDir, "UI.Tests.xml")
+	require.NoError(t, fileutil.WriteStringToFile(xunitResultPth, xunitResultContent))
+
+	nunitResult, err := ParseResult([]byte(nunit3ResultContent))
+	require.NoError(t, err)
+	xunitResult, err := ParseResult([]byte(xunitResultContent))
+	require.NoError(t, err)
+
+	projectResultMap := ProjectResultMap{
+		"Core.Tests": ProjectResultModel{ProjectName: "Core.Tests", ResultPth: nunitResultPth, Result: nunitResult},
+		"UI.Tests":   ProjectResultModel{ProjectName: "UI.Tests", ResultPth: xunitResultPth, Result: xunitResult},
+	}
+
+	t.Log("it sums the results of the projects")
+	{
+		summary := projectResultMap.Summary()
+		require.Equal(t, 6, summary.Total)
+		require.Equal(t, 2, summary.Passed)
+		require.Equal(t, 2, summary.Failed)
+		require.Equal(t, 2, summary.Skipped)
+	}
+
+	t.Log("it writes the results into one JUnit XML")
+	{
+		junitPth := filepath.Join(resultDir, "junit.xml")
+		require.NoError(t, projectResultMap.WriteJUnit(junitPth))
+
+		content, err := fileutil.ReadStringFromFile(junitPth)
+		require.NoError(t, err)
+		require.True(t, strings.Contains(content, `<testsuites tests="6" failures="2" errors="0" skipped="2"`), content)
+	}
+}
@@ -0,0 +1,113 @@
+package testrunner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+)
+
+// Outcome - the outcome of a test case
+type Outcome string
+
+const (
+	// OutcomePassed ...
+	OutcomePassed Outcome = "passed"
+	// OutcomeFailed ...
+	OutcomeFailed Outcome = "failed"
+	// OutcomeSkipped - ignored, skipped or inconclusive tests
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// CaseModel - a single test case
+type CaseModel struct {
+	Name       string // full name of the test, like: Namespace.Fixture.Test
+	Outcome    Outcome
+	Flaky      bool // failed, then passed on retry, the Message and StackTrace are of the failure
+	Duration   time.Duration
+	Message    string // failure message or skip reason
+	StackTrace string
+}
+
+// ResultModel - the summary and the test cases of a test assembly run
+type ResultModel struct {
+	Total    int
+	Passed   int
+	Failed   int
+	Skipped  int
+	Flaky    int // counted as passed as well
+	Duration time.Duration
+	Cases    []CaseModel
+}
+
+func (result *ResultModel) add(testCase CaseModel) {
+	result.Cases = append(result.Cases, testCase)
+	result.Total++
+	result.Duration += testCase.Duration
+
+	switch testCase.Outcome {
+	case OutcomePassed:
+		result.Passed++
+		if testCase.Flaky {
+			result.Flaky++
+		}
+	case OutcomeFailed:
+		result.Failed++
+	default:
+		result.Skipped++
+	}
+}
+
+// FailedCases ...
+func (result ResultModel) FailedCases() []CaseModel {
+	failed := []CaseModel{}
+	for _, testCase := range result.Cases {
+		if testCase.Outcome == OutcomeFailed {
+			failed = append(failed, testCase)
+		}
+	}
+	return failed
+}
+
+// FlakyCases - the cases failed at first, then passed on retry
+func (result ResultModel) FlakyCases() []CaseModel {
+	flaky := []CaseModel{}
+	for _, testCase := range result.Cases {
+		if testCase.Flaky {
+			flaky = append(flaky, testCase)
+		}
+	}
+	return flaky
+}
+
+// ParseResult - parses the NUnit 2, NUnit 3 or xUnit.net v2 result XML
+func ParseResult(content []byte) (ResultModel, error) {
+	report, err := results.Parse(content)
+	if err != nil {
+		return ResultModel{}, fmt.Errorf("failed to parse test result, error: %s", err)
+	}
+	return newResult(report), nil
+}
+
+func newResult(report results.ResultModel) ResultModel {
+	result := ResultModel{}
+	for _, testCase := range report.Cases() {
+		outcome := OutcomeSkipped
+		switch testCase.Outcome {
+		case results.OutcomePassed:
+			outcome = OutcomePassed
+		case results.OutcomeFailed:
+			outcome = OutcomeFailed
+		}
+
+		result.add(CaseModel{
+			Name:       testCase.FullName,
+			Outcome:    outcome,
+			Flaky:      testCase.Flaky,
+			Duration:   testCase.Duration,
+			Message:    testCase.Message,
+			StackTrace: testCase.StackTrace,
+		})
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package testrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const nunit3ResultContent = `<?xml version="1.0" encoding="utf-8" standalone="no"?>
+<test-run id="2" testcasecount="3" result="Failed" total="3" passed="1" failed="1" skipped="1" duration="0.52">
+  <test-suite type="Assembly" name="Core.Tests.dll" fullname="/bin/Debug/Core.Tests.dll">
+    <test-suite type="TestSuite" name="Core" fullname="Core">
+      <test-suite type="TestFixture" name="CalculatorTests" fullname="Core.CalculatorTests">
+        <test-case id="1-1" name="Add" fullname="Core.CalculatorTests.Add" result="Passed" duration="0.012" />
+        <test-case id="1-2" name="Divide" fullname="Core.CalculatorTests.Divide" result="Failed" duration="0.5">
+          <failure>
+            <message><![CDATA[  Expected: 2
+  But was:  0
+]]></message>
+            <stack-trace><![CDATA[at Core.CalculatorTests.Divide () [0x00001] in CalculatorTests.cs:21
+]]></stack-trace>
+          </failure>
+        </test-case>
+        <test-case id="1-3" name="Subtract" fullname="Core.CalculatorTests.Subtract" result="Skipped" label="Ignored" duration="0">
+          <reason>
+            <message><![CDATA[not implemented]]></message>
+          </reason>
+        </test-case>
+      </test-suite>
+    </test-suite>
+  </test-suite>
+</test-run>`
+
+const xunitResultContent = `<?xml version="1.0" encoding="utf-8"?>
+<assemblies>
+  <assembly name="/bin/Debug/Core.Tests.dll" total="3" passed="1" failed="1" skipped="1" time="0.250">
+    <collection total="3" passed="1" failed="1" skipped="1" name="Test collection for Core.CalculatorTests" time="0.200">
+      <test name="Core.CalculatorTests.Add" type="Core.CalculatorTests" method="Add" time="0.0500000" result="Pass" />
+      <test name="Core.CalculatorTests.Divide" type="Core.CalculatorTests" method="Divide" time="0.1500000" result="Fail">
+        <failure exception-type="Xunit.Sdk.EqualException">
+          <message><![CDATA[Assert.Equal() Failure
+Expected: 2
+Actual:   0]]></message>
+          <stack-trace><![CDATA[   at Core.CalculatorTests.Divide() in CalculatorTests.cs:line 21]]></stack-trace>
+        </failure>
+      </test>
+      <test name="Core.CalculatorTests.Subtract" type="Core.CalculatorTests" method="Subtract" time="0" result="Skip">
+        <reason><![CDATA[not implemented]]></reason>
+      </test>
+    </collection>
+  </assembly>
+</assemblies>`
+
+func TestParseResult(t *testing.T) {
+	t.Log("it parses the nested test cases of the NUnit result")
+	{
+		result, err := ParseResult([]byte(nunit3ResultContent))
+		require.NoError(t, err)
+
+		require.Equal(t, 3, result.Total)
+		require.Equal(t, 1, result.Passed)
+		require.Equal(t, 1, result.Failed)
+		require.Equal(t, 1, result.Skipped)
+		require.Equal(t, 512*time.Millisecond, result.Duration)
+
+		require.Equal(t, 3, len(result.Cases))
+		require.Equal(t, CaseModel{Name: "Core.CalculatorTests.Add", Outcome: OutcomePassed, Duration: 12 * time.Millisecond}, result.Cases[0])
+		require.Equal(t, CaseModel{
+			Name:       "Core.CalculatorTests.Divide",
+			Outcome:    OutcomeFailed,
+			Duration:   500 * time.Millisecond,
+			Message:    "Expected: 2\n  But was:  0",
+			StackTrace: "at Core.CalculatorTests.Divide () [0x00001] in CalculatorTests.cs:21",
+		}, result.Cases[1])
+		require.Equal(t, CaseModel{Name: "Core.CalculatorTests.Subtract", Outcome: OutcomeSkipped, Message: "not implemented"}, result.Cases[2])
+
+		require.Equal(t, []CaseModel{result.Cases[1]}, result.FailedCases())
+	}
+
+	t.Log("it parses the test cases of the xUnit collections")
+	{
+		result, err := ParseResult([]byte(xunitResultContent))
+		require.NoError(t, err)
+
+		require.Equal(t, 3, result.Total)
+		require.Equal(t, 1, result.Passed)
+		require.Equal(t, 1, result.Failed)
+		require.Equal(t, 1, result.Skipped)
+		require.Equal(t, 200*time.Millisecond, result.Duration)
+
+		require.Equal(t, 3, len(result.Cases))
+		require.Equal(t, CaseModel{Name: "Core.CalculatorTests.Add", Outcome: OutcomePassed, Duration: 50 * time.Millisecond}, result.Cases[0])
+		require.Equal(t, CaseModel{
+			Name:       "Core.CalculatorTests.Divide",
+			Outcome:    OutcomeFailed,
+			Duration:   150 * time.Millisecond,
+			Message:    "Assert.Equal() Failure\nExpected: 2\nActual:   0",
+			StackTrace: "at Core.CalculatorTests.Divide() in CalculatorTests.cs:line 21",
+		}, result.Cases[1])
+		require.Equal(t, CaseModel{Name: "Core.CalculatorTests.Subtract", Outcome: OutcomeSkipped, Message: "not implemented"}, result.Cases[2])
+	}
+
+	t.Log("it fails for invalid result")
+	{
+		_, err := ParseResult([]byte(`<?xml version="1.0"?><testsuites></testsuites>`))
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,91 @@
+package testrunner
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+)
+
+// retryFilter returns the filter selecting the failed cases
+func retryFilter(testFramework constants.TestFramework, failedCases []results.CaseModel) TestFilterModel {
+	if testFramework == constants.TestFrameworkXunitTest {
+		methods := []string{}
+		seen := map[string]bool{}
+		for _, testCase := range failedCases {
+			// theory cases share the method
+			method := testCase.ClassName + "." + testCase.Name
+			if idx := strings.Index(method, "("); idx > 0 {
+				method = method[:idx]
+			}
+			if !seen[method] {
+				seen[method] = true
+				methods = append(methods, method)
+			}
+		}
+		return TestFilterModel{XunitMethods: methods}
+	}
+
+	expressions := []string{}
+	for _, testCase := range failedCases {
+		expressions = append(expressions, fmt.Sprintf(`test == "%s"`, strings.Replace(testCase.FullName, `"`, `\"`, -1)))
+	}
+	return TestFilterModel{NunitWhere: strings.Join(expressions, " || ")}
+}
+
+// retryFailedTests re-runs the failed tests of the report, the tests passing on retry are marked as flaky.
+// The retries run without coverage, a failed retry keeps the report as is.
+func (runner Model) retryFailedTests(projectResult ProjectResultModel, report results.ResultModel, resultDir string, output io.Writer, callback builder.BuildCommandCallback) results.ResultModel {
+	retryRunner := runner
+	retryRunner.coverage = nil
+
+	for attempt := 1; attempt <= runner.retryAttempts; attempt++ {
+		failedCases := report.FailedCases()
+		if len(failedCases) == 0 {
+			break
+		}
+
+		retryResult := projectResult
+		retryResult.ResultPth = filepath.Join(resultDir, fmt.Sprintf("%s-retry-%d.xml", projectResult.ProjectName, attempt))
+		retryRunner.filter = retryFilter(projectResult.TestFramework, failedCases)
+
+		command, err := retryRunner.testCommand(retryResult, nil)
+		if err != nil {
+			log.Warnf("Failed to retry tests of project (%s), error: %s", projectResult.ProjectName, err)
+			break
+		}
+		redirectOutput(command, output)
+
+		// Callback to notify the caller about next running command
+		if callback != nil {
+			callback("", projectResult.ProjectName, constants.SDKUnknown, projectResult.TestFramework, command.PrintableCommand(), false)
+		}
+
+		if err := removeStaleResult(retryResult.ResultPth); err != nil {
+			log.Warnf("Failed to retry tests of project (%s), error: %s", projectResult.ProjectName, err)
+			break
+		}
+
+		runErr := command.Run()
+
+		retryReport, err := results.New(retryResult.ResultPth)
+		if err != nil {
+			if runErr != nil {
+				err = runErr
+			}
+			log.Warnf("Failed to retry tests of project (%s), error: %s", projectResult.ProjectName, err)
+			break
+		}
+
+		if flaky := report.MarkFlaky(retryReport); flaky > 0 {
+			log.Warnf("%d failed test(s) of project (%s) passed on retry %d/%d, marked as flaky", flaky, projectResult.ProjectName, attempt, runner.retryAttempts)
+		}
+	}
+
+	return report
+}
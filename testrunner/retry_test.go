@@ -0,0 +1,30 @@
+package testrunner
+
+import (
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryFilter(t *testing.T) {
+	t.Log("it selects the failed NUnit tests by their full name")
+	{
+		filter := retryFilter(constants.TestFrameworkNunitTest, []results.CaseModel{
+			{Name: "Divide", FullName: "Core.CalculatorTests.Divide", ClassName: "Core.CalculatorTests"},
+			{Name: `Parse("1")`, FullName: `Core.ParserTests.Parse("1")`, ClassName: "Core.ParserTests"},
+		})
+		require.Equal(t, TestFilterModel{NunitWhere: `test == "Core.CalculatorTests.Divide" || test == "Core.ParserTests.Parse(\"1\")"`}, filter)
+	}
+
+	t.Log("it selects the failed xUnit.net test methods once")
+	{
+		filter := retryFilter(constants.TestFrameworkXunitTest, []results.CaseModel{
+			{Name: "Divide", FullName: "Core.CalculatorTests.Divide", ClassName: "Core.CalculatorTests"},
+			{Name: "Parse(value: 1)", FullName: "Core.ParserTests.Parse(value: 1)", ClassName: "Core.ParserTests"},
+			{Name: "Parse(value: 2)", FullName: "Core.ParserTests.Parse(value: 2)", ClassName: "Core.ParserTests"},
+		})
+		require.Equal(t, TestFilterModel{XunitMethods: []string{"Core.CalculatorTests.Divide", "Core.ParserTests.Parse"}}, filter)
+	}
+}
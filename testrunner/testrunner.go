@@ -0,0 +1,381 @@
+package testrunner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/tools/androidsdk"
+	"github.com/brandonrisell/go-xamarin/tools/nunit"
+	"github.com/brandonrisell/go-xamarin/tools/touchunit"
+	"github.com/brandonrisell/go-xamarin/tools/xunit"
+)
+
+// ProjectResultModel - the test run of a unit test project
+type ProjectResultModel struct {
+	ProjectName   string
+	TestFramework constants.TestFramework
+	AssemblyPth   string
+	ResultPth     string // the result XML written by the test runner
+	JUnitPth      string // the result converted to JUnit XML
+	LogcatPth     string // the logcat of the emulator or device, Android test apps only
+	CoveragePth   string // the code coverage report, if the coverage is enabled
+	LogPth        string // the output of the test runner, parallel runs only
+	Result        ResultModel
+}
+
+// ProjectResultMap ...
+type ProjectResultMap map[string]ProjectResultModel // Test Project Name - ProjectResultModel
+
+// Failed - true if any test of any project failed
+func (projectResultMap ProjectResultMap) Failed() bool {
+	for _, projectResult := range projectResultMap {
+		if projectResult.Result.Failed > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TraitModel - xUnit.net trait, like: Category=Smoke
+type TraitModel struct {
+	Name  string
+	Value string
+}
+
+// ParseTrait - parses the name=value trait
+func ParseTrait(trait string) (TraitModel, error) {
+	split := strings.SplitN(trait, "=", 2)
+	if len(split) != 2 || strings.TrimSpace(split[0]) == "" {
+		return TraitModel{}, fmt.Errorf("invalid trait (%s), should be: name=value", trait)
+	}
+	return TraitModel{Name: strings.TrimSpace(split[0]), Value: strings.TrimSpace(split[1])}, nil
+}
+
+// TestFilterModel - selects the tests to run, like a smoke test subset
+type TestFilterModel struct {
+	NunitWhere string // NUnit test selection language, like: cat == Smoke
+
+	XunitTraits   []TraitModel // the tests having any of the traits run
+	XunitNoTraits []TraitModel // the tests having any of the traits are skipped
+	XunitMethods  []string     // fully qualified test methods, like: Namespace.Class.Method
+}
+
+// Model - builds the unit test projects of the solution and runs them by the NUnit or xUnit.net console runner
+type Model struct {
+	builder builder.Model
+
+	nunitConsolePth string
+	xunitConsolePth string
+
+	resultDir string
+	filter    TestFilterModel
+	coverage  *CoverageConfigModel
+
+	touchUnitLauncher   touchunit.Launcher
+	simulatorUDID       string
+	simulatorDeviceType string
+	simulatorRuntime    string
+
+	adbPth        string
+	androidSerial string
+	androidSDK    androidsdk.Model
+	androidAVD    string
+
+	parallelism   int
+	retryAttempts int
+	timeout       time.Duration
+}
+
+// New ...
+func New(builder builder.Model) *Model {
+	return &Model{builder: builder, touchUnitLauncher: touchunit.LauncherSimctl, parallelism: 1}
+}
+
+// SetNunitConsolePth - defaults to the nunit3-console.exe of the NUNIT_PATH directory
+func (runner *Model) SetNunitConsolePth(nunitConsolePth string) *Model {
+	runner.nunitConsolePth = nunitConsolePth
+	return runner
+}
+
+// SetXunitConsolePth - defaults to the xunit.console.exe of the XUNIT_PATH directory
+func (runner *Model) SetXunitConsolePth(xunitConsolePth string) *Model {
+	runner.xunitConsolePth = xunitConsolePth
+	return runner
+}
+
+// SetResultDir - the directory of the result XMLs, defaults to a temporary directory
+func (runner *Model) SetResultDir(resultDir string) *Model {
+	runner.resultDir = resultDir
+	return runner
+}
+
+// SetTestFilter - the NUnit and xUnit.net test assemblies run the selected tests only
+func (runner *Model) SetTestFilter(filter TestFilterModel) *Model {
+	runner.filter = filter
+	return runner
+}
+
+// SetParallelism - the number of the NUnit and xUnit.net test assemblies running at the same time, defaults to 1.
+// The output of the parallel runs is written into the <project name>.log of the result dir.
+func (runner *Model) SetParallelism(parallelism int) *Model {
+	runner.parallelism = parallelism
+	return runner
+}
+
+// SetTestRetry - the failed NUnit and xUnit.net tests are re-run up to the attempts times,
+// the tests passing on retry are reported as flaky instead of failed
+func (runner *Model) SetTestRetry(attempts int) *Model {
+	runner.retryAttempts = attempts
+	return runner
+}
+
+// SetTimeout - timeout of a single test assembly run
+func (runner *Model) SetTimeout(timeout time.Duration) *Model {
+	runner.timeout = timeout
+	return runner
+}
+
+// BuildAndRunTests - builds the solution, then runs the built NUnit and xUnit.net test assemblies.
+// Failing tests do not fail the run, they are reported in the results.
+func (runner Model) BuildAndRunTests(configuration, platform string, callback builder.BuildCommandCallback) (ProjectResultMap, []string, error) {
+	startTime := time.Now()
+
+	if err := runner.builder.BuildSolution(configuration, platform, callback); err != nil {
+		return nil, nil, err
+	}
+
+	testProjectOutputMap, warnings, err := runner.builder.CollectTestProjectOutputs(configuration, platform, startTime, time.Now())
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	resultDir, err := runner.ensureResultDir()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	projectNames := []string{}
+	for projectName := range testProjectOutputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	testProjectNames := []string{}
+	for _, projectName := range projectNames {
+		testProjectOutput := testProjectOutputMap[projectName]
+
+		switch testProjectOutput.TestFramwork {
+		case constants.TestFrameworkNunitTest, constants.TestFrameworkXunitTest:
+		case constants.TestFrameworkXamarinUITest:
+			continue
+		default:
+			warnings = append(warnings, fmt.Sprintf("running %s test project (%s) is not supported", testProjectOutput.TestFramwork, projectName))
+			continue
+		}
+
+		testProjectNames = append(testProjectNames, projectName)
+	}
+
+	projectResultMap, err := runner.runTestProjects(testProjectNames, testProjectOutputMap, resultDir, callback)
+	return projectResultMap, warnings, err
+}
+
+// ensureResultDir returns the result dir, or a temporary directory if not set
+func (runner Model) ensureResultDir() (string, error) {
+	if runner.resultDir == "" {
+		resultDir, err := pathutil.NormalizedOSTempDirPath("test_results")
+		if err != nil {
+			return "", fmt.Errorf("Failed to create result dir, error: %s", err)
+		}
+		return resultDir, nil
+	}
+
+	if err := pathutil.EnsureDirExist(runner.resultDir); err != nil {
+		return "", fmt.Errorf("Failed to create result dir (%s), error: %s", runner.resultDir, err)
+	}
+	return runner.resultDir, nil
+}
+
+// removeStaleResult removes the result of a previous run, so it is never reported as the outcome of the current run
+func removeStaleResult(pth string) error {
+	if err := os.Remove(pth); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Failed to remove previous test result (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+func (runner Model) runTests(projectName string, testProjectOutput builder.TestProjectOutputModel, resultDir string, callback builder.BuildCommandCallback) (ProjectResultModel, error) {
+	projectResult := ProjectResultModel{
+		ProjectName:   projectName,
+		TestFramework: testProjectOutput.TestFramwork,
+		AssemblyPth:   testProjectOutput.Output.Pth,
+		ResultPth:     filepath.Join(resultDir, projectName+".xml"),
+		JUnitPth:      filepath.Join(resultDir, projectName+"-junit.xml"),
+	}
+
+	var output io.Writer
+	if runner.parallelism > 1 {
+		projectResult.LogPth = filepath.Join(resultDir, projectName+".log")
+
+		logFile, err := os.Create(projectResult.LogPth)
+		if err != nil {
+			return ProjectResultModel{}, fmt.Errorf("Failed to create test log (%s), error: %s", projectResult.LogPth, err)
+		}
+		defer func() {
+			if err := logFile.Close(); err != nil {
+				log.Warnf("Failed to close test log (%s), error: %s", projectResult.LogPth, err)
+			}
+		}()
+		output = logFile
+	}
+
+	coverageRun, err := runner.newCoverageRun(projectName, projectResult.AssemblyPth, resultDir)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+	if coverageRun != nil {
+		prepareCommands, err := coverageRun.prepareCommands()
+		if err != nil {
+			return ProjectResultModel{}, err
+		}
+		if err := runner.runCoverageCommands(projectName, projectResult.TestFramework, prepareCommands, output, callback); err != nil {
+			return ProjectResultModel{}, err
+		}
+	}
+
+	command, err := runner.testCommand(projectResult, coverageRun)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+	redirectOutput(command, output)
+
+	// Callback to notify the caller about next running command
+	if callback != nil {
+		callback("", projectName, constants.SDKUnknown, projectResult.TestFramework, command.PrintableCommand(), false)
+	}
+
+	if err := removeStaleResult(projectResult.ResultPth); err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	// the runners exit with non zero status if any test fails, the result XML tells if the tests could run
+	runErr := command.Run()
+
+	if exist, err := pathutil.IsPathExists(projectResult.ResultPth); err != nil {
+		return ProjectResultModel{}, err
+	} else if !exist {
+		if runErr != nil {
+			return ProjectResultModel{}, fmt.Errorf("Failed to run tests of project (%s), error: %s", projectName, runErr)
+		}
+		return ProjectResultModel{}, fmt.Errorf("no test result generated for project (%s)", projectName)
+	}
+
+	report, err := results.New(projectResult.ResultPth)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+	if runner.retryAttempts > 0 {
+		report = runner.retryFailedTests(projectResult, report, resultDir, output, callback)
+	}
+	if err := report.WriteJUnit(projectResult.JUnitPth); err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	if coverageRun != nil {
+		collectCommands, err := coverageRun.collectCommands()
+		if err != nil {
+			return ProjectResultModel{}, err
+		}
+		if err := runner.runCoverageCommands(projectName, projectResult.TestFramework, collectCommands, output, callback); err != nil {
+			return ProjectResultModel{}, err
+		}
+		if err := coverageRun.finish(); err != nil {
+			return ProjectResultModel{}, err
+		}
+		projectResult.CoveragePth = coverageRun.reportPth
+	}
+
+	result := newResult(report)
+	if runErr != nil && result.Failed == 0 {
+		log.Warnf("Test runner of project (%s) failed, error: %s", projectName, runErr)
+	}
+
+	projectResult.Result = result
+	return projectResult, nil
+}
+
+func (runner Model) testCommand(projectResult ProjectResultModel, coverageRun *coverageRunModel) (tools.Runnable, error) {
+	assemblyPth := projectResult.AssemblyPth
+	monoOptions := []string{}
+	if coverageRun != nil {
+		assemblyPth = coverageRun.testAssemblyPth
+		monoOptions = coverageRun.monoOptions()
+	}
+
+	if projectResult.TestFramework == constants.TestFrameworkXunitTest {
+		xunitConsolePth := runner.xunitConsolePth
+		if xunitConsolePth == "" {
+			var err error
+			if xunitConsolePth, err = xunit.SystemXunitConsolePath(); err != nil {
+				return nil, err
+			}
+		}
+
+		command, err := xunit.New(xunitConsolePth)
+		if err != nil {
+			return nil, err
+		}
+		command.SetMonoOptions(monoOptions...)
+		command.SetDLLPth(assemblyPth)
+		command.SetResultLogPth(projectResult.ResultPth)
+		command.SetTimeout(runner.timeout)
+
+		for _, trait := range runner.filter.XunitTraits {
+			command.AddTrait(trait.Name, trait.Value)
+		}
+		for _, trait := range runner.filter.XunitNoTraits {
+			command.AddNoTrait(trait.Name, trait.Value)
+		}
+		for _, method := range runner.filter.XunitMethods {
+			command.AddMethod(method)
+		}
+
+		return command, nil
+	}
+
+	nunitConsolePth := runner.nunitConsolePth
+	if nunitConsolePth == "" {
+		var err error
+		if nunitConsolePth, err = nunit.SystemNunit3ConsolePath(); err != nil {
+			return nil, err
+		}
+	}
+
+	command, err := nunit.New(nunitConsolePth)
+	if err != nil {
+		return nil, err
+	}
+	command.SetMonoOptions(monoOptions...)
+	command.SetDLLPth(assemblyPth)
+	command.SetWhere(runner.filter.NunitWhere)
+	command.SetResultLogPth(projectResult.ResultPth)
+	command.SetTimeout(runner.timeout)
+
+	// the mono profiler measures the console process only, the tests can not run in a separate agent
+	if len(monoOptions) > 0 {
+		command.SetCustomOptions("--inprocess")
+	}
+
+	return command, nil
+}
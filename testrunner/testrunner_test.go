@@ -0,0 +1,80 @@
+package testrunner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrait(t *testing.T) {
+	t.Log("it parses the name=value trait")
+	{
+		trait, err := ParseTrait("Category = Smoke")
+		require.NoError(t, err)
+		require.Equal(t, TraitModel{Name: "Category", Value: "Smoke"}, trait)
+	}
+
+	t.Log("it fails without name")
+	{
+		_, err := ParseTrait("Smoke")
+		require.Error(t, err)
+
+		_, err = ParseTrait("=Smoke")
+		require.Error(t, err)
+	}
+}
+
+func TestTestCommand(t *testing.T) {
+	runner := New(builder.Model{})
+	runner.SetNunitConsolePth("/nunit/nunit3-console.exe")
+	runner.SetXunitConsolePth("/xunit/xunit.console.exe")
+	runner.SetTestFilter(TestFilterModel{
+		NunitWhere:    "cat == Smoke",
+		XunitTraits:   []TraitModel{{Name: "Category", Value: "Smoke"}},
+		XunitNoTraits: []TraitModel{{Name: "Category", Value: "Slow"}},
+	})
+
+	t.Log("it filters the NUnit tests by the where expression")
+	{
+		command, err := runner.testCommand(ProjectResultModel{TestFramework: constants.TestFrameworkNunitTest, AssemblyPth: "/bin/Tests.dll", ResultPth: "/results/Tests.xml"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, `"`+constants.MonoPath+`" "/nunit/nunit3-console.exe" "/bin/Tests.dll" "--where" "cat == Smoke" "--result" "/results/Tests.xml"`, command.PrintableCommand())
+	}
+
+	t.Log("it filters the xUnit.net tests by the traits")
+	{
+		command, err := runner.testCommand(ProjectResultModel{TestFramework: constants.TestFrameworkXunitTest, AssemblyPth: "/bin/Tests.dll", ResultPth: "/results/Tests.xml"}, nil)
+		require.NoError(t, err)
+		require.Equal(t, `"`+constants.MonoPath+`" "/xunit/xunit.console.exe" "/bin/Tests.dll" "-trait" "Category=Smoke" "-notrait" "Category=Slow" "-xml" "/results/Tests.xml"`, command.PrintableCommand())
+	}
+}
+
+func TestRemoveStaleResult(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("testrunner_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	t.Log("it removes the result of the previous run")
+	{
+		pth := filepath.Join(tmpDir, "Tests.xml")
+		require.NoError(t, fileutil.WriteStringToFile(pth, "<test-run />"))
+		require.NoError(t, removeStaleResult(pth))
+
+		exist, err := pathutil.IsPathExists(pth)
+		require.NoError(t, err)
+		require.Equal(t, false, exist)
+	}
+
+	t.Log("it does not fail without previous result")
+	{
+		require.NoError(t, removeStaleResult(filepath.Join(tmpDir, "Missing.xml")))
+	}
+}
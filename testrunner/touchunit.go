@@ -0,0 +1,229 @@
+package testrunner
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/log"
+	"github.com/brandonrisell/go-xamarin/analyzers/plist"
+	"github.com/brandonrisell/go-xamarin/analyzers/results"
+	"github.com/brandonrisell/go-xamarin/builder"
+	"github.com/brandonrisell/go-xamarin/constants"
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/brandonrisell/go-xamarin/tools/simulator"
+	"github.com/brandonrisell/go-xamarin/tools/touchunit"
+)
+
+// touchUnitResultTimeout - time to wait for the results after the test app exited
+const touchUnitResultTimeout = 10 * time.Second
+
+// simulatorBootTimeout - time to wait for the simulator to boot
+const simulatorBootTimeout = 5 * time.Minute
+
+// SetTouchUnitSimulator - the iOS NUnitLite (Touch.Unit) test apps are run in the simulator by the launcher,
+// the simulator is booted for simctl
+func (runner *Model) SetTouchUnitSimulator(launcher touchunit.Launcher, simulatorUDID string) *Model {
+	runner.touchUnitLauncher = launcher
+	runner.simulatorUDID = simulatorUDID
+	return runner
+}
+
+// SetTouchUnitSimulatorDevice - the iOS NUnitLite (Touch.Unit) test apps are run by the launcher in a simulator
+// of the device type and runtime, like: iPhone 8, iOS 12.1. The simulator is created if not exists.
+func (runner *Model) SetTouchUnitSimulatorDevice(launcher touchunit.Launcher, deviceType, runtime string) *Model {
+	runner.touchUnitLauncher = launcher
+	runner.simulatorDeviceType = deviceType
+	runner.simulatorRuntime = runtime
+	return runner
+}
+
+// touchUnitSimulator returns the udid of the simulator to run the tests in, the simulator is booted for simctl
+func (runner Model) touchUnitSimulator() (string, error) {
+	udid := runner.simulatorUDID
+	if udid == "" {
+		if runner.simulatorDeviceType == "" {
+			return "", fmt.Errorf("no simulator set to run the Touch.Unit tests")
+		}
+
+		device, err := simulator.Ensure(runner.simulatorDeviceType, runner.simulatorRuntime)
+		if err != nil {
+			return "", err
+		}
+		udid = device.UDID
+	}
+
+	if runner.touchUnitLauncher == touchunit.LauncherSimctl {
+		if err := simulator.Boot(udid, simulatorBootTimeout); err != nil {
+			return "", err
+		}
+	}
+	return udid, nil
+}
+
+// BuildAndRunTouchUnitTests - builds the iOS NUnitLite (Touch.Unit) test apps for the simulator, runs them in the simulator
+// and parses the results sent by the apps over TCP, or written to their console output
+func (runner Model) BuildAndRunTouchUnitTests(configuration, platform string, callback builder.BuildCommandCallback) (ProjectResultMap, []string, error) {
+	if runner.simulatorUDID == "" && runner.simulatorDeviceType == "" {
+		return nil, nil, fmt.Errorf("no simulator set to run the Touch.Unit tests")
+	}
+
+	testProjectOutputMap, warnings, err := runner.builder.BuildTouchUnitTestApps(configuration, platform, nil, callback)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	resultDir, err := runner.ensureResultDir()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	projectNames := []string{}
+	for projectName := range testProjectOutputMap {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	simulatorUDID, err := runner.touchUnitSimulator()
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	projectResultMap := ProjectResultMap{}
+	for _, projectName := range projectNames {
+		projectResult, err := runner.runTouchUnitTests(projectName, testProjectOutputMap[projectName].Output.Pth, simulatorUDID, resultDir, callback)
+		if err != nil {
+			return projectResultMap, warnings, err
+		}
+		projectResultMap[projectName] = projectResult
+	}
+
+	return projectResultMap, warnings, nil
+}
+
+func (runner Model) runTouchUnitTests(projectName, appPth, simulatorUDID, resultDir string, callback builder.BuildCommandCallback) (ProjectResultModel, error) {
+	infoPlist, err := plist.New(filepath.Join(appPth, "Info.plist"))
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+	bundleID, _ := infoPlist.GetString("CFBundleIdentifier")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return ProjectResultModel{}, fmt.Errorf("Failed to listen for the test results, error: %s", err)
+	}
+	defer func() {
+		if err := listener.Close(); err != nil {
+			log.Warnf("Failed to close test result listener, error: %s", err)
+		}
+	}()
+
+	command, err := touchunit.New(runner.touchUnitLauncher, appPth, bundleID, simulatorUDID)
+	if err != nil {
+		return ProjectResultModel{}, err
+	}
+	command.SetHost("127.0.0.1", listener.Addr().(*net.TCPAddr).Port)
+	command.SetTimeout(runner.timeout)
+
+	// the console output is parsed only if no result was received on the network
+	consoleOutput := tools.NewOutputCapture(tools.DefaultOutputTailLines)
+	command.SetStdout(io.MultiWriter(os.Stdout, consoleOutput))
+
+	networkOutput := make(chan []byte, 1)
+	go func() {
+		networkOutput <- receiveTouchUnitResults(listener)
+	}()
+
+	// Callback to notify the caller about next running command
+	if callback != nil {
+		callback("", projectName, constants.SDKIOS, constants.TestFrameworkNunitLiteTest, command.PrintableCommand(), false)
+	}
+
+	consoleResult := consoleOutput.Result(command.Run())
+	defer func() {
+		if err := consoleResult.Cleanup(); err != nil {
+			log.Warnf("%s", err)
+		}
+	}()
+	runErr := consoleResult.Err
+
+	var output []byte
+	select {
+	case output = <-networkOutput:
+	case <-time.After(touchUnitResultTimeout):
+	}
+	if len(output) == 0 {
+		if output, err = readConsoleOutput(consoleResult); err != nil {
+			log.Warnf("%s", err)
+		}
+	}
+
+	report, err := results.ParseTouchUnit(output)
+	if err != nil || report.Total() == 0 {
+		if runErr != nil {
+			return ProjectResultModel{}, fmt.Errorf("Failed to run tests of project (%s), error: %s", projectName, runErr)
+		}
+		return ProjectResultModel{}, fmt.Errorf("no test result received from project (%s)", projectName)
+	}
+
+	projectResult := ProjectResultModel{
+		ProjectName:   projectName,
+		TestFramework: constants.TestFrameworkNunitLiteTest,
+		AssemblyPth:   appPth,
+		ResultPth:     filepath.Join(resultDir, projectName+".xml"),
+		JUnitPth:      filepath.Join(resultDir, projectName+"-junit.xml"),
+		Result:        newResult(report),
+	}
+	if report.Format == results.FormatTouchUnit {
+		projectResult.ResultPth = filepath.Join(resultDir, projectName+".txt")
+	}
+
+	if err := fileutil.WriteBytesToFile(projectResult.ResultPth, output); err != nil {
+		return ProjectResultModel{}, fmt.Errorf("Failed to write test result (%s), error: %s", projectResult.ResultPth, err)
+	}
+	if err := report.WriteJUnit(projectResult.JUnitPth); err != nil {
+		return ProjectResultModel{}, err
+	}
+
+	return projectResult, nil
+}
+
+// receiveTouchUnitResults reads the results sent by the test app, the app closes the connection when the run finished
+func receiveTouchUnitResults(listener net.Listener) []byte {
+	connection, err := listener.Accept()
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		if err := connection.Close(); err != nil {
+			log.Warnf("Failed to close test result connection, error: %s", err)
+		}
+	}()
+
+	var output bytes.Buffer
+	if _, err := io.Copy(&output, connection); err != nil {
+		log.Warnf("Failed to receive test results, error: %s", err)
+	}
+	return output.Bytes()
+}
+
+// readConsoleOutput returns the captured console output of the test app,
+// or its last lines, if the output file is not available
+func readConsoleOutput(result tools.CommandResult) ([]byte, error) {
+	if result.OutputPth == "" {
+		return []byte(strings.Join(result.Tail, "\n")), nil
+	}
+
+	output, err := fileutil.ReadBytesFromFile(result.OutputPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read test app output (%s), error: %s", result.OutputPth, err)
+	}
+	return output, nil
+}
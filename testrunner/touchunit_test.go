@@ -0,0 +1,56 @@
+package testrunner
+
+import (
+	"net"
+	"testing"
+
+	"github.com/brandonrisell/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiveTouchUnitResults(t *testing.T) {
+	t.Log("it reads the results until the app closes the connection")
+	{
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, listener.Close())
+		}()
+
+		go func() {
+			connection, err := net.Dial("tcp", listener.Addr().String())
+			if err != nil {
+				return
+			}
+			_, _ = connection.Write([]byte("[PASS] Add\n[FAIL] Divide : Expected: 2\n"))
+			_ = connection.Close()
+		}()
+
+		require.Equal(t, "[PASS] Add\n[FAIL] Divide : Expected: 2\n", string(receiveTouchUnitResults(listener)))
+	}
+}
+
+func TestReadConsoleOutput(t *testing.T) {
+	t.Log("it reads the captured console output")
+	{
+		capture := tools.NewOutputCapture(1)
+		_, err := capture.Write([]byte("[PASS] Add\n[FAIL] Divide : Expected: 2\n"))
+		require.NoError(t, err)
+
+		result := capture.Result(nil)
+		defer func() {
+			require.NoError(t, result.Cleanup())
+		}()
+
+		output, err := readConsoleOutput(result)
+		require.NoError(t, err)
+		require.Equal(t, "[PASS] Add\n[FAIL] Divide : Expected: 2\n", string(output))
+	}
+
+	t.Log("it returns the last lines without output file")
+	{
+		output, err := readConsoleOutput(tools.CommandResult{Tail: []string{"[PASS] Add", "[FAIL] Divide : Expected: 2"}})
+		require.NoError(t, err)
+		require.Equal(t, "[PASS] Add\n[FAIL] Divide : Expected: 2", string(output))
+	}
+}
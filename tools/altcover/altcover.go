@@ -0,0 +1,197 @@
+package altcover
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+const altcoverExe = "AltCover.exe"
+
+// ReportFormat - format of the coverage report written by the instrumentation
+type ReportFormat string
+
+const (
+	// ReportFormatOpenCover ...
+	ReportFormatOpenCover ReportFormat = "OpenCover"
+	// ReportFormatNCover - the NCover 1.5 compatible format
+	ReportFormatNCover ReportFormat = "NCover"
+)
+
+// SystemAltCoverPath - the AltCover.exe of the ALTCOVER_PATH directory
+func SystemAltCoverPath() (string, error) {
+	altcoverDir := os.Getenv("ALTCOVER_PATH")
+	if altcoverDir == "" {
+		return "", tools.ToolNotInstalledErrorf("ALTCOVER_PATH environment is not set, failed to determine AltCover path")
+	}
+
+	altcoverPth := filepath.Join(altcoverDir, altcoverExe)
+	if exist, err := pathutil.IsPathExists(altcoverPth); err != nil {
+		return "", fmt.Errorf("Failed to check if AltCover exist at (%s), error: %s", altcoverPth, err)
+	} else if !exist {
+		return "", tools.ToolNotInstalledErrorf("AltCover not exist at: %s", altcoverPth)
+	}
+
+	return altcoverPth, nil
+}
+
+// AssemblyFilter - the AltCover assembly filter matching the assembly name,
+// the include filters are prefixed with ?
+func AssemblyFilter(assemblyName string, include bool) string {
+	filter := "^" + regexp.QuoteMeta(assemblyName) + "$"
+	if include {
+		filter = "?" + filter
+	}
+	return filter
+}
+
+// Model - instruments the assemblies of the input directory into the output directory,
+// or collects the coverage of the instrumented assemblies after the tests run
+type Model struct {
+	altcoverPth string
+
+	collect bool
+
+	inputDir     string
+	outputDir    string
+	reportPth    string
+	format       ReportFormat
+	filters      []string
+	coberturaPth string
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// NewInstrument - the tests have to run against the instrumented assemblies of the output directory
+func NewInstrument(altcoverPth, inputDir, outputDir, reportPth string) (*Model, error) {
+	absAltcoverPth, err := pathutil.AbsPath(altcoverPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", altcoverPth, err)
+	}
+
+	return &Model{
+		altcoverPth: absAltcoverPth,
+		inputDir:    inputDir,
+		outputDir:   outputDir,
+		reportPth:   reportPth,
+		format:      ReportFormatOpenCover,
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+	}, nil
+}
+
+// NewCollect - writes the coverage recorded by the instrumented assemblies of the directory into the report
+func NewCollect(altcoverPth, instrumentedDir string) (*Model, error) {
+	absAltcoverPth, err := pathutil.AbsPath(altcoverPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", altcoverPth, err)
+	}
+
+	return &Model{
+		altcoverPth: absAltcoverPth,
+		collect:     true,
+		outputDir:   instrumentedDir,
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+	}, nil
+}
+
+// SetReportFormat - format of the instrumentation report, defaults to OpenCover
+func (altcover *Model) SetReportFormat(format ReportFormat) *Model {
+	altcover.format = format
+	return altcover
+}
+
+// AddAssemblyFilter - the assemblies matching the filter are not instrumented, see: AssemblyFilter
+func (altcover *Model) AddAssemblyFilter(filter string) *Model {
+	altcover.filters = append(altcover.filters, filter)
+	return altcover
+}
+
+// SetCoberturaPth - the collected coverage is converted to Cobertura XML as well
+func (altcover *Model) SetCoberturaPth(coberturaPth string) *Model {
+	altcover.coberturaPth = coberturaPth
+	return altcover
+}
+
+// SetCustomOptions ...
+func (altcover *Model) SetCustomOptions(options ...string) {
+	altcover.customOptions = options
+}
+
+// SetStdout ...
+func (altcover *Model) SetStdout(out io.Writer) {
+	altcover.stdout = out
+}
+
+// SetStderr ...
+func (altcover *Model) SetStderr(err io.Writer) {
+	altcover.stderr = err
+}
+
+// SetTimeout ...
+func (altcover *Model) SetTimeout(timeout time.Duration) {
+	altcover.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (altcover *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	altcover.killGracePeriod = killGracePeriod
+}
+
+func (altcover Model) commandSlice() []string {
+	cmdSlice := []string{constants.MonoPath, altcover.altcoverPth}
+
+	if altcover.collect {
+		cmdSlice = append(cmdSlice, "runner", "--collect", "--recorderDirectory="+altcover.outputDir)
+		if altcover.coberturaPth != "" {
+			cmdSlice = append(cmdSlice, "--cobertura="+altcover.coberturaPth)
+		}
+		return append(cmdSlice, altcover.customOptions...)
+	}
+
+	cmdSlice = append(cmdSlice,
+		"--inputDirectory="+altcover.inputDir,
+		"--outputDirectory="+altcover.outputDir,
+		"--report="+altcover.reportPth,
+		"--reportFormat="+string(altcover.format),
+	)
+	for _, filter := range altcover.filters {
+		cmdSlice = append(cmdSlice, "--assemblyFilter="+filter)
+	}
+	cmdSlice = append(cmdSlice, "--save")
+
+	return append(cmdSlice, altcover.customOptions...)
+}
+
+// PrintableCommand ...
+func (altcover Model) PrintableCommand() string {
+	return command.PrintableCommandArgs(true, altcover.commandSlice())
+}
+
+// Run ...
+func (altcover Model) Run() error {
+	command, err := command.NewFromSlice(altcover.commandSlice())
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(altcover.stdout)
+	command.SetStderr(altcover.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), altcover.timeout, altcover.killGracePeriod)
+}
@@ -0,0 +1,281 @@
+package altool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Tool - the uploader of App Store Connect
+type Tool string
+
+const (
+	// ToolAltool - xcrun altool --upload-app
+	ToolAltool Tool = "altool"
+	// ToolTransporter - xcrun iTMSTransporter -m upload
+	ToolTransporter Tool = "transporter"
+)
+
+// PasswordEnvKey - the environment variable of the app-specific password, read by altool and iTMSTransporter by: -p @env:<key>
+const PasswordEnvKey = "GO_XAMARIN_APP_SPECIFIC_PASSWORD"
+
+// PlatformType - the platform of the uploaded package, the --type of altool
+type PlatformType string
+
+const (
+	// PlatformTypeIOS ...
+	PlatformTypeIOS PlatformType = "ios"
+	// PlatformTypeTvOS ...
+	PlatformTypeTvOS PlatformType = "appletvos"
+	// PlatformTypeMacOS ...
+	PlatformTypeMacOS PlatformType = "osx"
+)
+
+// CredentialsModel - either the Apple ID with an app-specific password, or the App Store Connect API key
+type CredentialsModel struct {
+	Username string // Apple ID
+	Password string // app-specific password of the Apple ID
+
+	APIKeyID  string // the AuthKey_<key id>.p8 is looked up in ./private_keys, ~/private_keys, ~/.private_keys and ~/.appstoreconnect/private_keys
+	APIIssuer string
+}
+
+// ErrorModel - an error reported by App Store Connect, like: ITMS-90189: Redundant Binary Upload
+type ErrorModel struct {
+	Code    string
+	Message string
+}
+
+// ResultModel - result of the upload
+type ResultModel struct {
+	Uploaded bool
+	Message  string
+	Errors   []ErrorModel
+}
+
+// Model - uploads an ipa or pkg to App Store Connect
+type Model struct {
+	tool         Tool
+	pth          string
+	platformType PlatformType
+	credentials  CredentialsModel
+
+	result *ResultModel
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// New - the platform type is used by altool only
+func New(tool Tool, pth string, platformType PlatformType, credentials CredentialsModel) (*Model, error) {
+	if tool != ToolAltool && tool != ToolTransporter {
+		return nil, fmt.Errorf("unknown upload tool: %s", tool)
+	}
+	if credentials.APIKeyID == "" && (credentials.Username == "" || credentials.Password == "") {
+		return nil, fmt.Errorf("either the Apple ID and password, or the API key is required for the upload")
+	}
+	if credentials.APIKeyID != "" && credentials.APIIssuer == "" {
+		return nil, fmt.Errorf("API issuer is required for the API key")
+	}
+
+	return &Model{
+		tool:         tool,
+		pth:          pth,
+		platformType: platformType,
+		credentials:  credentials,
+		result:       &ResultModel{},
+		stdout:       os.Stdout,
+		stderr:       os.Stderr,
+	}, nil
+}
+
+// Result - the result of the upload, set by Run
+func (upload Model) Result() ResultModel {
+	return *upload.result
+}
+
+// SetCustomOptions ...
+func (upload *Model) SetCustomOptions(options ...string) {
+	upload.customOptions = options
+}
+
+// SetStdout ...
+func (upload *Model) SetStdout(out io.Writer) {
+	upload.stdout = out
+}
+
+// SetStderr ...
+func (upload *Model) SetStderr(err io.Writer) {
+	upload.stderr = err
+}
+
+// SetTimeout ...
+func (upload *Model) SetTimeout(timeout time.Duration) {
+	upload.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (upload *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	upload.killGracePeriod = killGracePeriod
+}
+
+func (upload Model) commandSlice(maskSecrets bool) []string {
+	credentials := upload.credentials
+	password := "@env:" + PasswordEnvKey
+	if maskSecrets {
+		password = tools.SecretMask
+	}
+
+	var cmdSlice []string
+	if upload.tool == ToolTransporter {
+		cmdSlice = []string{"xcrun", "iTMSTransporter", "-m", "upload", "-assetFile", upload.pth}
+		if credentials.APIKeyID != "" {
+			cmdSlice = append(cmdSlice, "-apiKey", credentials.APIKeyID, "-apiIssuer", credentials.APIIssuer)
+		} else {
+			cmdSlice = append(cmdSlice, "-u", credentials.Username, "-p", password)
+		}
+		cmdSlice = append(cmdSlice, "-v", "informational")
+	} else {
+		cmdSlice = []string{"xcrun", "altool", "--upload-app", "-f", upload.pth, "-t", string(upload.platformType)}
+		if credentials.APIKeyID != "" {
+			cmdSlice = append(cmdSlice, "--apiKey", credentials.APIKeyID, "--apiIssuer", credentials.APIIssuer)
+		} else {
+			cmdSlice = append(cmdSlice, "-u", credentials.Username, "-p", password)
+		}
+		cmdSlice = append(cmdSlice, "--output-format", "json")
+	}
+
+	return append(cmdSlice, upload.customOptions...)
+}
+
+// PrintableCommand - the password is masked
+func (upload Model) PrintableCommand() string {
+	return command.PrintableCommandArgs(true, upload.commandSlice(true))
+}
+
+// environment passes the app-specific password to the command, instead of its arguments
+func (upload Model) environment() []string {
+	return append(os.Environ(), PasswordEnvKey+"="+upload.credentials.Password)
+}
+
+// altoolOutputModel - the json output of: altool --output-format json
+type altoolOutputModel struct {
+	SuccessMessage string `json:"success-message"`
+	ProductErrors  []struct {
+		Message  string                 `json:"message"`
+		Code     int                    `json:"code"`
+		UserInfo map[string]interface{} `json:"userInfo"`
+	} `json:"product-errors"`
+}
+
+// ParseAltoolOutput - parses the json output of: altool --upload-app --output-format json
+func ParseAltoolOutput(out []byte) (ResultModel, error) {
+	start, end := bytes.IndexByte(out, '{'), bytes.LastIndexByte(out, '}')
+	if start < 0 || end < start {
+		return ResultModel{}, fmt.Errorf("no upload result found in: %s", out)
+	}
+
+	var output altoolOutputModel
+	if err := json.Unmarshal(out[start:end+1], &output); err != nil {
+		return ResultModel{}, fmt.Errorf("failed to parse upload result, error: %s", err)
+	}
+
+	result := ResultModel{Message: output.SuccessMessage}
+	for _, productError := range output.ProductErrors {
+		message := productError.Message
+		if reason, ok := productError.UserInfo["NSLocalizedFailureReason"].(string); ok && reason != "" {
+			message += " " + reason
+		}
+		result.Errors = append(result.Errors, ErrorModel{Code: fmt.Sprintf("%d", productError.Code), Message: message})
+	}
+	result.Uploaded = len(result.Errors) == 0 && output.SuccessMessage != ""
+
+	return result, nil
+}
+
+var transporterErrorRegexp = regexp.MustCompile(`ERROR (ITMS-\d+): (.*)`)
+
+// ParseTransporterOutput - parses the log of: iTMSTransporter -m upload, like:
+// ERROR ITMS-90189: "Redundant Binary Upload. ..."
+// Package Summary: 1 package(s) were uploaded successfully
+func ParseTransporterOutput(out []byte) ResultModel {
+	result := ResultModel{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if match := transporterErrorRegexp.FindStringSubmatch(line); len(match) == 3 {
+			result.Errors = append(result.Errors, ErrorModel{Code: match[1], Message: strings.Trim(match[2], `"`)})
+		} else if strings.Contains(line, "uploaded successfully") {
+			result.Message = line
+		}
+	}
+	result.Uploaded = len(result.Errors) == 0 && result.Message != ""
+
+	return result
+}
+
+// ErrorMessage - the errors joined, like: ITMS-90189: Redundant Binary Upload
+func (result ResultModel) ErrorMessage() string {
+	messages := []string{}
+	for _, resultError := range result.Errors {
+		messages = append(messages, resultError.Code+": "+resultError.Message)
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Run - fails if the package is not uploaded, the result is available by Result
+func (upload Model) Run() error {
+	*upload.result = ResultModel{}
+
+	cmdSlice := upload.commandSlice(false)
+	var out bytes.Buffer
+
+	cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+	cmd.Env = upload.environment()
+	cmd.Stdout = io.MultiWriter(upload.stdout, &out)
+	cmd.Stderr = io.MultiWriter(upload.stderr, &out)
+
+	runErr := tools.RunCommandWithTimeout(cmd, upload.timeout, upload.killGracePeriod)
+
+	var result ResultModel
+	if upload.tool == ToolTransporter {
+		result = ParseTransporterOutput(out.Bytes())
+	} else {
+		var err error
+		if result, err = ParseAltoolOutput(out.Bytes()); err != nil {
+			if runErr != nil {
+				return fmt.Errorf("%s failed, error: %s", upload.tool, runErr)
+			}
+			return err
+		}
+	}
+	*upload.result = result
+
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("failed to upload (%s): %s", filepath.Base(upload.pth), result.ErrorMessage())
+	}
+	if runErr != nil {
+		return fmt.Errorf("%s failed, error: %s", upload.tool, runErr)
+	}
+	if !result.Uploaded {
+		return fmt.Errorf("failed to upload (%s): no upload confirmation found", filepath.Base(upload.pth))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package altool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it uploads the ipa by altool")
+	{
+		upload, err := New(ToolAltool, "/bin/Release/iOS.ipa", PlatformTypeIOS, CredentialsModel{Username: "bot@bitrise.io", Password: "app-secret"})
+		require.NoError(t, err)
+		require.Equal(t, `"xcrun" "altool" "--upload-app" "-f" "/bin/Release/iOS.ipa" "-t" "ios" "-u" "bot@bitrise.io" "-p" "***" "--output-format" "json"`, upload.PrintableCommand())
+	}
+
+	t.Log("it passes the password by environment variable")
+	{
+		upload, err := New(ToolAltool, "/bin/Release/iOS.ipa", PlatformTypeIOS, CredentialsModel{Username: "bot@bitrise.io", Password: "app-secret"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"xcrun", "altool", "--upload-app", "-f", "/bin/Release/iOS.ipa", "-t", "ios", "-u", "bot@bitrise.io", "-p", "@env:" + PasswordEnvKey, "--output-format", "json"}, upload.commandSlice(false))
+		require.Contains(t, upload.environment(), PasswordEnvKey+"=app-secret")
+	}
+
+	t.Log("it uploads the pkg by transporter with the API key")
+	{
+		upload, err := New(ToolTransporter, "/bin/Release/Mac.pkg", PlatformTypeMacOS, CredentialsModel{APIKeyID: "ABC", APIIssuer: "issuer-id"})
+		require.NoError(t, err)
+		require.Equal(t, `"xcrun" "iTMSTransporter" "-m" "upload" "-assetFile" "/bin/Release/Mac.pkg" "-apiKey" "ABC" "-apiIssuer" "issuer-id" "-v" "informational"`, upload.PrintableCommand())
+	}
+
+	t.Log("it requires credentials")
+	{
+		_, err := New(ToolAltool, "/bin/Release/iOS.ipa", PlatformTypeIOS, CredentialsModel{Username: "bot@bitrise.io"})
+		require.Error(t, err)
+
+		_, err = New(ToolAltool, "/bin/Release/iOS.ipa", PlatformTypeIOS, CredentialsModel{APIKeyID: "ABC"})
+		require.Error(t, err)
+
+		_, err = New(Tool("fastlane"), "/bin/Release/iOS.ipa", PlatformTypeIOS, CredentialsModel{APIKeyID: "ABC", APIIssuer: "issuer-id"})
+		require.Error(t, err)
+	}
+}
+
+func TestParseAltoolOutput(t *testing.T) {
+	t.Log("it parses the successful upload")
+	{
+		result, err := ParseAltoolOutput([]byte(`{"os-version":"10.14.6","success-message":"No errors uploading 'iOS.ipa'","tool-version":"4.00.1181"}`))
+		require.NoError(t, err)
+		require.Equal(t, ResultModel{Uploaded: true, Message: "No errors uploading 'iOS.ipa'"}, result)
+	}
+
+	t.Log("it parses the product errors")
+	{
+		result, err := ParseAltoolOutput([]byte(`*** Error: Error uploading 'iOS.ipa'.
+{"product-errors":[{"code":-19232,"message":"The bundle version must be higher than the previously uploaded version.","userInfo":{"NSLocalizedFailureReason":"ITMS-90189"}}]}`))
+		require.NoError(t, err)
+		require.Equal(t, false, result.Uploaded)
+		require.Equal(t, []ErrorModel{{Code: "-19232", Message: "The bundle version must be higher than the previously uploaded version. ITMS-90189"}}, result.Errors)
+	}
+
+	t.Log("it fails without result")
+	{
+		_, err := ParseAltoolOutput([]byte("xcrun: error: unable to find utility \"altool\""))
+		require.Error(t, err)
+	}
+}
+
+func TestParseTransporterOutput(t *testing.T) {
+	t.Log("it parses the successful upload")
+	{
+		result := ParseTransporterOutput([]byte("[2019-06-04 12:00:00 CEST] <main>  INFO: Uploaded package\nPackage Summary:\n\n1 package(s) were uploaded successfully:\n"))
+		require.Equal(t, true, result.Uploaded)
+		require.Equal(t, "1 package(s) were uploaded successfully:", result.Message)
+	}
+
+	t.Log("it parses the errors")
+	{
+		result := ParseTransporterOutput([]byte(`[2019-06-04 12:00:00 CEST] <main> ERROR: ERROR ITMS-90189: "Redundant Binary Upload."` + "\n"))
+		require.Equal(t, false, result.Uploaded)
+		require.Equal(t, []ErrorModel{{Code: "ITMS-90189", Message: "Redundant Binary Upload."}}, result.Errors)
+		require.Equal(t, "ITMS-90189: Redundant Binary Upload.", result.ErrorMessage())
+	}
+}
@@ -0,0 +1,207 @@
+package androidsdk
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Model - locations of the Android SDK, NDK and JDK used by the Android builds
+type Model struct {
+	SDKDir string
+	NDKDir string // optional, only required by the AOT and native builds
+	JDKDir string
+}
+
+// Resolve - detects the Android SDK by the ANDROID_HOME or ANDROID_SDK_ROOT environment,
+// the NDK by the ANDROID_NDK_HOME or ANDROID_NDK_ROOT environment or the latest NDK installed into the SDK,
+// and the JDK by the JAVA_HOME environment
+func Resolve() (Model, error) {
+	sdkDir := firstEnv("ANDROID_HOME", "ANDROID_SDK_ROOT")
+	if sdkDir == "" {
+		return Model{}, tools.ToolNotInstalledErrorf("neither ANDROID_HOME nor ANDROID_SDK_ROOT environment is set")
+	}
+	if exist, err := pathutil.IsDirExists(sdkDir); err != nil {
+		return Model{}, fmt.Errorf("Failed to check if Android SDK exist at (%s), error: %s", sdkDir, err)
+	} else if !exist {
+		return Model{}, tools.ToolNotInstalledErrorf("Android SDK not exist at: %s", sdkDir)
+	}
+
+	ndkDir := firstEnv("ANDROID_NDK_HOME", "ANDROID_NDK_ROOT")
+	if ndkDir == "" {
+		ndkDir = installedNDKDir(sdkDir)
+	}
+
+	jdkDir := os.Getenv("JAVA_HOME")
+	if jdkDir != "" {
+		if exist, err := pathutil.IsDirExists(jdkDir); err != nil || !exist {
+			return Model{}, tools.ToolNotInstalledErrorf("JDK not exist at JAVA_HOME: %s", jdkDir)
+		}
+	}
+
+	return Model{SDKDir: sdkDir, NDKDir: ndkDir, JDKDir: jdkDir}, nil
+}
+
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// installedNDKDir returns the latest side by side NDK (ndk/<version>) or the legacy ndk-bundle of the SDK
+func installedNDKDir(sdkDir string) string {
+	if versions := subdirVersions(filepath.Join(sdkDir, "ndk"), ""); len(versions) > 0 {
+		return filepath.Join(sdkDir, "ndk", versions[len(versions)-1])
+	}
+
+	ndkBundleDir := filepath.Join(sdkDir, "ndk-bundle")
+	if exist, err := pathutil.IsDirExists(ndkBundleDir); err == nil && exist {
+		return ndkBundleDir
+	}
+	return ""
+}
+
+// BuildToolsDir - returns the latest build-tools directory, which contains zipalign
+func (sdk Model) BuildToolsDir() (string, error) {
+	return LatestBuildToolsDir(filepath.Join(sdk.SDKDir, "build-tools"))
+}
+
+// LatestBuildToolsDir - returns the latest version directory of the build-tools root, which contains zipalign
+func LatestBuildToolsDir(buildToolsRootDir string) (string, error) {
+	if _, err := ioutil.ReadDir(buildToolsRootDir); err != nil {
+		return "", fmt.Errorf("failed to list build-tools (%s), error: %s", buildToolsRootDir, err)
+	}
+
+	versions := subdirVersions(buildToolsRootDir, "zipalign")
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no build-tools with zipalign found in: %s", buildToolsRootDir)
+	}
+
+	return filepath.Join(buildToolsRootDir, versions[len(versions)-1]), nil
+}
+
+// PlatformDir - returns the directory of the Android platform of the API level
+func (sdk Model) PlatformDir(apiLevel int) string {
+	return filepath.Join(sdk.SDKDir, "platforms", fmt.Sprintf("android-%d", apiLevel))
+}
+
+// ADBPth - returns the adb of the platform-tools
+func (sdk Model) ADBPth() string {
+	return filepath.Join(sdk.SDKDir, "platform-tools", "adb")
+}
+
+// EmulatorPth - returns the emulator of the SDK
+func (sdk Model) EmulatorPth() string {
+	return filepath.Join(sdk.SDKDir, "emulator", "emulator")
+}
+
+// AVDManagerPth - returns the avdmanager of the latest command-line tools, or of the legacy SDK tools
+func (sdk Model) AVDManagerPth() string {
+	avdManagerPth := filepath.Join(sdk.SDKDir, "cmdline-tools", "latest", "bin", "avdmanager")
+	if exist, err := pathutil.IsPathExists(avdManagerPth); err == nil && exist {
+		return avdManagerPth
+	}
+	return filepath.Join(sdk.SDKDir, "tools", "bin", "avdmanager")
+}
+
+// Validate - returns the problems of the environment for building against the API levels:
+// missing platforms or build-tools, 0 API levels are skipped
+func (sdk Model) Validate(apiLevels ...int) []string {
+	problems := []string{}
+
+	for _, apiLevel := range apiLevels {
+		if apiLevel <= 0 {
+			continue
+		}
+		if exist, err := pathutil.IsDirExists(sdk.PlatformDir(apiLevel)); err != nil || !exist {
+			problems = append(problems, fmt.Sprintf("Android platform (android-%d) is not installed in the Android SDK (%s)", apiLevel, sdk.SDKDir))
+		}
+	}
+
+	if _, err := sdk.BuildToolsDir(); err != nil {
+		problems = append(problems, fmt.Sprintf("no build-tools installed in the Android SDK (%s)", sdk.SDKDir))
+	}
+
+	return problems
+}
+
+// BuildProperties - the MSBuild properties pointing Xamarin.Android to the SDK, NDK and JDK
+func (sdk Model) BuildProperties() map[string]string {
+	properties := map[string]string{}
+	if sdk.SDKDir != "" {
+		properties["AndroidSdkDirectory"] = sdk.SDKDir
+	}
+	if sdk.NDKDir != "" {
+		properties["AndroidNdkDirectory"] = sdk.NDKDir
+	}
+	if sdk.JDKDir != "" {
+		properties["JavaSdkDirectory"] = sdk.JDKDir
+	}
+	return properties
+}
+
+// subdirVersions returns the version named subdirectories in ascending order,
+// which contain the required file, if given
+func subdirVersions(dir, requiredFile string) []string {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	versions := []string{}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		if requiredFile != "" {
+			if exist, err := pathutil.IsPathExists(filepath.Join(dir, info.Name(), requiredFile)); err != nil || !exist {
+				continue
+			}
+		}
+		versions = append(versions, info.Name())
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return VersionLess(versions[i], versions[j])
+	})
+	return versions
+}
+
+// VersionLess compares dot separated numeric versions, like: 25.0.3 < 28.0.0-rc1 < 28.0.0
+func VersionLess(version, other string) bool {
+	components, otherComponents := strings.Split(version, "."), strings.Split(other, ".")
+	for i := 0; i < len(components) && i < len(otherComponents); i++ {
+		number, preRelease := splitPreRelease(components[i])
+		otherNumber, otherPreRelease := splitPreRelease(otherComponents[i])
+		if number != otherNumber {
+			return number < otherNumber
+		}
+		if preRelease != otherPreRelease {
+			// a pre-release is less than the release
+			return preRelease != "" && (otherPreRelease == "" || preRelease < otherPreRelease)
+		}
+	}
+	return len(components) < len(otherComponents)
+}
+
+func splitPreRelease(component string) (int, string) {
+	split := strings.SplitN(component, "-", 2)
+	number, err := strconv.Atoi(split[0])
+	if err != nil {
+		return 0, component
+	}
+	if len(split) == 2 {
+		return number, split[1]
+	}
+	return number, ""
+}
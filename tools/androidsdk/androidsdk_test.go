@@ -0,0 +1,120 @@
+package androidsdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/stretchr/testify/require"
+)
+
+func createBuildTools(t *testing.T, dir string, tools ...string) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for _, tool := range tools {
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, tool), "test"))
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	require.Equal(t, true, VersionLess("25.0.3", "26.0.0"))
+	require.Equal(t, true, VersionLess("9.0.0", "25.0.3"))
+	require.Equal(t, true, VersionLess("28.0.0-rc1", "28.0.0"))
+	require.Equal(t, true, VersionLess("28.0.0-rc1", "28.0.0-rc2"))
+	require.Equal(t, true, VersionLess("28.0", "28.0.1"))
+	require.Equal(t, false, VersionLess("28.0.0", "28.0.0"))
+	require.Equal(t, false, VersionLess("28.0.0", "27.0.3"))
+}
+
+func TestLatestBuildToolsDir(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("androidsdk_test")
+	require.NoError(t, err)
+
+	t.Log("it returns the latest build-tools with zipalign")
+	{
+		createBuildTools(t, filepath.Join(tmpDir, "25.0.3"), "zipalign")
+		createBuildTools(t, filepath.Join(tmpDir, "28.0.0-rc1"), "zipalign", "apksigner")
+		createBuildTools(t, filepath.Join(tmpDir, "27.0.3"), "zipalign", "apksigner")
+		createBuildTools(t, filepath.Join(tmpDir, "29.0.0"))
+
+		dir, err := LatestBuildToolsDir(tmpDir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(tmpDir, "28.0.0-rc1"), dir)
+	}
+
+	t.Log("it fails if no build-tools found")
+	{
+		_, err := LatestBuildToolsDir(filepath.Join(tmpDir, "29.0.0"))
+		require.Error(t, err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	envs := []string{"ANDROID_HOME", "ANDROID_SDK_ROOT", "ANDROID_NDK_HOME", "ANDROID_NDK_ROOT", "JAVA_HOME"}
+	origEnvs := map[string]string{}
+	for _, env := range envs {
+		origEnvs[env] = os.Getenv(env)
+		require.NoError(t, os.Unsetenv(env))
+	}
+	defer func() {
+		for env, value := range origEnvs {
+			require.NoError(t, os.Setenv(env, value))
+		}
+	}()
+
+	sdkDir, err := pathutil.NormalizedOSTempDirPath("androidsdk_test")
+	require.NoError(t, err)
+	createBuildTools(t, filepath.Join(sdkDir, "build-tools", "27.0.3"), "zipalign")
+	require.NoError(t, os.MkdirAll(filepath.Join(sdkDir, "platforms", "android-27"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(sdkDir, "ndk", "21.4.7075529"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(sdkDir, "ndk", "25.1.8937393"), 0755))
+
+	t.Log("it fails without sdk environment")
+	{
+		_, err := Resolve()
+		require.Error(t, err)
+	}
+
+	t.Log("it resolves the sdk and its latest ndk")
+	{
+		require.NoError(t, os.Setenv("ANDROID_SDK_ROOT", sdkDir))
+
+		sdk, err := Resolve()
+		require.NoError(t, err)
+		require.Equal(t, Model{SDKDir: sdkDir, NDKDir: filepath.Join(sdkDir, "ndk", "25.1.8937393")}, sdk)
+		require.Equal(t, map[string]string{
+			"AndroidSdkDirectory": sdkDir,
+			"AndroidNdkDirectory": filepath.Join(sdkDir, "ndk", "25.1.8937393"),
+		}, sdk.BuildProperties())
+	}
+
+	t.Log("it fails for missing JAVA_HOME")
+	{
+		require.NoError(t, os.Setenv("JAVA_HOME", filepath.Join(sdkDir, "jdk")))
+
+		_, err := Resolve()
+		require.Error(t, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	sdkDir, err := pathutil.NormalizedOSTempDirPath("androidsdk_test")
+	require.NoError(t, err)
+	sdk := Model{SDKDir: sdkDir}
+
+	t.Log("it reports missing build-tools and platform")
+	{
+		require.Equal(t, 3, len(sdk.Validate(27, 28)))
+		require.Equal(t, 1, len(sdk.Validate(0)))
+	}
+
+	t.Log("it validates the installed platform and build-tools")
+	{
+		createBuildTools(t, filepath.Join(sdkDir, "build-tools", "27.0.3"), "zipalign")
+		require.NoError(t, os.MkdirAll(filepath.Join(sdkDir, "platforms", "android-27"), 0755))
+
+		require.Equal(t, []string{}, sdk.Validate(27))
+		require.Equal(t, 1, len(sdk.Validate(27, 28)))
+	}
+}
@@ -0,0 +1,243 @@
+package androidsigner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+	"github.com/bitrise-tools/go-xamarin/tools/androidsdk"
+)
+
+const (
+	// StorePasswordEnvKey - the environment variable of the keystore password, read by apksigner and jarsigner
+	StorePasswordEnvKey = "GO_XAMARIN_KEYSTORE_PASSWORD"
+	// KeyPasswordEnvKey - the environment variable of the key password, read by apksigner and jarsigner
+	KeyPasswordEnvKey = "GO_XAMARIN_KEY_PASSWORD"
+)
+
+// Model - zipaligns and signs an unsigned apk: zipalign then apksigner,
+// or jarsigner then zipalign, if apksigner is not available (build-tools older than 24.0.3)
+type Model struct {
+	buildToolsDir     string
+	useJarsigner      bool
+	apksignerNotExist bool // jarsigner is used, as apksigner is not installed
+
+	apkPth       string
+	signedAPKPth string
+
+	keystorePth   string
+	keyAlias      string
+	storePassword string
+	keyPassword   string
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+
+	logger tools.Logger
+}
+
+// SystemBuildToolsDir - returns the latest Android build-tools directory of the Android SDK
+// pointed by the ANDROID_HOME or ANDROID_SDK_ROOT environment
+func SystemBuildToolsDir() (string, error) {
+	sdk, err := androidsdk.Resolve()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine build-tools path, error: %w", err)
+	}
+
+	return sdk.BuildToolsDir()
+}
+
+// New - apkPth is the unsigned apk, the zipaligned and signed apk is written to signedAPKPth
+func New(buildToolsDir, apkPth, signedAPKPth string) (*Model, error) {
+	if exist, err := pathutil.IsPathExists(filepath.Join(buildToolsDir, "zipalign")); err != nil {
+		return nil, fmt.Errorf("Failed to check if zipalign exist in (%s), error: %s", buildToolsDir, err)
+	} else if !exist {
+		return nil, tools.ToolNotInstalledErrorf("zipalign not exist in: %s", buildToolsDir)
+	}
+
+	apksignerNotExist := false
+	if exist, err := pathutil.IsPathExists(filepath.Join(buildToolsDir, "apksigner")); err != nil {
+		return nil, fmt.Errorf("Failed to check if apksigner exist in (%s), error: %s", buildToolsDir, err)
+	} else if !exist {
+		apksignerNotExist = true
+	}
+
+	return &Model{
+		buildToolsDir:     buildToolsDir,
+		useJarsigner:      apksignerNotExist,
+		apksignerNotExist: apksignerNotExist,
+		apkPth:            apkPth,
+		signedAPKPth:      signedAPKPth,
+		stdout:            os.Stdout,
+		stderr:            os.Stderr,
+	}, nil
+}
+
+// SetKeystore - keyPassword defaults to the storePassword
+func (signer *Model) SetKeystore(keystorePth, keyAlias, storePassword, keyPassword string) *Model {
+	if keyPassword == "" {
+		keyPassword = storePassword
+	}
+
+	signer.keystorePth = keystorePth
+	signer.keyAlias = keyAlias
+	signer.storePassword = storePassword
+	signer.keyPassword = keyPassword
+	return signer
+}
+
+// SetUseJarsigner - forces the jarsigner (v1 scheme only) signing
+func (signer *Model) SetUseJarsigner(useJarsigner bool) *Model {
+	signer.useJarsigner = useJarsigner
+	return signer
+}
+
+// SetCustomOptions - options are passed to apksigner or jarsigner
+func (signer *Model) SetCustomOptions(options ...string) {
+	signer.customOptions = options
+}
+
+// SetStdout ...
+func (signer *Model) SetStdout(out io.Writer) {
+	signer.stdout = out
+}
+
+// SetStderr ...
+func (signer *Model) SetStderr(err io.Writer) {
+	signer.stderr = err
+}
+
+// SetTimeout - sets the timeout of each step
+func (signer *Model) SetTimeout(timeout time.Duration) {
+	signer.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (signer *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	signer.killGracePeriod = killGracePeriod
+}
+
+// SetLogger - receives the messages of the command, defaults to printing them
+func (signer *Model) SetLogger(logger tools.Logger) {
+	signer.logger = logger
+}
+
+func (signer Model) alignedAPKPth() string {
+	return strings.TrimSuffix(signer.signedAPKPth, filepath.Ext(signer.signedAPKPth)) + "-aligned.apk"
+}
+
+func (signer Model) jarsignedAPKPth() string {
+	return strings.TrimSuffix(signer.signedAPKPth, filepath.Ext(signer.signedAPKPth)) + "-jarsigned.apk"
+}
+
+func (signer Model) zipalignCommandSlice(inputPth, outputPth string) []string {
+	return []string{filepath.Join(signer.buildToolsDir, "zipalign"), "-f", "-p", "4", inputPth, outputPth}
+}
+
+func (signer Model) apksignerCommandSlice(inputPth string) []string {
+	cmdSlice := []string{
+		filepath.Join(signer.buildToolsDir, "apksigner"), "sign",
+		"--ks", signer.keystorePth,
+		"--ks-key-alias", signer.keyAlias,
+		"--ks-pass", "env:" + StorePasswordEnvKey,
+		"--key-pass", "env:" + KeyPasswordEnvKey,
+		"--out", signer.signedAPKPth,
+	}
+	cmdSlice = append(cmdSlice, signer.customOptions...)
+	return append(cmdSlice, inputPth)
+}
+
+func (signer Model) jarsignerCommandSlice(outputPth string) []string {
+	cmdSlice := []string{
+		"jarsigner",
+		"-sigalg", "SHA256withRSA",
+		"-digestalg", "SHA-256",
+		"-keystore", signer.keystorePth,
+		"-storepass:env", StorePasswordEnvKey,
+		"-keypass:env", KeyPasswordEnvKey,
+		"-signedjar", outputPth,
+	}
+	cmdSlice = append(cmdSlice, signer.customOptions...)
+	return append(cmdSlice, signer.apkPth, signer.keyAlias)
+}
+
+// commandSlices returns the commands of the signing steps in order:
+// apksigner signs the zipaligned apk, while zipalign has to run on the jarsigned apk
+func (signer Model) commandSlices() [][]string {
+	if signer.useJarsigner {
+		return [][]string{
+			signer.jarsignerCommandSlice(signer.jarsignedAPKPth()),
+			signer.zipalignCommandSlice(signer.jarsignedAPKPth(), signer.signedAPKPth),
+		}
+	}
+
+	return [][]string{
+		signer.zipalignCommandSlice(signer.apkPth, signer.alignedAPKPth()),
+		signer.apksignerCommandSlice(signer.alignedAPKPth()),
+	}
+}
+
+// environment returns the passwords, as the signers read them from the environment,
+// to keep them out of the process list
+func (signer Model) environment() []string {
+	return append(os.Environ(),
+		StorePasswordEnvKey+"="+signer.storePassword,
+		KeyPasswordEnvKey+"="+signer.keyPassword,
+	)
+}
+
+// PrintableCommand - the passwords are passed in the environment, they are not part of the command
+func (signer Model) PrintableCommand() string {
+	printableCommands := []string{}
+	for _, cmdSlice := range signer.commandSlices() {
+		printableCommands = append(printableCommands, command.PrintableCommandArgs(true, cmdSlice))
+	}
+	return strings.Join(printableCommands, " && ")
+}
+
+// Run ...
+func (signer Model) Run() error {
+	if signer.keystorePth == "" {
+		return fmt.Errorf("no keystore set")
+	}
+
+	logger := tools.LoggerOrDefault(signer.logger)
+	if signer.useJarsigner && signer.apksignerNotExist {
+		logger.Warnf("apksigner not exist in (%s), using jarsigner", signer.buildToolsDir)
+	}
+
+	intermediatePth := signer.alignedAPKPth()
+	if signer.useJarsigner {
+		intermediatePth = signer.jarsignedAPKPth()
+	}
+	defer func() {
+		if err := os.Remove(intermediatePth); err != nil && !os.IsNotExist(err) {
+			logger.Warnf("Failed to remove (%s), error: %s", intermediatePth, err)
+		}
+	}()
+
+	for _, cmdSlice := range signer.commandSlices() {
+		cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+		cmd.Env = signer.environment()
+		cmd.Stdout = signer.stdout
+		cmd.Stderr = signer.stderr
+
+		if err := tools.RunLoggedCommandWithTimeout(cmd, signer.timeout, signer.killGracePeriod, signer.logger); err != nil {
+			return fmt.Errorf("%s failed, error: %s", filepath.Base(cmdSlice[0]), err)
+		}
+	}
+
+	return nil
+}
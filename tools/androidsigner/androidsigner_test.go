@@ -0,0 +1,96 @@
+package androidsigner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+	"github.com/stretchr/testify/require"
+)
+
+func createBuildTools(t *testing.T, dir string, tools ...string) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for _, tool := range tools {
+		require.NoError(t, fileutil.WriteStringToFile(filepath.Join(dir, tool), "test"))
+	}
+}
+
+func TestPrintableCommand(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("androidsigner_test")
+	require.NoError(t, err)
+
+	t.Log("it zipaligns then signs by apksigner")
+	{
+		buildToolsDir := filepath.Join(tmpDir, "27.0.3")
+		createBuildTools(t, buildToolsDir, "zipalign", "apksigner")
+
+		signer, err := New(buildToolsDir, "/bin/app.apk", "/bin/app-Signed.apk")
+		require.NoError(t, err)
+		signer.SetKeystore("/release.keystore", "release", "store-secret", "")
+
+		commands := strings.Split(signer.PrintableCommand(), " && ")
+		require.Equal(t, 2, len(commands))
+		require.Equal(t, `"`+filepath.Join(buildToolsDir, "zipalign")+`" "-f" "-p" "4" "/bin/app.apk" "/bin/app-Signed-aligned.apk"`, commands[0])
+		require.Contains(t, commands[1], `"--ks-pass" "env:GO_XAMARIN_KEYSTORE_PASSWORD" "--key-pass" "env:GO_XAMARIN_KEY_PASSWORD" "--out" "/bin/app-Signed.apk" "/bin/app-Signed-aligned.apk"`)
+		require.NotContains(t, signer.PrintableCommand(), "store-secret")
+	}
+
+	t.Log("it signs by jarsigner then zipaligns, if apksigner not exist")
+	{
+		buildToolsDir := filepath.Join(tmpDir, "23.0.3")
+		createBuildTools(t, buildToolsDir, "zipalign")
+
+		signer, err := New(buildToolsDir, "/bin/app.apk", "/bin/app-Signed.apk")
+		require.NoError(t, err)
+		signer.SetKeystore("/release.keystore", "release", "store-secret", "key-secret")
+
+		commands := strings.Split(signer.PrintableCommand(), " && ")
+		require.Equal(t, 2, len(commands))
+		require.Contains(t, commands[0], `"jarsigner"`)
+		require.Contains(t, commands[0], `"-storepass:env" "GO_XAMARIN_KEYSTORE_PASSWORD" "-keypass:env" "GO_XAMARIN_KEY_PASSWORD" "-signedjar" "/bin/app-Signed-jarsigned.apk" "/bin/app.apk" "release"`)
+		require.Contains(t, commands[1], `"/bin/app-Signed-jarsigned.apk" "/bin/app-Signed.apk"`)
+		require.NotContains(t, signer.PrintableCommand(), "secret")
+	}
+
+	t.Log("it passes the passwords in the environment")
+	{
+		signer, err := New(filepath.Join(tmpDir, "27.0.3"), "/bin/app.apk", "/bin/app-Signed.apk")
+		require.NoError(t, err)
+		signer.SetKeystore("/release.keystore", "release", "store-secret", "key-secret")
+
+		env := signer.environment()
+		require.Contains(t, env, "GO_XAMARIN_KEYSTORE_PASSWORD=store-secret")
+		require.Contains(t, env, "GO_XAMARIN_KEY_PASSWORD=key-secret")
+	}
+
+	t.Log("it fails if zipalign not exist")
+	{
+		_, err := New(filepath.Join(tmpDir, "missing"), "/bin/app.apk", "/bin/app-Signed.apk")
+		require.Error(t, err)
+	}
+}
+
+func TestSystemBuildToolsDir(t *testing.T) {
+	envs := []string{"ANDROID_HOME", "ANDROID_SDK_ROOT"}
+	origEnvs := map[string]string{}
+	for _, env := range envs {
+		origEnvs[env] = os.Getenv(env)
+		require.NoError(t, os.Unsetenv(env))
+	}
+	defer func() {
+		for env, value := range origEnvs {
+			require.NoError(t, os.Setenv(env, value))
+		}
+	}()
+
+	t.Log("it fails with ErrToolNotInstalled without Android SDK")
+	{
+		_, err := SystemBuildToolsDir()
+		require.True(t, errors.Is(err, tools.ErrToolNotInstalled))
+	}
+}
@@ -0,0 +1,218 @@
+package appcenter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Model - appcenter test run uitest command, uploads the app and the Xamarin.UITest assemblies to App Center Test
+type Model struct {
+	appcenterPth string
+
+	app      string // owner/app
+	appPth   string // ipa or apk
+	buildDir string // directory of the test assemblies
+
+	devices        string // device set (owner/set) or device selection hash
+	series         string
+	locale         string
+	uitestToolsDir string
+	dsymDir        string
+	testOutputDir  string
+	async          bool
+
+	token string
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// SystemAppCenterPath - returns the appcenter cli pointed by the APPCENTER_CLI_PATH environment,
+// or the appcenter executable in the PATH
+func SystemAppCenterPath() (string, error) {
+	if appcenterPth := os.Getenv("APPCENTER_CLI_PATH"); appcenterPth != "" {
+		if exist, err := pathutil.IsPathExists(appcenterPth); err != nil {
+			return "", fmt.Errorf("Failed to check if appcenter cli exist at (%s), error: %s", appcenterPth, err)
+		} else if !exist {
+			return "", tools.ToolNotInstalledErrorf("appcenter cli not exist at: %s", appcenterPth)
+		}
+		return appcenterPth, nil
+	}
+
+	appcenterPth, err := exec.LookPath("appcenter")
+	if err != nil {
+		return "", tools.ToolNotInstalledErrorf("APPCENTER_CLI_PATH environment is not set and appcenter not found in PATH, error: %s", err)
+	}
+	return appcenterPth, nil
+}
+
+// New - app is the App Center app, like: owner/app
+func New(appcenterPth, app, appPth, buildDir string) (*Model, error) {
+	if app == "" {
+		return nil, fmt.Errorf("App Center app is required, like: owner/app")
+	}
+
+	absAppCenterPth, err := pathutil.AbsPath(appcenterPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", appcenterPth, err)
+	}
+
+	return &Model{
+		appcenterPth: absAppCenterPth,
+		app:          app,
+		appPth:       appPth,
+		buildDir:     buildDir,
+		stdout:       os.Stdout,
+		stderr:       os.Stderr,
+	}, nil
+}
+
+// SetDevices - the device set, like: owner/top-devices, or the hash of a device selection
+func (appcenter *Model) SetDevices(devices string) *Model {
+	appcenter.devices = devices
+	return appcenter
+}
+
+// SetSeries ...
+func (appcenter *Model) SetSeries(series string) *Model {
+	appcenter.series = series
+	return appcenter
+}
+
+// SetLocale - like: en_US
+func (appcenter *Model) SetLocale(locale string) *Model {
+	appcenter.locale = locale
+	return appcenter
+}
+
+// SetUITestToolsDir - directory of the test-cloud.exe, defaults to the one of the Xamarin.UITest nuget package in the build dir
+func (appcenter *Model) SetUITestToolsDir(uitestToolsDir string) *Model {
+	appcenter.uitestToolsDir = uitestToolsDir
+	return appcenter
+}
+
+// SetDSYMDir ...
+func (appcenter *Model) SetDSYMDir(dsymDir string) *Model {
+	appcenter.dsymDir = dsymDir
+	return appcenter
+}
+
+// SetTestOutputDir - the NUnit results are downloaded into the directory, ignored by async runs
+func (appcenter *Model) SetTestOutputDir(testOutputDir string) *Model {
+	appcenter.testOutputDir = testOutputDir
+	return appcenter
+}
+
+// SetAsync - the command exits when the upload is done, without waiting for the test results
+func (appcenter *Model) SetAsync(async bool) *Model {
+	appcenter.async = async
+	return appcenter
+}
+
+// SetToken - the App Center API token, the appcenter cli's logged in user is used otherwise
+func (appcenter *Model) SetToken(token string) *Model {
+	appcenter.token = token
+	return appcenter
+}
+
+// SetCustomOptions ...
+func (appcenter *Model) SetCustomOptions(options ...string) {
+	appcenter.customOptions = options
+}
+
+// SetStdout ...
+func (appcenter *Model) SetStdout(out io.Writer) {
+	appcenter.stdout = out
+}
+
+// SetStderr ...
+func (appcenter *Model) SetStderr(err io.Writer) {
+	appcenter.stderr = err
+}
+
+// SetTimeout ...
+func (appcenter *Model) SetTimeout(timeout time.Duration) {
+	appcenter.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (appcenter *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	appcenter.killGracePeriod = killGracePeriod
+}
+
+func (appcenter Model) commandSlice(maskSecrets bool) []string {
+	cmdSlice := []string{appcenter.appcenterPth, "test", "run", "uitest",
+		"--app", appcenter.app,
+		"--app-path", appcenter.appPth,
+		"--build-dir", appcenter.buildDir,
+	}
+
+	if appcenter.devices != "" {
+		cmdSlice = append(cmdSlice, "--devices", appcenter.devices)
+	}
+	if appcenter.series != "" {
+		cmdSlice = append(cmdSlice, "--test-series", appcenter.series)
+	}
+	if appcenter.locale != "" {
+		cmdSlice = append(cmdSlice, "--locale", appcenter.locale)
+	}
+	if appcenter.uitestToolsDir != "" {
+		cmdSlice = append(cmdSlice, "--uitest-tools-dir", appcenter.uitestToolsDir)
+	}
+	if appcenter.dsymDir != "" {
+		cmdSlice = append(cmdSlice, "--dsym-dir", appcenter.dsymDir)
+	}
+
+	if appcenter.async {
+		cmdSlice = append(cmdSlice, "--async")
+	} else if appcenter.testOutputDir != "" {
+		cmdSlice = append(cmdSlice, "--test-output-dir", appcenter.testOutputDir)
+	}
+
+	if appcenter.token != "" {
+		token := appcenter.token
+		if maskSecrets {
+			token = tools.SecretMask
+		}
+		cmdSlice = append(cmdSlice, "--token", token)
+	}
+
+	cmdSlice = append(cmdSlice, "--quiet")
+
+	cmdSlice = append(cmdSlice, appcenter.customOptions...)
+	return cmdSlice
+}
+
+// PrintableCommand - the token is masked
+func (appcenter Model) PrintableCommand() string {
+	cmdSlice := appcenter.commandSlice(true)
+
+	return command.PrintableCommandArgs(true, cmdSlice)
+}
+
+// Run ...
+func (appcenter Model) Run() error {
+	cmdSlice := appcenter.commandSlice(false)
+
+	command, err := command.NewFromSlice(cmdSlice)
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(appcenter.stdout)
+	command.SetStderr(appcenter.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), appcenter.timeout, appcenter.killGracePeriod)
+}
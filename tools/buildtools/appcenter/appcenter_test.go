@@ -0,0 +1,34 @@
+package appcenter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it runs the uitests and downloads the results")
+	{
+		appcenter, err := New("/usr/local/bin/appcenter", "bitrise/Sample-iOS", "/bin/iPhone/Release/Sample.ipa", "/UITests/bin/Release")
+		require.NoError(t, err)
+		appcenter.SetDevices("bitrise/top-devices").SetSeries("master").SetLocale("en_US").SetTestOutputDir("/results")
+
+		require.Equal(t, `"/usr/local/bin/appcenter" "test" "run" "uitest" "--app" "bitrise/Sample-iOS" "--app-path" "/bin/iPhone/Release/Sample.ipa" "--build-dir" "/UITests/bin/Release" "--devices" "bitrise/top-devices" "--test-series" "master" "--locale" "en_US" "--test-output-dir" "/results" "--quiet"`, appcenter.PrintableCommand())
+	}
+
+	t.Log("it runs async with masked token")
+	{
+		appcenter, err := New("/usr/local/bin/appcenter", "bitrise/Sample-Droid", "/bin/Release/com.bitrise.sample.apk", "/UITests/bin/Release")
+		require.NoError(t, err)
+		appcenter.SetDevices("a1b2c3").SetAsync(true).SetTestOutputDir("/results").SetToken("api-secret")
+
+		require.Equal(t, `"/usr/local/bin/appcenter" "test" "run" "uitest" "--app" "bitrise/Sample-Droid" "--app-path" "/bin/Release/com.bitrise.sample.apk" "--build-dir" "/UITests/bin/Release" "--devices" "a1b2c3" "--async" "--token" "***" "--quiet"`, appcenter.PrintableCommand())
+		require.Contains(t, appcenter.commandSlice(false), "api-secret")
+	}
+
+	t.Log("it requires the app")
+	{
+		_, err := New("/usr/local/bin/appcenter", "", "/bin/Release/com.bitrise.sample.apk", "/UITests/bin/Release")
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,193 @@
+package bundletool
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Mode - the apks to generate from the app bundle
+type Mode string
+
+const (
+	// ModeDefault - split apks, optionally filtered by the device spec
+	ModeDefault Mode = "default"
+	// ModeUniversal - a single universal.apk, installable on any device
+	ModeUniversal Mode = "universal"
+)
+
+// UniversalAPKName - name of the universal apk in the generated apk set
+const UniversalAPKName = "universal.apk"
+
+// Model - bundletool build-apks command, generates an apk set (.apks) from an app bundle (.aab)
+type Model struct {
+	bundletoolPth string
+
+	aabPth    string
+	outputPth string
+
+	mode          Mode
+	deviceSpecPth string
+
+	keystorePth   string
+	keyAlias      string
+	storePassword string
+	keyPassword   string
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// SystemBundletoolPath - returns the bundletool pointed by the BUNDLETOOL_PATH environment,
+// or the bundletool executable in the PATH
+func SystemBundletoolPath() (string, error) {
+	if bundletoolPth := os.Getenv("BUNDLETOOL_PATH"); bundletoolPth != "" {
+		if exist, err := pathutil.IsPathExists(bundletoolPth); err != nil {
+			return "", fmt.Errorf("Failed to check if bundletool exist at (%s), error: %s", bundletoolPth, err)
+		} else if !exist {
+			return "", tools.ToolNotInstalledErrorf("bundletool not exist at: %s", bundletoolPth)
+		}
+		return bundletoolPth, nil
+	}
+
+	bundletoolPth, err := exec.LookPath("bundletool")
+	if err != nil {
+		return "", tools.ToolNotInstalledErrorf("BUNDLETOOL_PATH environment is not set and bundletool not found in PATH, error: %s", err)
+	}
+	return bundletoolPth, nil
+}
+
+// New - bundletoolPth is either the bundletool executable or the bundletool-all.jar
+func New(bundletoolPth, aabPth, outputPth string) (*Model, error) {
+	absBundletoolPth, err := pathutil.AbsPath(bundletoolPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", bundletoolPth, err)
+	}
+
+	return &Model{
+		bundletoolPth: absBundletoolPth,
+		aabPth:        aabPth,
+		outputPth:     outputPth,
+		mode:          ModeDefault,
+		stdout:        os.Stdout,
+		stderr:        os.Stderr,
+	}, nil
+}
+
+// SetMode ...
+func (bundletool *Model) SetMode(mode Mode) *Model {
+	bundletool.mode = mode
+	return bundletool
+}
+
+// SetDeviceSpecPth - the generated apk set contains only the apks matching the device spec json
+func (bundletool *Model) SetDeviceSpecPth(deviceSpecPth string) *Model {
+	bundletool.deviceSpecPth = deviceSpecPth
+	return bundletool
+}
+
+// SetKeystore - the generated apks are signed with the keystore, bundletool uses the debug keystore otherwise,
+// keyPassword defaults to the storePassword
+func (bundletool *Model) SetKeystore(keystorePth, keyAlias, storePassword, keyPassword string) *Model {
+	if keyPassword == "" {
+		keyPassword = storePassword
+	}
+
+	bundletool.keystorePth = keystorePth
+	bundletool.keyAlias = keyAlias
+	bundletool.storePassword = storePassword
+	bundletool.keyPassword = keyPassword
+	return bundletool
+}
+
+// SetCustomOptions ...
+func (bundletool *Model) SetCustomOptions(options ...string) {
+	bundletool.customOptions = options
+}
+
+// SetStdout ...
+func (bundletool *Model) SetStdout(out io.Writer) {
+	bundletool.stdout = out
+}
+
+// SetStderr ...
+func (bundletool *Model) SetStderr(err io.Writer) {
+	bundletool.stderr = err
+}
+
+// SetTimeout ...
+func (bundletool *Model) SetTimeout(timeout time.Duration) {
+	bundletool.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (bundletool *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	bundletool.killGracePeriod = killGracePeriod
+}
+
+func (bundletool Model) commandSlice(maskSecrets bool) []string {
+	cmdSlice := []string{bundletool.bundletoolPth}
+	if strings.HasSuffix(strings.ToLower(bundletool.bundletoolPth), ".jar") {
+		cmdSlice = []string{"java", "-jar", bundletool.bundletoolPth}
+	}
+
+	cmdSlice = append(cmdSlice, "build-apks", "--bundle="+bundletool.aabPth, "--output="+bundletool.outputPth, "--overwrite")
+
+	if bundletool.mode != "" && bundletool.mode != ModeDefault {
+		cmdSlice = append(cmdSlice, "--mode="+string(bundletool.mode))
+	}
+	if bundletool.deviceSpecPth != "" {
+		cmdSlice = append(cmdSlice, "--device-spec="+bundletool.deviceSpecPth)
+	}
+
+	if bundletool.keystorePth != "" {
+		storePassword, keyPassword := bundletool.storePassword, bundletool.keyPassword
+		if maskSecrets {
+			storePassword, keyPassword = tools.SecretMask, tools.SecretMask
+		}
+
+		cmdSlice = append(cmdSlice,
+			"--ks="+bundletool.keystorePth,
+			"--ks-key-alias="+bundletool.keyAlias,
+			"--ks-pass=pass:"+storePassword,
+			"--key-pass=pass:"+keyPassword,
+		)
+	}
+
+	cmdSlice = append(cmdSlice, bundletool.customOptions...)
+	return cmdSlice
+}
+
+// PrintableCommand - the keystore passwords are masked
+func (bundletool Model) PrintableCommand() string {
+	cmdSlice := bundletool.commandSlice(true)
+
+	return command.PrintableCommandArgs(true, cmdSlice)
+}
+
+// Run ...
+func (bundletool Model) Run() error {
+	cmdSlice := bundletool.commandSlice(false)
+
+	command, err := command.NewFromSlice(cmdSlice)
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(bundletool.stdout)
+	command.SetStderr(bundletool.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), bundletool.timeout, bundletool.killGracePeriod)
+}
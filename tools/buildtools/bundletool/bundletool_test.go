@@ -0,0 +1,38 @@
+package bundletool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it builds the apk set by the bundletool executable")
+	{
+		bundletool, err := New("/usr/local/bin/bundletool", "/bin/com.bitrise.app.aab", "/bin/com.bitrise.app.apks")
+		require.NoError(t, err)
+
+		require.Equal(t, `"/usr/local/bin/bundletool" "build-apks" "--bundle=/bin/com.bitrise.app.aab" "--output=/bin/com.bitrise.app.apks" "--overwrite"`, bundletool.PrintableCommand())
+	}
+
+	t.Log("it runs the bundletool jar in universal mode with masked keystore passwords")
+	{
+		bundletool, err := New("/tools/bundletool-all.jar", "/bin/com.bitrise.app.aab", "/bin/com.bitrise.app.apks")
+		require.NoError(t, err)
+		bundletool.SetMode(ModeUniversal)
+		bundletool.SetKeystore("/release.keystore", "release", "store-secret", "")
+
+		require.Equal(t, `"java" "-jar" "/tools/bundletool-all.jar" "build-apks" "--bundle=/bin/com.bitrise.app.aab" "--output=/bin/com.bitrise.app.apks" "--overwrite" "--mode=universal" "--ks=/release.keystore" "--ks-key-alias=release" "--ks-pass=pass:***" "--key-pass=pass:***"`, bundletool.PrintableCommand())
+		require.Equal(t, "--key-pass=pass:store-secret", bundletool.commandSlice(false)[len(bundletool.commandSlice(false))-1])
+	}
+
+	t.Log("it filters the apk set by device spec")
+	{
+		bundletool, err := New("/usr/local/bin/bundletool", "/bin/com.bitrise.app.aab", "/bin/com.bitrise.app.apks")
+		require.NoError(t, err)
+		bundletool.SetDeviceSpecPth("/device-spec.json")
+
+		require.Contains(t, bundletool.PrintableCommand(), `"--device-spec=/device-spec.json"`)
+		require.NotContains(t, bundletool.PrintableCommand(), "--mode")
+	}
+}
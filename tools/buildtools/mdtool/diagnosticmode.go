@@ -3,30 +3,44 @@ package mdtool
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/bitrise-io/go-utils/command"
-	"github.com/bitrise-io/go-utils/log"
+	"github.com/bitrise-tools/go-xamarin/tools"
 )
 
-func runCommandInDiagnosticMode(command command.Model, checkPattern string, waitTime time.Duration, forceWaitTime time.Duration, retryOnHang bool) error {
-	log.Warnf("Run in diagnostic mode")
+// outputDrainTimeout - the time to read the remaining output after the process exited
+const outputDrainTimeout = 5 * time.Second
+
+func runCommandInDiagnosticMode(command command.Model, checkPattern string, waitTime time.Duration, forceWaitTime time.Duration, retryOnHang bool, outWriter, errWriter io.Writer, timeout, killGracePeriod time.Duration, logger tools.Logger) error {
+	logger.Warnf("Run in diagnostic mode")
 
 	// copy command model to avoid re-run error: Stdout already set
 	cmd := *command.GetCmd()
 
-	timeout := false
+	// the timers and their results are accessed by the output scanner and the timer goroutines
+	var mutex sync.Mutex
+
+	hanged := false
+	stopped := false // the process finished, the timers are not restarted
 
 	// Create a timer that will FORCE kill the process if normal kill does not work
 	var forceKillError error
 	var forceKillTimeoutHandler *time.Timer
 	startForceKillTimeoutHandler := func() {
 		forceKillTimeoutHandler = time.AfterFunc(forceWaitTime, func() {
-			log.Warnf("Process QUIT timeout")
+			logger.Warnf("Process QUIT timeout")
+
+			err := cmd.Process.Signal(syscall.SIGKILL)
 
-			forceKillError = cmd.Process.Signal(syscall.SIGKILL)
+			mutex.Lock()
+			forceKillError = err
+			mutex.Unlock()
 		})
 	}
 	// ----
@@ -36,30 +50,62 @@ func runCommandInDiagnosticMode(command command.Model, checkPattern string, wait
 	var killTimeoutHandler *time.Timer
 	startKillTimeoutHandler := func() {
 		killTimeoutHandler = time.AfterFunc(waitTime, func() {
-			log.Warnf("Process timed out")
+			logger.Warnf("Process timed out")
 
-			timeout = true
+			err := cmd.Process.Signal(syscall.SIGQUIT)
 
-			killError = cmd.Process.Signal(syscall.SIGQUIT)
+			mutex.Lock()
+			defer mutex.Unlock()
 
-			startForceKillTimeoutHandler()
+			hanged = true
+			killError = err
+			if !stopped {
+				startForceKillTimeoutHandler()
+			}
 		})
 	}
 
 	// ----
 
 	// Redirect output
-	stdoutReader, err := cmd.StdoutPipe()
+	cmd.Stderr = errWriter
+
+	// the pipe is not closed by Wait (unlike the cmd's StdoutPipe), so the output is drained after the process exited
+	stdoutReader, stdoutWriter, err := os.Pipe()
 	if err != nil {
 		return err
 	}
+	defer func() {
+		// the output is only read
+		_ = stdoutReader.Close()
+	}()
+	cmd.Stdout = stdoutWriter
 
+	startErr := cmd.Start()
+	// the started process holds its own copy of the write end
+	if err := stdoutWriter.Close(); err != nil && startErr == nil {
+		logger.Warnf("Failed to close output pipe, error: %s", err)
+	}
+	if startErr != nil {
+		return startErr
+	}
+
+	watchdog := tools.WatchProcess(&cmd, timeout, killGracePeriod)
+	watchdog.SetLogger(logger)
+
+	// the output is scanned until the process closes its stdout
 	scanner := bufio.NewScanner(stdoutReader)
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
+
 		for scanner.Scan() {
 			line := scanner.Text()
-			fmt.Println(line)
+			if _, err := fmt.Fprintln(outWriter, line); err != nil {
+				logger.Errorf("Failed to write output, error: %s", err)
+			}
 
+			mutex.Lock()
 			// stop timeout handler if new line comes
 			if killTimeoutHandler != nil {
 				killTimeoutHandler.Stop()
@@ -69,20 +115,27 @@ func runCommandInDiagnosticMode(command command.Model, checkPattern string, wait
 			if strings.Contains(strings.TrimSpace(line), checkPattern) {
 				startKillTimeoutHandler()
 			}
+			mutex.Unlock()
 		}
 	}()
-	if err := scanner.Err(); err != nil {
-		return err
-	}
 	// ----
 
-	if err := cmd.Start(); err != nil {
-		return err
-	}
-
 	// Only proceed once the process has finished
 	cmdErr := cmd.Wait()
 
+	// the output is drained, unless a child process keeps it open
+	scanErr := error(nil)
+	select {
+	case <-done:
+		scanErr = scanner.Err()
+	case <-time.After(outputDrainTimeout):
+		logger.Warnf("Output is still open %s after the process exited, closing it", outputDrainTimeout)
+		_ = stdoutReader.Close()
+		<-done
+	}
+
+	mutex.Lock()
+	stopped = true
 	if killTimeoutHandler != nil {
 		killTimeoutHandler.Stop()
 	}
@@ -90,23 +143,32 @@ func runCommandInDiagnosticMode(command command.Model, checkPattern string, wait
 	if forceKillTimeoutHandler != nil {
 		forceKillTimeoutHandler.Stop()
 	}
+	wasHanged, killErr, forceKillErr := hanged, killError, forceKillError
+	mutex.Unlock()
+
+	if watchdog.Stop() {
+		return watchdog.Error()
+	}
 
 	if cmdErr != nil {
-		if !timeout || cmdErr.Error() != "signal: killed" {
+		if !wasHanged || cmdErr.Error() != "signal: killed" {
 			return cmdErr
 		}
 	}
 
-	if killError != nil {
-		return killError
+	if killErr != nil {
+		return killErr
+	}
+	if forceKillErr != nil {
+		return forceKillErr
 	}
-	if forceKillError != nil {
-		return forceKillError
+	if scanErr != nil {
+		return fmt.Errorf("failed to read output, error: %s", scanErr)
 	}
 
-	if timeout {
+	if wasHanged {
 		if retryOnHang {
-			return runCommandInDiagnosticMode(command, checkPattern, waitTime, forceWaitTime, false)
+			return runCommandInDiagnosticMode(command, checkPattern, waitTime, forceWaitTime, false, outWriter, errWriter, timeout, killGracePeriod, logger)
 		}
 		return fmt.Errorf("timed out")
 	}
@@ -1,20 +1,34 @@
 package mdtool
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-tools/go-xamarin/tools"
 	"github.com/stretchr/testify/require"
 )
 
 func TestRunCommandInDiagnosticMode(t *testing.T) {
+	t.Log("it writes the complete output before returning")
+	{
+		var output bytes.Buffer
+		cmd := command.New("/bin/bash", "-c", "for i in $(seq 1 5000); do echo line $i; done")
+		err := runCommandInDiagnosticMode(*cmd, "pattern", 2*time.Second, 2*time.Second, false, &output, os.Stderr, 0, 0, tools.NewDefaultLogger())
+		require.NoError(t, err)
+		require.Equal(t, 5000, strings.Count(output.String(), "\n"))
+		require.Equal(t, true, strings.HasSuffix(output.String(), "line 5000\n"))
+	}
+
 	t.Log("test without retry")
 	{
 		cmd := command.New("/bin/bash", "-c", "echo pattern && sleep 100")
 		now := time.Now()
-		err := runCommandInDiagnosticMode(*cmd, "pattern", 2*time.Second, 2*time.Second, false)
+		err := runCommandInDiagnosticMode(*cmd, "pattern", 2*time.Second, 2*time.Second, false, os.Stdout, os.Stderr, 0, 0, tools.NewDefaultLogger())
 		require.Equal(t, "timed out", err.Error())
 		diff := time.Now().Sub(now)
 		require.Equal(t, true, diff.Seconds() < 10, fmt.Sprintf("diff: %v", diff.Seconds()))
@@ -24,7 +38,7 @@ func TestRunCommandInDiagnosticMode(t *testing.T) {
 	{
 		cmd := command.New("/bin/bash", "-c", "echo pattern && sleep 100")
 		now := time.Now()
-		err := runCommandInDiagnosticMode(*cmd, "pattern", 2*time.Second, 2*time.Second, true)
+		err := runCommandInDiagnosticMode(*cmd, "pattern", 2*time.Second, 2*time.Second, true, os.Stdout, os.Stderr, 0, 0, tools.NewDefaultLogger())
 		require.Equal(t, "timed out", err.Error())
 		diff := time.Now().Sub(now)
 		require.Equal(t, true, diff.Seconds() < 20, fmt.Sprintf("diff: %v", diff.Seconds()))
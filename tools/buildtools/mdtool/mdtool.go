@@ -2,11 +2,14 @@ package mdtool
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/bitrise-io/go-utils/command"
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 )
 
 const (
@@ -26,16 +29,37 @@ type Model struct {
 	target        string
 
 	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+
+	quiet bool
+
+	logger tools.Logger
 }
 
-// New ...
+// New - returns ErrToolNotInstalled (see: tools.ToolNotInstalledErrorf), if mdtool is not installed
 func New(solutionPth string) (*Model, error) {
+	if exist, err := pathutil.IsPathExists(constants.MDToolPath); err != nil {
+		return nil, fmt.Errorf("Failed to check if mdtool exist, error: %s", err)
+	} else if !exist {
+		return nil, tools.ToolNotInstalledErrorf("mdtool not exist at: %s", constants.MDToolPath)
+	}
+
+	return NewWithBuildTool(constants.MDToolPath, solutionPth)
+}
+
+// NewWithBuildTool - the command is run by the given build tool, its existence is not checked
+func NewWithBuildTool(buildTool, solutionPth string) (*Model, error) {
 	absSolutionPth, err := pathutil.AbsPath(solutionPth)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", solutionPth, err)
 	}
 
-	return &Model{solutionPth: absSolutionPth, buildTool: constants.MDToolPath}, nil
+	return &Model{solutionPth: absSolutionPth, buildTool: buildTool, stdout: os.Stdout, stderr: os.Stderr}, nil
 }
 
 // SetTarget ...
@@ -67,6 +91,36 @@ func (mdtool *Model) SetCustomOptions(options ...string) {
 	mdtool.customOptions = options
 }
 
+// SetStdout ...
+func (mdtool *Model) SetStdout(out io.Writer) {
+	mdtool.stdout = out
+}
+
+// SetStderr ...
+func (mdtool *Model) SetStderr(err io.Writer) {
+	mdtool.stderr = err
+}
+
+// SetTimeout ...
+func (mdtool *Model) SetTimeout(timeout time.Duration) {
+	mdtool.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (mdtool *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	mdtool.killGracePeriod = killGracePeriod
+}
+
+// SetQuiet - filters tool banners and NuGet progress from the output
+func (mdtool *Model) SetQuiet(quiet bool) {
+	mdtool.quiet = quiet
+}
+
+// SetLogger - receives the messages of the command, defaults to printing them
+func (mdtool *Model) SetLogger(logger tools.Logger) {
+	mdtool.logger = logger
+}
+
 func (mdtool Model) buildCommandSlice() []string {
 	cmdSlice := []string{mdtool.buildTool}
 
@@ -123,5 +177,19 @@ func (mdtool Model) Run() error {
 		return command.Run()
 	*/
 
-	return runCommandInDiagnosticMode(*command, "Loading projects", diagnosticModeWaitTime, diagnosticModeForceWaitTime, true)
+	stdout, stderr := mdtool.stdout, mdtool.stderr
+	if mdtool.quiet {
+		quietStdout, quietStderr := tools.NewQuietWriter(stdout), tools.NewQuietWriter(stderr)
+		defer func() {
+			if err := quietStdout.Flush(); err != nil {
+				tools.LoggerOrDefault(mdtool.logger).Warnf("Failed to write output, error: %s", err)
+			}
+			if err := quietStderr.Flush(); err != nil {
+				tools.LoggerOrDefault(mdtool.logger).Warnf("Failed to write output, error: %s", err)
+			}
+		}()
+		stdout, stderr = quietStdout, quietStderr
+	}
+
+	return runCommandInDiagnosticMode(*command, "Loading projects", diagnosticModeWaitTime, diagnosticModeForceWaitTime, true, stdout, stderr, mdtool.timeout, mdtool.killGracePeriod, tools.LoggerOrDefault(mdtool.logger))
 }
@@ -1,6 +1,7 @@
 package mdtool
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-io/go-utils/testutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +19,7 @@ func TestNew(t *testing.T) {
 		currentDir, err := pathutil.CurrentWorkingDirectoryAbsolutePath()
 		require.NoError(t, err)
 
-		mdtool, err := New("solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "solution.sln")
 		require.NoError(t, err)
 		require.NotNil(t, mdtool)
 
@@ -32,10 +34,25 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithoutBuildTool(t *testing.T) {
+	t.Log("it fails with ErrToolNotInstalled, if mdtool is not installed")
+	{
+		exist, err := pathutil.IsPathExists(constants.MDToolPath)
+		require.NoError(t, err)
+
+		_, err = New("solution.sln")
+		if exist {
+			require.NoError(t, err)
+		} else {
+			require.True(t, errors.Is(err, tools.ErrToolNotInstalled))
+		}
+	}
+}
+
 func TestSetProperties(t *testing.T) {
 	t.Log("it sets target")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		require.NotNil(t, mdtool)
 		require.Equal(t, "", mdtool.target)
@@ -46,7 +63,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets configuration")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		require.NotNil(t, mdtool)
 		require.Equal(t, "", mdtool.configuration)
@@ -57,7 +74,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets platform")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		require.NotNil(t, mdtool)
 		require.Equal(t, "", mdtool.platform)
@@ -68,7 +85,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets project name")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		require.NotNil(t, mdtool)
 		require.Equal(t, "", mdtool.projectName)
@@ -79,7 +96,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it appends custom options")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		require.NotNil(t, mdtool)
 		require.Equal(t, 0, len(mdtool.customOptions))
@@ -93,7 +110,7 @@ func TestSetProperties(t *testing.T) {
 func TestBuildCommandSlice(t *testing.T) {
 	t.Log("it build command slice from model")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		desired := []string{constants.MDToolPath, "/solution.sln"}
 		require.Equal(t, desired, mdtool.buildCommandSlice())
@@ -127,7 +144,7 @@ func TestBuildCommandSlice(t *testing.T) {
 func TestPrintableCommand(t *testing.T) {
 	t.Log("it creates printable command")
 	{
-		mdtool, err := New("/solution.sln")
+		mdtool, err := NewWithBuildTool(constants.MDToolPath, "/solution.sln")
 		require.NoError(t, err)
 		desired := fmt.Sprintf(`"%s" "/solution.sln"`, constants.MDToolPath)
 		require.Equal(t, desired, mdtool.PrintableCommand())
@@ -2,12 +2,16 @@ package xbuild
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/bitrise-io/go-utils/command"
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 )
 
 // Model ...
@@ -21,14 +25,39 @@ type Model struct {
 	configuration string
 	platform      string
 
-	buildIpa       bool
-	archiveOnBuild bool
+	buildIpa        bool
+	archiveOnBuild  bool
+	archiveBasePath string
+
+	properties       map[string]string
+	secretProperties map[string]bool
 
 	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+
+	quiet bool
+
+	logger tools.Logger
 }
 
-// New ...
+// New - returns ErrToolNotInstalled (see: tools.ToolNotInstalledErrorf), if xbuild is not installed
 func New(solutionPth, projectPth string) (*Model, error) {
+	if exist, err := pathutil.IsPathExists(constants.XbuildPath); err != nil {
+		return nil, fmt.Errorf("Failed to check if xbuild exist, error: %s", err)
+	} else if !exist {
+		return nil, tools.ToolNotInstalledErrorf("xbuild not exist at: %s", constants.XbuildPath)
+	}
+
+	return NewWithBuildTool(constants.XbuildPath, solutionPth, projectPth)
+}
+
+// NewWithBuildTool - the command is run by the given build tool, its existence is not checked
+func NewWithBuildTool(buildTool, solutionPth, projectPth string) (*Model, error) {
 	absSolutionPth, err := pathutil.AbsPath(solutionPth)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", solutionPth, err)
@@ -43,7 +72,13 @@ func New(solutionPth, projectPth string) (*Model, error) {
 		absProjectPth = absPth
 	}
 
-	return &Model{solutionPth: absSolutionPth, projectPth: absProjectPth, buildTool: constants.XbuildPath}, nil
+	return &Model{
+		solutionPth: absSolutionPth,
+		projectPth:  absProjectPth,
+		buildTool:   buildTool,
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+	}, nil
 }
 
 // SetTarget ...
@@ -76,12 +111,70 @@ func (xbuild *Model) SetArchiveOnBuild(archive bool) *Model {
 	return xbuild
 }
 
+// SetArchiveBasePath - sets the directory where the archive is created, instead of ~/Library/Developer/Xcode/Archives
+func (xbuild *Model) SetArchiveBasePath(archiveBasePath string) *Model {
+	xbuild.archiveBasePath = archiveBasePath
+	return xbuild
+}
+
+// SetProperty - sets an msbuild property (/p:name=value), which overrides the one defined in the project
+func (xbuild *Model) SetProperty(name, value string) *Model {
+	if xbuild.properties == nil {
+		xbuild.properties = map[string]string{}
+	}
+	xbuild.properties[name] = value
+	return xbuild
+}
+
+// SetSecretProperty - sets an msbuild property, like a signing password, whose value is masked in the printable command
+func (xbuild *Model) SetSecretProperty(name, value string) *Model {
+	if xbuild.secretProperties == nil {
+		xbuild.secretProperties = map[string]bool{}
+	}
+	xbuild.secretProperties[name] = true
+	return xbuild.SetProperty(name, value)
+}
+
 // SetCustomOptions ...
 func (xbuild *Model) SetCustomOptions(options ...string) {
 	xbuild.customOptions = options
 }
 
+// SetStdout ...
+func (xbuild *Model) SetStdout(out io.Writer) {
+	xbuild.stdout = out
+}
+
+// SetStderr ...
+func (xbuild *Model) SetStderr(err io.Writer) {
+	xbuild.stderr = err
+}
+
+// SetTimeout ...
+func (xbuild *Model) SetTimeout(timeout time.Duration) {
+	xbuild.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (xbuild *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	xbuild.killGracePeriod = killGracePeriod
+}
+
+// SetQuiet - filters tool banners and NuGet progress from the output
+func (xbuild *Model) SetQuiet(quiet bool) {
+	xbuild.quiet = quiet
+}
+
+// SetLogger - receives the messages of the command, defaults to printing them
+func (xbuild *Model) SetLogger(logger tools.Logger) {
+	xbuild.logger = logger
+}
+
 func (xbuild Model) buildCommandSlice() []string {
+	return xbuild.commandSlice(false)
+}
+
+func (xbuild Model) commandSlice(maskSecrets bool) []string {
 	cmdSlice := []string{xbuild.buildTool}
 
 	if xbuild.projectPth != "" {
@@ -108,10 +201,28 @@ func (xbuild Model) buildCommandSlice() []string {
 		cmdSlice = append(cmdSlice, "/p:ArchiveOnBuild=true")
 	}
 
+	if xbuild.archiveBasePath != "" {
+		cmdSlice = append(cmdSlice, fmt.Sprintf("/p:ArchivePath=%s", xbuild.archiveBasePath))
+	}
+
 	if xbuild.buildIpa {
 		cmdSlice = append(cmdSlice, "/p:BuildIpa=true")
 	}
 
+	propertyNames := []string{}
+	for name := range xbuild.properties {
+		propertyNames = append(propertyNames, name)
+	}
+	sort.Strings(propertyNames)
+
+	for _, name := range propertyNames {
+		value := xbuild.properties[name]
+		if maskSecrets && xbuild.secretProperties[name] {
+			value = tools.SecretMask
+		}
+		cmdSlice = append(cmdSlice, fmt.Sprintf("/p:%s=%s", name, value))
+	}
+
 	cmdSlice = append(cmdSlice, xbuild.customOptions...)
 
 	//cmdSlice = append(cmdSlice, "/verbosity:minimal", "/nologo")
@@ -119,9 +230,9 @@ func (xbuild Model) buildCommandSlice() []string {
 	return cmdSlice
 }
 
-// PrintableCommand ...
+// PrintableCommand - secret property values are masked
 func (xbuild Model) PrintableCommand() string {
-	cmdSlice := xbuild.buildCommandSlice()
+	cmdSlice := xbuild.commandSlice(true)
 
 	return command.PrintableCommandArgs(true, cmdSlice)
 }
@@ -135,8 +246,22 @@ func (xbuild Model) Run() error {
 		return err
 	}
 
-	command.SetStdout(os.Stdout)
-	command.SetStderr(os.Stderr)
+	stdout, stderr := xbuild.stdout, xbuild.stderr
+	if xbuild.quiet {
+		quietStdout, quietStderr := tools.NewQuietWriter(stdout), tools.NewQuietWriter(stderr)
+		defer func() {
+			if err := quietStdout.Flush(); err != nil {
+				tools.LoggerOrDefault(xbuild.logger).Warnf("Failed to write output, error: %s", err)
+			}
+			if err := quietStderr.Flush(); err != nil {
+				tools.LoggerOrDefault(xbuild.logger).Warnf("Failed to write output, error: %s", err)
+			}
+		}()
+		stdout, stderr = quietStdout, quietStderr
+	}
+
+	command.SetStdout(stdout)
+	command.SetStderr(stderr)
 
-	return command.Run()
+	return tools.RunLoggedCommandWithTimeout(command.GetCmd(), xbuild.timeout, xbuild.killGracePeriod, xbuild.logger)
 }
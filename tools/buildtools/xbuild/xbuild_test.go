@@ -1,6 +1,7 @@
 package xbuild
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-io/go-utils/testutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +19,7 @@ func TestNew(t *testing.T) {
 		currentDir, err := pathutil.CurrentWorkingDirectoryAbsolutePath()
 		require.NoError(t, err)
 
-		xbuild, err := New("solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 
@@ -38,7 +40,7 @@ func TestNew(t *testing.T) {
 		currentDir, err := pathutil.CurrentWorkingDirectoryAbsolutePath()
 		require.NoError(t, err)
 
-		xbuild, err := New("solution.sln", "project.csproj")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "solution.sln", "project.csproj")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 
@@ -56,10 +58,25 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestNewWithoutBuildTool(t *testing.T) {
+	t.Log("it fails with ErrToolNotInstalled, if xbuild is not installed")
+	{
+		exist, err := pathutil.IsPathExists(constants.XbuildPath)
+		require.NoError(t, err)
+
+		_, err = New("solution.sln", "")
+		if exist {
+			require.NoError(t, err)
+		} else {
+			require.True(t, errors.Is(err, tools.ErrToolNotInstalled))
+		}
+	}
+}
+
 func TestSetProperties(t *testing.T) {
 	t.Log("it sets target")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 		require.Equal(t, "", xbuild.target)
@@ -70,7 +87,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets configuration")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 		require.Equal(t, "", xbuild.configuration)
@@ -81,7 +98,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets platform")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 		require.Equal(t, "", xbuild.platform)
@@ -92,7 +109,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets build ipa")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 		require.Equal(t, false, xbuild.buildIpa)
@@ -103,7 +120,7 @@ func TestSetProperties(t *testing.T) {
 
 	t.Log("it sets archive on build")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 		require.Equal(t, false, xbuild.archiveOnBuild)
@@ -112,9 +129,47 @@ func TestSetProperties(t *testing.T) {
 		require.Equal(t, true, xbuild.archiveOnBuild)
 	}
 
+	t.Log("it sets archive base path")
+	{
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+		require.NotNil(t, xbuild)
+		require.Equal(t, "", xbuild.archiveBasePath)
+
+		xbuild.SetArchiveBasePath("/archives")
+		require.Equal(t, "/archives", xbuild.archiveBasePath)
+
+		xbuild.SetArchiveOnBuild(true)
+		require.Contains(t, xbuild.buildCommandSlice(), "/p:ArchivePath=/archives")
+	}
+
+	t.Log("it sets msbuild properties in sorted order")
+	{
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+		require.NotNil(t, xbuild)
+
+		xbuild.SetProperty("MtouchLink", "SdkOnly").SetProperty("CodesignKey", "iPhone Distribution")
+		cmdSlice := xbuild.buildCommandSlice()
+		require.Equal(t, []string{"/p:CodesignKey=iPhone Distribution", "/p:MtouchLink=SdkOnly"}, cmdSlice[len(cmdSlice)-2:])
+	}
+
+	t.Log("it masks secret properties in the printable command")
+	{
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
+		require.NoError(t, err)
+		require.NotNil(t, xbuild)
+
+		xbuild.SetSecretProperty("AndroidSigningStorePass", "store-pass").SetProperty("AndroidSigningKeyAlias", "alias")
+		cmdSlice := xbuild.buildCommandSlice()
+		require.Equal(t, []string{"/p:AndroidSigningKeyAlias=alias", "/p:AndroidSigningStorePass=store-pass"}, cmdSlice[len(cmdSlice)-2:])
+		require.Contains(t, xbuild.PrintableCommand(), `"/p:AndroidSigningStorePass=***"`)
+		require.NotContains(t, xbuild.PrintableCommand(), "store-pass")
+	}
+
 	t.Log("it appends custom options")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		require.NotNil(t, xbuild)
 		require.Equal(t, 0, len(xbuild.customOptions))
@@ -131,7 +186,7 @@ func TestBuildCommandSlice(t *testing.T) {
 		currentDir, err := pathutil.CurrentWorkingDirectoryAbsolutePath()
 		require.NoError(t, err)
 
-		xbuild, err := New("./test/solution.sln", "./test/ios/project.csproj")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "./test/solution.sln", "./test/ios/project.csproj")
 		require.NoError(t, err)
 		desired := []string{constants.XbuildPath, filepath.Join(currentDir, "test/ios/project.csproj"), fmt.Sprintf("/p:SolutionDir=%s", filepath.Join(currentDir, "test"))}
 		require.Equal(t, desired, xbuild.buildCommandSlice())
@@ -139,7 +194,7 @@ func TestBuildCommandSlice(t *testing.T) {
 
 	t.Log("solution-dir test")
 	{
-		xbuild, err := New("/Users/Develop/test/solution.sln", "/Users/Develop/test/test/ios/project.csproj")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/Users/Develop/test/solution.sln", "/Users/Develop/test/test/ios/project.csproj")
 		require.NoError(t, err)
 		desired := []string{constants.XbuildPath, "/Users/Develop/test/test/ios/project.csproj", "/p:SolutionDir=/Users/Develop/test"}
 		require.Equal(t, desired, xbuild.buildCommandSlice())
@@ -147,7 +202,7 @@ func TestBuildCommandSlice(t *testing.T) {
 
 	t.Log("it build command slice from model")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		desired := []string{constants.XbuildPath, "/solution.sln", "/p:SolutionDir=/"}
 		require.Equal(t, desired, xbuild.buildCommandSlice())
@@ -184,7 +239,7 @@ func TestPrintableCommand(t *testing.T) {
 		currentDir, err := pathutil.CurrentWorkingDirectoryAbsolutePath()
 		require.NoError(t, err)
 
-		xbuild, err := New("./test/solution.sln", "./test/ios/project.csproj")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "./test/solution.sln", "./test/ios/project.csproj")
 		require.NoError(t, err)
 		desired := fmt.Sprintf(`"%s" "%s" "%s"`, constants.XbuildPath, filepath.Join(currentDir, "test/ios/project.csproj"), fmt.Sprintf("/p:SolutionDir=%s", filepath.Join(currentDir, "test")))
 		require.Equal(t, desired, xbuild.PrintableCommand())
@@ -192,7 +247,7 @@ func TestPrintableCommand(t *testing.T) {
 
 	t.Log("solution-dir test")
 	{
-		xbuild, err := New("/Users/Develop/test/solution.sln", "/Users/Develop/test/test/ios/project.csproj")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/Users/Develop/test/solution.sln", "/Users/Develop/test/test/ios/project.csproj")
 		require.NoError(t, err)
 		desired := fmt.Sprintf(`"%s" "/Users/Develop/test/test/ios/project.csproj" "/p:SolutionDir=/Users/Develop/test"`, constants.XbuildPath)
 		require.Equal(t, desired, xbuild.PrintableCommand())
@@ -200,7 +255,7 @@ func TestPrintableCommand(t *testing.T) {
 
 	t.Log("it creates printable command")
 	{
-		xbuild, err := New("/solution.sln", "")
+		xbuild, err := NewWithBuildTool(constants.XbuildPath, "/solution.sln", "")
 		require.NoError(t, err)
 		desired := fmt.Sprintf(`"%s" "/solution.sln" "/p:SolutionDir=/"`, constants.XbuildPath)
 		require.Equal(t, desired, xbuild.PrintableCommand())
@@ -0,0 +1,234 @@
+package emulator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/bitrise-tools/go-xamarin/tools"
+	"github.com/bitrise-tools/go-xamarin/tools/androidsdk"
+)
+
+// bootCheckInterval - time between the boot completed checks
+const bootCheckInterval = 5 * time.Second
+
+// first and last console port of the emulators, the adb port is the console port + 1
+const (
+	firstPort = 5554
+	lastPort  = 5584
+)
+
+// DefaultOptions - options of a headless emulator
+var DefaultOptions = []string{"-no-window", "-no-audio", "-no-boot-anim", "-no-snapshot-save", "-gpu", "swiftshader_indirect"}
+
+// DeviceModel - a device connected to adb
+type DeviceModel struct {
+	Serial string // like: emulator-5554
+	State  string // like: device, offline, unauthorized
+}
+
+// ParseDevices - parses the output of: adb devices, like:
+// List of devices attached
+// emulator-5554	device
+func ParseDevices(out string) []DeviceModel {
+	devices := []DeviceModel{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] == "List" || strings.HasPrefix(fields[0], "*") {
+			continue
+		}
+		devices = append(devices, DeviceModel{Serial: fields[0], State: fields[1]})
+	}
+
+	return devices
+}
+
+// Devices - the devices and emulators connected to adb
+func Devices(sdk androidsdk.Model) ([]DeviceModel, error) {
+	out, err := exec.Command(sdk.ADBPth(), "devices").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("adb devices failed, output: %s, error: %s", out, err)
+	}
+	return ParseDevices(string(out)), nil
+}
+
+// ParseAVDList - parses the output of: emulator -list-avds
+func ParseAVDList(out string) []string {
+	avds := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// the emulator may log warnings, like: INFO    | Storing crashdata in: ...
+		if line == "" || strings.Contains(line, "|") || strings.Contains(line, " ") {
+			continue
+		}
+		avds = append(avds, line)
+	}
+
+	return avds
+}
+
+// AVDs - the names of the created Android Virtual Devices
+func AVDs(sdk androidsdk.Model) ([]string, error) {
+	out, err := exec.Command(sdk.EmulatorPth(), "-list-avds").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("emulator -list-avds failed, output: %s, error: %s", out, err)
+	}
+	return ParseAVDList(string(out)), nil
+}
+
+// createAVDSlice returns the avdmanager command creating the AVD
+func createAVDSlice(sdk androidsdk.Model, name, systemImage, device string) []string {
+	cmdSlice := []string{sdk.AVDManagerPth(), "create", "avd", "--force", "--name", name, "--package", systemImage}
+	if device != "" {
+		cmdSlice = append(cmdSlice, "--device", device)
+	}
+	return cmdSlice
+}
+
+// CreateAVD - creates (or overwrites) the Android Virtual Device of the installed system image,
+// like: system-images;android-28;google_apis;x86, the device is the hardware profile, like: pixel
+func CreateAVD(sdk androidsdk.Model, name, systemImage, device string) error {
+	cmdSlice := createAVDSlice(sdk, name, systemImage, device)
+
+	cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+	// answers the: Do you wish to create a custom hardware profile? [no]
+	cmd.Stdin = strings.NewReader("no\n")
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create AVD (%s), output: %s, error: %s", name, out, err)
+	}
+	return nil
+}
+
+// Model - a started emulator
+type Model struct {
+	sdk    androidsdk.Model
+	avd    string
+	Serial string
+
+	cmd    *exec.Cmd
+	exited chan error
+	logger tools.Logger
+}
+
+// SetLogger - receives the messages of the emulator's shutdown, defaults to printing them
+func (emulator *Model) SetLogger(logger tools.Logger) {
+	emulator.logger = logger
+}
+
+// freePort returns the first console port not used by a connected emulator
+func freePort(devices []DeviceModel) (int, error) {
+	used := map[string]bool{}
+	for _, device := range devices {
+		used[device.Serial] = true
+	}
+
+	for port := firstPort; port <= lastPort; port += 2 {
+		if !used[fmt.Sprintf("emulator-%d", port)] {
+			return port, nil
+		}
+	}
+	return 0, fmt.Errorf("no free emulator port found")
+}
+
+// startSlice returns the emulator command starting the AVD
+func startSlice(sdk androidsdk.Model, avd string, port int, options []string) []string {
+	cmdSlice := []string{sdk.EmulatorPth(), "-avd", avd, "-port", fmt.Sprintf("%d", port)}
+	return append(cmdSlice, options...)
+}
+
+// Start - starts the emulator of the AVD in the background on a free port,
+// the DefaultOptions are used if no options given. The output of the emulator is written to the out.
+func Start(sdk androidsdk.Model, avd string, out io.Writer, options ...string) (*Model, error) {
+	devices, err := Devices(sdk)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := freePort(devices)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(options) == 0 {
+		options = DefaultOptions
+	}
+	if out == nil {
+		out = os.Stdout
+	}
+
+	cmdSlice := startSlice(sdk, avd, port, options)
+	cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start emulator (%s), error: %s", avd, err)
+	}
+
+	emulator := &Model{sdk: sdk, avd: avd, Serial: fmt.Sprintf("emulator-%d", port), cmd: cmd, exited: make(chan error, 1)}
+	go func() {
+		emulator.exited <- cmd.Wait()
+	}()
+
+	return emulator, nil
+}
+
+// bootCompleted checks the sys.boot_completed property of the device
+func bootCompleted(sdk androidsdk.Model, serial string) bool {
+	out, err := exec.Command(sdk.ADBPth(), "-s", serial, "shell", "getprop", "sys.boot_completed").Output()
+	return err == nil && strings.TrimSpace(string(out)) == "1"
+}
+
+// WaitForBoot - waits until the emulator reports boot completed, fails if the emulator exits or the timeout elapses
+func (emulator Model) WaitForBoot(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if bootCompleted(emulator.sdk, emulator.Serial) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("emulator (%s) did not boot in %s", emulator.avd, timeout)
+		}
+
+		select {
+		case err := <-emulator.exited:
+			emulator.exited <- err
+			return fmt.Errorf("emulator (%s) exited while booting, error: %v", emulator.avd, err)
+		case <-time.After(bootCheckInterval):
+		}
+	}
+}
+
+// Kill - shuts down the emulator by its console, the process is killed if it does not exit in time
+func (emulator Model) Kill(timeout time.Duration) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(emulator.sdk.ADBPth(), "-s", emulator.Serial, "emu", "kill")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		tools.LoggerOrDefault(emulator.logger).Warnf("Failed to shut down emulator (%s), output: %s, error: %s", emulator.Serial, stderr.String(), err)
+	}
+
+	select {
+	case err := <-emulator.exited:
+		emulator.exited <- err
+		return nil
+	case <-time.After(timeout):
+	}
+
+	if err := emulator.cmd.Process.Kill(); err != nil {
+		return fmt.Errorf("failed to kill emulator (%s), error: %s", emulator.Serial, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package emulator
+
+import (
+	"testing"
+
+	"github.com/bitrise-tools/go-xamarin/tools/androidsdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDevices(t *testing.T) {
+	t.Log("it parses the connected devices")
+	{
+		devices := ParseDevices(`* daemon not running; starting now at tcp:5037
+* daemon started successfully
+List of devices attached
+emulator-5554	device
+0123456789ABCDEF	unauthorized
+
+`)
+		require.Equal(t, []DeviceModel{
+			{Serial: "emulator-5554", State: "device"},
+			{Serial: "0123456789ABCDEF", State: "unauthorized"},
+		}, devices)
+	}
+
+	t.Log("it returns the first free port")
+	{
+		port, err := freePort([]DeviceModel{{Serial: "emulator-5554", State: "device"}, {Serial: "emulator-5558", State: "offline"}})
+		require.NoError(t, err)
+		require.Equal(t, 5556, port)
+	}
+}
+
+func TestParseAVDList(t *testing.T) {
+	require.Equal(t, []string{"Nexus_5X_API_28", "pixel_api_29"}, ParseAVDList(`INFO    | Storing crashdata in: /tmp/android-vagrant/emu-crash.db
+Nexus_5X_API_28
+pixel_api_29
+`))
+}
+
+func TestCommandSlices(t *testing.T) {
+	sdk := androidsdk.Model{SDKDir: "/android-sdk"}
+
+	t.Log("it starts the headless emulator on the port")
+	{
+		require.Equal(t, []string{"/android-sdk/emulator/emulator", "-avd", "pixel_api_29", "-port", "5556", "-no-window", "-no-audio", "-no-boot-anim", "-no-snapshot-save", "-gpu", "swiftshader_indirect"}, startSlice(sdk, "pixel_api_29", 5556, DefaultOptions))
+	}
+
+	t.Log("it creates the AVD by avdmanager")
+	{
+		require.Equal(t, []string{"/android-sdk/tools/bin/avdmanager", "create", "avd", "--force", "--name", "pixel_api_29", "--package", "system-images;android-29;google_apis;x86", "--device", "pixel"}, createAVDSlice(sdk, "pixel_api_29", "system-images;android-29;google_apis;x86", "pixel"))
+	}
+}
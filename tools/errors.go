@@ -0,0 +1,28 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrToolNotInstalled - a required tool is not installed, or its path is not set
+var ErrToolNotInstalled = errors.New("tool not installed")
+
+// toolNotInstalledError keeps the tool specific message, and matches ErrToolNotInstalled
+type toolNotInstalledError struct {
+	message string
+}
+
+func (err toolNotInstalledError) Error() string {
+	return err.message
+}
+
+// Is ...
+func (err toolNotInstalledError) Is(target error) bool {
+	return target == ErrToolNotInstalled
+}
+
+// ToolNotInstalledErrorf - returns an error with the formatted message, which matches ErrToolNotInstalled by errors.Is
+func ToolNotInstalledErrorf(format string, v ...interface{}) error {
+	return toolNotInstalledError{message: fmt.Sprintf(format, v...)}
+}
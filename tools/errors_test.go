@@ -0,0 +1,24 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolNotInstalledErrorf(t *testing.T) {
+	t.Log("it keeps the message and matches ErrToolNotInstalled")
+	{
+		err := ToolNotInstalledErrorf("nunit console not exist at: %s", "/nunit/nunit3-console.exe")
+		require.Equal(t, "nunit console not exist at: /nunit/nunit3-console.exe", err.Error())
+		require.True(t, errors.Is(err, ErrToolNotInstalled))
+		require.True(t, errors.Is(fmt.Errorf("failed to run tests: %w", err), ErrToolNotInstalled))
+	}
+
+	t.Log("other errors do not match")
+	{
+		require.False(t, errors.Is(errors.New("nunit console not exist"), ErrToolNotInstalled))
+	}
+}
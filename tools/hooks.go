@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// CommandEvent ...
+type CommandEvent struct {
+	Command   string
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	ExitCode  int
+	Err       error
+}
+
+// CommandHook - receives command lifecycle events, e.g. for build telemetry
+type CommandHook interface {
+	CommandStarted(command string, startTime time.Time)
+	CommandFinished(event CommandEvent)
+}
+
+// RunWithHooks - runs the command and reports its start and end to the given hooks
+func RunWithHooks(command Runnable, hooks ...CommandHook) error {
+	if len(hooks) == 0 {
+		return command.Run()
+	}
+
+	printableCommand := command.PrintableCommand()
+	startTime := time.Now()
+
+	for _, hook := range hooks {
+		hook.CommandStarted(printableCommand, startTime)
+	}
+
+	err := command.Run()
+
+	endTime := time.Now()
+	event := CommandEvent{
+		Command:   printableCommand,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Duration:  endTime.Sub(startTime),
+		ExitCode:  ExitCode(err),
+		Err:       err,
+	}
+
+	for _, hook := range hooks {
+		hook.CommandFinished(event)
+	}
+
+	return err
+}
+
+// ExitCode - returns the exit code of a finished command (the error may wrap the *exec.ExitError), or -1 if the command did not exit normally
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		if status, ok := exitError.Sys().(syscall.WaitStatus); ok && status.Exited() {
+			return status.ExitStatus()
+		}
+	}
+
+	return -1
+}
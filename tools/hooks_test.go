@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"io"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testHook struct {
+	started  []string
+	finished []CommandEvent
+}
+
+func (hook *testHook) CommandStarted(command string, startTime time.Time) {
+	hook.started = append(hook.started, command)
+}
+
+func (hook *testHook) CommandFinished(event CommandEvent) {
+	hook.finished = append(hook.finished, event)
+}
+
+func TestRunWithHooks(t *testing.T) {
+	t.Log("it notifies hooks about command start and end")
+	{
+		hook := &testHook{}
+		command := &testCommand{outputs: []string{"error"}, stdout: io.Discard}
+
+		require.Error(t, RunWithHooks(command, hook))
+		require.Equal(t, []string{"test"}, hook.started)
+		require.Equal(t, 1, len(hook.finished))
+		require.Equal(t, "test", hook.finished[0].Command)
+		require.Error(t, hook.finished[0].Err)
+		require.Equal(t, -1, hook.finished[0].ExitCode)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	t.Log("it returns 0 for nil error")
+	{
+		require.Equal(t, 0, ExitCode(nil))
+	}
+
+	t.Log("it returns the exit status of the command")
+	{
+		err := exec.Command("/bin/bash", "-c", "exit 3").Run()
+		require.Equal(t, 3, ExitCode(err))
+	}
+}
@@ -0,0 +1,299 @@
+package instrumentation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Xamarin.Android NUnitLite (TestSuiteInstrumentation) result keys
+const (
+	ResultKeyPassed            = "passed"
+	ResultKeyFailed            = "failed"
+	ResultKeyNunit2ResultsPath = "nunit2-results-path"
+)
+
+// resultCodeOK - Activity.RESULT_OK, reported by a finished instrumentation
+const resultCodeOK = -1
+
+// ResultModel - the output of: am instrument -r
+type ResultModel struct {
+	Code    int
+	Results map[string]string // INSTRUMENTATION_RESULT: key=value
+	Failed  string            // INSTRUMENTATION_FAILED, like the crashed instrumentation
+	Output  string
+}
+
+// Finished - true if the instrumentation did not crash and finished with RESULT_OK
+func (result ResultModel) Finished() bool {
+	return result.Failed == "" && result.Code == resultCodeOK
+}
+
+// ParseOutput - parses the raw output of: am instrument -r, like:
+// INSTRUMENTATION_RESULT: nunit2-results-path=/data/user/0/com.sample.tests/files/.__override__/TestResults.xml
+// INSTRUMENTATION_CODE: -1
+func ParseOutput(out []byte) ResultModel {
+	result := ResultModel{Results: map[string]string{}, Output: string(out)}
+
+	hasCode := false
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "INSTRUMENTATION_RESULT:"):
+			split := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "INSTRUMENTATION_RESULT:")), "=", 2)
+			if len(split) == 2 {
+				result.Results[split[0]] = split[1]
+				if split[0] == "shortMsg" && result.Failed == "" {
+					result.Failed = split[1]
+				}
+			}
+		case strings.HasPrefix(line, "INSTRUMENTATION_FAILED:"):
+			result.Failed = strings.TrimSpace(strings.TrimPrefix(line, "INSTRUMENTATION_FAILED:"))
+		case strings.HasPrefix(line, "INSTRUMENTATION_CODE:"):
+			if code, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "INSTRUMENTATION_CODE:"))); err == nil {
+				result.Code = code
+				hasCode = true
+			}
+		}
+	}
+
+	if !hasCode && result.Failed == "" {
+		result.Failed = "no instrumentation result found"
+	}
+
+	return result
+}
+
+// Model - installs the test apk on the emulator or device, and runs its instrumentation by adb
+type Model struct {
+	adbPth          string
+	serial          string
+	apkPth          string
+	instrumentation string // <test package>/<instrumentation class>
+
+	arguments map[string]string
+
+	result *ResultModel
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// New - the serial selects the emulator or device, the only connected one is used if empty
+func New(adbPth, serial, apkPth, instrumentation string) (*Model, error) {
+	if instrumentation == "" {
+		return nil, fmt.Errorf("instrumentation is required")
+	}
+
+	absApkPth, err := pathutil.AbsPath(apkPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", apkPth, err)
+	}
+
+	return &Model{
+		adbPth:          adbPth,
+		serial:          serial,
+		apkPth:          absApkPth,
+		instrumentation: instrumentation,
+		arguments:       map[string]string{},
+		result:          &ResultModel{},
+		stdout:          os.Stdout,
+		stderr:          os.Stderr,
+	}, nil
+}
+
+// SetArgument - instrumentation argument, passed by: am instrument -e <key> <value>
+func (instrumentation *Model) SetArgument(key, value string) *Model {
+	instrumentation.arguments[key] = value
+	return instrumentation
+}
+
+// Result - the result of the instrumentation, set by Run
+func (instrumentation Model) Result() ResultModel {
+	return *instrumentation.result
+}
+
+// SetCustomOptions - options of the am instrument command
+func (instrumentation *Model) SetCustomOptions(options ...string) {
+	instrumentation.customOptions = options
+}
+
+// SetStdout ...
+func (instrumentation *Model) SetStdout(out io.Writer) {
+	instrumentation.stdout = out
+}
+
+// SetStderr ...
+func (instrumentation *Model) SetStderr(err io.Writer) {
+	instrumentation.stderr = err
+}
+
+// SetTimeout - timeout of the test run
+func (instrumentation *Model) SetTimeout(timeout time.Duration) {
+	instrumentation.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (instrumentation *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	instrumentation.killGracePeriod = killGracePeriod
+}
+
+func adbSlice(adbPth, serial string, args ...string) []string {
+	cmdSlice := []string{adbPth}
+	if serial != "" {
+		cmdSlice = append(cmdSlice, "-s", serial)
+	}
+	return append(cmdSlice, args...)
+}
+
+func (instrumentation Model) instrumentSlice() []string {
+	cmdSlice := adbSlice(instrumentation.adbPth, instrumentation.serial, "shell", "am", "instrument", "-r", "-w")
+
+	keys := []string{}
+	for key := range instrumentation.arguments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		cmdSlice = append(cmdSlice, "-e", key, instrumentation.arguments[key])
+	}
+	cmdSlice = append(cmdSlice, instrumentation.customOptions...)
+
+	return append(cmdSlice, instrumentation.instrumentation)
+}
+
+func (instrumentation Model) commandSlices() [][]string {
+	return [][]string{
+		adbSlice(instrumentation.adbPth, instrumentation.serial, "install", "-r", instrumentation.apkPth),
+		adbSlice(instrumentation.adbPth, instrumentation.serial, "logcat", "-c"),
+		instrumentation.instrumentSlice(),
+	}
+}
+
+// PrintableCommand ...
+func (instrumentation Model) PrintableCommand() string {
+	printableCommands := []string{}
+	for _, cmdSlice := range instrumentation.commandSlices() {
+		printableCommands = append(printableCommands, command.PrintableCommandArgs(true, cmdSlice))
+	}
+	return strings.Join(printableCommands, " && ")
+}
+
+// Run - installs the apk, clears the logcat and runs the instrumentation,
+// fails if the instrumentation crashed, failing tests are reported in the result
+func (instrumentation Model) Run() error {
+	*instrumentation.result = ResultModel{}
+
+	cmdSlices := instrumentation.commandSlices()
+	for _, cmdSlice := range cmdSlices[:len(cmdSlices)-1] {
+		cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+		cmd.Stdout = instrumentation.stdout
+		cmd.Stderr = instrumentation.stderr
+
+		if err := tools.RunCommandWithTimeout(cmd, instrumentation.timeout, instrumentation.killGracePeriod); err != nil {
+			return fmt.Errorf("%s failed, error: %s", strings.Join(cmdSlice[1:], " "), err)
+		}
+	}
+
+	instrumentSlice := cmdSlices[len(cmdSlices)-1]
+	var out bytes.Buffer
+
+	cmd := exec.Command(instrumentSlice[0], instrumentSlice[1:]...)
+	cmd.Stdout = io.MultiWriter(instrumentation.stdout, &out)
+	cmd.Stderr = instrumentation.stderr
+
+	runErr := tools.RunCommandWithTimeout(cmd, instrumentation.timeout, instrumentation.killGracePeriod)
+
+	result := ParseOutput(out.Bytes())
+	*instrumentation.result = result
+
+	if runErr != nil {
+		return fmt.Errorf("instrumentation (%s) failed, error: %s", instrumentation.instrumentation, runErr)
+	}
+	if !result.Finished() {
+		return fmt.Errorf("instrumentation (%s) failed: %s", instrumentation.instrumentation, result.Failed)
+	}
+	return nil
+}
+
+// ParseInstrumentationList - parses the output of: pm list instrumentation, like:
+// instrumentation:com.sample.tests/app.tests.TestInstrumentation (target=com.sample.tests)
+// and returns the instrumentations of the target package
+func ParseInstrumentationList(out, targetPackage string) []string {
+	instrumentations := []string{}
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "instrumentation:") {
+			continue
+		}
+
+		split := strings.SplitN(strings.TrimPrefix(line, "instrumentation:"), " ", 2)
+		if targetPackage != "" && (len(split) < 2 || strings.TrimSpace(split[1]) != "(target="+targetPackage+")") {
+			continue
+		}
+		instrumentations = append(instrumentations, split[0])
+	}
+
+	return instrumentations
+}
+
+// ListInstrumentations - returns the instrumentations installed on the emulator or device for the target package
+func ListInstrumentations(adbPth, serial, targetPackage string) ([]string, error) {
+	cmdSlice := adbSlice(adbPth, serial, "shell", "pm", "list", "instrumentation")
+	out, err := exec.Command(cmdSlice[0], cmdSlice[1:]...).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list instrumentations, output: %s, error: %s", out, err)
+	}
+	return ParseInstrumentationList(string(out), targetPackage), nil
+}
+
+// ReadFile - reads the file of the app's private storage by run-as, the app has to be debuggable
+func ReadFile(adbPth, serial, packageName, remotePth string) ([]byte, error) {
+	cmdSlice := adbSlice(adbPth, serial, "exec-out", "run-as", packageName, "cat", remotePth)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read (%s) of (%s), output: %s, error: %s", remotePth, packageName, stderr.String(), err)
+	}
+	return out, nil
+}
+
+// DumpLogcat - writes the logcat of the emulator or device to the path
+func DumpLogcat(adbPth, serial, pth string) error {
+	cmdSlice := adbSlice(adbPth, serial, "logcat", "-d")
+	out, err := exec.Command(cmdSlice[0], cmdSlice[1:]...).Output()
+	if err != nil {
+		return fmt.Errorf("Failed to dump logcat, error: %s", err)
+	}
+
+	if err := fileutil.WriteBytesToFile(pth, out); err != nil {
+		return fmt.Errorf("Failed to write logcat (%s), error: %s", pth, err)
+	}
+	return nil
+}
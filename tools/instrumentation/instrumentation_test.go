@@ -0,0 +1,89 @@
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it installs the apk, clears the logcat and runs the instrumentation")
+	{
+		instrumentation, err := New("/android-sdk/platform-tools/adb", "emulator-5554", "/bin/Debug/com.sample.tests-Signed.apk", "com.sample.tests/app.tests.TestInstrumentation")
+		require.NoError(t, err)
+		instrumentation.SetArgument("suite", "Sample.Tests")
+
+		require.Equal(t, `"/android-sdk/platform-tools/adb" "-s" "emulator-5554" "install" "-r" "/bin/Debug/com.sample.tests-Signed.apk" && `+
+			`"/android-sdk/platform-tools/adb" "-s" "emulator-5554" "logcat" "-c" && `+
+			`"/android-sdk/platform-tools/adb" "-s" "emulator-5554" "shell" "am" "instrument" "-r" "-w" "-e" "suite" "Sample.Tests" "com.sample.tests/app.tests.TestInstrumentation"`, instrumentation.PrintableCommand())
+	}
+
+	t.Log("it uses the only connected device without serial")
+	{
+		instrumentation, err := New("adb", "", "/bin/Debug/com.sample.tests-Signed.apk", "com.sample.tests/app.tests.TestInstrumentation")
+		require.NoError(t, err)
+
+		require.Equal(t, `"adb" "install" "-r" "/bin/Debug/com.sample.tests-Signed.apk" && `+
+			`"adb" "logcat" "-c" && `+
+			`"adb" "shell" "am" "instrument" "-r" "-w" "com.sample.tests/app.tests.TestInstrumentation"`, instrumentation.PrintableCommand())
+	}
+
+	t.Log("it requires the instrumentation")
+	{
+		_, err := New("adb", "", "/bin/Debug/com.sample.tests-Signed.apk", "")
+		require.Error(t, err)
+	}
+}
+
+func TestParseOutput(t *testing.T) {
+	t.Log("it parses the NUnitLite instrumentation results")
+	{
+		result := ParseOutput([]byte(`INSTRUMENTATION_RESULT: passed=4
+INSTRUMENTATION_RESULT: failed=1
+INSTRUMENTATION_RESULT: nunit2-results-path=/data/user/0/com.sample.tests/files/.__override__/TestResults.xml
+INSTRUMENTATION_CODE: -1
+`))
+		require.True(t, result.Finished())
+		require.Equal(t, "4", result.Results[ResultKeyPassed])
+		require.Equal(t, "1", result.Results[ResultKeyFailed])
+		require.Equal(t, "/data/user/0/com.sample.tests/files/.__override__/TestResults.xml", result.Results[ResultKeyNunit2ResultsPath])
+	}
+
+	t.Log("it reports the crashed instrumentation")
+	{
+		result := ParseOutput([]byte(`INSTRUMENTATION_RESULT: shortMsg=Process crashed.
+INSTRUMENTATION_CODE: 0
+`))
+		require.False(t, result.Finished())
+		require.Equal(t, "Process crashed.", result.Failed)
+	}
+
+	t.Log("it reports the missing instrumentation")
+	{
+		result := ParseOutput([]byte(`INSTRUMENTATION_FAILED: com.sample.tests/app.tests.TestInstrumentation
+`))
+		require.False(t, result.Finished())
+		require.Equal(t, "com.sample.tests/app.tests.TestInstrumentation", result.Failed)
+	}
+
+	t.Log("it fails without result")
+	{
+		require.False(t, ParseOutput([]byte("Error: device offline")).Finished())
+	}
+}
+
+func TestParseInstrumentationList(t *testing.T) {
+	out := `instrumentation:com.sample.tests/app.tests.TestInstrumentation (target=com.sample.tests)
+instrumentation:com.other/androidx.test.runner.AndroidJUnitRunner (target=com.other)
+`
+
+	t.Log("it returns the instrumentations of the target package")
+	{
+		require.Equal(t, []string{"com.sample.tests/app.tests.TestInstrumentation"}, ParseInstrumentationList(out, "com.sample.tests"))
+	}
+
+	t.Log("it returns every instrumentation without target package")
+	{
+		require.Equal(t, []string{"com.sample.tests/app.tests.TestInstrumentation", "com.other/androidx.test.runner.AndroidJUnitRunner"}, ParseInstrumentationList(out, ""))
+	}
+}
@@ -0,0 +1,189 @@
+package ipaexporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Method - distribution method of the exported ipa
+type Method string
+
+const (
+	// MethodAppStore ...
+	MethodAppStore Method = "app-store"
+	// MethodAdHoc ...
+	MethodAdHoc Method = "ad-hoc"
+	// MethodEnterprise ...
+	MethodEnterprise Method = "enterprise"
+	// MethodDevelopment ...
+	MethodDevelopment Method = "development"
+)
+
+// ExportOptionsModel - the exportOptions.plist of xcodebuild -exportArchive
+type ExportOptionsModel struct {
+	Method               Method
+	TeamID               string
+	ProvisioningProfiles map[string]string // bundle id - provisioning profile uuid or name, manual signing is used if set
+	SigningCertificate   string            // like: iPhone Distribution, defaults to the certificate of the method
+	CompileBitcode       bool
+	UploadSymbols        bool
+}
+
+// Plist - the export options as XML property list
+func (options ExportOptionsModel) Plist() string {
+	lines := []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">`,
+		`<plist version="1.0">`,
+		`<dict>`,
+	}
+
+	addString := func(key, value string) {
+		lines = append(lines, "\t<key>"+key+"</key>", "\t<string>"+xmlEscaper.Replace(value)+"</string>")
+	}
+	addBool := func(key string, value bool) {
+		lines = append(lines, "\t<key>"+key+"</key>", fmt.Sprintf("\t<%t/>", value))
+	}
+
+	addString("method", string(options.Method))
+	if options.TeamID != "" {
+		addString("teamID", options.TeamID)
+	}
+
+	if len(options.ProvisioningProfiles) > 0 {
+		addString("signingStyle", "manual")
+		if options.SigningCertificate != "" {
+			addString("signingCertificate", options.SigningCertificate)
+		}
+
+		bundleIDs := []string{}
+		for bundleID := range options.ProvisioningProfiles {
+			bundleIDs = append(bundleIDs, bundleID)
+		}
+		sort.Strings(bundleIDs)
+
+		lines = append(lines, "\t<key>provisioningProfiles</key>", "\t<dict>")
+		for _, bundleID := range bundleIDs {
+			lines = append(lines,
+				"\t\t<key>"+xmlEscaper.Replace(bundleID)+"</key>",
+				"\t\t<string>"+xmlEscaper.Replace(options.ProvisioningProfiles[bundleID])+"</string>",
+			)
+		}
+		lines = append(lines, "\t</dict>")
+	}
+
+	if options.Method == MethodAppStore {
+		addBool("uploadSymbols", options.UploadSymbols)
+	} else {
+		addBool("compileBitcode", options.CompileBitcode)
+	}
+
+	lines = append(lines, "</dict>", "</plist>")
+	return strings.Join(lines, "\n") + "\n"
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Model - xcodebuild -exportArchive command, exports an ipa from the xcarchive into the output dir
+type Model struct {
+	xcarchivePth      string
+	exportOptionsPth  string
+	outputDir         string
+	customOptions     []string
+	allowProvisioning bool
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// New - exportOptionsPth is the plist written by WriteExportOptions
+func New(xcarchivePth, exportOptionsPth, outputDir string) *Model {
+	return &Model{
+		xcarchivePth:     xcarchivePth,
+		exportOptionsPth: exportOptionsPth,
+		outputDir:        outputDir,
+		stdout:           os.Stdout,
+		stderr:           os.Stderr,
+	}
+}
+
+// WriteExportOptions - writes the export options plist to the given path
+func WriteExportOptions(options ExportOptionsModel, pth string) error {
+	if err := fileutil.WriteStringToFile(pth, options.Plist()); err != nil {
+		return fmt.Errorf("failed to write export options (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+// SetAllowProvisioningUpdates - xcodebuild may create and update the profiles of the automatic signing
+func (exporter *Model) SetAllowProvisioningUpdates(allow bool) *Model {
+	exporter.allowProvisioning = allow
+	return exporter
+}
+
+// SetCustomOptions ...
+func (exporter *Model) SetCustomOptions(options ...string) {
+	exporter.customOptions = options
+}
+
+// SetStdout ...
+func (exporter *Model) SetStdout(out io.Writer) {
+	exporter.stdout = out
+}
+
+// SetStderr ...
+func (exporter *Model) SetStderr(err io.Writer) {
+	exporter.stderr = err
+}
+
+// SetTimeout ...
+func (exporter *Model) SetTimeout(timeout time.Duration) {
+	exporter.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (exporter *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	exporter.killGracePeriod = killGracePeriod
+}
+
+func (exporter Model) commandSlice() []string {
+	cmdSlice := []string{
+		"xcodebuild", "-exportArchive",
+		"-archivePath", exporter.xcarchivePth,
+		"-exportPath", exporter.outputDir,
+		"-exportOptionsPlist", exporter.exportOptionsPth,
+	}
+	if exporter.allowProvisioning {
+		cmdSlice = append(cmdSlice, "-allowProvisioningUpdates")
+	}
+	return append(cmdSlice, exporter.customOptions...)
+}
+
+// PrintableCommand ...
+func (exporter Model) PrintableCommand() string {
+	return command.PrintableCommandArgs(true, exporter.commandSlice())
+}
+
+// Run ...
+func (exporter Model) Run() error {
+	command, err := command.NewFromSlice(exporter.commandSlice())
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(exporter.stdout)
+	command.SetStderr(exporter.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), exporter.timeout, exporter.killGracePeriod)
+}
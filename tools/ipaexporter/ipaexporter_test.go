@@ -0,0 +1,71 @@
+package ipaexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlist(t *testing.T) {
+	t.Log("it writes the method and the bitcode option")
+	{
+		options := ExportOptionsModel{Method: MethodAdHoc}
+		require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>method</key>
+	<string>ad-hoc</string>
+	<key>compileBitcode</key>
+	<false/>
+</dict>
+</plist>
+`, options.Plist())
+	}
+
+	t.Log("it uses manual signing with the provisioning profiles")
+	{
+		options := ExportOptionsModel{
+			Method:        MethodAppStore,
+			TeamID:        "72SA8V3WYL",
+			UploadSymbols: true,
+			ProvisioningProfiles: map[string]string{
+				"com.bitrise.sampleapp.extension": "extension-uuid",
+				"com.bitrise.sampleapp":           "app-uuid",
+			},
+		}
+		require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>method</key>
+	<string>app-store</string>
+	<key>teamID</key>
+	<string>72SA8V3WYL</string>
+	<key>signingStyle</key>
+	<string>manual</string>
+	<key>provisioningProfiles</key>
+	<dict>
+		<key>com.bitrise.sampleapp</key>
+		<string>app-uuid</string>
+		<key>com.bitrise.sampleapp.extension</key>
+		<string>extension-uuid</string>
+	</dict>
+	<key>uploadSymbols</key>
+	<true/>
+</dict>
+</plist>
+`, options.Plist())
+	}
+}
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it exports the archive")
+	{
+		exporter := New("/archives/App.xcarchive", "/tmp/exportOptions.plist", "/tmp/export")
+		require.Equal(t, `"xcodebuild" "-exportArchive" "-archivePath" "/archives/App.xcarchive" "-exportPath" "/tmp/export" "-exportOptionsPlist" "/tmp/exportOptions.plist"`, exporter.PrintableCommand())
+
+		exporter.SetAllowProvisioningUpdates(true)
+		require.Contains(t, exporter.PrintableCommand(), `"-allowProvisioningUpdates"`)
+	}
+}
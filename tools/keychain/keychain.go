@@ -0,0 +1,123 @@
+package keychain
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// DefaultLockTimeout - the keychain stays unlocked for a long build, instead of the default 5 minutes
+const DefaultLockTimeout = 6 * time.Hour
+
+// Model - unlocks a keychain for code signing: security unlock-keychain,
+// then security set-keychain-settings to keep it unlocked during the build
+type Model struct {
+	keychainPth string
+	password    string
+	lockTimeout time.Duration
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// New ...
+func New(keychainPth, password string) (*Model, error) {
+	absKeychainPth, err := pathutil.AbsPath(keychainPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", keychainPth, err)
+	}
+
+	return &Model{
+		keychainPth: absKeychainPth,
+		password:    password,
+		lockTimeout: DefaultLockTimeout,
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+	}, nil
+}
+
+// SetLockTimeout - the keychain is locked after the given inactivity, 0 keeps it unlocked until it is locked explicitly
+func (keychain *Model) SetLockTimeout(lockTimeout time.Duration) *Model {
+	keychain.lockTimeout = lockTimeout
+	return keychain
+}
+
+// SetCustomOptions - options are passed to security unlock-keychain
+func (keychain *Model) SetCustomOptions(options ...string) {
+	keychain.customOptions = options
+}
+
+// SetStdout ...
+func (keychain *Model) SetStdout(out io.Writer) {
+	keychain.stdout = out
+}
+
+// SetStderr ...
+func (keychain *Model) SetStderr(err io.Writer) {
+	keychain.stderr = err
+}
+
+// SetTimeout - sets the timeout of each step
+func (keychain *Model) SetTimeout(timeout time.Duration) {
+	keychain.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (keychain *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	keychain.killGracePeriod = killGracePeriod
+}
+
+func (keychain Model) commandSlices(maskSecrets bool) [][]string {
+	password := keychain.password
+	if maskSecrets {
+		password = tools.SecretMask
+	}
+
+	unlockCmdSlice := []string{"security", "unlock-keychain", "-p", password}
+	unlockCmdSlice = append(unlockCmdSlice, keychain.customOptions...)
+	unlockCmdSlice = append(unlockCmdSlice, keychain.keychainPth)
+
+	settingsCmdSlice := []string{"security", "set-keychain-settings"}
+	if keychain.lockTimeout > 0 {
+		settingsCmdSlice = append(settingsCmdSlice, "-lut", fmt.Sprintf("%d", int(keychain.lockTimeout.Seconds())))
+	}
+	settingsCmdSlice = append(settingsCmdSlice, keychain.keychainPth)
+
+	return [][]string{unlockCmdSlice, settingsCmdSlice}
+}
+
+// PrintableCommand - the password is masked
+func (keychain Model) PrintableCommand() string {
+	printableCommands := []string{}
+	for _, cmdSlice := range keychain.commandSlices(true) {
+		printableCommands = append(printableCommands, command.PrintableCommandArgs(true, cmdSlice))
+	}
+	return strings.Join(printableCommands, " && ")
+}
+
+// Run ...
+func (keychain Model) Run() error {
+	for _, cmdSlice := range keychain.commandSlices(false) {
+		cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+		cmd.Stdout = keychain.stdout
+		cmd.Stderr = keychain.stderr
+
+		if err := tools.RunCommandWithTimeout(cmd, keychain.timeout, keychain.killGracePeriod); err != nil {
+			return fmt.Errorf("security %s failed, error: %s", cmdSlice[1], err)
+		}
+	}
+
+	return nil
+}
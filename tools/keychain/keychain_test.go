@@ -0,0 +1,32 @@
+package keychain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it unlocks the keychain and sets the lock timeout")
+	{
+		keychain, err := New("/build.keychain", "keychain-secret")
+		require.NoError(t, err)
+
+		commands := strings.Split(keychain.PrintableCommand(), " && ")
+		require.Equal(t, 2, len(commands))
+		require.Equal(t, `"security" "unlock-keychain" "-p" "***" "/build.keychain"`, commands[0])
+		require.Equal(t, `"security" "set-keychain-settings" "-lut" "21600" "/build.keychain"`, commands[1])
+		require.NotContains(t, keychain.PrintableCommand(), "keychain-secret")
+	}
+
+	t.Log("it keeps the keychain unlocked without lock timeout")
+	{
+		keychain, err := New("/build.keychain", "keychain-secret")
+		require.NoError(t, err)
+		keychain.SetLockTimeout(0)
+
+		commands := strings.Split(keychain.PrintableCommand(), " && ")
+		require.Equal(t, `"security" "set-keychain-settings" "/build.keychain"`, commands[1])
+	}
+}
@@ -0,0 +1,53 @@
+package tools
+
+import "github.com/bitrise-io/go-utils/log"
+
+// Logger - receives the messages of the builder and the build tools,
+// host applications can route them into their own logging system
+type Logger interface {
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+}
+
+// Loggable ...
+type Loggable interface {
+	SetLogger(logger Logger)
+}
+
+// defaultLogger prints by the go-utils log package, debug messages are printed without color
+type defaultLogger struct{}
+
+// NewDefaultLogger ...
+func NewDefaultLogger() Logger {
+	return defaultLogger{}
+}
+
+// Debugf ...
+func (defaultLogger) Debugf(format string, v ...interface{}) {
+	log.Printf(format, v...)
+}
+
+// Infof ...
+func (defaultLogger) Infof(format string, v ...interface{}) {
+	log.Infof(format, v...)
+}
+
+// Warnf ...
+func (defaultLogger) Warnf(format string, v ...interface{}) {
+	log.Warnf(format, v...)
+}
+
+// Errorf ...
+func (defaultLogger) Errorf(format string, v ...interface{}) {
+	log.Errorf(format, v...)
+}
+
+// LoggerOrDefault - returns the default logger, if the logger is not set
+func LoggerOrDefault(logger Logger) Logger {
+	if logger == nil {
+		return defaultLogger{}
+	}
+	return logger
+}
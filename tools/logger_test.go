@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (logger *recordingLogger) Debugf(format string, v ...interface{}) {
+	logger.messages = append(logger.messages, "debug: "+fmt.Sprintf(format, v...))
+}
+
+func (logger *recordingLogger) Infof(format string, v ...interface{}) {
+	logger.messages = append(logger.messages, "info: "+fmt.Sprintf(format, v...))
+}
+
+func (logger *recordingLogger) Warnf(format string, v ...interface{}) {
+	logger.messages = append(logger.messages, "warn: "+fmt.Sprintf(format, v...))
+}
+
+func (logger *recordingLogger) Errorf(format string, v ...interface{}) {
+	logger.messages = append(logger.messages, "error: "+fmt.Sprintf(format, v...))
+}
+
+func TestLoggerOrDefault(t *testing.T) {
+	t.Log("it returns the default logger, if the logger is not set")
+	{
+		require.Equal(t, NewDefaultLogger(), LoggerOrDefault(nil))
+	}
+
+	t.Log("it returns the logger")
+	{
+		logger := &recordingLogger{}
+		LoggerOrDefault(logger).Warnf("Process timed out after %d minutes", 10)
+		require.Equal(t, []string{"warn: Process timed out after 10 minutes"}, logger.messages)
+	}
+}
@@ -0,0 +1,126 @@
+package monocoverage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// MprofReportPath - the mprof-report of the Mono installation
+const MprofReportPath = "/Library/Frameworks/Mono.framework/Versions/Current/Commands/mprof-report"
+
+// ProfilerOption - the mono option enabling the coverage of the log profiler,
+// the coverage is written to the output in mlpd format
+func ProfilerOption(outputPth, filterPth string) string {
+	option := "--profile=log:coverage,onlycoverage,output=" + outputPth
+	if filterPth != "" {
+		option += ",covfilter-file=" + filterPth
+	}
+	return option
+}
+
+// WriteFilterFile - writes the coverage filter of the log profiler, like:
+// +[Sample.Core]
+// -[Sample.Core.Tests]
+func WriteFilterFile(pth string, includeAssemblies, excludeAssemblies []string) error {
+	lines := []string{}
+	for _, assembly := range includeAssemblies {
+		lines = append(lines, "+["+assembly+"]")
+	}
+	for _, assembly := range excludeAssemblies {
+		lines = append(lines, "-["+assembly+"]")
+	}
+
+	if err := fileutil.WriteStringToFile(pth, strings.Join(lines, "\n")+"\n"); err != nil {
+		return fmt.Errorf("Failed to write coverage filter (%s), error: %s", pth, err)
+	}
+	return nil
+}
+
+// Model - converts the mlpd output of the log profiler to coverage XML by mprof-report
+type Model struct {
+	mprofReportPth string
+
+	mlpdPth     string
+	coveragePth string
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// New ...
+func New(mlpdPth, coveragePth string) *Model {
+	return &Model{
+		mprofReportPth: MprofReportPath,
+		mlpdPth:        mlpdPth,
+		coveragePth:    coveragePth,
+		stdout:         os.Stdout,
+		stderr:         os.Stderr,
+	}
+}
+
+// SetMprofReportPth ...
+func (report *Model) SetMprofReportPth(mprofReportPth string) *Model {
+	report.mprofReportPth = mprofReportPth
+	return report
+}
+
+// SetCustomOptions ...
+func (report *Model) SetCustomOptions(options ...string) {
+	report.customOptions = options
+}
+
+// SetStdout ...
+func (report *Model) SetStdout(out io.Writer) {
+	report.stdout = out
+}
+
+// SetStderr ...
+func (report *Model) SetStderr(err io.Writer) {
+	report.stderr = err
+}
+
+// SetTimeout ...
+func (report *Model) SetTimeout(timeout time.Duration) {
+	report.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (report *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	report.killGracePeriod = killGracePeriod
+}
+
+func (report Model) commandSlice() []string {
+	cmdSlice := []string{report.mprofReportPth, "--coverage-out=" + report.coveragePth}
+	cmdSlice = append(cmdSlice, report.customOptions...)
+	return append(cmdSlice, report.mlpdPth)
+}
+
+// PrintableCommand ...
+func (report Model) PrintableCommand() string {
+	return command.PrintableCommandArgs(true, report.commandSlice())
+}
+
+// Run ...
+func (report Model) Run() error {
+	command, err := command.NewFromSlice(report.commandSlice())
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(report.stdout)
+	command.SetStderr(report.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), report.timeout, report.killGracePeriod)
+}
@@ -0,0 +1,221 @@
+package notarytool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// StatusAccepted - status of the successful notarization
+const StatusAccepted = "Accepted"
+
+// CredentialsModel - either the Apple ID with an app-specific password, or the App Store Connect API key
+type CredentialsModel struct {
+	AppleID  string
+	TeamID   string
+	Password string // app-specific password of the Apple ID
+
+	APIKeyPth string // AuthKey_<key id>.p8
+	APIKeyID  string
+	APIIssuer string
+}
+
+// SubmissionModel - result of the notarization
+type SubmissionModel struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"` // Accepted, Invalid, Rejected
+	Message string `json:"message"`
+
+	Stapled bool `json:"-"`
+}
+
+// Model - notarizes a signed .app, .pkg or .dmg: xcrun notarytool submit --wait, then xcrun stapler staple,
+// an .app is zipped by ditto for the submission
+type Model struct {
+	pth         string
+	credentials CredentialsModel
+
+	submission *SubmissionModel
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+
+	logger tools.Logger
+}
+
+// New ...
+func New(pth string, credentials CredentialsModel) (*Model, error) {
+	if credentials.APIKeyPth == "" && (credentials.AppleID == "" || credentials.Password == "" || credentials.TeamID == "") {
+		return nil, fmt.Errorf("either the Apple ID, team id and password, or the API key is required for notarization")
+	}
+
+	return &Model{
+		pth:         pth,
+		credentials: credentials,
+		submission:  &SubmissionModel{},
+		stdout:      os.Stdout,
+		stderr:      os.Stderr,
+	}, nil
+}
+
+// Submission - the result of the notarization, set by Run
+func (notary Model) Submission() SubmissionModel {
+	return *notary.submission
+}
+
+// SetCustomOptions - options are passed to notarytool submit
+func (notary *Model) SetCustomOptions(options ...string) {
+	notary.customOptions = options
+}
+
+// SetStdout ...
+func (notary *Model) SetStdout(out io.Writer) {
+	notary.stdout = out
+}
+
+// SetStderr ...
+func (notary *Model) SetStderr(err io.Writer) {
+	notary.stderr = err
+}
+
+// SetTimeout - sets the timeout of each step, notarization usually takes minutes
+func (notary *Model) SetTimeout(timeout time.Duration) {
+	notary.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (notary *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	notary.killGracePeriod = killGracePeriod
+}
+
+// SetLogger - receives the messages of the command, defaults to printing them
+func (notary *Model) SetLogger(logger tools.Logger) {
+	notary.logger = logger
+}
+
+func (notary Model) isApp() bool {
+	return strings.EqualFold(filepath.Ext(notary.pth), ".app")
+}
+
+func (notary Model) submissionPth() string {
+	if notary.isApp() {
+		return strings.TrimSuffix(notary.pth, filepath.Ext(notary.pth)) + "-notarization.zip"
+	}
+	return notary.pth
+}
+
+func (notary Model) dittoCommandSlice() []string {
+	return []string{"ditto", "-c", "-k", "--keepParent", notary.pth, notary.submissionPth()}
+}
+
+func (notary Model) submitCommandSlice(maskSecrets bool) []string {
+	cmdSlice := []string{"xcrun", "notarytool", "submit", notary.submissionPth(), "--wait", "--output-format", "json"}
+
+	credentials := notary.credentials
+	if credentials.APIKeyPth != "" {
+		cmdSlice = append(cmdSlice, "--key", credentials.APIKeyPth, "--key-id", credentials.APIKeyID, "--issuer", credentials.APIIssuer)
+	} else {
+		password := credentials.Password
+		if maskSecrets {
+			password = tools.SecretMask
+		}
+		cmdSlice = append(cmdSlice, "--apple-id", credentials.AppleID, "--team-id", credentials.TeamID, "--password", password)
+	}
+
+	return append(cmdSlice, notary.customOptions...)
+}
+
+func (notary Model) staplerCommandSlice() []string {
+	return []string{"xcrun", "stapler", "staple", notary.pth}
+}
+
+func (notary Model) commandSlices(maskSecrets bool) [][]string {
+	cmdSlices := [][]string{}
+	if notary.isApp() {
+		cmdSlices = append(cmdSlices, notary.dittoCommandSlice())
+	}
+	return append(cmdSlices, notary.submitCommandSlice(maskSecrets), notary.staplerCommandSlice())
+}
+
+// PrintableCommand - the password is masked
+func (notary Model) PrintableCommand() string {
+	printableCommands := []string{}
+	for _, cmdSlice := range notary.commandSlices(true) {
+		printableCommands = append(printableCommands, command.PrintableCommandArgs(true, cmdSlice))
+	}
+	return strings.Join(printableCommands, " && ")
+}
+
+// ParseSubmitOutput - parses the json output of: notarytool submit --output-format json
+func ParseSubmitOutput(out []byte) (SubmissionModel, error) {
+	start, end := bytes.IndexByte(out, '{'), bytes.LastIndexByte(out, '}')
+	if start < 0 || end < start {
+		return SubmissionModel{}, fmt.Errorf("no submission result found in: %s", out)
+	}
+
+	var submission SubmissionModel
+	if err := json.Unmarshal(out[start:end+1], &submission); err != nil {
+		return SubmissionModel{}, fmt.Errorf("failed to parse submission result, error: %s", err)
+	}
+	return submission, nil
+}
+
+func (notary Model) run(cmdSlice []string, stdout io.Writer) error {
+	cmd := exec.Command(cmdSlice[0], cmdSlice[1:]...)
+	cmd.Stdout = stdout
+	cmd.Stderr = notary.stderr
+
+	return tools.RunLoggedCommandWithTimeout(cmd, notary.timeout, notary.killGracePeriod, notary.logger)
+}
+
+// Run - fails if the notarization is not accepted, the result is available by Submission
+func (notary Model) Run() error {
+	*notary.submission = SubmissionModel{}
+
+	if notary.isApp() {
+		if err := notary.run(notary.dittoCommandSlice(), notary.stdout); err != nil {
+			return fmt.Errorf("failed to zip (%s), error: %s", notary.pth, err)
+		}
+		defer func() {
+			if err := os.Remove(notary.submissionPth()); err != nil {
+				tools.LoggerOrDefault(notary.logger).Warnf("Failed to remove (%s), error: %s", notary.submissionPth(), err)
+			}
+		}()
+	}
+
+	var out bytes.Buffer
+	submitErr := notary.run(notary.submitCommandSlice(false), io.MultiWriter(notary.stdout, &out))
+	submission, err := ParseSubmitOutput(out.Bytes())
+	if err != nil {
+		if submitErr != nil {
+			return fmt.Errorf("notarytool submit failed, error: %s", submitErr)
+		}
+		return err
+	}
+	*notary.submission = submission
+
+	if submission.Status != StatusAccepted {
+		return fmt.Errorf("notarization (%s) finished with status: %s, see: xcrun notarytool log %s", submission.ID, submission.Status, submission.ID)
+	}
+
+	if err := notary.run(notary.staplerCommandSlice(), notary.stdout); err != nil {
+		return fmt.Errorf("failed to staple the notarization ticket to (%s), error: %s", notary.pth, err)
+	}
+	notary.submission.Stapled = true
+
+	return nil
+}
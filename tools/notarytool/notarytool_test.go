@@ -0,0 +1,53 @@
+package notarytool
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it zips, submits and staples the app")
+	{
+		notary, err := New("/bin/Release/Mac.app", CredentialsModel{AppleID: "bot@bitrise.io", TeamID: "72SA8V3WYL", Password: "app-secret"})
+		require.NoError(t, err)
+
+		commands := strings.Split(notary.PrintableCommand(), " && ")
+		require.Equal(t, 3, len(commands))
+		require.Equal(t, `"ditto" "-c" "-k" "--keepParent" "/bin/Release/Mac.app" "/bin/Release/Mac-notarization.zip"`, commands[0])
+		require.Equal(t, `"xcrun" "notarytool" "submit" "/bin/Release/Mac-notarization.zip" "--wait" "--output-format" "json" "--apple-id" "bot@bitrise.io" "--team-id" "72SA8V3WYL" "--password" "***"`, commands[1])
+		require.Equal(t, `"xcrun" "stapler" "staple" "/bin/Release/Mac.app"`, commands[2])
+	}
+
+	t.Log("it submits the pkg with the API key")
+	{
+		notary, err := New("/bin/Release/Mac.pkg", CredentialsModel{APIKeyPth: "/AuthKey_ABC.p8", APIKeyID: "ABC", APIIssuer: "issuer-id"})
+		require.NoError(t, err)
+
+		commands := strings.Split(notary.PrintableCommand(), " && ")
+		require.Equal(t, 2, len(commands))
+		require.Equal(t, `"xcrun" "notarytool" "submit" "/bin/Release/Mac.pkg" "--wait" "--output-format" "json" "--key" "/AuthKey_ABC.p8" "--key-id" "ABC" "--issuer" "issuer-id"`, commands[0])
+	}
+
+	t.Log("it requires credentials")
+	{
+		_, err := New("/bin/Release/Mac.pkg", CredentialsModel{AppleID: "bot@bitrise.io"})
+		require.Error(t, err)
+	}
+}
+
+func TestParseSubmitOutput(t *testing.T) {
+	t.Log("it parses the submission result")
+	{
+		submission, err := ParseSubmitOutput([]byte("Conducting pre-submission checks...\n" + `{"id":"2efe2717-52ef-43a5-96dc-0797e4ca1041","status":"Accepted","message":"Processing complete"}` + "\n"))
+		require.NoError(t, err)
+		require.Equal(t, SubmissionModel{ID: "2efe2717-52ef-43a5-96dc-0797e4ca1041", Status: StatusAccepted, Message: "Processing complete"}, submission)
+	}
+
+	t.Log("it fails without result")
+	{
+		_, err := ParseSubmitOutput([]byte("Error: HTTP status code: 401"))
+		require.Error(t, err)
+	}
+}
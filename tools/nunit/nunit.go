@@ -2,12 +2,15 @@ package nunit
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/bitrise-io/go-utils/command"
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 )
 
 const (
@@ -23,24 +26,32 @@ type Model struct {
 
 	dllPth string
 	test   string
+	where  string
 
 	resultLogPth string
 
+	monoOptions   []string
 	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
 }
 
 // SystemNunit3ConsolePath ...
 func SystemNunit3ConsolePath() (string, error) {
 	nunitDir := os.Getenv("NUNIT_PATH")
 	if nunitDir == "" {
-		return "", fmt.Errorf("NUNIT_PATH environment is not set, failed to determin nunit console path")
+		return "", tools.ToolNotInstalledErrorf("NUNIT_PATH environment is not set, failed to determin nunit console path")
 	}
 
 	nunitConsolePth := filepath.Join(nunitDir, nunit3Console)
 	if exist, err := pathutil.IsPathExists(nunitConsolePth); err != nil {
 		return "", fmt.Errorf("Failed to check if nunit console exist at (%s), error: %s", nunitConsolePth, err)
 	} else if !exist {
-		return "", fmt.Errorf("nunit console not exist at: %s", nunitConsolePth)
+		return "", tools.ToolNotInstalledErrorf("nunit console not exist at: %s", nunitConsolePth)
 	}
 
 	return nunitConsolePth, nil
@@ -53,7 +64,7 @@ func New(nunitConsolePth string) (*Model, error) {
 		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", nunitConsolePth, err)
 	}
 
-	return &Model{nunitConsolePth: absNunitConsolePth}, nil
+	return &Model{nunitConsolePth: absNunitConsolePth, stdout: os.Stdout, stderr: os.Stderr}, nil
 }
 
 // SetProjectPth ...
@@ -80,19 +91,52 @@ func (nunitConsole *Model) SetTestToRun(test string) *Model {
 	return nunitConsole
 }
 
+// SetWhere - test selection language expression, like: cat == Smoke && Priority < 2
+func (nunitConsole *Model) SetWhere(where string) *Model {
+	nunitConsole.where = where
+	return nunitConsole
+}
+
 // SetResultLogPth ...
 func (nunitConsole *Model) SetResultLogPth(resultLogPth string) *Model {
 	nunitConsole.resultLogPth = resultLogPth
 	return nunitConsole
 }
 
+// SetMonoOptions - options of the mono runtime running the console, like the profiler
+func (nunitConsole *Model) SetMonoOptions(options ...string) *Model {
+	nunitConsole.monoOptions = options
+	return nunitConsole
+}
+
 // SetCustomOptions ...
 func (nunitConsole *Model) SetCustomOptions(options ...string) {
 	nunitConsole.customOptions = options
 }
 
+// SetStdout ...
+func (nunitConsole *Model) SetStdout(out io.Writer) {
+	nunitConsole.stdout = out
+}
+
+// SetStderr ...
+func (nunitConsole *Model) SetStderr(err io.Writer) {
+	nunitConsole.stderr = err
+}
+
+// SetTimeout ...
+func (nunitConsole *Model) SetTimeout(timeout time.Duration) {
+	nunitConsole.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (nunitConsole *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	nunitConsole.killGracePeriod = killGracePeriod
+}
+
 func (nunitConsole *Model) commandSlice() []string {
 	cmdSlice := []string{constants.MonoPath}
+	cmdSlice = append(cmdSlice, nunitConsole.monoOptions...)
 	cmdSlice = append(cmdSlice, nunitConsole.nunitConsolePth)
 
 	if nunitConsole.projectPth != "" {
@@ -108,6 +152,9 @@ func (nunitConsole *Model) commandSlice() []string {
 	if nunitConsole.test != "" {
 		cmdSlice = append(cmdSlice, "--test", nunitConsole.test)
 	}
+	if nunitConsole.where != "" {
+		cmdSlice = append(cmdSlice, "--where", nunitConsole.where)
+	}
 
 	if nunitConsole.resultLogPth != "" {
 		cmdSlice = append(cmdSlice, "--result", nunitConsole.resultLogPth)
@@ -133,8 +180,8 @@ func (nunitConsole Model) Run() error {
 		return err
 	}
 
-	command.SetStdout(os.Stdout)
-	command.SetStderr(os.Stderr)
+	command.SetStdout(nunitConsole.stdout)
+	command.SetStderr(nunitConsole.stderr)
 
-	return command.Run()
+	return tools.RunCommandWithTimeout(command.GetCmd(), nunitConsole.timeout, nunitConsole.killGracePeriod)
 }
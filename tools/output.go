@@ -0,0 +1,248 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultOutputTailLines - the number of the last output lines kept in memory for error reporting
+const DefaultOutputTailLines = 50
+
+// maxTailLineLength - longer lines are truncated in the tail, like base64 encoded resources of diagnostic logs
+const maxTailLineLength = 4096
+
+// OutputTail - keeps the last lines of the written output in a ring buffer
+type OutputTail struct {
+	mutex   sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	partial []byte
+}
+
+// NewOutputTail ...
+func NewOutputTail(lines int) *OutputTail {
+	if lines < 1 {
+		lines = 1
+	}
+	return &OutputTail{lines: make([]string, lines)}
+}
+
+func (tail *OutputTail) push(line []byte) {
+	if len(line) > maxTailLineLength {
+		line = line[:maxTailLineLength]
+	}
+	tail.lines[tail.next] = string(bytes.TrimRight(line, "\r"))
+	tail.next = (tail.next + 1) % len(tail.lines)
+	if tail.next == 0 {
+		tail.full = true
+	}
+}
+
+// Write ...
+func (tail *OutputTail) Write(p []byte) (int, error) {
+	tail.mutex.Lock()
+	defer tail.mutex.Unlock()
+
+	data := p
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		if len(tail.partial) > 0 {
+			tail.push(append(tail.partial, data[:idx]...))
+			tail.partial = nil
+		} else {
+			tail.push(data[:idx])
+		}
+		data = data[idx+1:]
+	}
+
+	if len(tail.partial) < maxTailLineLength {
+		tail.partial = append(tail.partial, data...)
+	}
+
+	return len(p), nil
+}
+
+// Lines - returns the last lines in the written order, including the not terminated last line
+func (tail *OutputTail) Lines() []string {
+	tail.mutex.Lock()
+	defer tail.mutex.Unlock()
+
+	lines := []string{}
+	if tail.full {
+		lines = append(lines, tail.lines[tail.next:]...)
+	}
+	lines = append(lines, tail.lines[:tail.next]...)
+	if len(tail.partial) > 0 {
+		if len(lines) == len(tail.lines) {
+			lines = lines[1:]
+		}
+		partial := tail.partial
+		if len(partial) > maxTailLineLength {
+			partial = partial[:maxTailLineLength]
+		}
+		lines = append(lines, string(bytes.TrimRight(partial, "\r")))
+	}
+	return lines
+}
+
+// CommandResult - the result of a command run by RunCapturingOutput, the complete output is streamed into a temporary file,
+// only its last lines are kept in memory
+type CommandResult struct {
+	Err       error
+	ExitCode  int
+	OutputPth string   // the complete output, empty if it could not be written, removed by Cleanup
+	Tail      []string // the last lines of the output
+}
+
+// MatchOutput - reports whether any of the patterns matches the output. The output is not read into memory:
+// the patterns are matched against each line of the output file (or of the tail, if the output file is not available),
+// and against the newline joined tail, so patterns spanning more lines match within the last lines of the output.
+func (result CommandResult) MatchOutput(patterns ...*regexp.Regexp) (bool, error) {
+	matchLine := func(line []byte) bool {
+		for _, re := range patterns {
+			if re.Match(line) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matchLine([]byte(strings.Join(result.Tail, "\n"))) {
+		return true, nil
+	}
+
+	if result.OutputPth == "" {
+		for _, line := range result.Tail {
+			if matchLine([]byte(line)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	file, err := os.Open(result.OutputPth)
+	if err != nil {
+		return false, fmt.Errorf("failed to open command output (%s), error: %s", result.OutputPth, err)
+	}
+	defer func() {
+		// the output is only read
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 && matchLine(bytes.TrimRight(line, "\r\n")) {
+			return true, nil
+		}
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read command output (%s), error: %s", result.OutputPth, err)
+		}
+	}
+}
+
+// Cleanup - removes the output file
+func (result CommandResult) Cleanup() error {
+	if result.OutputPth == "" {
+		return nil
+	}
+	if err := os.Remove(result.OutputPth); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove command output (%s), error: %s", result.OutputPth, err)
+	}
+	return nil
+}
+
+// OutputCapture - streams the written output into a temporary file, and keeps its last lines in memory,
+// failing to write the file does not fail the writes, as the command's output is written to the console as well
+type OutputCapture struct {
+	mutex  sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	tail   *OutputTail
+}
+
+// NewOutputCapture - if the temporary file can not be created, only the last lines are kept
+func NewOutputCapture(tailLines int) *OutputCapture {
+	capture := &OutputCapture{tail: NewOutputTail(tailLines)}
+	if file, err := ioutil.TempFile("", "command-output"); err == nil {
+		capture.file = file
+		capture.writer = bufio.NewWriter(file)
+	}
+	return capture
+}
+
+// Write ...
+func (capture *OutputCapture) Write(p []byte) (int, error) {
+	capture.mutex.Lock()
+	defer capture.mutex.Unlock()
+
+	if capture.writer != nil {
+		if _, err := capture.writer.Write(p); err != nil {
+			capture.discardFile()
+		}
+	}
+	return capture.tail.Write(p)
+}
+
+// discardFile drops the partially written output file
+func (capture *OutputCapture) discardFile() {
+	_ = capture.file.Close()
+	_ = os.Remove(capture.file.Name())
+	capture.file, capture.writer = nil, nil
+}
+
+// Result - closes the output file, and returns the result of the command
+func (capture *OutputCapture) Result(err error) CommandResult {
+	capture.mutex.Lock()
+	defer capture.mutex.Unlock()
+
+	result := CommandResult{Err: err, ExitCode: ExitCode(err), Tail: capture.tail.Lines()}
+	if capture.file == nil {
+		return result
+	}
+
+	if err := capture.writer.Flush(); err != nil {
+		capture.discardFile()
+		return result
+	}
+	pth := capture.file.Name()
+	if err := capture.file.Close(); err != nil {
+		_ = os.Remove(pth)
+		capture.file, capture.writer = nil, nil
+		return result
+	}
+	capture.file, capture.writer = nil, nil
+
+	result.OutputPth = pth
+	return result
+}
+
+// RunCapturingOutput - runs the command, its output is written to the stdout and stderr, and captured for analysis,
+// the captured output is removed by the result's Cleanup. Commands, which are not OutputRedirectable, are run without capturing.
+func RunCapturingOutput(command Runnable, stdout, stderr io.Writer, tailLines int) CommandResult {
+	redirectable, ok := command.(OutputRedirectable)
+	if !ok {
+		err := command.Run()
+		return CommandResult{Err: err, ExitCode: ExitCode(err)}
+	}
+
+	capture := NewOutputCapture(tailLines)
+	redirectable.SetStdout(io.MultiWriter(stdout, capture))
+	redirectable.SetStderr(io.MultiWriter(stderr, capture))
+
+	return capture.Result(command.Run())
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputTail(t *testing.T) {
+	t.Log("it keeps the last lines")
+	{
+		tail := NewOutputTail(3)
+		for i := 1; i <= 5; i++ {
+			_, err := fmt.Fprintf(tail, "line %d\r\n", i)
+			require.NoError(t, err)
+		}
+		require.Equal(t, []string{"line 3", "line 4", "line 5"}, tail.Lines())
+	}
+
+	t.Log("it keeps the not terminated last line")
+	{
+		tail := NewOutputTail(2)
+		_, err := tail.Write([]byte("line 1\nline 2\nli"))
+		require.NoError(t, err)
+		_, err = tail.Write([]byte("ne 3"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"line 2", "line 3"}, tail.Lines())
+	}
+
+	t.Log("it truncates the long lines")
+	{
+		tail := NewOutputTail(2)
+		_, err := tail.Write([]byte(strings.Repeat("a", 2*maxTailLineLength) + "\n"))
+		require.NoError(t, err)
+		require.Equal(t, []string{strings.Repeat("a", maxTailLineLength)}, tail.Lines())
+	}
+}
+
+func TestRunCapturingOutput(t *testing.T) {
+	t.Log("it streams the output into a file")
+	{
+		output := strings.Repeat("restoring packages\n", 1000) + "error: network timeout\nBuild FAILED."
+		result := RunCapturingOutput(&testCommand{outputs: []string{output}}, io.Discard, io.Discard, 2)
+		defer func() {
+			require.NoError(t, result.Cleanup())
+		}()
+
+		require.Error(t, result.Err)
+		require.Equal(t, []string{"error: network timeout", "Build FAILED."}, result.Tail)
+
+		content, err := fileutil.ReadStringFromFile(result.OutputPth)
+		require.NoError(t, err)
+		require.Equal(t, output, content)
+
+		match, err := result.MatchOutput(regexp.MustCompile(`(?i)timeout`))
+		require.NoError(t, err)
+		require.Equal(t, true, match)
+
+		match, err = result.MatchOutput(regexp.MustCompile(`^restoring packages\s+error`))
+		require.NoError(t, err)
+		require.Equal(t, false, match)
+	}
+
+	t.Log("it removes the output file")
+	{
+		result := RunCapturingOutput(&testCommand{outputs: []string{"ok"}}, io.Discard, io.Discard, DefaultOutputTailLines)
+		require.NoError(t, result.Err)
+		require.NoError(t, result.Cleanup())
+
+		_, err := os.Stat(result.OutputPth)
+		require.True(t, os.IsNotExist(err))
+	}
+
+	t.Log("it matches the patterns spanning more lines within the tail")
+	{
+		output := "error: network timeout\n" + strings.Repeat("restoring packages\n", 10) + "Build FAILED."
+		result := RunCapturingOutput(&testCommand{outputs: []string{output}}, io.Discard, io.Discard, 2)
+		defer func() {
+			require.NoError(t, result.Cleanup())
+		}()
+
+		match, err := result.MatchOutput(regexp.MustCompile(`restoring packages\s+Build FAILED`))
+		require.NoError(t, err)
+		require.Equal(t, true, match)
+
+		match, err = result.MatchOutput(regexp.MustCompile(`(?s)network timeout.*Build FAILED`))
+		require.NoError(t, err)
+		require.Equal(t, false, match)
+
+		match, err = result.MatchOutput(regexp.MustCompile(`^restoring packages$`))
+		require.NoError(t, err)
+		require.Equal(t, true, match)
+	}
+
+	t.Log("it matches the tail without output file")
+	{
+		result := CommandResult{Tail: []string{"error: network timeout"}}
+		match, err := result.MatchOutput(regexp.MustCompile(`(?i)timeout`))
+		require.NoError(t, err)
+		require.Equal(t, true, match)
+	}
+}
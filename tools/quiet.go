@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+)
+
+var (
+	keepLinePattern = regexp.MustCompile(`(?i)\b(warning|error)\b`)
+
+	noiseLinePatterns = []*regexp.Regexp{
+		// Tool banners
+		regexp.MustCompile(`^XBuild Engine Version`),
+		regexp.MustCompile(`^Microsoft \(R\) Build Engine version`),
+		regexp.MustCompile(`^Mono, Version`),
+		regexp.MustCompile(`^Copyright \([Cc]\)`),
+		regexp.MustCompile(`(?i)^(Xamarin Studio|Visual Studio|MonoDevelop) Build Tool`),
+		regexp.MustCompile(`(?i)^XBuild is deprecated`),
+
+		// NuGet progress
+		regexp.MustCompile(`(?i)^\s*Restoring NuGet packages?`),
+		regexp.MustCompile(`(?i)^\s*(GET|OK|CACHE|NotFound) https?://`),
+		regexp.MustCompile(`(?i)^\s*(Installing|Adding package|Added package|Successfully installed) `),
+		regexp.MustCompile(`(?i)^\s*Acquiring lock for`),
+		regexp.MustCompile(`(?i)^\s*All packages listed in .* are already installed`),
+		regexp.MustCompile(`(?i)^\s*Package .* already exists in folder`),
+	}
+)
+
+// Quietable ...
+type Quietable interface {
+	SetQuiet(quiet bool)
+}
+
+// QuietWriter - drops tool banners and NuGet progress lines, but keeps warnings and errors
+type QuietWriter struct {
+	writer io.Writer
+	buffer []byte
+}
+
+// NewQuietWriter ...
+func NewQuietWriter(writer io.Writer) *QuietWriter {
+	return &QuietWriter{writer: writer}
+}
+
+// Write ...
+func (quietWriter *QuietWriter) Write(p []byte) (int, error) {
+	quietWriter.buffer = append(quietWriter.buffer, p...)
+
+	for {
+		idx := bytes.IndexByte(quietWriter.buffer, '\n')
+		if idx < 0 {
+			break
+		}
+
+		line := quietWriter.buffer[:idx+1]
+		if err := quietWriter.writeLine(line); err != nil {
+			return 0, err
+		}
+		quietWriter.buffer = quietWriter.buffer[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush - writes the pending, not new line terminated output
+func (quietWriter *QuietWriter) Flush() error {
+	if len(quietWriter.buffer) == 0 {
+		return nil
+	}
+
+	line := quietWriter.buffer
+	quietWriter.buffer = nil
+	return quietWriter.writeLine(line)
+}
+
+func (quietWriter *QuietWriter) writeLine(line []byte) error {
+	if isNoiseLine(line) {
+		return nil
+	}
+	_, err := quietWriter.writer.Write(line)
+	return err
+}
+
+func isNoiseLine(line []byte) bool {
+	if keepLinePattern.Match(line) {
+		return false
+	}
+
+	for _, re := range noiseLinePatterns {
+		if re.Match(line) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietWriter(t *testing.T) {
+	t.Log("it filters banners and nuget progress, but keeps warnings and errors")
+	{
+		output := `XBuild Engine Version 14.0
+Mono, Version 4.8.0.495
+Copyright (C) 2005-2013 Various Mono authors
+
+Build started 3/1/2017 10:00:00 AM.
+  GET https://api.nuget.org/v3-flatcontainer/xamarin.forms/index.json
+  OK https://api.nuget.org/v3-flatcontainer/xamarin.forms/index.json 120ms
+Restoring NuGet packages...
+Restoring NuGet packages: warning NU1603: package version not found
+MainActivity.cs(10,5): error CS1002: ; expected
+Build FAILED.`
+
+		var buffer bytes.Buffer
+		writer := NewQuietWriter(&buffer)
+		_, err := writer.Write([]byte(output))
+		require.NoError(t, err)
+		require.NoError(t, writer.Flush())
+
+		desired := `
+Build started 3/1/2017 10:00:00 AM.
+Restoring NuGet packages: warning NU1603: package version not found
+MainActivity.cs(10,5): error CS1002: ; expected
+Build FAILED.`
+		require.Equal(t, desired, buffer.String())
+	}
+
+	t.Log("it handles lines split between writes")
+	{
+		var buffer bytes.Buffer
+		writer := NewQuietWriter(&buffer)
+		_, err := writer.Write([]byte("XBuild Engine "))
+		require.NoError(t, err)
+		_, err = writer.Write([]byte("Version 14.0\nBuild succeeded.\n"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Flush())
+
+		require.Equal(t, "Build succeeded.\n", buffer.String())
+	}
+}
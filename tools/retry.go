@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+)
+
+// RetryingCommand - wraps a Runnable and re-runs it on failure
+type RetryingCommand struct {
+	command Runnable
+
+	attempts      int
+	backoff       time.Duration
+	retryPatterns []*regexp.Regexp
+
+	stdout io.Writer
+	stderr io.Writer
+	logger Logger
+}
+
+// NewRetryingCommand ...
+func NewRetryingCommand(command Runnable, attempts int, backoff time.Duration) *RetryingCommand {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return &RetryingCommand{
+		command:  command,
+		attempts: attempts,
+		backoff:  backoff,
+		stdout:   os.Stdout,
+		stderr:   os.Stderr,
+	}
+}
+
+// SetRetryOnOutputPatterns - if set, the command is retried only if its output matches any of the given regexp patterns.
+// The patterns are matched line by line, patterns spanning more lines (or anchored to the output's start or end)
+// are matched only within the last DefaultOutputTailLines lines, see: CommandResult.MatchOutput.
+// Output matching requires the wrapped command to be OutputRedirectable.
+func (cmd *RetryingCommand) SetRetryOnOutputPatterns(patterns ...string) (*RetryingCommand, error) {
+	retryPatterns := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return cmd, fmt.Errorf("invalid retry pattern (%s), error: %s", pattern, err)
+		}
+		retryPatterns = append(retryPatterns, re)
+	}
+
+	cmd.retryPatterns = retryPatterns
+	return cmd, nil
+}
+
+// PrintableCommand ...
+func (cmd RetryingCommand) PrintableCommand() string {
+	return cmd.command.PrintableCommand()
+}
+
+// SetCustomOptions ...
+func (cmd *RetryingCommand) SetCustomOptions(options ...string) {
+	cmd.command.SetCustomOptions(options...)
+}
+
+// SetStdout ...
+func (cmd *RetryingCommand) SetStdout(out io.Writer) {
+	cmd.stdout = out
+}
+
+// SetStderr ...
+func (cmd *RetryingCommand) SetStderr(err io.Writer) {
+	cmd.stderr = err
+}
+
+// SetLogger - receives the messages of the retries, it is passed to the wrapped command as well
+func (cmd *RetryingCommand) SetLogger(logger Logger) {
+	cmd.logger = logger
+	if loggable, ok := cmd.command.(Loggable); ok {
+		loggable.SetLogger(logger)
+	}
+}
+
+// SetTimeout - sets the timeout of a single attempt
+func (cmd *RetryingCommand) SetTimeout(timeout time.Duration) {
+	if timeoutable, ok := cmd.command.(Timeoutable); ok {
+		timeoutable.SetTimeout(timeout)
+	}
+}
+
+// SetKillGracePeriod ...
+func (cmd *RetryingCommand) SetKillGracePeriod(killGracePeriod time.Duration) {
+	if timeoutable, ok := cmd.command.(Timeoutable); ok {
+		timeoutable.SetKillGracePeriod(killGracePeriod)
+	}
+}
+
+// SetQuiet ...
+func (cmd *RetryingCommand) SetQuiet(quiet bool) {
+	if quietable, ok := cmd.command.(Quietable); ok {
+		quietable.SetQuiet(quiet)
+	}
+}
+
+// Run ...
+func (cmd RetryingCommand) Run() error {
+	backoff := cmd.backoff
+
+	var err error
+	for attempt := 1; attempt <= cmd.attempts; attempt++ {
+		result := RunCapturingOutput(cmd.command, cmd.stdout, cmd.stderr, DefaultOutputTailLines)
+		err = result.Err
+
+		retry := err != nil && attempt < cmd.attempts && cmd.shouldRetry(result)
+		if cleanupErr := result.Cleanup(); cleanupErr != nil {
+			LoggerOrDefault(cmd.logger).Warnf("%s", cleanupErr)
+		}
+
+		if err == nil {
+			return nil
+		}
+		if !retry {
+			break
+		}
+
+		LoggerOrDefault(cmd.logger).Warnf("Attempt %d/%d failed, error: %s", attempt, cmd.attempts, err)
+		LoggerOrDefault(cmd.logger).Debugf("Retrying in %s...", backoff)
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	return err
+}
+
+func (cmd RetryingCommand) shouldRetry(result CommandResult) bool {
+	if len(cmd.retryPatterns) == 0 {
+		return true
+	}
+
+	match, err := result.MatchOutput(cmd.retryPatterns...)
+	if err != nil {
+		LoggerOrDefault(cmd.logger).Warnf("%s", err)
+		return false
+	}
+	return match
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCommand struct {
+	outputs []string
+	runs    int
+	stdout  io.Writer
+}
+
+func (cmd testCommand) PrintableCommand() string            { return "test" }
+func (cmd *testCommand) SetCustomOptions(options ...string) {}
+func (cmd *testCommand) SetStdout(out io.Writer)            { cmd.stdout = out }
+func (cmd *testCommand) SetStderr(err io.Writer)            {}
+func (cmd *testCommand) Run() error {
+	output := cmd.outputs[cmd.runs]
+	cmd.runs++
+	if _, err := fmt.Fprint(cmd.stdout, output); err != nil {
+		return err
+	}
+	if output != "ok" {
+		return fmt.Errorf("exit status 1")
+	}
+	return nil
+}
+
+func TestRetryingCommand(t *testing.T) {
+	t.Log("it retries until the command succeeds")
+	{
+		command := &testCommand{outputs: []string{"error", "error", "ok"}}
+		retryingCommand := NewRetryingCommand(command, 3, 0)
+		retryingCommand.SetStdout(io.Discard)
+
+		require.NoError(t, retryingCommand.Run())
+		require.Equal(t, 3, command.runs)
+		require.Equal(t, "test", retryingCommand.PrintableCommand())
+	}
+
+	t.Log("it fails after the given attempts")
+	{
+		command := &testCommand{outputs: []string{"error", "error", "ok"}}
+		retryingCommand := NewRetryingCommand(command, 2, 0)
+		retryingCommand.SetStdout(io.Discard)
+
+		require.Error(t, retryingCommand.Run())
+		require.Equal(t, 2, command.runs)
+	}
+
+	t.Log("it retries only if output matches retry pattern")
+	{
+		command := &testCommand{outputs: []string{"network timeout", "compile error", "ok"}}
+		retryingCommand, err := NewRetryingCommand(command, 3, 0).SetRetryOnOutputPatterns(`(?i)timeout`)
+		require.NoError(t, err)
+		retryingCommand.SetStdout(io.Discard)
+
+		require.Error(t, retryingCommand.Run())
+		require.Equal(t, 2, command.runs)
+	}
+
+	t.Log("it matches the retry patterns line by line")
+	{
+		command := &testCommand{outputs: []string{"restoring packages\nerror: network timeout\nBuild FAILED.", "restoring packages\nBuild FAILED.", "ok"}}
+		retryingCommand, err := NewRetryingCommand(command, 3, 0).SetRetryOnOutputPatterns(`^error: .*timeout$`)
+		require.NoError(t, err)
+		retryingCommand.SetStdout(io.Discard)
+
+		require.Error(t, retryingCommand.Run())
+		require.Equal(t, 2, command.runs)
+	}
+
+	t.Log("it passes the retry messages to the logger")
+	{
+		logger := &recordingLogger{}
+		command := &testCommand{outputs: []string{"error", "ok"}}
+		retryingCommand := NewRetryingCommand(command, 2, 0)
+		retryingCommand.SetStdout(io.Discard)
+		retryingCommand.SetLogger(logger)
+
+		require.NoError(t, retryingCommand.Run())
+		require.Equal(t, []string{"warn: Attempt 1/2 failed, error: exit status 1", "debug: Retrying in 0s..."}, logger.messages)
+	}
+
+	t.Log("it fails for invalid retry pattern")
+	{
+		_, err := NewRetryingCommand(&testCommand{}, 3, 0).SetRetryOnOutputPatterns(`(`)
+		require.Error(t, err)
+	}
+}
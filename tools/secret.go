@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SecretMask - replaces secret values, like signing passwords, in printable commands
+const SecretMask = "***"
+
+// Secrets - registry of secret values, which are masked in printable commands and command outputs
+type Secrets struct {
+	mutex  sync.RWMutex
+	values []string
+}
+
+// NewSecrets ...
+func NewSecrets() *Secrets {
+	return &Secrets{}
+}
+
+// Add - registers the secret values, empty values are ignored
+func (secrets *Secrets) Add(values ...string) {
+	secrets.mutex.Lock()
+	defer secrets.mutex.Unlock()
+
+	for _, value := range values {
+		if value == "" || containsString(secrets.values, value) {
+			continue
+		}
+		secrets.values = append(secrets.values, value)
+	}
+
+	// longer secrets first, so a secret containing an other one is masked entirely
+	sort.SliceStable(secrets.values, func(i, j int) bool {
+		return len(secrets.values[i]) > len(secrets.values[j])
+	})
+}
+
+// Empty ...
+func (secrets *Secrets) Empty() bool {
+	secrets.mutex.RLock()
+	defer secrets.mutex.RUnlock()
+
+	return len(secrets.values) == 0
+}
+
+// Mask - replaces the registered secret values with SecretMask
+func (secrets *Secrets) Mask(str string) string {
+	secrets.mutex.RLock()
+	defer secrets.mutex.RUnlock()
+
+	for _, value := range secrets.values {
+		str = strings.Replace(str, value, SecretMask, -1)
+	}
+	return str
+}
+
+// MaskedCommand - wraps a Runnable, masks the secrets in its printable command
+type MaskedCommand struct {
+	Runnable
+	secrets *Secrets
+}
+
+// NewMaskedCommand ...
+func NewMaskedCommand(command Runnable, secrets *Secrets) MaskedCommand {
+	return MaskedCommand{Runnable: command, secrets: secrets}
+}
+
+// PrintableCommand ...
+func (cmd MaskedCommand) PrintableCommand() string {
+	return cmd.secrets.Mask(cmd.Runnable.PrintableCommand())
+}
+
+// MaskingWriter - masks the secrets in the written output, the output is written line by line,
+// so secrets split between writes are masked as well
+type MaskingWriter struct {
+	writer  io.Writer
+	secrets *Secrets
+	buffer  []byte
+}
+
+// NewMaskingWriter ...
+func NewMaskingWriter(writer io.Writer, secrets *Secrets) *MaskingWriter {
+	return &MaskingWriter{writer: writer, secrets: secrets}
+}
+
+// Write ...
+func (maskingWriter *MaskingWriter) Write(p []byte) (int, error) {
+	maskingWriter.buffer = append(maskingWriter.buffer, p...)
+
+	idx := bytes.LastIndexByte(maskingWriter.buffer, '\n')
+	if idx < 0 {
+		return len(p), nil
+	}
+
+	lines := maskingWriter.buffer[:idx+1]
+	maskingWriter.buffer = append([]byte{}, maskingWriter.buffer[idx+1:]...)
+	if _, err := io.WriteString(maskingWriter.writer, maskingWriter.secrets.Mask(string(lines))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Flush - writes the pending, not new line terminated output
+func (maskingWriter *MaskingWriter) Flush() error {
+	if len(maskingWriter.buffer) == 0 {
+		return nil
+	}
+
+	output := maskingWriter.buffer
+	maskingWriter.buffer = nil
+	_, err := io.WriteString(maskingWriter.writer, maskingWriter.secrets.Mask(string(output)))
+	return err
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
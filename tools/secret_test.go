@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type printableTestCommand struct {
+	EmptyCommand
+	printableCommand string
+}
+
+func (cmd printableTestCommand) PrintableCommand() string { return cmd.printableCommand }
+
+func TestSecrets(t *testing.T) {
+	t.Log("it masks the registered secrets")
+	{
+		secrets := NewSecrets()
+		require.Equal(t, true, secrets.Empty())
+
+		secrets.Add("pass", "", "password", "pass")
+		require.Equal(t, false, secrets.Empty())
+		require.Equal(t, "/p:StorePass=*** /p:KeyPass=***", secrets.Mask("/p:StorePass=password /p:KeyPass=pass"))
+		require.Equal(t, "no secret", secrets.Mask("no secret"))
+	}
+
+	t.Log("it masks the printable command")
+	{
+		secrets := NewSecrets()
+		secrets.Add("secret")
+
+		command := NewMaskedCommand(printableTestCommand{printableCommand: `"xbuild" "/p:Pass=secret"`}, secrets)
+		require.Equal(t, `"xbuild" "/p:Pass=***"`, command.PrintableCommand())
+	}
+}
+
+func TestMaskingWriter(t *testing.T) {
+	t.Log("it masks secrets split between writes")
+	{
+		secrets := NewSecrets()
+		secrets.Add("secret")
+
+		var out bytes.Buffer
+		writer := NewMaskingWriter(&out, secrets)
+
+		_, err := writer.Write([]byte("signing with sec"))
+		require.NoError(t, err)
+		require.Equal(t, "", out.String())
+
+		_, err = writer.Write([]byte("ret\nthe secret"))
+		require.NoError(t, err)
+		require.Equal(t, "signing with ***\n", out.String())
+
+		require.NoError(t, writer.Flush())
+		require.Equal(t, "signing with ***\nthe ***", out.String())
+	}
+}
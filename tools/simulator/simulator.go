@@ -0,0 +1,280 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// Simulator states, reported by: xcrun simctl list
+const (
+	StateBooted   = "Booted"
+	StateShutdown = "Shutdown"
+)
+
+// DeviceTypeModel - like: iPhone 8, com.apple.CoreSimulator.SimDeviceType.iPhone-8
+type DeviceTypeModel struct {
+	Name       string `json:"name"`
+	Identifier string `json:"identifier"`
+}
+
+// RuntimeModel - like: iOS 12.1, com.apple.CoreSimulator.SimRuntime.iOS-12-1
+type RuntimeModel struct {
+	Name        string `json:"name"`
+	Identifier  string `json:"identifier"`
+	Version     string `json:"version"`
+	IsAvailable bool   `json:"isAvailable"`
+}
+
+// DeviceModel - a created simulator
+type DeviceModel struct {
+	UDID                 string `json:"udid"`
+	Name                 string `json:"name"`
+	State                string `json:"state"`
+	IsAvailable          bool   `json:"isAvailable"`
+	DeviceTypeIdentifier string `json:"deviceTypeIdentifier"`
+	Runtime              string `json:"-"` // the runtime identifier
+}
+
+// ListModel - the device types, runtimes and simulators of the selected Xcode
+type ListModel struct {
+	DeviceTypes []DeviceTypeModel
+	Runtimes    []RuntimeModel
+	Devices     []DeviceModel // sorted by the runtime and the name
+}
+
+// listOutputModel - the json output of: xcrun simctl list --json,
+// older Xcodes report the availability as: "availability" : "(available)"
+type listOutputModel struct {
+	DeviceTypes []DeviceTypeModel `json:"devicetypes"`
+	Runtimes    []struct {
+		RuntimeModel
+		Availability string `json:"availability"`
+	} `json:"runtimes"`
+	Devices map[string][]struct {
+		DeviceModel
+		Availability string `json:"availability"`
+	} `json:"devices"`
+}
+
+func available(isAvailable bool, availability string) bool {
+	return isAvailable || availability == "(available)"
+}
+
+// ParseList - parses the output of: xcrun simctl list --json
+func ParseList(out []byte) (ListModel, error) {
+	var output listOutputModel
+	if err := json.Unmarshal(out, &output); err != nil {
+		return ListModel{}, fmt.Errorf("failed to parse simulator list, error: %s", err)
+	}
+
+	list := ListModel{DeviceTypes: output.DeviceTypes}
+	for _, runtime := range output.Runtimes {
+		runtimeModel := runtime.RuntimeModel
+		runtimeModel.IsAvailable = available(runtime.IsAvailable, runtime.Availability)
+		list.Runtimes = append(list.Runtimes, runtimeModel)
+	}
+
+	runtimeIDs := []string{}
+	for runtimeID := range output.Devices {
+		runtimeIDs = append(runtimeIDs, runtimeID)
+	}
+	sort.Strings(runtimeIDs)
+
+	for _, runtimeID := range runtimeIDs {
+		devices := output.Devices[runtimeID]
+		sort.SliceStable(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+
+		for _, device := range devices {
+			deviceModel := device.DeviceModel
+			deviceModel.IsAvailable = available(device.IsAvailable, device.Availability)
+			deviceModel.Runtime = runtimeID
+			list.Devices = append(list.Devices, deviceModel)
+		}
+	}
+
+	return list, nil
+}
+
+// List - lists the device types, runtimes and simulators
+func List() (ListModel, error) {
+	out, err := simctl("list", "--json")
+	if err != nil {
+		return ListModel{}, err
+	}
+	return ParseList(out)
+}
+
+// FindDeviceType - finds the device type by its name or identifier
+func (list ListModel) FindDeviceType(deviceType string) (DeviceTypeModel, bool) {
+	for _, model := range list.DeviceTypes {
+		if model.Name == deviceType || model.Identifier == deviceType {
+			return model, true
+		}
+	}
+	return DeviceTypeModel{}, false
+}
+
+// FindRuntime - finds the available runtime by its name or identifier, like: iOS 12.1.
+// The runtime can be given by platform only, like: iOS, then the latest runtime of the platform is returned.
+func (list ListModel) FindRuntime(runtime string) (RuntimeModel, bool) {
+	var latest *RuntimeModel
+	for i, model := range list.Runtimes {
+		if !model.IsAvailable {
+			continue
+		}
+		if model.Name == runtime || model.Identifier == runtime {
+			return model, true
+		}
+		if strings.HasPrefix(model.Name, runtime+" ") && (latest == nil || versionLess(latest.Version, model.Version)) {
+			latest = &list.Runtimes[i]
+		}
+	}
+
+	if latest != nil {
+		return *latest, true
+	}
+	return RuntimeModel{}, false
+}
+
+// FindDevice - finds an available simulator of the device type and runtime identifiers
+func (list ListModel) FindDevice(deviceTypeID, runtimeID string) (DeviceModel, bool) {
+	for _, device := range list.Devices {
+		if device.IsAvailable && device.DeviceTypeIdentifier == deviceTypeID && device.Runtime == runtimeID {
+			return device, true
+		}
+	}
+	return DeviceModel{}, false
+}
+
+// Device - finds the simulator by its udid
+func (list ListModel) Device(udid string) (DeviceModel, bool) {
+	for _, device := range list.Devices {
+		if device.UDID == udid {
+			return device, true
+		}
+	}
+	return DeviceModel{}, false
+}
+
+// versionLess compares dot separated numeric versions, like: 9.3 < 12.1
+func versionLess(version, other string) bool {
+	components, otherComponents := strings.Split(version, "."), strings.Split(other, ".")
+	for i := 0; i < len(components) && i < len(otherComponents); i++ {
+		number, _ := strconv.Atoi(components[i])
+		otherNumber, _ := strconv.Atoi(otherComponents[i])
+		if number != otherNumber {
+			return number < otherNumber
+		}
+	}
+	return len(components) < len(otherComponents)
+}
+
+// Create - creates a simulator of the device type and runtime, given by their name or identifier,
+// returns the udid of the new simulator
+func Create(name, deviceType, runtime string) (string, error) {
+	out, err := simctl("create", name, deviceType, runtime)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Ensure - returns an available simulator of the device type and runtime, given by their name or identifier,
+// the simulator is created if not exists
+func Ensure(deviceType, runtime string) (DeviceModel, error) {
+	list, err := List()
+	if err != nil {
+		return DeviceModel{}, err
+	}
+
+	deviceTypeModel, ok := list.FindDeviceType(deviceType)
+	if !ok {
+		return DeviceModel{}, fmt.Errorf("simulator device type not found: %s", deviceType)
+	}
+	runtimeModel, ok := list.FindRuntime(runtime)
+	if !ok {
+		return DeviceModel{}, fmt.Errorf("simulator runtime not found: %s", runtime)
+	}
+
+	if device, ok := list.FindDevice(deviceTypeModel.Identifier, runtimeModel.Identifier); ok {
+		return device, nil
+	}
+
+	name := fmt.Sprintf("%s (%s)", deviceTypeModel.Name, runtimeModel.Name)
+	udid, err := Create(name, deviceTypeModel.Identifier, runtimeModel.Identifier)
+	if err != nil {
+		return DeviceModel{}, err
+	}
+
+	return DeviceModel{
+		UDID:                 udid,
+		Name:                 name,
+		State:                StateShutdown,
+		IsAvailable:          true,
+		DeviceTypeIdentifier: deviceTypeModel.Identifier,
+		Runtime:              runtimeModel.Identifier,
+	}, nil
+}
+
+// Boot - boots the simulator and waits until it finishes booting, an already booted simulator is not an error.
+// The wait is skipped if the timeout is 0.
+func Boot(udid string, timeout time.Duration) error {
+	if out, err := simctl("boot", udid); err != nil && !strings.Contains(string(out), "current state: "+StateBooted) {
+		return err
+	}
+
+	if timeout <= 0 {
+		return nil
+	}
+
+	cmd := exec.Command("xcrun", "simctl", "bootstatus", udid)
+	if err := tools.RunCommandWithTimeout(cmd, timeout, 0); err != nil {
+		return fmt.Errorf("simulator (%s) did not boot, error: %s", udid, err)
+	}
+	return nil
+}
+
+// Shutdown - shuts down the simulator, an already shut down simulator is not an error
+func Shutdown(udid string) error {
+	if out, err := simctl("shutdown", udid); err != nil && !strings.Contains(string(out), "current state: "+StateShutdown) {
+		return err
+	}
+	return nil
+}
+
+// Erase - erases the contents and settings of the simulator, the simulator is shut down first
+func Erase(udid string) error {
+	if err := Shutdown(udid); err != nil {
+		return err
+	}
+	_, err := simctl("erase", udid)
+	return err
+}
+
+// Delete - deletes the simulator
+func Delete(udid string) error {
+	_, err := simctl("delete", udid)
+	return err
+}
+
+// simctl runs: xcrun simctl <args>, returns the stdout, or the combined output on failure
+func simctl(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("xcrun", append([]string{"simctl"}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		out := append(stdout.Bytes(), stderr.Bytes()...)
+		return out, fmt.Errorf("xcrun simctl %s failed, output: %s, error: %s", strings.Join(args, " "), out, err)
+	}
+	return stdout.Bytes(), nil
+}
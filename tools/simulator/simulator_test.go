@@ -0,0 +1,97 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const listOutput = `{
+  "devicetypes" : [
+    { "name" : "iPhone 8", "identifier" : "com.apple.CoreSimulator.SimDeviceType.iPhone-8" },
+    { "name" : "iPad Air 2", "identifier" : "com.apple.CoreSimulator.SimDeviceType.iPad-Air-2" }
+  ],
+  "runtimes" : [
+    { "version" : "11.4", "identifier" : "com.apple.CoreSimulator.SimRuntime.iOS-11-4", "name" : "iOS 11.4", "availability" : "(available)" },
+    { "version" : "12.1", "identifier" : "com.apple.CoreSimulator.SimRuntime.iOS-12-1", "name" : "iOS 12.1", "isAvailable" : true },
+    { "version" : "12.0", "identifier" : "com.apple.CoreSimulator.SimRuntime.tvOS-12-0", "name" : "tvOS 12.0", "isAvailable" : true },
+    { "version" : "10.3", "identifier" : "com.apple.CoreSimulator.SimRuntime.iOS-10-3", "name" : "iOS 10.3", "isAvailable" : false }
+  ],
+  "devices" : {
+    "com.apple.CoreSimulator.SimRuntime.iOS-12-1" : [
+      { "state" : "Shutdown", "isAvailable" : true, "name" : "iPhone 8", "udid" : "UDID-2", "deviceTypeIdentifier" : "com.apple.CoreSimulator.SimDeviceType.iPhone-8" },
+      { "state" : "Booted", "isAvailable" : true, "name" : "iPad Air 2", "udid" : "UDID-3", "deviceTypeIdentifier" : "com.apple.CoreSimulator.SimDeviceType.iPad-Air-2" }
+    ],
+    "com.apple.CoreSimulator.SimRuntime.iOS-11-4" : [
+      { "state" : "Shutdown", "availability" : "(available)", "name" : "iPhone 8", "udid" : "UDID-1", "deviceTypeIdentifier" : "com.apple.CoreSimulator.SimDeviceType.iPhone-8" }
+    ]
+  }
+}`
+
+func TestParseList(t *testing.T) {
+	list, err := ParseList([]byte(listOutput))
+	require.NoError(t, err)
+
+	t.Log("it parses the devices of the runtimes")
+	{
+		require.Equal(t, 2, len(list.DeviceTypes))
+		require.Equal(t, 4, len(list.Runtimes))
+		require.Equal(t, []DeviceModel{
+			{UDID: "UDID-1", Name: "iPhone 8", State: StateShutdown, IsAvailable: true, DeviceTypeIdentifier: "com.apple.CoreSimulator.SimDeviceType.iPhone-8", Runtime: "com.apple.CoreSimulator.SimRuntime.iOS-11-4"},
+			{UDID: "UDID-3", Name: "iPad Air 2", State: StateBooted, IsAvailable: true, DeviceTypeIdentifier: "com.apple.CoreSimulator.SimDeviceType.iPad-Air-2", Runtime: "com.apple.CoreSimulator.SimRuntime.iOS-12-1"},
+			{UDID: "UDID-2", Name: "iPhone 8", State: StateShutdown, IsAvailable: true, DeviceTypeIdentifier: "com.apple.CoreSimulator.SimDeviceType.iPhone-8", Runtime: "com.apple.CoreSimulator.SimRuntime.iOS-12-1"},
+		}, list.Devices)
+	}
+
+	t.Log("it fails for invalid output")
+	{
+		_, err := ParseList([]byte("xcrun: error: unable to find utility"))
+		require.Error(t, err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	list, err := ParseList([]byte(listOutput))
+	require.NoError(t, err)
+
+	t.Log("it finds the device type by name or identifier")
+	{
+		deviceType, ok := list.FindDeviceType("iPhone 8")
+		require.True(t, ok)
+		require.Equal(t, "com.apple.CoreSimulator.SimDeviceType.iPhone-8", deviceType.Identifier)
+
+		_, ok = list.FindDeviceType("com.apple.CoreSimulator.SimDeviceType.iPad-Air-2")
+		require.True(t, ok)
+
+		_, ok = list.FindDeviceType("iPhone 42")
+		require.False(t, ok)
+	}
+
+	t.Log("it finds the available runtime, or the latest of the platform")
+	{
+		runtime, ok := list.FindRuntime("iOS 11.4")
+		require.True(t, ok)
+		require.Equal(t, "com.apple.CoreSimulator.SimRuntime.iOS-11-4", runtime.Identifier)
+
+		runtime, ok = list.FindRuntime("iOS")
+		require.True(t, ok)
+		require.Equal(t, "com.apple.CoreSimulator.SimRuntime.iOS-12-1", runtime.Identifier)
+
+		_, ok = list.FindRuntime("iOS 10.3")
+		require.False(t, ok)
+	}
+
+	t.Log("it finds the device of the device type and runtime")
+	{
+		device, ok := list.FindDevice("com.apple.CoreSimulator.SimDeviceType.iPhone-8", "com.apple.CoreSimulator.SimRuntime.iOS-12-1")
+		require.True(t, ok)
+		require.Equal(t, "UDID-2", device.UDID)
+
+		_, ok = list.FindDevice("com.apple.CoreSimulator.SimDeviceType.iPad-Air-2", "com.apple.CoreSimulator.SimRuntime.iOS-11-4")
+		require.False(t, ok)
+
+		device, ok = list.Device("UDID-3")
+		require.True(t, ok)
+		require.Equal(t, StateBooted, device.State)
+	}
+}
@@ -0,0 +1,208 @@
+package symbolupload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// DefaultTimeout - timeout of each request
+const DefaultTimeout = 10 * time.Minute
+
+// AppCenterAPIURL ...
+const AppCenterAPIURL = "https://api.appcenter.ms"
+
+func putFile(logger tools.Logger, client *http.Client, url, pth string, headers map[string]string) error {
+	file, err := os.Open(pth)
+	if err != nil {
+		return fmt.Errorf("failed to open (%s), error: %s", pth, err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logger.Warnf("Failed to close (%s), error: %s", pth, err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPut, url, file)
+	if err != nil {
+		return err
+	}
+	request.ContentLength = info.Size()
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	_, err = do(logger, client, request)
+	return err
+}
+
+// do sends the request and returns the response body, non 2xx status codes are returned as error
+func do(logger tools.Logger, client *http.Client, request *http.Request) ([]byte, error) {
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed, error: %s", request.Method, request.URL.Host, err)
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Warnf("Failed to close response body, error: %s", err)
+		}
+	}()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response of %s %s, error: %s", request.Method, request.URL.Host, err)
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s failed, status: %d, response: %s", request.Method, request.URL.Host, response.StatusCode, body)
+	}
+	return body, nil
+}
+
+// HTTPPutModel - uploads the symbols by a HTTP PUT request, like to a pre-signed storage url
+type HTTPPutModel struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	logger  tools.Logger
+}
+
+// NewHTTPPut - the {filename} placeholder of the url is replaced by the uploaded file's name
+func NewHTTPPut(url string) *HTTPPutModel {
+	return &HTTPPutModel{
+		url:     url,
+		headers: map[string]string{},
+		client:  &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// SetHeader - like the authorization header
+func (upload *HTTPPutModel) SetHeader(name, value string) *HTTPPutModel {
+	upload.headers[name] = value
+	return upload
+}
+
+// SetTimeout ...
+func (upload *HTTPPutModel) SetTimeout(timeout time.Duration) *HTTPPutModel {
+	upload.client.Timeout = timeout
+	return upload
+}
+
+// SetLogger - receives the messages of the upload, defaults to printing them
+func (upload *HTTPPutModel) SetLogger(logger tools.Logger) {
+	upload.logger = logger
+}
+
+// UploadSymbols - uploads the dSYM or mSYM zip
+func (upload HTTPPutModel) UploadSymbols(pth string, outputType constants.OutputType) error {
+	url := strings.Replace(upload.url, "{filename}", filepath.Base(pth), -1)
+	if err := putFile(tools.LoggerOrDefault(upload.logger), upload.client, url, pth, upload.headers); err != nil {
+		return fmt.Errorf("failed to upload %s (%s), error: %s", outputType, pth, err)
+	}
+	return nil
+}
+
+// AppCenterModel - uploads the symbols to App Center Diagnostics: creates a symbol upload,
+// uploads the zip to its blob url, then commits the upload
+type AppCenterModel struct {
+	ownerName string
+	appName   string
+	apiToken  string
+
+	apiURL string
+	client *http.Client
+	logger tools.Logger
+}
+
+// NewAppCenter ...
+func NewAppCenter(ownerName, appName, apiToken string) (*AppCenterModel, error) {
+	if ownerName == "" || appName == "" || apiToken == "" {
+		return nil, fmt.Errorf("owner name, app name and api token are required for App Center symbol upload")
+	}
+
+	return &AppCenterModel{
+		ownerName: ownerName,
+		appName:   appName,
+		apiToken:  apiToken,
+		apiURL:    AppCenterAPIURL,
+		client:    &http.Client{Timeout: DefaultTimeout},
+	}, nil
+}
+
+// SetTimeout ...
+func (upload *AppCenterModel) SetTimeout(timeout time.Duration) *AppCenterModel {
+	upload.client.Timeout = timeout
+	return upload
+}
+
+// SetLogger - receives the messages of the upload, defaults to printing them
+func (upload *AppCenterModel) SetLogger(logger tools.Logger) {
+	upload.logger = logger
+}
+
+func (upload AppCenterModel) symbolUploadsURL() string {
+	return fmt.Sprintf("%s/v0.1/apps/%s/%s/symbol_uploads", upload.apiURL, upload.ownerName, upload.appName)
+}
+
+func (upload AppCenterModel) request(method, url string, body interface{}) ([]byte, error) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(method, url, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-API-Token", upload.apiToken)
+
+	return do(tools.LoggerOrDefault(upload.logger), upload.client, request)
+}
+
+// UploadSymbols - uploads the dSYM zip, mSYMs are not supported by App Center
+func (upload AppCenterModel) UploadSymbols(pth string, outputType constants.OutputType) error {
+	if outputType != constants.OutputTypeDSYM {
+		return fmt.Errorf("%s upload is not supported by App Center", outputType)
+	}
+
+	response, err := upload.request(http.MethodPost, upload.symbolUploadsURL(), map[string]string{"symbol_type": "Apple", "file_name": filepath.Base(pth)})
+	if err != nil {
+		return fmt.Errorf("failed to create symbol upload, error: %s", err)
+	}
+
+	var symbolUpload struct {
+		ID        string `json:"symbol_upload_id"`
+		UploadURL string `json:"upload_url"`
+	}
+	if err := json.Unmarshal(response, &symbolUpload); err != nil || symbolUpload.ID == "" || symbolUpload.UploadURL == "" {
+		return fmt.Errorf("invalid symbol upload response: %s", response)
+	}
+
+	logger := tools.LoggerOrDefault(upload.logger)
+	commitURL := upload.symbolUploadsURL() + "/" + symbolUpload.ID
+	if err := putFile(logger, upload.client, symbolUpload.UploadURL, pth, map[string]string{"x-ms-blob-type": "BlockBlob"}); err != nil {
+		if _, abortErr := upload.request(http.MethodPatch, commitURL, map[string]string{"status": "aborted"}); abortErr != nil {
+			logger.Warnf("Failed to abort symbol upload (%s), error: %s", symbolUpload.ID, abortErr)
+		}
+		return fmt.Errorf("failed to upload %s (%s), error: %s", outputType, pth, err)
+	}
+
+	if _, err := upload.request(http.MethodPatch, commitURL, map[string]string{"status": "committed"}); err != nil {
+		return fmt.Errorf("failed to commit symbol upload (%s), error: %s", symbolUpload.ID, err)
+	}
+	return nil
+}
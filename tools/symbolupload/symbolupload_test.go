@@ -0,0 +1,101 @@
+package symbolupload
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func testSymbolsZip(t *testing.T) (string, func()) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("symbolupload_test")
+	require.NoError(t, err)
+
+	pth := filepath.Join(tmpDir, "iOS.app.dSYM.zip")
+	require.NoError(t, fileutil.WriteStringToFile(pth, "dsym"))
+	return pth, func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}
+}
+
+func TestHTTPPutUploadSymbols(t *testing.T) {
+	pth, cleanup := testSymbolsZip(t)
+	defer cleanup()
+
+	t.Log("it puts the file to the url")
+	{
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "/symbols/iOS.app.dSYM.zip", r.URL.Path)
+			require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			require.Equal(t, "dsym", string(body))
+		}))
+		defer server.Close()
+
+		upload := NewHTTPPut(server.URL+"/symbols/{filename}").SetHeader("Authorization", "Bearer token")
+		require.NoError(t, upload.UploadSymbols(pth, constants.OutputTypeDSYM))
+	}
+
+	t.Log("it fails for error status")
+	{
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		require.Error(t, NewHTTPPut(server.URL).UploadSymbols(pth, constants.OutputTypeDSYM))
+	}
+}
+
+func TestAppCenterUploadSymbols(t *testing.T) {
+	pth, cleanup := testSymbolsZip(t)
+	defer cleanup()
+
+	t.Log("it creates, uploads and commits the symbol upload")
+	{
+		requests := []string{}
+		var server *httptest.Server
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			if r.Method == http.MethodPost {
+				require.Equal(t, "api-token", r.Header.Get("X-API-Token"))
+				_, err := w.Write([]byte(`{"symbol_upload_id":"upload-id","upload_url":"` + server.URL + `/blob"}`))
+				require.NoError(t, err)
+			}
+			if r.Method == http.MethodPut {
+				require.Equal(t, "BlockBlob", r.Header.Get("x-ms-blob-type"))
+			}
+		}))
+		defer server.Close()
+
+		upload, err := NewAppCenter("bitrise", "sample", "api-token")
+		require.NoError(t, err)
+		upload.apiURL = server.URL
+
+		require.NoError(t, upload.UploadSymbols(pth, constants.OutputTypeDSYM))
+		require.Equal(t, []string{
+			"POST /v0.1/apps/bitrise/sample/symbol_uploads",
+			"PUT /blob",
+			"PATCH /v0.1/apps/bitrise/sample/symbol_uploads/upload-id",
+		}, requests)
+	}
+
+	t.Log("it fails for mSYMs and missing credentials")
+	{
+		upload, err := NewAppCenter("bitrise", "sample", "api-token")
+		require.NoError(t, err)
+		require.Error(t, upload.UploadSymbols(pth, constants.OutputTypeMSYM))
+
+		_, err = NewAppCenter("bitrise", "sample", "")
+		require.Error(t, err)
+	}
+}
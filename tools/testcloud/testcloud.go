@@ -3,10 +3,14 @@ package testcloud
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"time"
 
 	"github.com/bitrise-io/go-utils/command"
 	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
 )
 
 // Parallelization ...
@@ -52,6 +56,12 @@ type Model struct {
 
 	signOptions   []string
 	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
 }
 
 // NewModel ...
@@ -61,7 +71,7 @@ func NewModel(testCloudExexPth string) (*Model, error) {
 		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", testCloudExexPth, err)
 	}
 
-	return &Model{testCloudExePth: absTestCloudExexPth}, nil
+	return &Model{testCloudExePth: absTestCloudExexPth, stdout: os.Stdout, stderr: os.Stderr}, nil
 }
 
 // SetAPKPth ...
@@ -137,12 +147,31 @@ func (testCloud *Model) SetSignOptions(options ...string) *Model {
 }
 
 // SetCustomOptions ...
-func (testCloud *Model) SetCustomOptions(options ...string) *Model {
+func (testCloud *Model) SetCustomOptions(options ...string) {
 	testCloud.customOptions = options
-	return testCloud
 }
 
-func (testCloud *Model) submitCommandSlice() []string {
+// SetStdout ...
+func (testCloud *Model) SetStdout(out io.Writer) {
+	testCloud.stdout = out
+}
+
+// SetStderr ...
+func (testCloud *Model) SetStderr(err io.Writer) {
+	testCloud.stderr = err
+}
+
+// SetTimeout ...
+func (testCloud *Model) SetTimeout(timeout time.Duration) {
+	testCloud.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (testCloud *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	testCloud.killGracePeriod = killGracePeriod
+}
+
+func (testCloud *Model) submitCommandSlice(maskSecrets bool) []string {
 	cmdSlice := []string{constants.MonoPath}
 	cmdSlice = append(cmdSlice, testCloud.testCloudExePth)
 	cmdSlice = append(cmdSlice, "submit")
@@ -158,7 +187,11 @@ func (testCloud *Model) submitCommandSlice() []string {
 		cmdSlice = append(cmdSlice, "--dsym", testCloud.dsymPth)
 	}
 
-	cmdSlice = append(cmdSlice, testCloud.apiKey)
+	if maskSecrets {
+		cmdSlice = append(cmdSlice, tools.SecretMask)
+	} else {
+		cmdSlice = append(cmdSlice, testCloud.apiKey)
+	}
 
 	for _, option := range testCloud.signOptions {
 		cmdSlice = append(cmdSlice, option)
@@ -189,9 +222,9 @@ func (testCloud *Model) submitCommandSlice() []string {
 	return cmdSlice
 }
 
-// PrintableCommand ...
+// PrintableCommand - the api key is masked
 func (testCloud Model) PrintableCommand() string {
-	cmdSlice := testCloud.submitCommandSlice()
+	cmdSlice := testCloud.submitCommandSlice(true)
 
 	return command.PrintableCommandArgs(true, cmdSlice)
 }
@@ -201,7 +234,7 @@ type CaptureLineCallback func(line string)
 
 // Submit ...
 func (testCloud Model) Submit(callback CaptureLineCallback) error {
-	cmdSlice := testCloud.submitCommandSlice()
+	cmdSlice := testCloud.submitCommandSlice(false)
 
 	command, err := command.NewFromSlice(cmdSlice)
 	if err != nil {
@@ -235,3 +268,18 @@ func (testCloud Model) Submit(callback CaptureLineCallback) error {
 
 	return cmd.Wait()
 }
+
+// Run - submits the tests, the output is written to the stdout and stderr
+func (testCloud Model) Run() error {
+	cmdSlice := testCloud.submitCommandSlice(false)
+
+	command, err := command.NewFromSlice(cmdSlice)
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(testCloud.stdout)
+	command.SetStderr(testCloud.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), testCloud.timeout, testCloud.killGracePeriod)
+}
@@ -0,0 +1,27 @@
+package testcloud
+
+import (
+	"testing"
+
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it submits the apk with masked api key")
+	{
+		testCloud, err := NewModel("/packages/Xamarin.UITest/tools/test-cloud.exe")
+		require.NoError(t, err)
+		testCloud.SetAPKPth("/bin/Release/com.bitrise.sample.apk").
+			SetAPIKey("api-secret").
+			SetUser("bot@bitrise.io").
+			SetAssemblyDir("/UITests/bin/Release").
+			SetDevices("a1b2c3").
+			SetSeries("master").
+			SetNunitXMLPth("/results/UITests.xml").
+			SetParallelization(ParallelizationByTestFixture)
+
+		require.Equal(t, `"`+constants.MonoPath+`" "/packages/Xamarin.UITest/tools/test-cloud.exe" "submit" "/bin/Release/com.bitrise.sample.apk" "***" "--user" "bot@bitrise.io" "--assembly-dir" "/UITests/bin/Release" "--devices" "a1b2c3" "--series" "master" "--nunit-xml" "/results/UITests.xml" "--fixture-chunk"`, testCloud.PrintableCommand())
+		require.Contains(t, testCloud.submitCommandSlice(false), "api-secret")
+	}
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultKillGracePeriod - time to wait between SIGTERM and SIGKILL, if not specified
+const DefaultKillGracePeriod = 10 * time.Second
+
+// Timeoutable ...
+type Timeoutable interface {
+	SetTimeout(timeout time.Duration)
+	SetKillGracePeriod(killGracePeriod time.Duration)
+}
+
+// TimeoutError ...
+type TimeoutError struct {
+	Tool    string
+	Timeout time.Duration
+}
+
+// Error ...
+func (err TimeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s and was terminated", err.Tool, err.Timeout)
+}
+
+// Watchdog - terminates a started process if it does not finish in time
+type Watchdog struct {
+	cmd             *exec.Cmd
+	timeout         time.Duration
+	killGracePeriod time.Duration
+
+	mutex     sync.Mutex
+	logger    Logger
+	timedOut  bool
+	termTimer *time.Timer
+	killTimer *time.Timer
+}
+
+// WatchProcess - starts watching the already started cmd:
+// SIGTERM is sent after timeout, SIGKILL after the additional kill grace period
+func WatchProcess(cmd *exec.Cmd, timeout, killGracePeriod time.Duration) *Watchdog {
+	if killGracePeriod <= 0 {
+		killGracePeriod = DefaultKillGracePeriod
+	}
+
+	watchdog := &Watchdog{cmd: cmd, timeout: timeout, killGracePeriod: killGracePeriod}
+	if timeout > 0 {
+		watchdog.termTimer = time.AfterFunc(timeout, watchdog.terminate)
+	}
+	return watchdog
+}
+
+// SetLogger - receives the messages of the watchdog, defaults to printing them
+func (watchdog *Watchdog) SetLogger(logger Logger) {
+	watchdog.mutex.Lock()
+	defer watchdog.mutex.Unlock()
+
+	watchdog.logger = logger
+}
+
+func (watchdog *Watchdog) terminate() {
+	watchdog.mutex.Lock()
+	defer watchdog.mutex.Unlock()
+
+	logger := LoggerOrDefault(watchdog.logger)
+	logger.Warnf("Process timed out after %s, terminating", watchdog.timeout)
+
+	watchdog.timedOut = true
+	if err := watchdog.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		logger.Warnf("Failed to terminate process, error: %s", err)
+	}
+
+	watchdog.killTimer = time.AfterFunc(watchdog.killGracePeriod, func() {
+		logger.Warnf("Process did not terminate in %s, killing", watchdog.killGracePeriod)
+
+		if err := watchdog.cmd.Process.Kill(); err != nil {
+			logger.Warnf("Failed to kill process, error: %s", err)
+		}
+	})
+}
+
+// Stop - stops watching the process, returns true if the process was terminated because of the timeout
+func (watchdog *Watchdog) Stop() bool {
+	watchdog.mutex.Lock()
+	defer watchdog.mutex.Unlock()
+
+	if watchdog.termTimer != nil {
+		watchdog.termTimer.Stop()
+	}
+	if watchdog.killTimer != nil {
+		watchdog.killTimer.Stop()
+	}
+
+	return watchdog.timedOut
+}
+
+// Error - returns the TimeoutError of the watched process
+func (watchdog *Watchdog) Error() error {
+	return TimeoutError{Tool: filepath.Base(watchdog.cmd.Path), Timeout: watchdog.timeout}
+}
+
+// RunCommandWithTimeout - runs the cmd, terminates it if it does not finish in time
+func RunCommandWithTimeout(cmd *exec.Cmd, timeout, killGracePeriod time.Duration) error {
+	return RunLoggedCommandWithTimeout(cmd, timeout, killGracePeriod, nil)
+}
+
+// RunLoggedCommandWithTimeout - like RunCommandWithTimeout, the watchdog's messages are passed to the logger
+func RunLoggedCommandWithTimeout(cmd *exec.Cmd, timeout, killGracePeriod time.Duration, logger Logger) error {
+	if timeout <= 0 {
+		return cmd.Run()
+	}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	watchdog := WatchProcess(cmd, timeout, killGracePeriod)
+	watchdog.SetLogger(logger)
+	err := cmd.Wait()
+	if watchdog.Stop() {
+		return watchdog.Error()
+	}
+
+	return err
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommandWithTimeout(t *testing.T) {
+	t.Log("it runs the command without timeout")
+	{
+		cmd := exec.Command("/bin/bash", "-c", "exit 0")
+		require.NoError(t, RunCommandWithTimeout(cmd, 0, 0))
+	}
+
+	t.Log("it terminates the command after timeout")
+	{
+		cmd := exec.Command("/bin/bash", "-c", "sleep 10")
+		startTime := time.Now()
+		err := RunCommandWithTimeout(cmd, 500*time.Millisecond, time.Second)
+		require.Error(t, err)
+		require.IsType(t, TimeoutError{}, err)
+		require.Equal(t, "bash timed out after 500ms and was terminated", err.Error())
+		require.Equal(t, true, time.Since(startTime) < 5*time.Second)
+	}
+
+	t.Log("it kills the command if it ignores termination")
+	{
+		cmd := exec.Command("/bin/bash", "-c", "trap '' TERM; sleep 10")
+		startTime := time.Now()
+		err := RunCommandWithTimeout(cmd, 500*time.Millisecond, 500*time.Millisecond)
+		require.IsType(t, TimeoutError{}, err)
+		require.Equal(t, true, time.Since(startTime) < 5*time.Second)
+	}
+
+	t.Log("it passes the watchdog's messages to the logger")
+	{
+		logger := &recordingLogger{}
+		cmd := exec.Command("/bin/bash", "-c", "sleep 10")
+		err := RunLoggedCommandWithTimeout(cmd, 500*time.Millisecond, time.Second, logger)
+		require.IsType(t, TimeoutError{}, err)
+		require.Equal(t, []string{"warn: Process timed out after 500ms, terminating"}, logger.messages)
+	}
+}
@@ -1,5 +1,7 @@
 package tools
 
+import "io"
+
 // Runnable ...
 type Runnable interface {
 	PrintableCommand() string
@@ -17,6 +19,12 @@ type Editable interface {
 	SetCustomOptions(options ...string)
 }
 
+// OutputRedirectable ...
+type OutputRedirectable interface {
+	SetStdout(out io.Writer)
+	SetStderr(err io.Writer)
+}
+
 //
 // EmptyCommand - for return type in case of failed to create a RunnableCommand
 type EmptyCommand struct{}
@@ -0,0 +1,206 @@
+package touchunit
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+// MlaunchPath - the mlaunch of the Xamarin.iOS installation
+const MlaunchPath = "/Library/Frameworks/Xamarin.iOS.framework/Versions/Current/bin/mlaunch"
+
+// Launcher - the tool installing and launching the test app in the simulator
+type Launcher string
+
+const (
+	// LauncherSimctl - xcrun simctl install and launch, the simulator has to be booted
+	LauncherSimctl Launcher = "simctl"
+	// LauncherMlaunch - mlaunch --launchsim, boots the simulator if needed
+	LauncherMlaunch Launcher = "mlaunch"
+)
+
+// ParseLauncher ...
+func ParseLauncher(launcher string) (Launcher, error) {
+	switch launcher {
+	case "simctl":
+		return LauncherSimctl, nil
+	case "mlaunch":
+		return LauncherMlaunch, nil
+	default:
+		return "", fmt.Errorf("unknown launcher: %s", launcher)
+	}
+}
+
+// Model - runs a Touch.Unit (NUnitLite) test app in the simulator, the app starts the tests automatically,
+// sends the results to the host port and exits
+type Model struct {
+	launcher   Launcher
+	mlaunchPth string
+
+	appPth        string
+	bundleID      string
+	simulatorUDID string
+
+	hostName string
+	hostPort int
+
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// New - the bundle id is required by simctl only
+func New(launcher Launcher, appPth, bundleID, simulatorUDID string) (*Model, error) {
+	if launcher != LauncherSimctl && launcher != LauncherMlaunch {
+		return nil, fmt.Errorf("unknown launcher: %s", launcher)
+	}
+	if simulatorUDID == "" {
+		return nil, fmt.Errorf("simulator udid is required")
+	}
+	if launcher == LauncherSimctl && bundleID == "" {
+		return nil, fmt.Errorf("bundle id is required to launch the app by simctl")
+	}
+
+	absAppPth, err := pathutil.AbsPath(appPth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", appPth, err)
+	}
+
+	return &Model{
+		launcher:      launcher,
+		mlaunchPth:    MlaunchPath,
+		appPth:        absAppPth,
+		bundleID:      bundleID,
+		simulatorUDID: simulatorUDID,
+		hostName:      "127.0.0.1",
+		stdout:        os.Stdout,
+		stderr:        os.Stderr,
+	}, nil
+}
+
+// SetMlaunchPth ...
+func (touchUnit *Model) SetMlaunchPth(mlaunchPth string) *Model {
+	touchUnit.mlaunchPth = mlaunchPth
+	return touchUnit
+}
+
+// SetHost - the test results are sent to the host over TCP, the results are written to the stdout otherwise
+func (touchUnit *Model) SetHost(hostName string, hostPort int) *Model {
+	touchUnit.hostName = hostName
+	touchUnit.hostPort = hostPort
+	return touchUnit
+}
+
+// SetCustomOptions - options of the launch command
+func (touchUnit *Model) SetCustomOptions(options ...string) {
+	touchUnit.customOptions = options
+}
+
+// SetStdout ...
+func (touchUnit *Model) SetStdout(out io.Writer) {
+	touchUnit.stdout = out
+}
+
+// SetStderr ...
+func (touchUnit *Model) SetStderr(err io.Writer) {
+	touchUnit.stderr = err
+}
+
+// SetTimeout - timeout of the test run
+func (touchUnit *Model) SetTimeout(timeout time.Duration) {
+	touchUnit.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (touchUnit *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	touchUnit.killGracePeriod = killGracePeriod
+}
+
+// environment - the Touch.Unit options, read by the test app on start
+func (touchUnit Model) environment() []string {
+	envs := map[string]string{
+		"NUNIT_AUTOSTART":         "true",
+		"NUNIT_AUTOEXIT":          "true",
+		"NUNIT_ENABLE_XML_OUTPUT": "true",
+		"NUNIT_XML_VERSION":       "NUnitV3",
+	}
+	if touchUnit.hostPort > 0 {
+		envs["NUNIT_ENABLE_NETWORK"] = "true"
+		envs["NUNIT_TRANSPORT"] = "TCP"
+		envs["NUNIT_HOSTNAME"] = touchUnit.hostName
+		envs["NUNIT_HOSTPORT"] = fmt.Sprintf("%d", touchUnit.hostPort)
+	}
+
+	keys := []string{}
+	for key := range envs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	environment := []string{}
+	for _, key := range keys {
+		environment = append(environment, key+"="+envs[key])
+	}
+	return environment
+}
+
+func (touchUnit Model) commandSlices() [][]string {
+	if touchUnit.launcher == LauncherMlaunch {
+		cmdSlice := []string{touchUnit.mlaunchPth, "--launchsim", touchUnit.appPth, "--device", ":v2:udid=" + touchUnit.simulatorUDID}
+		for _, env := range touchUnit.environment() {
+			cmdSlice = append(cmdSlice, "--setenv="+env)
+		}
+		cmdSlice = append(cmdSlice, "--wait-for-exit")
+		return [][]string{append(cmdSlice, touchUnit.customOptions...)}
+	}
+
+	// simctl passes the SIMCTL_CHILD_ prefixed environments to the launched app
+	launchSlice := []string{"env"}
+	for _, env := range touchUnit.environment() {
+		launchSlice = append(launchSlice, "SIMCTL_CHILD_"+env)
+	}
+	launchSlice = append(launchSlice, "xcrun", "simctl", "launch", "--console", touchUnit.simulatorUDID, touchUnit.bundleID)
+
+	return [][]string{
+		{"xcrun", "simctl", "install", touchUnit.simulatorUDID, touchUnit.appPth},
+		append(launchSlice, touchUnit.customOptions...),
+	}
+}
+
+// PrintableCommand ...
+func (touchUnit Model) PrintableCommand() string {
+	printableCommands := []string{}
+	for _, cmdSlice := range touchUnit.commandSlices() {
+		printableCommands = append(printableCommands, command.PrintableCommandArgs(true, cmdSlice))
+	}
+	return strings.Join(printableCommands, " && ")
+}
+
+// Run - installs and launches the app, returns when the app exits
+func (touchUnit Model) Run() error {
+	for _, cmdSlice := range touchUnit.commandSlices() {
+		command, err := command.NewFromSlice(cmdSlice)
+		if err != nil {
+			return err
+		}
+
+		command.SetStdout(touchUnit.stdout)
+		command.SetStderr(touchUnit.stderr)
+
+		if err := tools.RunCommandWithTimeout(command.GetCmd(), touchUnit.timeout, touchUnit.killGracePeriod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
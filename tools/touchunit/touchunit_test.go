@@ -0,0 +1,35 @@
+package touchunit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintableCommand(t *testing.T) {
+	t.Log("it installs and launches the app by simctl")
+	{
+		touchUnit, err := New(LauncherSimctl, "/bin/iPhoneSimulator/Debug/Tests.app", "com.bitrise.tests", "UDID-1")
+		require.NoError(t, err)
+		touchUnit.SetHost("127.0.0.1", 16384)
+
+		require.Equal(t, `"xcrun" "simctl" "install" "UDID-1" "/bin/iPhoneSimulator/Debug/Tests.app" && `+
+			`"env" "SIMCTL_CHILD_NUNIT_AUTOEXIT=true" "SIMCTL_CHILD_NUNIT_AUTOSTART=true" "SIMCTL_CHILD_NUNIT_ENABLE_NETWORK=true" "SIMCTL_CHILD_NUNIT_ENABLE_XML_OUTPUT=true" "SIMCTL_CHILD_NUNIT_HOSTNAME=127.0.0.1" "SIMCTL_CHILD_NUNIT_HOSTPORT=16384" "SIMCTL_CHILD_NUNIT_TRANSPORT=TCP" "SIMCTL_CHILD_NUNIT_XML_VERSION=NUnitV3" `+
+			`"xcrun" "simctl" "launch" "--console" "UDID-1" "com.bitrise.tests"`, touchUnit.PrintableCommand())
+	}
+
+	t.Log("it launches the app by mlaunch")
+	{
+		touchUnit, err := New(LauncherMlaunch, "/bin/iPhoneSimulator/Debug/Tests.app", "", "UDID-1")
+		require.NoError(t, err)
+		touchUnit.SetMlaunchPth("/usr/local/bin/mlaunch")
+
+		require.Equal(t, `"/usr/local/bin/mlaunch" "--launchsim" "/bin/iPhoneSimulator/Debug/Tests.app" "--device" ":v2:udid=UDID-1" "--setenv=NUNIT_AUTOEXIT=true" "--setenv=NUNIT_AUTOSTART=true" "--setenv=NUNIT_ENABLE_XML_OUTPUT=true" "--setenv=NUNIT_XML_VERSION=NUnitV3" "--wait-for-exit"`, touchUnit.PrintableCommand())
+	}
+
+	t.Log("it requires the bundle id for simctl")
+	{
+		_, err := New(LauncherSimctl, "/bin/iPhoneSimulator/Debug/Tests.app", "", "UDID-1")
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,123 @@
+package xcode
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+// XamariniOSVersionPth - the version file of the installed Xamarin.iOS
+const XamariniOSVersionPth = "/Library/Frameworks/Xamarin.iOS.framework/Versions/Current/Version"
+
+// Model - the selected Xcode
+type Model struct {
+	DeveloperDir string // like: /Applications/Xcode.app/Contents/Developer
+	Version      string // like: 9.4.1
+	BuildVersion string // like: 9F2000
+}
+
+// Selected - detects the Xcode selected by xcode-select (or by the DEVELOPER_DIR environment)
+func Selected() (Model, error) {
+	developerDir, err := command.RunCommandAndReturnStdout("xcode-select", "-p")
+	if err != nil {
+		return Model{}, fmt.Errorf("xcode-select -p failed, output: %s, error: %s", developerDir, err)
+	}
+
+	out, err := command.RunCommandAndReturnCombinedStdoutAndStderr("xcodebuild", "-version")
+	if err != nil {
+		return Model{}, fmt.Errorf("xcodebuild -version failed, output: %s, error: %s", out, err)
+	}
+
+	version, buildVersion, err := ParseVersionOutput(out)
+	if err != nil {
+		return Model{}, err
+	}
+
+	return Model{DeveloperDir: strings.TrimSpace(developerDir), Version: version, BuildVersion: buildVersion}, nil
+}
+
+// ParseVersionOutput - parses the output of: xcodebuild -version, like:
+// Xcode 9.4.1
+// Build version 9F2000
+func ParseVersionOutput(out string) (string, string, error) {
+	version, buildVersion := "", ""
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "Xcode ") {
+			version = strings.TrimSpace(strings.TrimPrefix(line, "Xcode "))
+		} else if strings.HasPrefix(line, "Build version ") {
+			buildVersion = strings.TrimSpace(strings.TrimPrefix(line, "Build version "))
+		}
+	}
+
+	if version == "" {
+		return "", "", fmt.Errorf("no Xcode version found in: %s", out)
+	}
+	return version, buildVersion, nil
+}
+
+// XamariniOSVersion - reads the installed Xamarin.iOS version, like: 11.12.0.4
+func XamariniOSVersion(versionPth string) (string, error) {
+	version, err := fileutil.ReadStringFromFile(versionPth)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Xamarin.iOS version (%s), error: %s", versionPth, err)
+	}
+	return strings.TrimSpace(version), nil
+}
+
+// CompatibilityModel - result of the Xcode and Xamarin.iOS compatibility check
+type CompatibilityModel struct {
+	XcodeVersion        string
+	XamariniOSVersion   string
+	SupportedXcodeMajor int // 0 if the Xamarin.iOS version is unknown
+
+	Compatible bool
+	Message    string
+}
+
+// CheckCompatibility - every Xamarin.iOS major release targets an Xcode major release:
+// Xamarin.iOS 10 supports Xcode 8, Xamarin.iOS 11 supports Xcode 9, and so on.
+// Unknown versions are treated as compatible.
+func CheckCompatibility(xcodeVersion, xamariniOSVersion string) CompatibilityModel {
+	compatibility := CompatibilityModel{
+		XcodeVersion:      xcodeVersion,
+		XamariniOSVersion: xamariniOSVersion,
+		Compatible:        true,
+	}
+
+	xamariniOSMajor, err := majorVersion(xamariniOSVersion)
+	if err != nil || xamariniOSMajor < 10 {
+		compatibility.Message = fmt.Sprintf("unknown Xamarin.iOS version (%s), Xcode compatibility is not checked", xamariniOSVersion)
+		return compatibility
+	}
+	compatibility.SupportedXcodeMajor = xamariniOSMajor - 2
+
+	xcodeMajor, err := majorVersion(xcodeVersion)
+	if err != nil {
+		compatibility.Message = fmt.Sprintf("unknown Xcode version (%s), Xcode compatibility is not checked", xcodeVersion)
+		return compatibility
+	}
+
+	switch {
+	case xcodeMajor < compatibility.SupportedXcodeMajor:
+		compatibility.Compatible = false
+		compatibility.Message = fmt.Sprintf("Xcode %s is older than Xcode %d.x required by Xamarin.iOS %s, select a newer Xcode", xcodeVersion, compatibility.SupportedXcodeMajor, xamariniOSVersion)
+	case xcodeMajor > compatibility.SupportedXcodeMajor:
+		compatibility.Compatible = false
+		compatibility.Message = fmt.Sprintf("Xcode %s is newer than Xcode %d.x supported by Xamarin.iOS %s, update Xamarin.iOS or select an older Xcode", xcodeVersion, compatibility.SupportedXcodeMajor, xamariniOSVersion)
+	default:
+		compatibility.Message = fmt.Sprintf("Xcode %s is supported by Xamarin.iOS %s", xcodeVersion, xamariniOSVersion)
+	}
+
+	return compatibility
+}
+
+func majorVersion(version string) (int, error) {
+	return strconv.Atoi(strings.SplitN(strings.TrimSpace(version), ".", 2)[0])
+}
@@ -0,0 +1,49 @@
+package xcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersionOutput(t *testing.T) {
+	t.Log("it parses the version and the build version")
+	{
+		version, buildVersion, err := ParseVersionOutput("Xcode 9.4.1\nBuild version 9F2000\n")
+		require.NoError(t, err)
+		require.Equal(t, "9.4.1", version)
+		require.Equal(t, "9F2000", buildVersion)
+	}
+
+	t.Log("it fails without version")
+	{
+		_, _, err := ParseVersionOutput("xcode-select: error: tool 'xcodebuild' requires Xcode")
+		require.Error(t, err)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Log("it accepts the supported Xcode")
+	{
+		compatibility := CheckCompatibility("9.4.1", "11.12.0.4")
+		require.Equal(t, true, compatibility.Compatible)
+		require.Equal(t, 9, compatibility.SupportedXcodeMajor)
+	}
+
+	t.Log("it rejects older and newer Xcodes")
+	{
+		compatibility := CheckCompatibility("9.4.1", "12.0.0.15")
+		require.Equal(t, false, compatibility.Compatible)
+		require.Contains(t, compatibility.Message, "older")
+
+		compatibility = CheckCompatibility("10.0", "11.12.0.4")
+		require.Equal(t, false, compatibility.Compatible)
+		require.Contains(t, compatibility.Message, "newer")
+	}
+
+	t.Log("it does not check unknown versions")
+	{
+		require.Equal(t, true, CheckCompatibility("9.4.1", "").Compatible)
+		require.Equal(t, true, CheckCompatibility("", "11.12.0.4").Compatible)
+	}
+}
@@ -0,0 +1,168 @@
+package xunit
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bitrise-io/go-utils/command"
+	"github.com/bitrise-io/go-utils/pathutil"
+	"github.com/bitrise-tools/go-xamarin/constants"
+	"github.com/bitrise-tools/go-xamarin/tools"
+)
+
+const (
+	xunitConsole = "xunit.console.exe"
+)
+
+// Model - runs the test assembly by the xUnit.net console runner
+type Model struct {
+	xunitConsolePth string
+
+	dllPth string
+
+	traits   []string
+	noTraits []string
+	methods  []string
+
+	resultLogPth string
+
+	monoOptions   []string
+	customOptions []string
+
+	stdout io.Writer
+	stderr io.Writer
+
+	timeout         time.Duration
+	killGracePeriod time.Duration
+}
+
+// SystemXunitConsolePath - the xunit.console.exe of the XUNIT_PATH directory
+func SystemXunitConsolePath() (string, error) {
+	xunitDir := os.Getenv("XUNIT_PATH")
+	if xunitDir == "" {
+		return "", tools.ToolNotInstalledErrorf("XUNIT_PATH environment is not set, failed to determine xunit console path")
+	}
+
+	xunitConsolePth := filepath.Join(xunitDir, xunitConsole)
+	if exist, err := pathutil.IsPathExists(xunitConsolePth); err != nil {
+		return "", fmt.Errorf("Failed to check if xunit console exist at (%s), error: %s", xunitConsolePth, err)
+	} else if !exist {
+		return "", tools.ToolNotInstalledErrorf("xunit console not exist at: %s", xunitConsolePth)
+	}
+
+	return xunitConsolePth, nil
+}
+
+// New ...
+func New(xunitConsolePth string) (*Model, error) {
+	absXunitConsolePth, err := pathutil.AbsPath(xunitConsolePth)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to expand path (%s), error: %s", xunitConsolePth, err)
+	}
+
+	return &Model{xunitConsolePth: absXunitConsolePth, stdout: os.Stdout, stderr: os.Stderr}, nil
+}
+
+// SetDLLPth ...
+func (xunitConsole *Model) SetDLLPth(dllPth string) *Model {
+	xunitConsole.dllPth = dllPth
+	return xunitConsole
+}
+
+// AddTrait - runs the tests having the trait, the tests having any of the added traits run
+func (xunitConsole *Model) AddTrait(name, value string) *Model {
+	xunitConsole.traits = append(xunitConsole.traits, name+"="+value)
+	return xunitConsole
+}
+
+// AddNoTrait - skips the tests having the trait
+func (xunitConsole *Model) AddNoTrait(name, value string) *Model {
+	xunitConsole.noTraits = append(xunitConsole.noTraits, name+"="+value)
+	return xunitConsole
+}
+
+// AddMethod - runs the test method, like: Namespace.Class.Method
+func (xunitConsole *Model) AddMethod(method string) *Model {
+	xunitConsole.methods = append(xunitConsole.methods, method)
+	return xunitConsole
+}
+
+// SetResultLogPth - the results are written in xUnit v2 XML format
+func (xunitConsole *Model) SetResultLogPth(resultLogPth string) *Model {
+	xunitConsole.resultLogPth = resultLogPth
+	return xunitConsole
+}
+
+// SetMonoOptions - options of the mono runtime running the console, like the profiler
+func (xunitConsole *Model) SetMonoOptions(options ...string) *Model {
+	xunitConsole.monoOptions = options
+	return xunitConsole
+}
+
+// SetCustomOptions ...
+func (xunitConsole *Model) SetCustomOptions(options ...string) {
+	xunitConsole.customOptions = options
+}
+
+// SetStdout ...
+func (xunitConsole *Model) SetStdout(out io.Writer) {
+	xunitConsole.stdout = out
+}
+
+// SetStderr ...
+func (xunitConsole *Model) SetStderr(err io.Writer) {
+	xunitConsole.stderr = err
+}
+
+// SetTimeout ...
+func (xunitConsole *Model) SetTimeout(timeout time.Duration) {
+	xunitConsole.timeout = timeout
+}
+
+// SetKillGracePeriod ...
+func (xunitConsole *Model) SetKillGracePeriod(killGracePeriod time.Duration) {
+	xunitConsole.killGracePeriod = killGracePeriod
+}
+
+func (xunitConsole Model) commandSlice() []string {
+	cmdSlice := []string{constants.MonoPath}
+	cmdSlice = append(cmdSlice, xunitConsole.monoOptions...)
+	cmdSlice = append(cmdSlice, xunitConsole.xunitConsolePth, xunitConsole.dllPth)
+
+	for _, trait := range xunitConsole.traits {
+		cmdSlice = append(cmdSlice, "-trait", trait)
+	}
+	for _, trait := range xunitConsole.noTraits {
+		cmdSlice = append(cmdSlice, "-notrait", trait)
+	}
+	for _, method := range xunitConsole.methods {
+		cmdSlice = append(cmdSlice, "-method", method)
+	}
+
+	if xunitConsole.resultLogPth != "" {
+		cmdSlice = append(cmdSlice, "-xml", xunitConsole.resultLogPth)
+	}
+
+	return append(cmdSlice, xunitConsole.customOptions...)
+}
+
+// PrintableCommand ...
+func (xunitConsole Model) PrintableCommand() string {
+	return command.PrintableCommandArgs(true, xunitConsole.commandSlice())
+}
+
+// Run ...
+func (xunitConsole Model) Run() error {
+	command, err := command.NewFromSlice(xunitConsole.commandSlice())
+	if err != nil {
+		return err
+	}
+
+	command.SetStdout(xunitConsole.stdout)
+	command.SetStderr(xunitConsole.stderr)
+
+	return tools.RunCommandWithTimeout(command.GetCmd(), xunitConsole.timeout, xunitConsole.killGracePeriod)
+}
@@ -0,0 +1,54 @@
+package utility
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolvePath - returns the absolute path of the (possibly relative, Windows style) pth, relative to dir.
+// Project files written on Windows refer files case-insensitively,
+// so if the path does not exist, but a case-insensitive match exists, the existing path is returned.
+func ResolvePath(dir, pth string) string {
+	pth = FixWindowsPath(strings.TrimSpace(pth))
+
+	resolvedPth := filepath.Clean(pth)
+	if !filepath.IsAbs(resolvedPth) {
+		resolvedPth = filepath.Join(dir, pth)
+	}
+
+	if matchingPth, ok := caseInsensitiveMatch(resolvedPth); ok {
+		return matchingPth
+	}
+	return resolvedPth
+}
+
+// caseInsensitiveMatch returns the existing path, which matches the pth case-insensitively
+func caseInsensitiveMatch(pth string) (string, bool) {
+	if _, err := os.Lstat(pth); err == nil {
+		return pth, true
+	}
+
+	dir, base := filepath.Dir(pth), filepath.Base(pth)
+	if dir == pth {
+		return "", false
+	}
+
+	matchingDir, ok := caseInsensitiveMatch(dir)
+	if !ok {
+		return "", false
+	}
+
+	entries, err := ioutil.ReadDir(matchingDir)
+	if err != nil {
+		return "", false
+	}
+
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), base) {
+			return filepath.Join(matchingDir, entry.Name()), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,53 @@
+package utility
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/bitrise-io/go-utils/fileutil"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// DecodeText - decodes the UTF-8 (with or without BOM) or UTF-16 (with BOM) encoded content,
+// and normalizes the CRLF and CR line endings to LF
+func DecodeText(content []byte) string {
+	text := ""
+
+	switch {
+	case bytes.HasPrefix(content, utf8BOM):
+		text = string(content[len(utf8BOM):])
+	case bytes.HasPrefix(content, utf16LEBOM):
+		text = decodeUTF16(content[len(utf16LEBOM):], binary.LittleEndian)
+	case bytes.HasPrefix(content, utf16BEBOM):
+		text = decodeUTF16(content[len(utf16BEBOM):], binary.BigEndian)
+	default:
+		text = string(content)
+	}
+
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	return strings.Replace(text, "\r", "\n", -1)
+}
+
+func decodeUTF16(content []byte, byteOrder binary.ByteOrder) string {
+	codeUnits := make([]uint16, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		codeUnits = append(codeUnits, byteOrder.Uint16(content[i:]))
+	}
+	return string(utf16.Decode(codeUnits))
+}
+
+// ReadTextFile - reads the file and decodes its content by DecodeText
+func ReadTextFile(pth string) (string, error) {
+	content, err := fileutil.ReadBytesFromFile(pth)
+	if err != nil {
+		return "", err
+	}
+	return DecodeText(content), nil
+}
@@ -1,8 +1,12 @@
 package utility
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/bitrise-io/go-utils/fileutil"
+	"github.com/bitrise-io/go-utils/pathutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,3 +109,58 @@ func TestSplitAndStripList(t *testing.T) {
 		require.Equal(t, "ARMv7, ARM64", split[0])
 	}
 }
+
+func TestResolvePath(t *testing.T) {
+	tmpDir, err := pathutil.NormalizedOSTempDirPath("utility_test")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(tmpDir))
+	}()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "Properties"), 0777))
+	require.NoError(t, fileutil.WriteStringToFile(filepath.Join(tmpDir, "Properties", "AndroidManifest.xml"), ""))
+
+	t.Log("it resolves windows style relative path")
+	{
+		require.Equal(t, filepath.Join(tmpDir, "Properties", "AndroidManifest.xml"), ResolvePath(tmpDir, `Properties\AndroidManifest.xml`))
+	}
+
+	t.Log("it resolves case-insensitive match")
+	{
+		require.Equal(t, filepath.Join(tmpDir, "Properties", "AndroidManifest.xml"), ResolvePath(tmpDir, `properties\androidmanifest.xml`))
+	}
+
+	t.Log("it keeps not existing path")
+	{
+		require.Equal(t, filepath.Join(tmpDir, "bin", "Debug"), ResolvePath(tmpDir, `bin\Debug\`))
+	}
+
+	t.Log("it keeps absolute path")
+	{
+		require.Equal(t, "/bin/Debug", ResolvePath(tmpDir, "/bin/Debug"))
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	t.Log("it strips the utf-8 bom")
+	{
+		require.Equal(t, "Microsoft Visual Studio Solution File\n", DecodeText([]byte("\xEF\xBB\xBFMicrosoft Visual Studio Solution File\r\n")))
+	}
+
+	t.Log("it decodes utf-16 little endian")
+	{
+		content := []byte{0xFF, 0xFE, 'a', 0x00, '\r', 0x00, '\n', 0x00, 0x51, 0x01}
+		require.Equal(t, "a\nő", DecodeText(content))
+	}
+
+	t.Log("it decodes utf-16 big endian")
+	{
+		content := []byte{0xFE, 0xFF, 0x00, 'a', 0x00, '\r', 0x00, 'b'}
+		require.Equal(t, "a\nb", DecodeText(content))
+	}
+
+	t.Log("it normalizes line endings")
+	{
+		require.Equal(t, "a\nb\nc\n", DecodeText([]byte("a\r\nb\rc\n")))
+	}
+}